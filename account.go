@@ -24,7 +24,8 @@ import (
 //   - *Account: The account information
 //   - error: Any error that occurred during the request
 func (c *Client) GetSellerAccount(ctx context.Context) (*Account, error) {
-	c.logger.Debugf("Getting seller account")
+	ctx, _ = ensureCorrelationID(ctx)
+	c.loggerFor(ctx).Debugf("Getting seller account")
 
 	resp, err := c.doRequest(ctx, "GET", "/account", nil)
 	if err != nil {
@@ -36,14 +37,44 @@ func (c *Client) GetSellerAccount(ctx context.Context) (*Account, error) {
 		return nil, fmt.Errorf("failed to decode seller account: %w", err)
 	}
 
-	c.logger.Debugf("Retrieved seller account: %s (%s)", account.Username, account.Email)
+	c.loggerFor(ctx).Debugf("Retrieved seller account: %s (%s)", account.Username, account.Email)
 
 	return &account, nil
 }
 
-// UpdateSellerAccount updates the seller account settings.
+// WhoAmI confirms the client's credentials by fetching the seller account.
+// It fails without contacting the API when the token or email is blank, so
+// it is suitable as a startup check.
+//
+// Example:
+//
+//	account, err := client.WhoAmI(ctx)
+//	if err != nil {
+//	    log.Fatalf("invalid Manapool credentials: %v", err)
+//	}
+//	log.Printf("authenticated as %s", account.Username)
+func (c *Client) WhoAmI(ctx context.Context) (*Account, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	account, err := c.GetSellerAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return account, nil
+}
+
+// UpdateSellerAccount updates the seller account settings. At least one
+// field of update must be set.
 func (c *Client) UpdateSellerAccount(ctx context.Context, update SellerAccountUpdate) (*Account, error) {
-	c.logger.Debugf("Updating seller account")
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, _ = ensureCorrelationID(ctx)
+	c.loggerFor(ctx).Debugf("Updating seller account")
 
 	resp, err := c.doJSONRequest(ctx, "PUT", "/account", nil, update)
 	if err != nil {
@@ -55,7 +86,62 @@ func (c *Client) UpdateSellerAccount(ctx context.Context, update SellerAccountUp
 		return nil, fmt.Errorf("failed to decode updated seller account: %w", err)
 	}
 
-	c.logger.Debugf("Updated seller account: %s (%s)", account.Username, account.Email)
+	c.loggerFor(ctx).Debugf("Updated seller account: %s (%s)", account.Username, account.Email)
 
 	return &account, nil
 }
+
+// Validate checks that the update sets at least one field.
+func (u SellerAccountUpdate) Validate() error {
+	if u.SinglesLive == nil && u.SealedLive == nil {
+		return NewValidationError("update", "at least one field must be set")
+	}
+	return nil
+}
+
+// Changes returns the fields of u that differ from current, leaving fields
+// that already match nil.
+func (u SellerAccountUpdate) Changes(current Account) SellerAccountUpdate {
+	var changes SellerAccountUpdate
+	if u.SinglesLive != nil && *u.SinglesLive != current.SinglesLive {
+		changes.SinglesLive = Bool(*u.SinglesLive)
+	}
+	if u.SealedLive != nil && *u.SealedLive != current.SealedLive {
+		changes.SealedLive = Bool(*u.SealedLive)
+	}
+	return changes
+}
+
+// ApplySellerAccountUpdate fetches the current account and sends only the
+// fields of update that differ from it. If nothing differs, no update is
+// sent and the current account is returned with changed set to false.
+//
+// Example:
+//
+//	account, changed, err := client.ApplySellerAccountUpdate(ctx, manapool.SellerAccountUpdate{SinglesLive: manapool.Bool(true)})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !changed {
+//	    fmt.Println("singles already live")
+//	}
+func (c *Client) ApplySellerAccountUpdate(ctx context.Context, update SellerAccountUpdate) (account *Account, changed bool, err error) {
+	if err := update.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	current, err := c.GetSellerAccount(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	changes := update.Changes(*current)
+	if changes.Validate() != nil {
+		return current, false, nil
+	}
+
+	account, err = c.UpdateSellerAccount(ctx, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return account, true, nil
+}
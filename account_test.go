@@ -219,3 +219,34 @@ func TestClient_GetSellerAccount_WithLogger(t *testing.T) {
 		t.Error("expected debug messages to be logged")
 	}
 }
+
+func TestClient_WhoAmI(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/account" {
+			t.Errorf("path = %q, want /account", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"username": "testuser", "email": "test@example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	account, err := client.WhoAmI(context.Background())
+	if err != nil {
+		t.Fatalf("WhoAmI() error = %v", err)
+	}
+	if account.Username != "testuser" {
+		t.Errorf("Username = %q, want testuser", account.Username)
+	}
+
+	blank := NewClient("", "", WithBaseURL(server.URL+"/"))
+	_, err = blank.WhoAmI(context.Background())
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
@@ -3,8 +3,10 @@ package manapool
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -39,3 +41,53 @@ func TestClient_UpdateSellerAccount(t *testing.T) {
 		t.Fatalf("singles_live = false, want true")
 	}
 }
+
+func TestSellerAccountUpdate_Validate(t *testing.T) {
+	client := NewClient("test-token", "test@example.com", WithBaseURL("http://127.0.0.1:0/"))
+	var valErr *ValidationError
+	if _, err := client.UpdateSellerAccount(context.Background(), SellerAccountUpdate{}); !errors.As(err, &valErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	value := false
+	if err := (SellerAccountUpdate{SealedLive: &value}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestClient_ApplySellerAccountUpdate(t *testing.T) {
+	var puts []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			var payload map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("decode payload: %v", err)
+			}
+			puts = append(puts, payload)
+			_, _ = w.Write([]byte(`{"username":"test","singles_live":true,"sealed_live":false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"username":"test","singles_live":true,"sealed_live":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	on, off := true, false
+
+	account, changed, err := client.ApplySellerAccountUpdate(ctx, SellerAccountUpdate{SinglesLive: &on})
+	if err != nil || changed || !account.SinglesLive {
+		t.Fatalf("unchanged update = %+v, %v, %v", account, changed, err)
+	}
+	if len(puts) != 0 {
+		t.Fatalf("sent %d updates for an unchanged account", len(puts))
+	}
+
+	account, changed, err = client.ApplySellerAccountUpdate(ctx, SellerAccountUpdate{SinglesLive: &on, SealedLive: &off})
+	if err != nil || !changed || account.SealedLive {
+		t.Fatalf("update = %+v, %v, %v", account, changed, err)
+	}
+	want := map[string]any{"sealed_live": false}
+	if len(puts) != 1 || !reflect.DeepEqual(puts[0], want) {
+		t.Errorf("payloads = %v, want %v", puts, want)
+	}
+}
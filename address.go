@@ -0,0 +1,299 @@
+package manapool
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ISO 3166-1 alpha-2 codes for the countries with known address formats.
+// The API only accepts CountryUS and CountryCA in buyer order addresses and
+// optimizer requests.
+const (
+	CountryAT = "AT"
+	CountryAU = "AU"
+	CountryBE = "BE"
+	CountryBR = "BR"
+	CountryCA = "CA"
+	CountryCH = "CH"
+	CountryCZ = "CZ"
+	CountryDE = "DE"
+	CountryDK = "DK"
+	CountryES = "ES"
+	CountryFI = "FI"
+	CountryFR = "FR"
+	CountryGB = "GB"
+	CountryIE = "IE"
+	CountryIT = "IT"
+	CountryJP = "JP"
+	CountryKR = "KR"
+	CountryMX = "MX"
+	CountryNL = "NL"
+	CountryNO = "NO"
+	CountryNZ = "NZ"
+	CountryPL = "PL"
+	CountryPT = "PT"
+	CountrySE = "SE"
+	CountrySG = "SG"
+	CountryUS = "US"
+)
+
+// AddressCountries lists the countries the API accepts in buyer order
+// billing, shipping and tax addresses.
+var AddressCountries = []string{CountryUS, CountryCA}
+
+// countryNames maps ISO 3166-1 alpha-2 codes to the English country names
+// printed on international shipping labels.
+var countryNames = map[string]string{
+	"AT": "AUSTRIA",
+	"AU": "AUSTRALIA",
+	"BE": "BELGIUM",
+	"BR": "BRAZIL",
+	"CA": "CANADA",
+	"CH": "SWITZERLAND",
+	"CZ": "CZECH REPUBLIC",
+	"DE": "GERMANY",
+	"DK": "DENMARK",
+	"ES": "SPAIN",
+	"FI": "FINLAND",
+	"FR": "FRANCE",
+	"GB": "UNITED KINGDOM",
+	"IE": "IRELAND",
+	"IT": "ITALY",
+	"JP": "JAPAN",
+	"KR": "SOUTH KOREA",
+	"MX": "MEXICO",
+	"NL": "NETHERLANDS",
+	"NO": "NORWAY",
+	"NZ": "NEW ZEALAND",
+	"PL": "POLAND",
+	"PT": "PORTUGAL",
+	"SE": "SWEDEN",
+	"SG": "SINGAPORE",
+	"US": "UNITED STATES",
+}
+
+// postalCodeFirst lists countries whose labels put the postal code before the city.
+var postalCodeFirst = map[string]bool{
+	"AT": true, "BE": true, "CH": true, "CZ": true, "DE": true, "DK": true, "ES": true,
+	"FI": true, "FR": true, "IT": true, "NL": true, "NO": true, "PL": true, "PT": true, "SE": true,
+}
+
+// postalCodePatterns validates postal codes for countries with a well-known format.
+var postalCodePatterns = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"CA": regexp.MustCompile(`^[A-Za-z]\d[A-Za-z] ?\d[A-Za-z]\d$`),
+	"GB": regexp.MustCompile(`^[A-Za-z]{1,2}\d[A-Za-z\d]? ?\d[A-Za-z]{2}$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Za-z]{2}$`),
+	"JP": regexp.MustCompile(`^\d{3}-?\d{4}$`),
+}
+
+// stateRequired lists countries where the state/province line is required.
+var stateRequired = map[string]bool{
+	"US": true, "CA": true, "AU": true, "MX": true, "BR": true,
+}
+
+// CountryName returns the English name for an ISO 3166-1 alpha-2 country code.
+// Unknown codes are returned upper-cased.
+func CountryName(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if name, ok := countryNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// AddressLabelOptions controls how an address is rendered for a shipping label.
+type AddressLabelOptions struct {
+	// MaxLineLength folds lines longer than this many characters (0 disables folding).
+	MaxLineLength int
+
+	// Uppercase renders every line in upper case, as preferred by most carriers.
+	Uppercase bool
+
+	// OriginCountry is the ship-from country code. The country line is omitted
+	// for domestic shipments and printed as a full name for international ones.
+	OriginCountry string
+}
+
+// LabelLines returns the address as carrier-ready label lines.
+func (a Address) LabelLines(opts AddressLabelOptions) []string {
+	country := strings.ToUpper(strings.TrimSpace(a.Country))
+
+	var lines []string
+	add := func(s string) {
+		if s = strings.Join(strings.Fields(s), " "); s != "" {
+			lines = append(lines, s)
+		}
+	}
+
+	add(a.Name)
+	add(a.Line1)
+	if a.Line2 != nil {
+		add(*a.Line2)
+	}
+	if a.Line3 != nil {
+		add(*a.Line3)
+	}
+
+	switch {
+	case country == "GB":
+		add(a.City)
+		add(a.State)
+		add(a.PostalCode)
+	case postalCodeFirst[country]:
+		add(strings.TrimSpace(a.PostalCode + " " + a.City))
+		add(a.State)
+	case a.State != "" && a.City != "":
+		add(fmt.Sprintf("%s, %s %s", a.City, a.State, a.PostalCode))
+	default:
+		add(strings.Join([]string{a.City, a.State, a.PostalCode}, " "))
+	}
+
+	if country != "" && !strings.EqualFold(country, strings.TrimSpace(opts.OriginCountry)) {
+		add(CountryName(country))
+	}
+
+	if opts.MaxLineLength > 0 {
+		folded := make([]string, 0, len(lines))
+		for _, line := range lines {
+			folded = append(folded, foldLine(line, opts.MaxLineLength)...)
+		}
+		lines = folded
+	}
+
+	if opts.Uppercase {
+		for i := range lines {
+			lines[i] = strings.ToUpper(lines[i])
+		}
+	}
+
+	return lines
+}
+
+// FormatLabel returns the address as a newline-separated label block.
+//
+// Example:
+//
+//	label := order.Order.ShippingAddress.FormatLabel(manapool.AddressLabelOptions{
+//	    MaxLineLength: 35,
+//	    Uppercase:     true,
+//	    OriginCountry: "US",
+//	})
+func (a Address) FormatLabel(opts AddressLabelOptions) string {
+	return strings.Join(a.LabelLines(opts), "\n")
+}
+
+// ValidateForShipping checks that the address has the fields required to ship
+// to its destination country, including a recipient name for the label. It
+// returns a ValidationError naming the first missing or malformed field.
+func (a Address) ValidateForShipping() error {
+	return a.validateFields(true)
+}
+
+// validateFields checks the country, street, city, state and postal code of
+// the address, and the recipient name when requireName is set.
+func (a Address) validateFields(requireName bool) error {
+	country := strings.ToUpper(strings.TrimSpace(a.Country))
+	if country == "" {
+		return NewValidationError("country", "country is required")
+	}
+	if len(country) != 2 {
+		return NewValidationError("country", fmt.Sprintf("country must be a 2-letter ISO code, got %q", a.Country))
+	}
+	if requireName && strings.TrimSpace(a.Name) == "" {
+		return NewValidationError("name", "recipient name is required")
+	}
+	if strings.TrimSpace(a.Line1) == "" {
+		return NewValidationError("line1", "line1 is required")
+	}
+	if strings.TrimSpace(a.City) == "" {
+		return NewValidationError("city", "city is required")
+	}
+	if stateRequired[country] && strings.TrimSpace(a.State) == "" {
+		return NewValidationError("state", fmt.Sprintf("state is required for %s addresses", country))
+	}
+	if err := validateSubdivision(country, a.State); err != nil {
+		return err
+	}
+
+	postal := strings.TrimSpace(a.PostalCode)
+	if postal == "" && country != "IE" {
+		return NewValidationError("postal_code", "postal code is required")
+	}
+	if pattern, ok := postalCodePatterns[country]; ok && !pattern.MatchString(postal) {
+		return NewValidationError("postal_code", fmt.Sprintf("invalid postal code %q for %s", a.PostalCode, country))
+	}
+
+	return nil
+}
+
+// Validate checks the address against the API's address rules: the country
+// must be one of AddressCountries, the state a 2-letter code for that
+// country, and the fields required by ValidateForShipping other than the
+// recipient name present. Pending order addresses have no name; purchases
+// check it separately. Buyer order methods call Validate before sending an
+// address, so mistakes are reported as a ValidationError naming the field
+// instead of an API error.
+func (a Address) Validate() error {
+	country := strings.ToUpper(strings.TrimSpace(a.Country))
+	if country != "" && !containsString(AddressCountries, country) {
+		return NewValidationError("country", fmt.Sprintf("unsupported country %q (supported: %s)",
+			a.Country, strings.Join(AddressCountries, ", ")))
+	}
+	return a.validateFields(false)
+}
+
+// validateSubdivision checks that state is a known state or province code
+// for countries listed in subdivisionCodes. Empty states are not checked.
+func validateSubdivision(country, state string) error {
+	codes, ok := subdivisionCodes[country]
+	state = strings.TrimSpace(state)
+	if !ok || state == "" {
+		return nil
+	}
+	upper := strings.ToUpper(state)
+	for _, code := range codes {
+		if upper == code {
+			return nil
+		}
+	}
+	if code, ok := codes[strings.TrimSuffix(upper, ".")]; ok {
+		return NewValidationError("state", fmt.Sprintf("state must be a 2-letter code for %s addresses: use %q instead of %q", country, code, state))
+	}
+	return NewValidationError("state", fmt.Sprintf("unknown state code %q for %s", state, country))
+}
+
+// foldLine splits s on word boundaries so that no line exceeds width
+// characters. Words longer than width are hard-wrapped.
+func foldLine(s string, width int) []string {
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(s) {
+		for len([]rune(word)) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			r := []rune(word)
+			lines = append(lines, string(r[:width]))
+			word = string(r[width:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case len([]rune(current))+1+len([]rune(word)) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,209 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// countryAliases maps common country spellings and ISO 3166-1 alpha-3 codes
+// to alpha-2 codes. Full names from countryNames are added in init.
+var countryAliases = map[string]string{
+	"USA": "US", "U.S.": "US", "U.S.A.": "US", "UNITED STATES OF AMERICA": "US", "AMERICA": "US",
+	"CAN": "CA",
+	"GBR": "GB", "UK": "GB", "U.K.": "GB", "GREAT BRITAIN": "GB", "ENGLAND": "GB", "SCOTLAND": "GB", "WALES": "GB", "NORTHERN IRELAND": "GB",
+	"AUS": "AU", "AUT": "AT", "BEL": "BE", "BRA": "BR", "CHE": "CH", "CZE": "CZ", "CZECHIA": "CZ",
+	"DEU": "DE", "DNK": "DK", "ESP": "ES", "FIN": "FI", "FRA": "FR", "IRL": "IE", "ITA": "IT",
+	"JPN": "JP", "KOR": "KR", "KOREA": "KR", "REPUBLIC OF KOREA": "KR", "MEX": "MX", "NLD": "NL", "HOLLAND": "NL",
+	"NOR": "NO", "NZL": "NZ", "POL": "PL", "PRT": "PT", "SWE": "SE", "SGP": "SG",
+}
+
+// subdivisionCodes maps state and province names to their postal codes for
+// countries where carriers expect the abbreviation. Address.ValidateForShipping
+// only accepts these codes as states for these countries.
+var subdivisionCodes = map[string]map[string]string{
+	"US": {
+		"ALABAMA": "AL", "ALASKA": "AK", "ARIZONA": "AZ", "ARKANSAS": "AR", "CALIFORNIA": "CA",
+		"COLORADO": "CO", "CONNECTICUT": "CT", "DELAWARE": "DE", "DISTRICT OF COLUMBIA": "DC", "FLORIDA": "FL",
+		"GEORGIA": "GA", "HAWAII": "HI", "IDAHO": "ID", "ILLINOIS": "IL", "INDIANA": "IN",
+		"IOWA": "IA", "KANSAS": "KS", "KENTUCKY": "KY", "LOUISIANA": "LA", "MAINE": "ME",
+		"MARYLAND": "MD", "MASSACHUSETTS": "MA", "MICHIGAN": "MI", "MINNESOTA": "MN", "MISSISSIPPI": "MS",
+		"MISSOURI": "MO", "MONTANA": "MT", "NEBRASKA": "NE", "NEVADA": "NV", "NEW HAMPSHIRE": "NH",
+		"NEW JERSEY": "NJ", "NEW MEXICO": "NM", "NEW YORK": "NY", "NORTH CAROLINA": "NC", "NORTH DAKOTA": "ND",
+		"OHIO": "OH", "OKLAHOMA": "OK", "OREGON": "OR", "PENNSYLVANIA": "PA", "RHODE ISLAND": "RI",
+		"SOUTH CAROLINA": "SC", "SOUTH DAKOTA": "SD", "TENNESSEE": "TN", "TEXAS": "TX", "UTAH": "UT",
+		"VERMONT": "VT", "VIRGINIA": "VA", "WASHINGTON": "WA", "WEST VIRGINIA": "WV", "WISCONSIN": "WI",
+		"WYOMING": "WY", "AMERICAN SAMOA": "AS", "GUAM": "GU", "NORTHERN MARIANA ISLANDS": "MP",
+		"PUERTO RICO": "PR", "U.S. VIRGIN ISLANDS": "VI", "VIRGIN ISLANDS": "VI",
+		"ARMED FORCES AMERICAS": "AA", "ARMED FORCES EUROPE": "AE", "ARMED FORCES PACIFIC": "AP",
+	},
+	"CA": {
+		"ALBERTA": "AB", "BRITISH COLUMBIA": "BC", "MANITOBA": "MB", "NEW BRUNSWICK": "NB",
+		"NEWFOUNDLAND AND LABRADOR": "NL", "NEWFOUNDLAND": "NL", "NOVA SCOTIA": "NS", "NORTHWEST TERRITORIES": "NT",
+		"NUNAVUT": "NU", "ONTARIO": "ON", "PRINCE EDWARD ISLAND": "PE", "QUEBEC": "QC", "QUÉBEC": "QC",
+		"SASKATCHEWAN": "SK", "YUKON": "YT",
+	},
+}
+
+func init() {
+	for code, name := range countryNames {
+		if _, ok := countryAliases[name]; !ok {
+			countryAliases[name] = code
+		}
+	}
+}
+
+// NormalizeCountryCode converts a country name, alpha-3 code or alpha-2 code
+// to an upper-case ISO 3166-1 alpha-2 code. Unrecognized values are returned
+// trimmed and upper-cased.
+func NormalizeCountryCode(country string) string {
+	key := strings.ToUpper(strings.Join(strings.Fields(country), " "))
+	if code, ok := countryAliases[key]; ok {
+		return code
+	}
+	return key
+}
+
+// Normalize returns a copy of the address with whitespace trimmed and
+// collapsed, the country converted to an ISO alpha-2 code, US and Canadian
+// state names abbreviated, and state and postal codes upper-cased. Empty
+// optional lines are set to nil.
+//
+// Example:
+//
+//	address = address.Normalize()
+//	if err := address.ValidateForShipping(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (a Address) Normalize() Address {
+	clean := func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	cleanOptional := func(s *string) *string {
+		if s == nil {
+			return nil
+		}
+		v := clean(*s)
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+
+	a.Name = clean(a.Name)
+	a.Line1 = clean(a.Line1)
+	a.Line2 = cleanOptional(a.Line2)
+	a.Line3 = cleanOptional(a.Line3)
+	a.City = clean(a.City)
+	a.Country = NormalizeCountryCode(a.Country)
+
+	state := strings.ToUpper(clean(a.State))
+	if codes, ok := subdivisionCodes[a.Country]; ok {
+		if code, ok := codes[strings.TrimSuffix(state, ".")]; ok {
+			state = code
+		}
+		a.State = strings.ReplaceAll(state, ".", "")
+	} else if len(state) <= 3 {
+		a.State = state
+	} else {
+		a.State = clean(a.State)
+	}
+
+	a.PostalCode = strings.ToUpper(clean(a.PostalCode))
+	if a.Country == "CA" && len(a.PostalCode) == 6 {
+		a.PostalCode = a.PostalCode[:3] + " " + a.PostalCode[3:]
+	}
+
+	return a
+}
+
+// AddressValidator checks and optionally corrects an address before it is
+// sent to the API. Implementations may call an external address verification
+// service; the returned address replaces the original.
+type AddressValidator interface {
+	ValidateAddress(ctx context.Context, address Address) (Address, error)
+}
+
+// AddressValidatorFunc adapts a function to the AddressValidator interface.
+type AddressValidatorFunc func(ctx context.Context, address Address) (Address, error)
+
+// ValidateAddress calls f(ctx, address).
+func (f AddressValidatorFunc) ValidateAddress(ctx context.Context, address Address) (Address, error) {
+	return f(ctx, address)
+}
+
+// NormalizeAddressValidator normalizes addresses without rejecting any.
+var NormalizeAddressValidator AddressValidator = AddressValidatorFunc(func(_ context.Context, address Address) (Address, error) {
+	return address.Normalize(), nil
+})
+
+// ShippingAddressValidator normalizes addresses and rejects those missing
+// the fields Address.ValidateForShipping requires for the destination
+// country. The recipient name is not checked, since pending order addresses
+// have none.
+var ShippingAddressValidator AddressValidator = AddressValidatorFunc(func(_ context.Context, address Address) (Address, error) {
+	address = address.Normalize()
+	return address, address.validateFields(false)
+})
+
+// validatePendingOrderAddresses validates the addresses on a pending order
+// request, replacing them with copies so the caller's values are not modified.
+func (c *Client) validatePendingOrderAddresses(ctx context.Context, req *PendingOrderRequest) error {
+	if req.ShippingAddress != nil {
+		address := *req.ShippingAddress
+		if err := c.validateAddress(ctx, "shipping_address", &address); err != nil {
+			return err
+		}
+		req.ShippingAddress = &address
+	}
+	if req.TaxAddress != nil {
+		address := *req.TaxAddress
+		if err := c.validateAddress(ctx, "tax_address", &address); err != nil {
+			return err
+		}
+		req.TaxAddress = &address
+	}
+	return nil
+}
+
+// validateAddress runs the client's address validator, if any, on address in
+// place and then checks the result with Address.Validate. Validation errors
+// are re-keyed under field, e.g. "shipping_address.city".
+func (c *Client) validateAddress(ctx context.Context, field string, address *Address) error {
+	if address == nil {
+		return nil
+	}
+
+	if c.addressValidator != nil {
+		validated, err := c.addressValidator.ValidateAddress(ctx, *address)
+		if err != nil {
+			return addressFieldError(field, err)
+		}
+		*address = validated
+	}
+	if err := address.Validate(); err != nil {
+		return addressFieldError(field, err)
+	}
+	return nil
+}
+
+// validatePurchaseAddress validates a purchase address like validateAddress
+// and also requires the recipient name, which purchases must include.
+func (c *Client) validatePurchaseAddress(ctx context.Context, field string, address *Address) error {
+	if err := c.validateAddress(ctx, field, address); err != nil {
+		return err
+	}
+	if strings.TrimSpace(address.Name) == "" {
+		return NewValidationError(field+".name", "recipient name is required")
+	}
+	return nil
+}
+
+// addressFieldError re-keys a validation error under field.
+func addressFieldError(field string, err error) error {
+	var valErr *ValidationError
+	if errors.As(err, &valErr) {
+		return NewValidationError(field+"."+valErr.Field, valErr.Message)
+	}
+	return NewValidationError(field, err.Error())
+}
@@ -0,0 +1,134 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeCountryCode(t *testing.T) {
+	tests := map[string]string{
+		"us":                       "US",
+		" USA ":                    "US",
+		"United States of America": "US",
+		"united  states":           "US",
+		"Canada":                   "CA",
+		"GBR":                      "GB",
+		"uk":                       "GB",
+		"Deutschland":              "DEUTSCHLAND",
+		"":                         "",
+	}
+	for input, want := range tests {
+		if got := NormalizeCountryCode(input); got != want {
+			t.Errorf("NormalizeCountryCode(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestAddress_Normalize(t *testing.T) {
+	blank := "   "
+	line2 := "  Apt   4 "
+	a := Address{
+		Name:       "  Jane   Doe ",
+		Line1:      "1  Main St",
+		Line2:      &line2,
+		Line3:      &blank,
+		City:       " Portland ",
+		State:      "oregon",
+		PostalCode: " 97201 ",
+		Country:    "usa",
+	}
+
+	got := a.Normalize()
+	if got.Name != "Jane Doe" || got.Line1 != "1 Main St" || got.City != "Portland" {
+		t.Errorf("whitespace not normalized: %+v", got)
+	}
+	if got.Line2 == nil || *got.Line2 != "Apt 4" || got.Line3 != nil {
+		t.Errorf("optional lines not normalized: %v %v", got.Line2, got.Line3)
+	}
+	if got.State != "OR" || got.Country != "US" || got.PostalCode != "97201" {
+		t.Errorf("codes not normalized: %+v", got)
+	}
+	if *a.Line2 != "  Apt   4 " {
+		t.Error("Normalize modified the original address")
+	}
+
+	tests := []struct {
+		in    Address
+		state string
+		zip   string
+	}{
+		{Address{State: "n.y.", Country: "US"}, "NY", ""},
+		{Address{State: "Québec", PostalCode: "h2x1y4", Country: "canada"}, "QC", "H2X 1Y4"},
+		{Address{State: "Bavaria", Country: "DE"}, "Bavaria", ""},
+		{Address{State: "nsw", Country: "AU"}, "NSW", ""},
+	}
+	for _, tt := range tests {
+		got := tt.in.Normalize()
+		if got.State != tt.state || got.PostalCode != tt.zip {
+			t.Errorf("Normalize(%+v) = state %q, postal %q; want %q, %q", tt.in, got.State, got.PostalCode, tt.state, tt.zip)
+		}
+	}
+}
+
+func TestClient_WithAddressValidator(t *testing.T) {
+	var received PurchasePendingOrderRequest
+	var pending PendingOrderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/orders/pending-orders/po/purchase":
+			_ = json.NewDecoder(r.Body).Decode(&received)
+		case "/buyer/orders/pending-orders":
+			_ = json.NewDecoder(r.Body).Decode(&pending)
+		}
+		_, _ = w.Write([]byte(`{"id":"po","line_items":[],"status":"pending","totals":{},"order":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com",
+		WithBaseURL(server.URL+"/"),
+		WithAddressValidator(ShippingAddressValidator),
+	)
+	ctx := context.Background()
+	address := Address{Name: "Jane Doe", Line1: "1 Main St", City: "Portland", State: "Oregon", PostalCode: "97201", Country: "United States"}
+
+	if _, err := client.PurchasePendingOrder(ctx, "po", PurchasePendingOrderRequest{
+		PaymentMethod:   "user_credit",
+		BillingAddress:  address,
+		ShippingAddress: address,
+	}); err != nil {
+		t.Fatalf("PurchasePendingOrder() error = %v", err)
+	}
+	if received.ShippingAddress.State != "OR" || received.BillingAddress.Country != "US" {
+		t.Errorf("addresses not normalized before sending: %+v", received)
+	}
+
+	if _, err := client.CreatePendingOrder(ctx, PendingOrderRequest{ShippingAddress: &address}); err != nil {
+		t.Fatalf("CreatePendingOrder() error = %v", err)
+	}
+	if pending.ShippingAddress == nil || pending.ShippingAddress.State != "OR" || address.State != "Oregon" {
+		t.Errorf("pending order address not normalized or caller's copy modified: %+v", pending.ShippingAddress)
+	}
+
+	bad := address
+	bad.PostalCode = "ABC"
+	_, err := client.CreatePendingOrder(ctx, PendingOrderRequest{TaxAddress: &bad})
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) || valErr.Field != "tax_address.postal_code" {
+		t.Fatalf("expected tax_address.postal_code ValidationError, got %v", err)
+	}
+
+	failing := NewClient("test-token", "test@example.com",
+		WithBaseURL(server.URL+"/"),
+		WithAddressValidator(AddressValidatorFunc(func(context.Context, Address) (Address, error) {
+			return Address{}, errors.New("service unavailable")
+		})),
+	)
+	_, err = failing.PurchasePendingOrder(ctx, "po", PurchasePendingOrderRequest{BillingAddress: address})
+	if !errors.As(err, &valErr) || valErr.Field != "billing_address" {
+		t.Fatalf("expected billing_address ValidationError, got %v", err)
+	}
+}
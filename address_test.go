@@ -0,0 +1,192 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddress_LabelLines(t *testing.T) {
+	line2 := "Suite 200"
+	tests := []struct {
+		name string
+		addr Address
+		opts AddressLabelOptions
+		want []string
+	}{
+		{
+			name: "domestic US",
+			addr: Address{Name: "Jane Doe", Line1: "123 Main St", Line2: &line2, City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"},
+			opts: AddressLabelOptions{OriginCountry: "US"},
+			want: []string{"Jane Doe", "123 Main St", "Suite 200", "Springfield, IL 62701"},
+		},
+		{
+			name: "international uppercase",
+			addr: Address{Name: "Hans Meier", Line1: "Hauptstrasse  5", City: "Berlin", PostalCode: "10115", Country: "de"},
+			opts: AddressLabelOptions{OriginCountry: "US", Uppercase: true},
+			want: []string{"HANS MEIER", "HAUPTSTRASSE 5", "10115 BERLIN", "GERMANY"},
+		},
+		{
+			name: "united kingdom",
+			addr: Address{Name: "Ann", Line1: "1 High St", City: "London", PostalCode: "SW1A 1AA", Country: "GB"},
+			opts: AddressLabelOptions{OriginCountry: "GB"},
+			want: []string{"Ann", "1 High St", "London", "SW1A 1AA"},
+		},
+		{
+			name: "folded",
+			addr: Address{Name: "A", Line1: "1234 Extremely Long Boulevard Name Northwest", City: "Town", PostalCode: "1", Country: "ZZ"},
+			opts: AddressLabelOptions{MaxLineLength: 20},
+			want: []string{"A", "1234 Extremely Long", "Boulevard Name", "Northwest", "Town 1", "ZZ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.addr.LabelLines(tt.opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("LabelLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	addr := Address{Name: "Jane", Line1: "1 A St", City: "X", State: "CA", PostalCode: "90001", Country: "US"}
+	if got := addr.FormatLabel(AddressLabelOptions{}); got != "Jane\n1 A St\nX, CA 90001\nUNITED STATES" {
+		t.Fatalf("FormatLabel() = %q", got)
+	}
+}
+
+func TestFoldLine_HardWrap(t *testing.T) {
+	got := foldLine("ab abcdefghij", 4)
+	want := []string{"ab", "abcd", "efgh", "ij"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("foldLine() = %q, want %q", got, want)
+	}
+}
+
+func TestCountryName(t *testing.T) {
+	if got := CountryName(" ca "); got != "CANADA" {
+		t.Errorf("CountryName(ca) = %q", got)
+	}
+	if got := CountryName("xx"); got != "XX" {
+		t.Errorf("CountryName(xx) = %q", got)
+	}
+}
+
+func TestAddress_ValidateForShipping(t *testing.T) {
+	valid := Address{Name: "Jane", Line1: "1 A St", City: "X", State: "CA", PostalCode: "90001-1234", Country: "US"}
+
+	tests := []struct {
+		name      string
+		mutate    func(*Address)
+		wantField string
+	}{
+		{"valid", func(a *Address) {}, ""},
+		{"missing country", func(a *Address) { a.Country = "" }, "country"},
+		{"bad country", func(a *Address) { a.Country = "USA" }, "country"},
+		{"missing name", func(a *Address) { a.Name = " " }, "name"},
+		{"missing line1", func(a *Address) { a.Line1 = "" }, "line1"},
+		{"missing city", func(a *Address) { a.City = "" }, "city"},
+		{"missing state", func(a *Address) { a.State = "" }, "state"},
+		{"missing postal", func(a *Address) { a.PostalCode = "" }, "postal_code"},
+		{"bad US zip", func(a *Address) { a.PostalCode = "9000" }, "postal_code"},
+		{"valid canada", func(a *Address) { a.Country = "CA"; a.State = "ON"; a.PostalCode = "K1A 0B1" }, ""},
+		{"bad canada", func(a *Address) { a.Country = "CA"; a.State = "ON"; a.PostalCode = "12345" }, "postal_code"},
+		{"ireland without postal", func(a *Address) { a.Country = "IE"; a.State = ""; a.PostalCode = "" }, ""},
+		{"unknown country no state", func(a *Address) { a.Country = "SG"; a.State = ""; a.PostalCode = "018956" }, ""},
+		{"unknown US state", func(a *Address) { a.State = "ZZ" }, "state"},
+		{"US state name", func(a *Address) { a.State = "California" }, "state"},
+		{"lower-case state code", func(a *Address) { a.State = "ca" }, ""},
+		{"US state in canada", func(a *Address) { a.Country = "CA"; a.State = "NY"; a.PostalCode = "K1A 0B1" }, "state"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := valid
+			tt.mutate(&addr)
+			err := addr.ValidateForShipping()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if valErr.Field != tt.wantField {
+				t.Fatalf("field = %q, want %q", valErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestAddress_Validate(t *testing.T) {
+	valid := Address{Name: "Jane", Line1: "1 A St", City: "Toronto", State: "ON", PostalCode: "M5V 2T6", Country: CountryCA}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	nameless := valid
+	nameless.Name = ""
+	if err := nameless.Validate(); err != nil {
+		t.Fatalf("Validate() without name error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(*Address)
+		wantField   string
+		wantMessage string
+	}{
+		{"unsupported country", func(a *Address) { a.Country = CountryGB; a.State = ""; a.PostalCode = "SW1A 1AA" }, "country", "supported: US, CA"},
+		{"state name", func(a *Address) { a.State = "Ontario" }, "state", `use "ON" instead of "Ontario"`},
+		{"missing line1", func(a *Address) { a.Line1 = "" }, "line1", "line1 is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := valid
+			tt.mutate(&addr)
+			var valErr *ValidationError
+			if err := addr.Validate(); !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if valErr.Field != tt.wantField || !strings.Contains(valErr.Message, tt.wantMessage) {
+				t.Errorf("error = %s: %s, want %s containing %q", valErr.Field, valErr.Message, tt.wantField, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestClient_PurchasePendingOrderValidatesAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	address := Address{Name: "Jane", Line1: "1 A St", City: "Portland", State: "Oregon", PostalCode: "97201", Country: CountryUS}
+	_, err := client.PurchasePendingOrder(context.Background(), "po-1", PurchasePendingOrderRequest{
+		PaymentMethod:   "user_credit",
+		BillingAddress:  address,
+		ShippingAddress: address,
+	})
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) || valErr.Field != "billing_address.state" {
+		t.Fatalf("expected billing_address.state ValidationError, got %v", err)
+	}
+
+	address.State = "OR"
+	address.Name = ""
+	_, err = client.PurchasePendingOrder(context.Background(), "po-1", PurchasePendingOrderRequest{
+		PaymentMethod:   "user_credit",
+		BillingAddress:  Address{Name: "Jane", Line1: "1 A St", City: "Portland", State: "OR", PostalCode: "97201", Country: CountryUS},
+		ShippingAddress: address,
+	})
+	if !errors.As(err, &valErr) || valErr.Field != "shipping_address.name" {
+		t.Fatalf("expected shipping_address.name ValidationError, got %v", err)
+	}
+}
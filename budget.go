@@ -0,0 +1,207 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+)
+
+// BudgetAction describes how a flexible cart item was cut.
+type BudgetAction string
+
+// Budget actions.
+const (
+	// BudgetSubstituted means the item's printing restrictions were dropped so
+	// the optimizer could pick a cheaper printing.
+	BudgetSubstituted BudgetAction = "substituted"
+
+	// BudgetDropped means the item was removed from the cart.
+	BudgetDropped BudgetAction = "dropped"
+)
+
+// BudgetOptions controls OptimizeCartWithinBudget.
+type BudgetOptions struct {
+	// BudgetCents is the maximum acceptable cart total, including shipping.
+	BudgetCents int
+
+	// Flexible lists the cart indexes that may be cut. Items not listed are
+	// always kept. When two flexible items cost the same, the one listed first
+	// is cut first.
+	Flexible []int
+
+	// Substitute tries a cheaper printing of a flexible item, by dropping its
+	// set, collector number, finish and product restrictions, before dropping
+	// the item entirely.
+	Substitute bool
+}
+
+// BudgetCut records one change made to bring the cart under budget.
+type BudgetCut struct {
+	// Index is the item's position in the original request cart.
+	Index  int
+	Name   string
+	Action BudgetAction
+
+	// CostCents is the item's cost in the cart before the cut.
+	CostCents int
+}
+
+// BudgetOptimization is the result of OptimizeCartWithinBudget.
+type BudgetOptimization struct {
+	// Cart is the optimized cart from the final attempt.
+	Cart *OptimizedCart
+
+	// Request is the request that produced Cart. Dropped items are removed.
+	Request OptimizerRequest
+
+	// Cuts lists the changes made, in the order they were applied.
+	Cuts []BudgetCut
+
+	// UnderBudget reports whether Cart's total is within the budget.
+	UnderBudget bool
+}
+
+// OptimizeCartWithinBudget runs OptimizeCart and, while the cart total
+// exceeds opts.BudgetCents, cuts the most expensive flexible item and
+// optimizes again. Item costs are taken from the listings the optimizer
+// selected, matched to cart items by card name.
+//
+// If the cart is still over budget after every flexible item has been cut,
+// the result is returned with UnderBudget set to false along with an error.
+//
+// Example:
+//
+//	result, err := client.OptimizeCartWithinBudget(ctx, req, manapool.BudgetOptions{
+//	    BudgetCents: 10000,
+//	    Flexible:    []int{3, 4, 7},
+//	    Substitute:  true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, cut := range result.Cuts {
+//	    fmt.Printf("%s %s ($%.2f)\n", cut.Action, cut.Name, float64(cut.CostCents)/100)
+//	}
+func (c *Client) OptimizeCartWithinBudget(ctx context.Context, req OptimizerRequest, opts BudgetOptions) (*BudgetOptimization, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	if opts.BudgetCents <= 0 {
+		return nil, NewValidationError("budget_cents", "budget must be positive")
+	}
+
+	priority := make(map[int]int, len(opts.Flexible))
+	for i, index := range opts.Flexible {
+		if index < 0 || index >= len(req.Cart) {
+			return nil, NewValidationError("flexible", fmt.Sprintf("cart index %d out of range", index))
+		}
+		if _, ok := priority[index]; !ok {
+			priority[index] = i
+		}
+	}
+
+	items := make([]OptimizerCartItem, len(req.Cart))
+	for i, item := range req.Cart {
+		items[i] = item.Clone()
+	}
+	dropped := make([]bool, len(items))
+	substituted := make([]bool, len(items))
+	result := &BudgetOptimization{}
+
+	for {
+		current := req
+		current.Cart = make([]OptimizerCartItem, 0, len(items))
+		for i, item := range items {
+			if !dropped[i] {
+				current.Cart = append(current.Cart, item)
+			}
+		}
+		result.Request = current
+		result.Cart = nil
+
+		if len(current.Cart) == 0 {
+			return result, fmt.Errorf("failed to fit cart within budget of %d cents: every item was dropped", opts.BudgetCents)
+		}
+
+		cart, err := c.OptimizeCart(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		result.Cart = cart
+		if cart.Totals.TotalCents <= opts.BudgetCents {
+			result.UnderBudget = true
+			return result, nil
+		}
+
+		costs, err := c.cartCostsByName(ctx, cart)
+		if err != nil {
+			return nil, err
+		}
+
+		cut := -1
+		for index := range priority {
+			if dropped[index] {
+				continue
+			}
+			if cut < 0 {
+				cut = index
+				continue
+			}
+			a, b := costs[collectionKey(items[index].Name)], costs[collectionKey(items[cut].Name)]
+			if a > b || (a == b && priority[index] < priority[cut]) {
+				cut = index
+			}
+		}
+		if cut < 0 {
+			return result, fmt.Errorf("failed to fit cart within budget of %d cents: total is %d cents after cutting all flexible items",
+				opts.BudgetCents, cart.Totals.TotalCents)
+		}
+
+		record := BudgetCut{Index: cut, Name: items[cut].Name, CostCents: costs[collectionKey(items[cut].Name)]}
+		if opts.Substitute && !substituted[cut] && items[cut].Name != "" && hasPrintingRestriction(items[cut]) {
+			substituted[cut] = true
+			clearPrintingRestrictions(&items[cut])
+			record.Action = BudgetSubstituted
+		} else {
+			dropped[cut] = true
+			record.Action = BudgetDropped
+		}
+		result.Cuts = append(result.Cuts, record)
+	}
+}
+
+// cartCostsByName totals the cost of an optimized cart per normalized card name.
+func (c *Client) cartCostsByName(ctx context.Context, cart *OptimizedCart) (map[string]int, error) {
+	ids := make([]string, 0, len(cart.Cart))
+	selected := make(map[string]int, len(cart.Cart))
+	for _, item := range cart.Cart {
+		if _, ok := selected[item.InventoryID]; !ok {
+			ids = append(ids, item.InventoryID)
+		}
+		selected[item.InventoryID] += item.QuantitySelected
+	}
+
+	listings, err := c.GetInventoryListings(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price optimized cart: %w", err)
+	}
+
+	costs := make(map[string]int)
+	for _, listing := range listings.InventoryItems {
+		costs[collectionKey(productName(listing.Product))] += listing.PriceCents * selected[listing.ID]
+	}
+	return costs, nil
+}
+
+func hasPrintingRestriction(item OptimizerCartItem) bool {
+	return item.SetCode != "" || item.CollectorNumber != "" || item.MTGJsonID != nil ||
+		len(item.FinishIDs) > 0 || len(item.ProductIDs) > 0 || len(item.TCGPlayerSKUIds) > 0
+}
+
+func clearPrintingRestrictions(item *OptimizerCartItem) {
+	item.SetCode = ""
+	item.CollectorNumber = ""
+	item.MTGJsonID = nil
+	item.FinishIDs = nil
+	item.ProductIDs = nil
+	item.TCGPlayerSKUIds = nil
+}
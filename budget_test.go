@@ -0,0 +1,134 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newBudgetTestServer returns an optimizer that selects one listing per cart
+// item. Prices are per unit; a specific printing costs three times as much.
+func newBudgetTestServer(t *testing.T, prices map[string]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode optimizer request: %v", err)
+			}
+			var items []string
+			total := 0
+			for _, item := range req.Cart {
+				id := item.Name
+				price := prices[item.Name]
+				if item.SetCode != "" {
+					id += "|" + item.SetCode
+					price *= 3
+				}
+				total += price * item.QuantityRequested
+				items = append(items, fmt.Sprintf(`{"inventory_id":%q,"quantity_selected":%d}`, id, item.QuantityRequested))
+			}
+			_, _ = fmt.Fprintf(w, `{"cart":[%s],"totals":{"subtotal_cents":%d,"shipping_cents":0,"total_cents":%d,"seller_count":1}}`,
+				strings.Join(items, ","), total, total)
+		case r.Method == http.MethodGet && r.URL.Path == "/inventory/listings":
+			var listings []string
+			for _, id := range r.URL.Query()["id"] {
+				name, set, _ := strings.Cut(id, "|")
+				price := prices[name]
+				if set != "" {
+					price *= 3
+				}
+				listings = append(listings, fmt.Sprintf(`{"id":%q,"price_cents":%d,"product":{"single":{"name":%q}}}`, id, price, name))
+			}
+			_, _ = fmt.Fprintf(w, `{"inventory_items":[%s]}`, strings.Join(listings, ","))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestClient_OptimizeCartWithinBudget(t *testing.T) {
+	server := newBudgetTestServer(t, map[string]int{"Core": 1000, "Cheap": 100, "Pricey": 800, "Mid": 500})
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := OptimizerRequest{Cart: []OptimizerCartItem{
+		{Name: "Core", QuantityRequested: 1},
+		{Name: "Cheap", QuantityRequested: 1},
+		{Name: "Pricey", SetCode: "LEA", QuantityRequested: 1},
+		{Name: "Mid", QuantityRequested: 1},
+	}}
+
+	t.Run("already under budget", func(t *testing.T) {
+		result, err := client.OptimizeCartWithinBudget(ctx, req, BudgetOptions{BudgetCents: 5000, Flexible: []int{1, 2, 3}})
+		if err != nil {
+			t.Fatalf("OptimizeCartWithinBudget() error = %v", err)
+		}
+		if !result.UnderBudget || len(result.Cuts) != 0 || result.Cart.Totals.TotalCents != 4000 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("substitutes then drops", func(t *testing.T) {
+		result, err := client.OptimizeCartWithinBudget(ctx, req, BudgetOptions{BudgetCents: 1500, Flexible: []int{1, 2, 3}, Substitute: true})
+		if err != nil {
+			t.Fatalf("OptimizeCartWithinBudget() error = %v", err)
+		}
+		if !result.UnderBudget || result.Cart.Totals.TotalCents != 1100 {
+			t.Fatalf("unexpected totals: %+v", result.Cart.Totals)
+		}
+		want := []BudgetCut{
+			{Index: 2, Name: "Pricey", Action: BudgetSubstituted, CostCents: 2400},
+			{Index: 2, Name: "Pricey", Action: BudgetDropped, CostCents: 800},
+			{Index: 3, Name: "Mid", Action: BudgetDropped, CostCents: 500},
+		}
+		if len(result.Cuts) != len(want) {
+			t.Fatalf("cuts = %+v, want %+v", result.Cuts, want)
+		}
+		for i := range want {
+			if result.Cuts[i] != want[i] {
+				t.Errorf("cut %d = %+v, want %+v", i, result.Cuts[i], want[i])
+			}
+		}
+		if len(result.Request.Cart) != 2 || req.Cart[2].SetCode != "LEA" {
+			t.Errorf("unexpected final request or modified input: %+v", result.Request.Cart)
+		}
+	})
+
+	t.Run("cannot reach budget", func(t *testing.T) {
+		result, err := client.OptimizeCartWithinBudget(ctx, req, BudgetOptions{BudgetCents: 500, Flexible: []int{1}})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if result == nil || result.UnderBudget || len(result.Cuts) != 1 || result.Cuts[0].Name != "Cheap" {
+			t.Fatalf("unexpected partial result: %+v", result)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name string
+			req  OptimizerRequest
+			opts BudgetOptions
+		}{
+			{"empty cart", OptimizerRequest{}, BudgetOptions{BudgetCents: 100}},
+			{"no budget", req, BudgetOptions{}},
+			{"bad index", req, BudgetOptions{BudgetCents: 100, Flexible: []int{9}}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var valErr *ValidationError
+				if _, err := client.OptimizeCartWithinBudget(ctx, tt.req, tt.opts); !errors.As(err, &valErr) {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+			})
+		}
+	})
+}
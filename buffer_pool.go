@@ -0,0 +1,122 @@
+package manapool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the largest buffer returned to the pool, so one
+// large upload does not pin its memory for the life of the process.
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer resets buf and returns it to the pool.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// pooledBody is a request body backed by a pooled buffer. Each attempt at
+// sending a request reads its own copy of the body from Open, which is also
+// the request's GetBody, so retries and transport replays send the whole
+// body again. The HTTP transport closes those readers, possibly after
+// Client.Do returns, so the buffer goes back to the pool only once the body
+// and every reader from Open are closed. Reads after Close return io.EOF
+// instead of touching the reused buffer.
+type pooledBody struct {
+	mu     sync.Mutex
+	buf    *bytes.Buffer
+	refs   int
+	reader *pooledReader
+	size   int
+}
+
+// newPooledBody returns a request body that reads buf and releases it to
+// the pool when it and its readers are closed.
+func newPooledBody(buf *bytes.Buffer) *pooledBody {
+	b := &pooledBody{buf: buf, refs: 1, size: buf.Len()}
+	b.reader = &pooledReader{body: b, reader: bytes.NewReader(buf.Bytes())}
+	return b
+}
+
+// Read implements io.Reader, reading the body once from the start.
+func (b *pooledBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Close implements io.Closer. It stops reads from b; the buffer returns to
+// the pool once every reader from Open is closed too.
+func (b *pooledBody) Close() error {
+	return b.reader.Close()
+}
+
+// Open returns a new reader over the whole body, for one attempt at sending
+// it. It has the signature of http.Request.GetBody.
+func (b *pooledBody) Open() (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.buf == nil {
+		return nil, errors.New("request body already released")
+	}
+	b.refs++
+	return &pooledReader{body: b, reader: bytes.NewReader(b.buf.Bytes())}, nil
+}
+
+// release drops one reference to the buffer, returning it to the pool when
+// none are left.
+func (b *pooledBody) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refs--
+	if b.refs == 0 && b.buf != nil {
+		putBuffer(b.buf)
+		b.buf = nil
+	}
+}
+
+// Len returns the size of the body, so requests can send a Content-Length.
+func (b *pooledBody) Len() int {
+	return b.size
+}
+
+// pooledReader reads a pooledBody and holds a reference to its buffer until
+// it is closed.
+type pooledReader struct {
+	mu     sync.Mutex
+	body   *pooledBody
+	reader *bytes.Reader
+}
+
+// Read implements io.Reader.
+func (r *pooledReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reader == nil {
+		return 0, io.EOF
+	}
+	return r.reader.Read(p)
+}
+
+// Close implements io.Closer and releases the reader's reference.
+func (r *pooledReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reader != nil {
+		r.reader = nil
+		r.body.release()
+	}
+	return nil
+}
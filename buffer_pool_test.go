@@ -0,0 +1,214 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPooledBody(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString(`{"quantity":1}`)
+	body := newPooledBody(buf)
+
+	if body.Len() != 14 {
+		t.Errorf("Len() = %d, want 14", body.Len())
+	}
+	data, err := io.ReadAll(body)
+	if err != nil || string(data) != `{"quantity":1}` {
+		t.Fatalf("ReadAll() = %q, %v", data, err)
+	}
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+	if n, err := body.Read(make([]byte, 4)); n != 0 || err != io.EOF {
+		t.Errorf("Read() after Close = %d, %v, want 0, EOF", n, err)
+	}
+}
+
+func TestPooledBody_Open(t *testing.T) {
+	buf := getBuffer()
+	buf.WriteString(`{"quantity":1}`)
+	body := newPooledBody(buf)
+
+	first, err := body.Open()
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	second, _ := body.Open()
+	_ = body.Close()
+	for i, reader := range []io.ReadCloser{first, second} {
+		if data, _ := io.ReadAll(reader); string(data) != `{"quantity":1}` {
+			t.Errorf("reader %d read %q", i, data)
+		}
+	}
+
+	_ = first.Close()
+	if body.buf == nil {
+		t.Fatal("buffer released while a reader is open")
+	}
+	_ = second.Close()
+	if body.buf != nil {
+		t.Error("buffer not released after every reader closed")
+	}
+	if _, err := body.Open(); err == nil {
+		t.Error("Open() after release error = nil")
+	}
+}
+
+func TestPutBuffer_DropsLargeBuffers(t *testing.T) {
+	large := bytes.NewBuffer(make([]byte, 0, maxPooledBufferSize+1))
+	putBuffer(large)
+	if got := getBuffer(); got == large {
+		t.Error("getBuffer() returned a buffer larger than maxPooledBufferSize")
+	}
+}
+
+func TestClient_PooledJSONBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(data)) {
+			t.Errorf("ContentLength = %d, body length %d", r.ContentLength, len(data))
+		}
+		var items []InventoryBulkItemBySKU
+		if err := json.Unmarshal(data, &items); err != nil || len(items) != 1 {
+			t.Errorf("body = %s, err = %v", data, err)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"inventory":[{"id":"%d"}]}`, items[0].TCGPlayerSKU)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRateLimit(1000, 20))
+
+	// Concurrent requests must not see each other's pooled buffers.
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(sku int64) {
+			defer wg.Done()
+			resp, err := client.CreateInventoryBulkBySKU(context.Background(), []InventoryBulkItemBySKU{{TCGPlayerSKU: sku, PriceCents: 100, Quantity: 1}})
+			if err != nil {
+				t.Errorf("CreateInventoryBulkBySKU() error = %v", err)
+				return
+			}
+			if want := fmt.Sprint(sku); len(resp.Inventory) != 1 || resp.Inventory[0].ID != want {
+				t.Errorf("response = %+v, want id %s", resp.Inventory, want)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+}
+
+func benchmarkBulkItems() []InventoryBulkItemBySKU {
+	items := make([]InventoryBulkItemBySKU, 500)
+	for i := range items {
+		items[i] = InventoryBulkItemBySKU{TCGPlayerSKU: int64(4549403 + i), PriceCents: 199, Quantity: 4}
+	}
+	return items
+}
+
+// BenchmarkRequestBodyEncoding compares encoding a bulk update into a fresh
+// buffer per request with encoding into a pooled one.
+func BenchmarkRequestBodyEncoding(b *testing.B) {
+	items := benchmarkBulkItems()
+
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := &bytes.Buffer{}
+			if err := json.NewEncoder(buf).Encode(items); err != nil {
+				b.Fatal(err)
+			}
+			_, _ = io.Copy(io.Discard, buf)
+		}
+	})
+
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf := getBuffer()
+			if err := json.NewEncoder(buf).Encode(items); err != nil {
+				b.Fatal(err)
+			}
+			body := newPooledBody(buf)
+			_, _ = io.Copy(io.Discard, body)
+			_ = body.Close()
+		}
+	})
+}
+
+func BenchmarkClient_CreateInventoryBulkBySKU(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte(`{"inventory":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRateLimit(1e9, 1))
+	items := benchmarkBulkItems()
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.CreateInventoryBulkBySKU(ctx, items); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestClient_RetriedPUTResendsBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(data)) {
+			t.Errorf("attempt %d: ContentLength = %d, body length %d", len(bodies)+1, r.ContentLength, len(data))
+		}
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"inventory":{"id":"i1"}}`))
+	}))
+	defer server.Close()
+
+	var replayable bool
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		replayable = r.GetBody != nil
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"),
+		WithHTTPClient(&http.Client{Transport: transport}), WithRetry(1, time.Millisecond))
+
+	update := InventoryUpdateRequest{PriceCents: 199, Quantity: 4}
+	if _, err := client.UpdateSellerInventoryByProduct(context.Background(), ProductTypeSingle, "p1", update); err != nil {
+		t.Fatalf("UpdateSellerInventoryByProduct() error = %v", err)
+	}
+	want := `{"price_cents":199,"quantity":4}` + "\n"
+	if len(bodies) != 2 || bodies[0] != want || bodies[1] != want {
+		t.Errorf("bodies = %q, want the update twice", bodies)
+	}
+	if !replayable {
+		t.Error("request has no GetBody, so the transport cannot replay it")
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
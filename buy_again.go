@@ -0,0 +1,157 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// conditionRank orders condition IDs from best to worst.
+var conditionRank = []string{"NM", "LP", "MP", "HP", "DMG"}
+
+// BuyAgainOptions controls BuyerOrderDetails.BuyAgainRequest.
+type BuyAgainOptions struct {
+	// SellerID limits the request to items sold by one seller, e.g. to
+	// replace a single lost package. Empty includes every seller.
+	SellerID string
+
+	// AnyPrinting requests singles by name only instead of the same set and
+	// collector number.
+	AnyPrinting bool
+
+	// AnyFinish, AnyCondition and AnyLanguage drop the corresponding
+	// restriction. By default each single is restricted to the same finish,
+	// the same or a better condition, and the same language.
+	AnyFinish    bool
+	AnyCondition bool
+	AnyLanguage  bool
+
+	// Model and DestinationCountry are copied to the request.
+	Model              string
+	DestinationCountry string
+}
+
+// BuyAgainRequest builds an optimizer request for the items in a previous
+// order. Singles are requested by name, printing, finish, condition and
+// language as purchased, and sealed products by MTGJSON ID. Identical items
+// are combined. The restrictions are strict; pass the request to
+// OptimizeCartWithRelaxation to treat finish, condition and language as
+// preferences instead.
+//
+// Example:
+//
+//	order, err := client.GetBuyerOrder(ctx, id)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	req, err := order.Order.BuyAgainRequest(manapool.BuyAgainOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.OptimizeCartWithRelaxation(ctx, req, manapool.RelaxationOptions{})
+func (o BuyerOrderDetails) BuyAgainRequest(opts BuyAgainOptions) (OptimizerRequest, error) {
+	req := OptimizerRequest{Model: opts.Model, DestinationCountry: opts.DestinationCountry}
+	positions := make(map[string]int)
+	sellerFound := false
+
+	for _, detail := range o.OrderSellerDetail {
+		if opts.SellerID != "" && detail.SellerID != opts.SellerID {
+			continue
+		}
+		sellerFound = true
+
+		for _, orderItem := range detail.Items {
+			item, ok := buyAgainItem(orderItem.Product, opts)
+			if !ok || orderItem.Quantity <= 0 {
+				continue
+			}
+			key := buyAgainKey(item)
+			if i, ok := positions[key]; ok {
+				req.Cart[i].QuantityRequested += orderItem.Quantity
+				continue
+			}
+			item.QuantityRequested = orderItem.Quantity
+			positions[key] = len(req.Cart)
+			req.Cart = append(req.Cart, item)
+		}
+	}
+
+	if opts.SellerID != "" && !sellerFound {
+		return OptimizerRequest{}, NewValidationError("seller_id", fmt.Sprintf("order %s has no items from seller %s", o.ID, opts.SellerID))
+	}
+	if len(req.Cart) == 0 {
+		return OptimizerRequest{}, NewValidationError("order", fmt.Sprintf("order %s has no items that can be repurchased", o.ID))
+	}
+	return req, nil
+}
+
+// BuyAgain fetches a buyer order and optimizes a cart for its items, relaxing
+// finish, condition and language restrictions if the exact items are not
+// available.
+//
+// Example:
+//
+//	result, err := client.BuyAgain(ctx, orderID, manapool.BuyAgainOptions{SellerID: lostSellerID})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Replacement cart: $%.2f\n", float64(result.Cart.Totals.TotalCents)/100)
+func (c *Client) BuyAgain(ctx context.Context, orderID string, opts BuyAgainOptions) (*RelaxedOptimization, error) {
+	order, err := c.GetBuyerOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := order.Order.BuyAgainRequest(opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.OptimizeCartWithRelaxation(ctx, req, RelaxationOptions{})
+}
+
+func buyAgainItem(product BuyerOrderProduct, opts BuyAgainOptions) (OptimizerCartItem, bool) {
+	switch {
+	case product.Single != nil && product.Single.Name != "":
+		single := product.Single
+		item := OptimizerCartItem{Type: "mtg_single", Name: single.Name}
+		if !opts.AnyPrinting {
+			item.SetCode = strings.ToUpper(single.Set)
+			item.CollectorNumber = single.Number
+		}
+		if !opts.AnyFinish && single.FinishID != "" {
+			item.FinishIDs = []string{single.FinishID}
+		}
+		if !opts.AnyCondition {
+			item.ConditionIDs = conditionsAtLeast(single.ConditionID)
+		}
+		if !opts.AnyLanguage && single.LanguageID != "" {
+			item.LanguageIDs = []string{single.LanguageID}
+		}
+		return item, true
+	case product.Sealed != nil && product.Sealed.MTGJsonID != "":
+		id := product.Sealed.MTGJsonID
+		item := OptimizerCartItem{Type: "mtg_sealed", MTGJsonID: &id}
+		if !opts.AnyLanguage && product.Sealed.LanguageID != "" {
+			item.LanguageIDs = []string{product.Sealed.LanguageID}
+		}
+		return item, true
+	}
+	return OptimizerCartItem{}, false
+}
+
+// conditionsAtLeast returns condition and every better condition, or nil if
+// condition is unknown.
+func conditionsAtLeast(condition string) []string {
+	for i, id := range conditionRank {
+		if id == condition {
+			return append([]string(nil), conditionRank[:i+1]...)
+		}
+	}
+	return nil
+}
+
+func buyAgainKey(item OptimizerCartItem) string {
+	return strings.Join([]string{
+		item.Type, collectionKey(item.Name), item.SetCode, item.CollectorNumber, StringValue(item.MTGJsonID),
+		strings.Join(item.FinishIDs, ","), strings.Join(item.ConditionIDs, ","), strings.Join(item.LanguageIDs, ","),
+	}, "|")
+}
@@ -0,0 +1,95 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const buyAgainOrderJSON = `{"order":{"id":"o1","order_seller_details":[
+	{"seller_id":"s1","seller_username":"one","item_count":3,"items":[
+		{"price_cents":100,"quantity":2,"product":{"product_type":"mtg_single","product_id":"p1","single":{"name":"Lightning Bolt","set":"m10","number":"146","language_id":"EN","condition_id":"LP","finish_id":"NF"}}},
+		{"price_cents":100,"quantity":1,"product":{"product_type":"mtg_single","product_id":"p1","single":{"name":"Lightning Bolt","set":"m10","number":"146","language_id":"EN","condition_id":"LP","finish_id":"NF"}}}
+	]},
+	{"seller_id":"s2","seller_username":"two","item_count":1,"items":[
+		{"price_cents":9000,"quantity":1,"product":{"product_type":"mtg_sealed","product_id":"p2","sealed":{"mtgjson_id":"box-1","name":"Booster Box","language_id":"EN"}}}
+	]}
+]}}`
+
+func TestBuyerOrderDetails_BuyAgainRequest(t *testing.T) {
+	var resp BuyerOrderResponse
+	if err := json.Unmarshal([]byte(buyAgainOrderJSON), &resp); err != nil {
+		t.Fatalf("failed to decode order: %v", err)
+	}
+	order := resp.Order
+
+	req, err := order.BuyAgainRequest(BuyAgainOptions{Model: OptimizerModelLowestPrice})
+	if err != nil {
+		t.Fatalf("BuyAgainRequest() error = %v", err)
+	}
+	if req.Model != OptimizerModelLowestPrice || len(req.Cart) != 2 {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	bolt := req.Cart[0]
+	if bolt.Name != "Lightning Bolt" || bolt.SetCode != "M10" || bolt.CollectorNumber != "146" || bolt.QuantityRequested != 3 {
+		t.Errorf("unexpected single: %+v", bolt)
+	}
+	if !reflect.DeepEqual(bolt.ConditionIDs, []string{"NM", "LP"}) || !reflect.DeepEqual(bolt.FinishIDs, []string{"NF"}) ||
+		!reflect.DeepEqual(bolt.LanguageIDs, []string{"EN"}) {
+		t.Errorf("unexpected single restrictions: %+v", bolt)
+	}
+	box := req.Cart[1]
+	if box.Type != "mtg_sealed" || box.MTGJsonID == nil || *box.MTGJsonID != "box-1" || box.QuantityRequested != 1 {
+		t.Errorf("unexpected sealed item: %+v", box)
+	}
+
+	loose, err := order.BuyAgainRequest(BuyAgainOptions{SellerID: "s1", AnyPrinting: true, AnyCondition: true})
+	if err != nil {
+		t.Fatalf("BuyAgainRequest() error = %v", err)
+	}
+	if len(loose.Cart) != 1 || loose.Cart[0].SetCode != "" || loose.Cart[0].ConditionIDs != nil {
+		t.Errorf("unexpected loose request: %+v", loose.Cart)
+	}
+
+	var valErr *ValidationError
+	if _, err := order.BuyAgainRequest(BuyAgainOptions{SellerID: "missing"}); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for unknown seller, got %v", err)
+	}
+	if _, err := (BuyerOrderDetails{ID: "empty"}).BuyAgainRequest(BuyAgainOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for empty order, got %v", err)
+	}
+}
+
+func TestClient_BuyAgain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/orders/o1":
+			_, _ = w.Write([]byte(buyAgainOrderJSON))
+		case "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode optimizer request: %v", err)
+			}
+			if len(req.Cart) != 1 || req.Cart[0].Type != "mtg_sealed" {
+				t.Errorf("unexpected cart: %+v", req.Cart)
+			}
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv","quantity_selected":1}],"totals":{"subtotal_cents":9000,"shipping_cents":0,"total_cents":9000,"seller_count":1}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	result, err := client.BuyAgain(context.Background(), "o1", BuyAgainOptions{SellerID: "s2"})
+	if err != nil {
+		t.Fatalf("BuyAgain() error = %v", err)
+	}
+	if result.Cart.Totals.TotalCents != 9000 || result.Attempts != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
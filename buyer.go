@@ -3,13 +3,14 @@ package manapool
 import (
 	"context"
 	"fmt"
-	"net/url"
-	"strconv"
-	"time"
 )
 
 // OptimizeCart creates an optimized cart.
 func (c *Client) OptimizeCart(ctx context.Context, req OptimizerRequest) (*OptimizedCart, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.doJSONRequest(ctx, "POST", "/buyer/optimizer", nil, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to optimize cart: %w", err)
@@ -25,18 +26,20 @@ func (c *Client) OptimizeCart(ctx context.Context, req OptimizerRequest) (*Optim
 
 // GetBuyerOrders retrieves buyer orders with optional filtering.
 func (c *Client) GetBuyerOrders(ctx context.Context, opts BuyerOrdersOptions) (*BuyerOrdersResponse, error) {
-	params := url.Values{}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := newQuery()
 	if opts.Since != nil {
-		params.Add("since", opts.Since.Format(time.RFC3339Nano))
+		params.add("since", formatTimestamp(*opts.Since, c.timestampLayout))
 	}
 	if opts.Limit > 0 {
-		params.Add("limit", strconv.Itoa(opts.Limit))
-	}
-	if opts.Offset > 0 {
-		params.Add("offset", strconv.Itoa(opts.Offset))
+		params.addInt("limit", opts.Limit)
 	}
+	params.addInt("offset", opts.Offset)
 
-	resp, err := c.doRequest(ctx, "GET", "/buyer/orders", params)
+	resp, err := c.doQueryRequest(ctx, "GET", "/buyer/orders", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get buyer orders: %w", err)
 	}
@@ -49,13 +52,47 @@ func (c *Client) GetBuyerOrders(ctx context.Context, opts BuyerOrdersOptions) (*
 	return &orders, nil
 }
 
+// IterateBuyerOrders pages through buyer orders matching opts and calls
+// callback for each order summary. opts.Offset is used as the starting offset
+// and opts.Limit as the page size (default: 100).
+func (c *Client) IterateBuyerOrders(ctx context.Context, opts BuyerOrdersOptions, callback func(*BuyerOrderSummary) error) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultOrdersPageSize
+	}
+
+	for {
+		resp, err := c.GetBuyerOrders(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get buyer orders at offset %d: %w", opts.Offset, err)
+		}
+
+		for i := range resp.Orders {
+			if err := callback(&resp.Orders[i]); err != nil {
+				return fmt.Errorf("callback error at offset %d: %w", opts.Offset, err)
+			}
+		}
+
+		if len(resp.Orders) == 0 || len(resp.Orders) < opts.Limit {
+			return nil
+		}
+		if resp.Pagination.Total > 0 && opts.Offset+len(resp.Orders) >= resp.Pagination.Total {
+			return nil
+		}
+
+		opts.Offset += len(resp.Orders)
+	}
+}
+
 // GetBuyerOrder retrieves a buyer order by ID.
 func (c *Client) GetBuyerOrder(ctx context.Context, id string) (*BuyerOrderResponse, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/buyer/orders/%s", id)
+	endpoint := endpointPath("/buyer/orders/%s", id)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get buyer order: %w", err)
@@ -69,8 +106,33 @@ func (c *Client) GetBuyerOrder(ctx context.Context, id string) (*BuyerOrderRespo
 	return &order, nil
 }
 
+// LineItems returns the cart's listings as pending order line items.
+//
+// Example:
+//
+//	pending, err := client.CreatePendingOrder(ctx, manapool.PendingOrderRequest{LineItems: cart.LineItems()})
+func (c OptimizedCart) LineItems() []PendingOrderLineItem {
+	items := make([]PendingOrderLineItem, 0, len(c.Cart))
+	for _, item := range c.Cart {
+		items = append(items, PendingOrderLineItem{
+			InventoryID:      item.InventoryID,
+			QuantitySelected: item.QuantitySelected,
+		})
+	}
+	return items
+}
+
 // CreatePendingOrder creates a pending order.
+// Shipping overrides are validated with ValidateShippingOverrides before the request is sent,
+// and addresses are checked by the client's address validator, if any, and then by Address.Validate.
 func (c *Client) CreatePendingOrder(ctx context.Context, req PendingOrderRequest) (*PendingOrder, error) {
+	if err := ValidateShippingOverrides(req.ShippingOverrides); err != nil {
+		return nil, err
+	}
+	if err := c.validatePendingOrderAddresses(ctx, &req); err != nil {
+		return nil, err
+	}
+
 	resp, err := c.doJSONRequest(ctx, "POST", "/buyer/orders/pending-orders", nil, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pending order: %w", err)
@@ -90,7 +152,7 @@ func (c *Client) GetPendingOrder(ctx context.Context, id string) (*PendingOrder,
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/buyer/orders/pending-orders/%s", id)
+	endpoint := endpointPath("/buyer/orders/pending-orders/%s", id)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending order: %w", err)
@@ -105,12 +167,20 @@ func (c *Client) GetPendingOrder(ctx context.Context, id string) (*PendingOrder,
 }
 
 // UpdatePendingOrder updates a pending order.
+// Shipping overrides are validated with ValidateShippingOverrides before the request is sent,
+// and addresses are checked by the client's address validator, if any, and then by Address.Validate.
 func (c *Client) UpdatePendingOrder(ctx context.Context, id string, req PendingOrderRequest) (*PendingOrder, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
+	if err := ValidateShippingOverrides(req.ShippingOverrides); err != nil {
+		return nil, err
+	}
+	if err := c.validatePendingOrderAddresses(ctx, &req); err != nil {
+		return nil, err
+	}
 
-	endpoint := fmt.Sprintf("/buyer/orders/pending-orders/%s", id)
+	endpoint := endpointPath("/buyer/orders/pending-orders/%s", id)
 	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, nil, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update pending order: %w", err)
@@ -125,12 +195,25 @@ func (c *Client) UpdatePendingOrder(ctx context.Context, id string, req PendingO
 }
 
 // PurchasePendingOrder purchases a pending order.
+// Addresses are checked by the client's address validator, if any, and then
+// by Address.Validate; both must also include a recipient name.
 func (c *Client) PurchasePendingOrder(ctx context.Context, id string, req PurchasePendingOrderRequest) (*PendingOrder, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
+	if err := c.validatePurchaseAddress(ctx, "billing_address", &req.BillingAddress); err != nil {
+		return nil, err
+	}
+	if err := c.validatePurchaseAddress(ctx, "shipping_address", &req.ShippingAddress); err != nil {
+		return nil, err
+	}
+	return c.purchasePendingOrder(ctx, id, req)
+}
 
-	endpoint := fmt.Sprintf("/buyer/orders/pending-orders/%s/purchase", id)
+// purchasePendingOrder purchases a pending order with addresses that have
+// already been validated.
+func (c *Client) purchasePendingOrder(ctx context.Context, id string, req PurchasePendingOrderRequest) (*PendingOrder, error) {
+	endpoint := endpointPath("/buyer/orders/pending-orders/%s/purchase", id)
 	resp, err := c.doJSONRequest(ctx, "POST", endpoint, nil, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to purchase pending order: %w", err)
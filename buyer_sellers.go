@@ -0,0 +1,170 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HasSeller reports whether any part of the order was sold by seller, which
+// may be a seller ID or a case-insensitive username.
+func (o BuyerOrderSummary) HasSeller(seller string) bool {
+	for _, detail := range o.OrderSellerDetail {
+		if detail.SellerID == seller || strings.EqualFold(detail.SellerUsername, seller) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterBuyerOrdersBySeller returns the orders that include items from
+// seller, which may be a seller ID or username.
+func FilterBuyerOrdersBySeller(orders []BuyerOrderSummary, seller string) []BuyerOrderSummary {
+	var filtered []BuyerOrderSummary
+	for _, order := range orders {
+		if order.HasSeller(seller) {
+			filtered = append(filtered, order)
+		}
+	}
+	return filtered
+}
+
+// GetBuyerOrdersBySeller returns every buyer order matching opts that
+// includes items from seller, which may be a seller ID or username. The API
+// has no seller filter, so all pages from opts.Offset onward are fetched and
+// filtered client-side.
+//
+// Example:
+//
+//	orders, err := client.GetBuyerOrdersBySeller(ctx, "cardshop", manapool.BuyerOrdersOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d orders from cardshop\n", len(orders))
+func (c *Client) GetBuyerOrdersBySeller(ctx context.Context, seller string, opts BuyerOrdersOptions) ([]BuyerOrderSummary, error) {
+	if seller == "" {
+		return nil, NewValidationError("seller", "seller cannot be empty")
+	}
+
+	var orders []BuyerOrderSummary
+	err := c.IterateBuyerOrders(ctx, opts, func(order *BuyerOrderSummary) error {
+		if order.HasSeller(seller) {
+			orders = append(orders, *order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// SellerSpend is the buyer's total spend with one seller.
+type SellerSpend struct {
+	SellerID       string
+	SellerUsername string
+	OrderCount     int
+	ItemCount      int
+	SubtotalCents  int
+	ShippingCents  int
+	TaxCents       int
+	TotalCents     int
+}
+
+// SpendBySeller totals orders per seller, largest total first. Multi-seller
+// orders are split with BuyerOrderSummary.SplitBySeller; when a summary has
+// no item prices, its subtotal is allocated by item count instead, and
+// shipping and tax follow that allocation.
+func SpendBySeller(orders []BuyerOrderSummary) []SellerSpend {
+	bySeller := make(map[string]*SellerSpend)
+	var keys []string
+	for _, order := range orders {
+		splits := order.SplitBySeller()
+		subtotal := 0
+		for _, split := range splits {
+			subtotal += split.SubtotalCents
+		}
+		if subtotal == 0 && order.SubtotalCents > 0 {
+			weights := make([]int, len(splits))
+			for i, split := range splits {
+				weights[i] = split.ItemCount
+			}
+			subtotals := allocateCents(order.SubtotalCents, weights)
+			shipping := allocateCents(order.ShippingCents, subtotals)
+			tax := allocateCents(order.TaxCents, subtotals)
+			for i := range splits {
+				splits[i].SubtotalCents = subtotals[i]
+				splits[i].ShippingCents = shipping[i]
+				splits[i].TaxCents = tax[i]
+				splits[i].TotalCents = subtotals[i] + shipping[i] + tax[i]
+			}
+		}
+
+		for _, split := range splits {
+			key := split.SellerID
+			if key == "" {
+				key = strings.ToLower(split.SellerUsername)
+			}
+			spend, ok := bySeller[key]
+			if !ok {
+				spend = &SellerSpend{SellerID: split.SellerID, SellerUsername: split.SellerUsername}
+				bySeller[key] = spend
+				keys = append(keys, key)
+			}
+			spend.OrderCount++
+			spend.ItemCount += split.ItemCount
+			spend.SubtotalCents += split.SubtotalCents
+			spend.ShippingCents += split.ShippingCents
+			spend.TaxCents += split.TaxCents
+			spend.TotalCents += split.TotalCents
+		}
+	}
+
+	spends := make([]SellerSpend, 0, len(keys))
+	for _, key := range keys {
+		spends = append(spends, *bySeller[key])
+	}
+	sort.SliceStable(spends, func(i, j int) bool {
+		return spends[i].TotalCents > spends[j].TotalCents
+	})
+	return spends
+}
+
+// BuyerSpendBySeller totals the buyer's spend per seller for orders created
+// in [since, until). A zero until means no upper bound.
+//
+// Example:
+//
+//	start := time.Now().AddDate(0, -1, 0)
+//	spends, err := client.BuyerSpendBySeller(ctx, start, time.Time{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range spends {
+//	    fmt.Printf("%s: $%.2f over %d orders\n", s.SellerUsername, float64(s.TotalCents)/100, s.OrderCount)
+//	}
+func (c *Client) BuyerSpendBySeller(ctx context.Context, since, until time.Time) ([]SellerSpend, error) {
+	if !until.IsZero() && !until.After(since) {
+		return nil, NewValidationError("until", "until must be after since")
+	}
+
+	opts := BuyerOrdersOptions{}
+	if !since.IsZero() {
+		opts.Since = &Timestamp{Time: since}
+	}
+
+	var orders []BuyerOrderSummary
+	err := c.IterateBuyerOrders(ctx, opts, func(order *BuyerOrderSummary) error {
+		if order.CreatedAt.Before(since) || (!until.IsZero() && !order.CreatedAt.Before(until)) {
+			return nil
+		}
+		orders = append(orders, *order)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to total buyer spend: %w", err)
+	}
+	return SpendBySeller(orders), nil
+}
@@ -0,0 +1,115 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const buyerSellerOrdersJSON = `{"orders":[
+	{"id":"o1","created_at":"2024-03-01T00:00:00Z","subtotal_cents":1000,"shipping_cents":100,"tax_cents":0,"total_cents":1100,"order_seller_details":[
+		{"seller_id":"s1","seller_username":"CardShop","item_count":1,"items":[{"price_cents":1000,"quantity":1}]}]},
+	{"id":"o2","created_at":"2024-03-15T00:00:00Z","subtotal_cents":600,"shipping_cents":200,"tax_cents":0,"total_cents":800,"order_seller_details":[
+		{"seller_id":"s1","seller_username":"CardShop","item_count":1},
+		{"seller_id":"s2","seller_username":"other","item_count":2}]},
+	{"id":"o3","created_at":"2024-04-10T00:00:00Z","subtotal_cents":500,"shipping_cents":0,"tax_cents":0,"total_cents":500,"order_seller_details":[
+		{"seller_id":"s2","seller_username":"other","item_count":1,"items":[{"price_cents":500,"quantity":1}]}]}
+]}`
+
+func newBuyerSellerServer(t *testing.T, limits *[]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/buyer/orders" {
+			http.NotFound(w, r)
+			return
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limits != nil {
+			*limits = append(*limits, limit)
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			_, _ = w.Write([]byte(`{"orders":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(buyerSellerOrdersJSON))
+	}))
+}
+
+func TestClient_GetBuyerOrdersBySeller(t *testing.T) {
+	server := newBuyerSellerServer(t, nil)
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	orders, err := client.GetBuyerOrdersBySeller(context.Background(), "cardshop", BuyerOrdersOptions{})
+	if err != nil {
+		t.Fatalf("GetBuyerOrdersBySeller() error = %v", err)
+	}
+	if len(orders) != 2 || orders[0].ID != "o1" || orders[1].ID != "o2" {
+		t.Errorf("unexpected orders: %+v", orders)
+	}
+
+	byID, err := client.GetBuyerOrdersBySeller(context.Background(), "s2", BuyerOrdersOptions{})
+	if err != nil {
+		t.Fatalf("GetBuyerOrdersBySeller() error = %v", err)
+	}
+	if len(byID) != 2 || byID[0].ID != "o2" {
+		t.Errorf("unexpected orders for seller ID: %+v", byID)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.GetBuyerOrdersBySeller(context.Background(), "", BuyerOrdersOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for empty seller, got %v", err)
+	}
+}
+
+func TestClient_BuyerSpendBySeller(t *testing.T) {
+	var limits []int
+	server := newBuyerSellerServer(t, &limits)
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	spends, err := client.BuyerSpendBySeller(context.Background(), since, until)
+	if err != nil {
+		t.Fatalf("BuyerSpendBySeller() error = %v", err)
+	}
+	if len(limits) == 0 || limits[0] != 100 {
+		t.Errorf("page limits = %v, want 100", limits)
+	}
+	if len(spends) != 2 {
+		t.Fatalf("unexpected spends: %+v", spends)
+	}
+	// o1 is all CardShop; o2 has no item prices, so its subtotal is split
+	// 1:2 by item count and shipping follows the subtotal.
+	shop, other := spends[0], spends[1]
+	if shop.SellerID != "s1" || shop.OrderCount != 2 || shop.SubtotalCents != 1200 || shop.TotalCents != 1367 {
+		t.Errorf("unexpected CardShop spend: %+v", shop)
+	}
+	if other.SellerID != "s2" || other.OrderCount != 1 || other.SubtotalCents != 400 || other.TotalCents != 533 {
+		t.Errorf("unexpected other spend: %+v", other)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.BuyerSpendBySeller(context.Background(), until, since); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for reversed period, got %v", err)
+	}
+}
+
+func TestFilterBuyerOrdersBySeller(t *testing.T) {
+	orders := []BuyerOrderSummary{
+		{ID: "a", OrderSellerDetail: []BuyerOrderSellerDetail{{SellerID: "s1", SellerUsername: "One"}}},
+		{ID: "b", OrderSellerDetail: []BuyerOrderSellerDetail{{SellerID: "s2", SellerUsername: "Two"}}},
+	}
+	if got := FilterBuyerOrdersBySeller(orders, "two"); len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("FilterBuyerOrdersBySeller() = %+v", got)
+	}
+	if got := FilterBuyerOrdersBySeller(orders, "missing"); len(got) != 0 {
+		t.Errorf("FilterBuyerOrdersBySeller() = %+v, want none", got)
+	}
+}
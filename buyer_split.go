@@ -0,0 +1,117 @@
+package manapool
+
+// BuyerSellerSplit is the portion of a buyer order fulfilled by one seller.
+type BuyerSellerSplit struct {
+	SellerID       string
+	SellerUsername string
+	OrderNumber    string
+	ItemCount      int
+	Items          []BuyerOrderItem
+	Fulfillments   []BuyerOrderFulfillment
+
+	// SubtotalCents is the sum of the seller's item prices.
+	SubtotalCents int
+
+	// ShippingCents and TaxCents are the seller's share of the order's
+	// shipping and tax, allocated in proportion to SubtotalCents.
+	ShippingCents int
+	TaxCents      int
+
+	TotalCents int
+}
+
+// SplitBySeller returns per-seller totals for the order. Item subtotals come
+// from each seller's items; order-level shipping and tax are allocated in
+// proportion to each seller's subtotal so the shares add up to the order totals.
+//
+// Example:
+//
+//	order, err := client.GetBuyerOrder(ctx, id)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, split := range order.Order.SplitBySeller() {
+//	    fmt.Printf("%s: $%.2f\n", split.SellerUsername, float64(split.TotalCents)/100)
+//	}
+func (o BuyerOrderDetails) SplitBySeller() []BuyerSellerSplit {
+	return splitBuyerOrder(o.OrderSellerDetail, o.ShippingCents, o.TaxCents)
+}
+
+// SplitBySeller returns per-seller totals for the order summary.
+// See BuyerOrderDetails.SplitBySeller.
+func (o BuyerOrderSummary) SplitBySeller() []BuyerSellerSplit {
+	return splitBuyerOrder(o.OrderSellerDetail, o.ShippingCents, o.TaxCents)
+}
+
+func splitBuyerOrder(details []BuyerOrderSellerDetail, shippingCents, taxCents int) []BuyerSellerSplit {
+	splits := make([]BuyerSellerSplit, len(details))
+	weights := make([]int, len(details))
+	for i, detail := range details {
+		split := BuyerSellerSplit{
+			SellerID:       detail.SellerID,
+			SellerUsername: detail.SellerUsername,
+			OrderNumber:    detail.OrderNumber,
+			ItemCount:      detail.ItemCount,
+			Items:          detail.Items,
+			Fulfillments:   detail.Fulfillments,
+		}
+		for _, item := range detail.Items {
+			split.SubtotalCents += item.PriceCents * item.Quantity
+		}
+		splits[i] = split
+		weights[i] = split.SubtotalCents
+	}
+
+	shipping := allocateCents(shippingCents, weights)
+	tax := allocateCents(taxCents, weights)
+	for i := range splits {
+		splits[i].ShippingCents = shipping[i]
+		splits[i].TaxCents = tax[i]
+		splits[i].TotalCents = splits[i].SubtotalCents + shipping[i] + tax[i]
+	}
+
+	return splits
+}
+
+// allocateCents splits amount across weights using the largest remainder
+// method so that the shares always sum to amount. When all weights are zero
+// the amount is split evenly.
+func allocateCents(amount int, weights []int) []int {
+	shares := make([]int, len(weights))
+	if len(weights) == 0 || amount == 0 {
+		return shares
+	}
+
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		weights = make([]int, len(shares))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = len(weights)
+	}
+
+	remainders := make([]int, len(weights))
+	allocated := 0
+	for i, w := range weights {
+		shares[i] = amount * w / total
+		remainders[i] = amount * w % total
+		allocated += shares[i]
+	}
+
+	for left := amount - allocated; left > 0; left-- {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		shares[best]++
+		remainders[best] = -1
+	}
+
+	return shares
+}
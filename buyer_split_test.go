@@ -0,0 +1,71 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuyerOrderDetails_SplitBySeller(t *testing.T) {
+	order := BuyerOrderDetails{
+		SubtotalCents: 3000,
+		ShippingCents: 100,
+		TaxCents:      301,
+		TotalCents:    3401,
+		OrderSellerDetail: []BuyerOrderSellerDetail{
+			{SellerID: "s1", SellerUsername: "alpha", OrderNumber: "1-1", ItemCount: 2, Items: []BuyerOrderItem{
+				{PriceCents: 500, Quantity: 2},
+			}},
+			{SellerID: "s2", SellerUsername: "beta", OrderNumber: "1-2", ItemCount: 1, Items: []BuyerOrderItem{
+				{PriceCents: 2000, Quantity: 1},
+			}},
+		},
+	}
+
+	splits := order.SplitBySeller()
+	if len(splits) != 2 {
+		t.Fatalf("splits = %d, want 2", len(splits))
+	}
+	if splits[0].SubtotalCents != 1000 || splits[1].SubtotalCents != 2000 {
+		t.Fatalf("subtotals = %d, %d", splits[0].SubtotalCents, splits[1].SubtotalCents)
+	}
+	if splits[0].ShippingCents+splits[1].ShippingCents != 100 {
+		t.Fatalf("shipping shares do not sum to order shipping: %+v", splits)
+	}
+	if splits[0].TaxCents != 100 || splits[1].TaxCents != 201 {
+		t.Fatalf("tax shares = %d, %d, want 100 and 201", splits[0].TaxCents, splits[1].TaxCents)
+	}
+	if splits[0].TotalCents+splits[1].TotalCents != order.TotalCents {
+		t.Fatalf("split totals do not sum to order total")
+	}
+	if splits[1].SellerUsername != "beta" || splits[1].OrderNumber != "1-2" {
+		t.Fatalf("seller metadata not copied: %+v", splits[1])
+	}
+
+	summary := BuyerOrderSummary{ShippingCents: 10, OrderSellerDetail: order.OrderSellerDetail}
+	if got := summary.SplitBySeller(); len(got) != 2 || got[0].ShippingCents+got[1].ShippingCents != 10 {
+		t.Fatalf("summary split mismatch: %+v", got)
+	}
+}
+
+func TestAllocateCents(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int
+		weights []int
+		want    []int
+	}{
+		{"empty", 100, nil, []int{}},
+		{"zero amount", 0, []int{1, 2}, []int{0, 0}},
+		{"proportional", 100, []int{1, 3}, []int{25, 75}},
+		{"remainder", 100, []int{1, 1, 1}, []int{34, 33, 33}},
+		{"zero weights split evenly", 5, []int{0, 0}, []int{3, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := allocateCents(tt.amount, tt.weights); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("allocateCents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
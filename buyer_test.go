@@ -115,7 +115,7 @@ func TestClient_BuyerEndpoints(t *testing.T) {
 			t.Fatalf("pending subtotal = %d, want 2000", pending.Totals.SubtotalCents)
 		}
 
-		pending, err = client.PurchasePendingOrder(ctx, "123", PurchasePendingOrderRequest{PaymentMethod: "user_credit", BillingAddress: Address{Line1: "line", City: "City", State: "CA", PostalCode: "12345", Country: "US"}, ShippingAddress: Address{Line1: "line", City: "City", State: "CA", PostalCode: "12345", Country: "US"}})
+		pending, err = client.PurchasePendingOrder(ctx, "123", PurchasePendingOrderRequest{PaymentMethod: "user_credit", BillingAddress: Address{Name: "Jane Doe", Line1: "line", City: "City", State: "CA", PostalCode: "12345", Country: "US"}, ShippingAddress: Address{Name: "Jane Doe", Line1: "line", City: "City", State: "CA", PostalCode: "12345", Country: "US"}})
 		if err != nil {
 			t.Fatalf("PurchasePendingOrder error: %v", err)
 		}
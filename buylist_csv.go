@@ -0,0 +1,184 @@
+package manapool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Default header names recognized by LoadBuylistCSV when a column is not
+// configured. Matching is case-insensitive.
+var (
+	buylistNameHeaders     = []string{"name", "card name", "card"}
+	buylistSetHeaders      = []string{"set", "set code", "edition", "set_code"}
+	buylistNumberHeaders   = []string{"number", "collector number", "collector_number", "cn", "#"}
+	buylistQuantityHeaders = []string{"qty", "quantity", "count", "amount"}
+	buylistMaxPriceHeaders = []string{"max price", "max_price", "maxprice", "max", "price limit", "budget"}
+)
+
+// BuylistCSVOptions configures LoadBuylistCSV. Each column option names the
+// header of that column; when empty, common header names are tried. Only the
+// name column is required.
+type BuylistCSVOptions struct {
+	NameColumn     string
+	SetColumn      string
+	NumberColumn   string
+	QuantityColumn string
+
+	// MaxPriceColumn holds the highest acceptable price per copy in dollars,
+	// e.g. "4.99" or "$4.99". It is stored in Buylist.MaxPriceCents.
+	MaxPriceColumn string
+
+	// Comma is the field delimiter (default: ',').
+	Comma rune
+}
+
+// Buylist is a buylist read by LoadBuylistCSV.
+type Buylist struct {
+	// Items holds one mtg_single cart item per row.
+	Items []OptimizerCartItem
+
+	// MaxPriceCents holds each item's price cap, by position in Items, in
+	// the form PriceCapOptions.MaxPriceCents takes. Zero means no cap.
+	MaxPriceCents []int
+}
+
+// LoadBuylistCSV reads a buylist spreadsheet exported as CSV with a header
+// row and returns one mtg_single cart item per row. Rows with a blank name
+// are skipped and a blank quantity means one copy. A leading UTF-8 byte order
+// mark is ignored.
+//
+// Example:
+//
+//	list, err := manapool.LoadBuylistCSV(file, manapool.BuylistCSVOptions{MaxPriceColumn: "Limit"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.OptimizeCartWithPriceCaps(ctx, manapool.OptimizerRequest{Cart: list.Items},
+//	    manapool.PriceCapOptions{MaxPriceCents: list.MaxPriceCents})
+func LoadBuylistCSV(r io.Reader, opts BuylistCSVOptions) (*Buylist, error) {
+	reader := newCSVReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, NewValidationError("csv", "buylist CSV is empty")
+		}
+		return nil, fmt.Errorf("failed to read buylist header: %w", err)
+	}
+
+	nameCol, err := buylistColumn(header, opts.NameColumn, buylistNameHeaders)
+	if err != nil {
+		return nil, err
+	}
+	if nameCol < 0 {
+		return nil, NewValidationError("csv", "buylist CSV has no name column")
+	}
+	setCol, err := buylistColumn(header, opts.SetColumn, buylistSetHeaders)
+	if err != nil {
+		return nil, err
+	}
+	numberCol, err := buylistColumn(header, opts.NumberColumn, buylistNumberHeaders)
+	if err != nil {
+		return nil, err
+	}
+	qtyCol, err := buylistColumn(header, opts.QuantityColumn, buylistQuantityHeaders)
+	if err != nil {
+		return nil, err
+	}
+	priceCol, err := buylistColumn(header, opts.MaxPriceColumn, buylistMaxPriceHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &Buylist{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read buylist line %d: %w", line, err)
+		}
+		field := func(col int) string {
+			if col < 0 || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+
+		name := field(nameCol)
+		if name == "" {
+			continue
+		}
+		item := OptimizerCartItem{
+			Type:              "mtg_single",
+			Name:              name,
+			SetCode:           strings.ToUpper(field(setCol)),
+			CollectorNumber:   field(numberCol),
+			QuantityRequested: 1,
+		}
+
+		if qty := field(qtyCol); qty != "" {
+			item.QuantityRequested, err = strconv.Atoi(strings.TrimSuffix(strings.ToLower(qty), "x"))
+			if err != nil || item.QuantityRequested <= 0 {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid quantity %q", line, qty))
+			}
+		}
+		maxPrice := 0
+		if price := field(priceCol); price != "" {
+			maxPrice, err = parseDollarsToCents(price)
+			if err != nil {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid max price %q", line, price))
+			}
+		}
+
+		list.Items = append(list.Items, item)
+		list.MaxPriceCents = append(list.MaxPriceCents, maxPrice)
+	}
+
+	return list, nil
+}
+
+// buylistColumn returns the index of the configured column, or of the first
+// header matching a default name when none is configured. It returns -1 if
+// no default matches.
+func buylistColumn(header []string, configured string, defaults []string) (int, error) {
+	if configured != "" {
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), strings.TrimSpace(configured)) {
+				return i, nil
+			}
+		}
+		return -1, NewValidationError("csv", fmt.Sprintf("buylist CSV has no %q column", configured))
+	}
+
+	for _, name := range defaults {
+		for i, column := range header {
+			if strings.EqualFold(strings.TrimSpace(column), name) {
+				return i, nil
+			}
+		}
+	}
+	return -1, nil
+}
+
+// parseDollarsToCents parses a non-negative dollar amount such as "4.99",
+// "$4.99" or "1,299.00" into cents.
+func parseDollarsToCents(s string) (int, error) {
+	s = strings.ReplaceAll(strings.TrimPrefix(strings.TrimSpace(s), "$"), ",", "")
+	dollars, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if dollars < 0 || math.IsInf(dollars, 0) || math.IsNaN(dollars) {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return int(math.Round(dollars * 100)), nil
+}
@@ -0,0 +1,74 @@
+package manapool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadBuylistCSV(t *testing.T) {
+	t.Run("default headers", func(t *testing.T) {
+		input := "\ufeffCard Name,Edition,Collector Number,Qty,Max Price\n" +
+			"Lightning Bolt,m10,146,4,$2.50\n" +
+			"\"Delver of Secrets // Insectile Aberration\",,,,\n" +
+			",,,3,\n" +
+			"Sol Ring,,,2x,1\n"
+		list, err := LoadBuylistCSV(strings.NewReader(input), BuylistCSVOptions{})
+		if err != nil {
+			t.Fatalf("LoadBuylistCSV() error = %v", err)
+		}
+		items, caps := list.Items, list.MaxPriceCents
+		if len(items) != 3 || len(caps) != 3 {
+			t.Fatalf("got %d items, want 3: %+v", len(items), items)
+		}
+		bolt := items[0]
+		if bolt.Type != "mtg_single" || bolt.Name != "Lightning Bolt" || bolt.SetCode != "M10" || bolt.CollectorNumber != "146" ||
+			bolt.QuantityRequested != 4 || caps[0] != 250 {
+			t.Errorf("unexpected item: %+v", bolt)
+		}
+		if items[1].QuantityRequested != 1 || caps[1] != 0 {
+			t.Errorf("unexpected defaults: %+v", items[1])
+		}
+		if items[2].QuantityRequested != 2 || caps[2] != 100 {
+			t.Errorf("unexpected item: %+v", items[2])
+		}
+	})
+
+	t.Run("configured columns and delimiter", func(t *testing.T) {
+		input := "Karte;Anzahl;Limit\nSol Ring;3;1,299.00\n"
+		list, err := LoadBuylistCSV(strings.NewReader(input), BuylistCSVOptions{
+			NameColumn:     "karte",
+			QuantityColumn: "Anzahl",
+			MaxPriceColumn: "Limit",
+			Comma:          ';',
+		})
+		if err != nil {
+			t.Fatalf("LoadBuylistCSV() error = %v", err)
+		}
+		if len(list.Items) != 1 || list.Items[0].QuantityRequested != 3 || list.MaxPriceCents[0] != 129900 {
+			t.Errorf("unexpected buylist: %+v", list)
+		}
+	})
+
+	errorTests := []struct {
+		name  string
+		input string
+		opts  BuylistCSVOptions
+	}{
+		{"empty", "", BuylistCSVOptions{}},
+		{"no name column", "Qty\n1\n", BuylistCSVOptions{}},
+		{"missing configured column", "Name\nSol Ring\n", BuylistCSVOptions{MaxPriceColumn: "Limit"}},
+		{"bad quantity", "Name,Qty\nSol Ring,many\n", BuylistCSVOptions{}},
+		{"zero quantity", "Name,Qty\nSol Ring,0\n", BuylistCSVOptions{}},
+		{"bad price", "Name,Max Price\nSol Ring,cheap\n", BuylistCSVOptions{}},
+		{"negative price", "Name,Max Price\nSol Ring,-1\n", BuylistCSVOptions{}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if _, err := LoadBuylistCSV(strings.NewReader(tt.input), tt.opts); !errors.As(err, &valErr) {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
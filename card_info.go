@@ -3,19 +3,108 @@ package manapool
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 )
 
+// DefaultCardInfoBatchSize is the default maximum number of card names sent
+// in one card info request. Change it with WithCardInfoBatchSize.
+const DefaultCardInfoBatchSize = 100
+
 // GetCardInfo retrieves card information for a list of card names.
+//
+// Lists longer than the client's card info batch size are split into several
+// requests and the results merged. Names are looked up once even if repeated
+// with different case. Cards and NotFound follow the order of the requested
+// names; cards whose name does not match a requested name, such as a flavor
+// name lookup, come last in the order the API returned them.
 func (c *Client) GetCardInfo(ctx context.Context, req CardInfoRequest) (*CardInfoResponse, error) {
-	resp, err := c.doJSONRequest(ctx, "POST", "/card_info", nil, req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get card info: %w", err)
+	batchSize := c.cardInfoBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultCardInfoBatchSize
+	}
+
+	order := make(map[string]int, len(req.CardNames))
+	names := make([]string, 0, len(req.CardNames))
+	for _, name := range req.CardNames {
+		key := normalizeSearchKey(name)
+		if _, ok := order[key]; ok {
+			continue
+		}
+		order[key] = len(names)
+		names = append(names, name)
 	}
 
-	var response CardInfoResponse
-	if err := c.decodeResponse(resp, &response); err != nil {
-		return nil, fmt.Errorf("failed to decode card info: %w", err)
+	if len(names) <= batchSize {
+		response, err := c.getCardInfo(ctx, CardInfoRequest{CardNames: names})
+		if err != nil {
+			return nil, err
+		}
+		sortCardInfoResponse(response, order)
+		return response, nil
 	}
 
-	return &response, nil
+	merged := &CardInfoResponse{Cards: []CardInfo{}, NotFound: []string{}}
+	for start := 0; start < len(names); start += batchSize {
+		end := start + batchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		response, err := c.getCardInfo(ctx, CardInfoRequest{CardNames: names[start:end]})
+		if err != nil {
+			return nil, fmt.Errorf("card info batch %d-%d: %w", start+1, end, err)
+		}
+		merged.Cards = append(merged.Cards, response.Cards...)
+		merged.NotFound = append(merged.NotFound, response.NotFound...)
+	}
+	sortCardInfoResponse(merged, order)
+	return merged, nil
+}
+
+// getCardInfo requests one batch of card info. Concurrent requests for the
+// same names share one API call; a shared response is copied, because
+// callers sort it in place.
+func (c *Client) getCardInfo(ctx context.Context, req CardInfoRequest) (*CardInfoResponse, error) {
+	key := strings.Join(req.CardNames, "\x00")
+	response, shared, err := c.cardInfoCalls.Do(ctx, key, func(ctx context.Context) (*CardInfoResponse, error) {
+		resp, err := c.doJSONRequest(ctx, "POST", "/card_info", nil, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get card info: %w", err)
+		}
+
+		var response CardInfoResponse
+		if err := c.decodeResponse(resp, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode card info: %w", err)
+		}
+
+		return &response, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		response = &CardInfoResponse{
+			Cards:    append([]CardInfo(nil), response.Cards...),
+			NotFound: append([]string(nil), response.NotFound...),
+		}
+	}
+	return response, nil
+}
+
+// sortCardInfoResponse orders cards and not-found names by their position in
+// the request. Entries that match no requested name keep their relative
+// order after the rest.
+func sortCardInfoResponse(response *CardInfoResponse, order map[string]int) {
+	position := func(name string) int {
+		if i, ok := order[normalizeSearchKey(name)]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(response.Cards, func(i, j int) bool {
+		return position(response.Cards[i].Name) < position(response.Cards[j].Name)
+	})
+	sort.SliceStable(response.NotFound, func(i, j int) bool {
+		return position(response.NotFound[i]) < position(response.NotFound[j])
+	})
 }
@@ -0,0 +1,312 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/repricah/manapool/internal/lru"
+)
+
+// DefaultCardInfoCacheTTL is how long CardInfoCache keeps card info by default.
+const DefaultCardInfoCacheTTL = 24 * time.Hour
+
+// cardInfoCacheEntry is the cached result for one normalized card name.
+type cardInfoCacheEntry struct {
+	Cards     []CardInfo `json:"cards,omitempty"`
+	NotFound  bool       `json:"not_found,omitempty"`
+	FetchedAt time.Time  `json:"fetched_at"`
+}
+
+// CacheStats counts a cache's lookups. Hits and Misses count names or IDs
+// looked up; an expired entry counts as a miss.
+type CacheStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// HitRate returns the fraction of lookups that were hits, or 0 before any
+// lookup.
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// CardInfoCache caches GetCardInfo results by card name, so repeated lookups
+// across deck validations and enrichment passes do not re-query the API.
+// Names are matched case-insensitively, and names the API did not find are
+// cached too. Entries expire after TTL, and when MaxEntries is set the least
+// recently used names are evicted to stay within it. The cache can be saved
+// to and loaded from a JSON file to persist it between runs. CardInfoCache is
+// safe for concurrent use.
+//
+// Example:
+//
+//	cache := manapool.NewCardInfoCache(client, 12*time.Hour)
+//	cache.MaxEntries = 50000
+//	_ = cache.LoadFile("card-info.json")
+//	info, err := cache.GetCardInfo(ctx, manapool.CardInfoRequest{CardNames: names})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	check := manapool.PrecheckDeck(deck, cache.Cards())
+//	_ = cache.SaveFile("card-info.json")
+type CardInfoCache struct {
+	client *Client
+
+	// TTL is how long entries are used (default: DefaultCardInfoCacheTTL).
+	TTL time.Duration
+
+	// MaxEntries bounds the number of cached names (default: 0, unbounded).
+	MaxEntries int
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries lru.Cache[string, cardInfoCacheEntry]
+	stats   CacheStats
+}
+
+// NewCardInfoCache creates an empty cache in front of client. A ttl of zero
+// uses DefaultCardInfoCacheTTL.
+func NewCardInfoCache(client *Client, ttl time.Duration) *CardInfoCache {
+	return &CardInfoCache{
+		client: client,
+		TTL:    ttl,
+	}
+}
+
+// GetCardInfo returns card info like Client.GetCardInfo, requesting only the
+// names that are not cached or have expired. Cards the API returns under a
+// different name than requested, such as flavor name matches, are returned
+// but not cached.
+func (cc *CardInfoCache) GetCardInfo(ctx context.Context, req CardInfoRequest) (*CardInfoResponse, error) {
+	now := cc.now()
+	order := make(map[string]int, len(req.CardNames))
+	var missing []string
+	response := &CardInfoResponse{Cards: []CardInfo{}, NotFound: []string{}}
+
+	cc.mu.Lock()
+	for _, name := range req.CardNames {
+		key := normalizeSearchKey(name)
+		if _, ok := order[key]; ok {
+			continue
+		}
+		order[key] = len(order)
+		entry, ok := cc.lookup(key, now)
+		switch {
+		case !ok:
+			missing = append(missing, name)
+		case entry.NotFound:
+			response.NotFound = append(response.NotFound, name)
+		default:
+			response.Cards = append(response.Cards, entry.Cards...)
+		}
+	}
+	cc.mu.Unlock()
+
+	if len(missing) > 0 {
+		if cc.client == nil {
+			return nil, NewValidationError("client", "client cannot be nil")
+		}
+		fetched, err := cc.client.GetCardInfo(ctx, CardInfoRequest{CardNames: missing})
+		if err != nil {
+			return nil, err
+		}
+		cc.store(missing, fetched, now)
+		response.Cards = append(response.Cards, fetched.Cards...)
+		response.NotFound = append(response.NotFound, fetched.NotFound...)
+	}
+
+	sortCardInfoResponse(response, order)
+	return response, nil
+}
+
+// store caches a response to a request for names.
+func (cc *CardInfoCache) store(names []string, response *CardInfoResponse, now time.Time) {
+	requested := make(map[string]bool, len(names))
+	for _, name := range names {
+		requested[normalizeSearchKey(name)] = true
+	}
+
+	fresh := make(map[string]cardInfoCacheEntry)
+	for _, card := range response.Cards {
+		key := normalizeSearchKey(card.Name)
+		if !requested[key] {
+			continue
+		}
+		entry := fresh[key]
+		entry.Cards = append(entry.Cards, card)
+		entry.FetchedAt = now
+		fresh[key] = entry
+	}
+	for _, name := range response.NotFound {
+		key := normalizeSearchKey(name)
+		if requested[key] {
+			if _, ok := fresh[key]; !ok {
+				fresh[key] = cardInfoCacheEntry{NotFound: true, FetchedAt: now}
+			}
+		}
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	for key, entry := range fresh {
+		cc.entries.Add(key, entry)
+	}
+	cc.trim()
+}
+
+// Get returns the cached, unexpired info for name without calling the API.
+// If the API returned several printings for name, the first is returned.
+func (cc *CardInfoCache) Get(name string) (CardInfo, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	entry, ok := cc.lookup(normalizeSearchKey(name), cc.now())
+	if !ok || entry.NotFound || len(entry.Cards) == 0 {
+		return CardInfo{}, false
+	}
+	return entry.Cards[0], true
+}
+
+// Put adds cards to the cache, for example from a previous GetCardInfo call.
+func (cc *CardInfoCache) Put(cards ...CardInfo) {
+	now := cc.now()
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	for _, card := range cards {
+		cc.entries.Add(normalizeSearchKey(card.Name), cardInfoCacheEntry{Cards: []CardInfo{card}, FetchedAt: now})
+	}
+	cc.trim()
+}
+
+// Cards returns every unexpired cached card, sorted by name.
+func (cc *CardInfoCache) Cards() []CardInfo {
+	now := cc.now()
+	cc.mu.Lock()
+	var cards []CardInfo
+	cc.entries.Each(func(_ string, entry cardInfoCacheEntry) {
+		if !cc.expired(entry, now) {
+			cards = append(cards, entry.Cards...)
+		}
+	})
+	cc.mu.Unlock()
+
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Name < cards[j].Name })
+	return cards
+}
+
+// Invalidate removes name from the cache.
+func (cc *CardInfoCache) Invalidate(name string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.entries.Remove(normalizeSearchKey(name))
+}
+
+// Len returns the number of cached names, including expired ones.
+func (cc *CardInfoCache) Len() int {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.entries.Len()
+}
+
+// Stats returns the cache's hit, miss and eviction counts so far.
+//
+// Example:
+//
+//	stats := cache.Stats()
+//	log.Printf("card info cache: %.0f%% hits, %d evictions", stats.HitRate()*100, stats.Evictions)
+func (cc *CardInfoCache) Stats() CacheStats {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.stats
+}
+
+// SaveFile writes the unexpired entries to path as JSON.
+func (cc *CardInfoCache) SaveFile(path string) error {
+	now := cc.now()
+	cc.mu.Lock()
+	entries := make(map[string]cardInfoCacheEntry, cc.entries.Len())
+	cc.entries.Each(func(key string, entry cardInfoCacheEntry) {
+		if !cc.expired(entry, now) {
+			entries[key] = entry
+		}
+	})
+	cc.mu.Unlock()
+
+	if err := writeJSONFile(path, entries); err != nil {
+		return fmt.Errorf("failed to save card info cache: %w", err)
+	}
+	return nil
+}
+
+// LoadFile adds the entries saved in path to the cache. A missing file is
+// not an error. Entries keep their original fetch time, so they expire as if
+// they had never left memory.
+func (cc *CardInfoCache) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load card info cache: %w", err)
+	}
+
+	var entries map[string]cardInfoCacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to decode card info cache: %w", err)
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	for key, entry := range entries {
+		if current, ok := cc.entries.Peek(key); !ok || entry.FetchedAt.After(current.FetchedAt) {
+			cc.entries.Add(key, entry)
+		}
+	}
+	cc.trim()
+	return nil
+}
+
+// lookup returns the unexpired entry for key, marking it recently used and
+// counting the hit or miss. cc.mu must be held.
+func (cc *CardInfoCache) lookup(key string, now time.Time) (cardInfoCacheEntry, bool) {
+	entry, ok := cc.entries.Get(key)
+	if !ok || cc.expired(entry, now) {
+		cc.stats.Misses++
+		return cardInfoCacheEntry{}, false
+	}
+	cc.stats.Hits++
+	return entry, true
+}
+
+// trim evicts the least recently used names beyond MaxEntries. cc.mu must
+// be held.
+func (cc *CardInfoCache) trim() {
+	cc.stats.Evictions += int64(cc.entries.Trim(cc.MaxEntries))
+}
+
+func (cc *CardInfoCache) expired(entry cardInfoCacheEntry, now time.Time) bool {
+	ttl := cc.TTL
+	if ttl <= 0 {
+		ttl = DefaultCardInfoCacheTTL
+	}
+	return now.Sub(entry.FetchedAt) >= ttl
+}
+
+func (cc *CardInfoCache) now() time.Time {
+	if cc.Now != nil {
+		return cc.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,129 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCardInfoCache(t *testing.T) {
+	var requested [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CardInfoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		requested = append(requested, req.CardNames)
+		var cards, notFound []string
+		for _, name := range req.CardNames {
+			if name == "Missing" {
+				notFound = append(notFound, `"Missing"`)
+				continue
+			}
+			cards = append(cards, fmt.Sprintf(`{"name":%q,"legal_formats":["commander"]}`, name))
+		}
+		_, _ = fmt.Fprintf(w, `{"cards":[%s],"not_found":[%s]}`, strings.Join(cards, ","), strings.Join(notFound, ","))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	cache := NewCardInfoCache(client, time.Hour)
+	cache.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := cache.GetCardInfo(ctx, CardInfoRequest{CardNames: []string{"Sol Ring", "Missing"}}); err != nil {
+		t.Fatalf("GetCardInfo() error = %v", err)
+	}
+
+	// Cached names, including not-found ones, are not requested again.
+	resp, err := cache.GetCardInfo(ctx, CardInfoRequest{CardNames: []string{"Brainstorm", "missing", "SOL RING"}})
+	if err != nil {
+		t.Fatalf("GetCardInfo() error = %v", err)
+	}
+	want := [][]string{{"Sol Ring", "Missing"}, {"Brainstorm"}}
+	if !reflect.DeepEqual(requested, want) {
+		t.Errorf("requested = %q, want %q", requested, want)
+	}
+	if len(resp.Cards) != 2 || resp.Cards[0].Name != "Brainstorm" || resp.Cards[1].Name != "Sol Ring" {
+		t.Errorf("cards = %+v, want Brainstorm then Sol Ring", resp.Cards)
+	}
+	if !reflect.DeepEqual(resp.NotFound, []string{"missing"}) {
+		t.Errorf("not found = %q", resp.NotFound)
+	}
+	if card, ok := cache.Get("sol ring"); !ok || card.Name != "Sol Ring" {
+		t.Errorf("Get() = %+v, %v", card, ok)
+	}
+
+	// Saved entries survive a restart.
+	path := filepath.Join(t.TempDir(), "card-info.json")
+	if err := cache.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+	restored := NewCardInfoCache(client, time.Hour)
+	restored.Now = cache.Now
+	if err := restored.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if restored.Len() != 3 || len(restored.Cards()) != 2 {
+		t.Errorf("restored Len() = %d, Cards() = %d", restored.Len(), len(restored.Cards()))
+	}
+
+	// Expired entries are fetched again.
+	now = now.Add(time.Hour)
+	if _, ok := cache.Get("Sol Ring"); ok {
+		t.Error("expected expired entry to be ignored")
+	}
+	if _, err := cache.GetCardInfo(ctx, CardInfoRequest{CardNames: []string{"Sol Ring"}}); err != nil {
+		t.Fatalf("GetCardInfo() error = %v", err)
+	}
+	if len(requested) != 3 {
+		t.Errorf("expected a refetch after expiry, got %d requests", len(requested))
+	}
+
+	cache.Invalidate("Sol Ring")
+	if _, ok := cache.Get("Sol Ring"); ok {
+		t.Error("expected invalidated entry to be gone")
+	}
+	if err := NewCardInfoCache(client, 0).LoadFile(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Errorf("LoadFile() of a missing file error = %v", err)
+	}
+}
+
+func TestCardInfoCache_MaxEntries(t *testing.T) {
+	cache := NewCardInfoCache(nil, time.Hour)
+	cache.MaxEntries = 2
+	cache.Put(CardInfo{Name: "Sol Ring"}, CardInfo{Name: "Brainstorm"})
+
+	// Using Sol Ring makes Brainstorm the least recently used.
+	if _, ok := cache.Get("Sol Ring"); !ok {
+		t.Fatal("Get(Sol Ring) missed")
+	}
+	cache.Put(CardInfo{Name: "Counterspell"})
+
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+	if _, ok := cache.Get("Brainstorm"); ok {
+		t.Error("Brainstorm should have been evicted")
+	}
+	if _, ok := cache.Get("Counterspell"); !ok {
+		t.Error("Counterspell should be cached")
+	}
+
+	want := CacheStats{Hits: 2, Misses: 1, Evictions: 1}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if got := cache.Stats().HitRate(); got < 0.66 || got > 0.67 {
+		t.Errorf("HitRate() = %v, want 2/3", got)
+	}
+}
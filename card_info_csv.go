@@ -0,0 +1,78 @@
+package manapool
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WriteCardInfoCSV writes cards as CSV with a header row, for planning cubes
+// and collections in a spreadsheet. Each card has the columns name, set_code,
+// set_name, card_number, rarity, from_price_cents, quantity_available,
+// mana_value and color_identity, followed by one legal_<format> column per
+// format holding true or false. When formats is empty, every format any card
+// is legal in gets a column, in alphabetical order. Missing prices and mana
+// values are written as empty cells.
+//
+// Example:
+//
+//	info, err := client.GetCardInfo(ctx, manapool.CardInfoRequest{CardNames: cube})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = manapool.WriteCardInfoCSV(file, info.Cards, []string{"commander", "legacy", "pauper"})
+func WriteCardInfoCSV(w io.Writer, cards []CardInfo, formats []string) error {
+	if len(formats) == 0 {
+		seen := make(map[string]bool)
+		for _, card := range cards {
+			for _, format := range card.LegalFormats {
+				format = strings.ToLower(format)
+				if !seen[format] {
+					seen[format] = true
+					formats = append(formats, format)
+				}
+			}
+		}
+		sort.Strings(formats)
+	}
+
+	header := []string{"name", "set_code", "set_name", "card_number", "rarity", "from_price_cents", "quantity_available", "mana_value", "color_identity"}
+	for _, format := range formats {
+		header = append(header, "legal_"+strings.ToLower(format))
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write card info header: %w", err)
+	}
+	for _, card := range cards {
+		record := []string{
+			card.Name,
+			card.SetCode,
+			card.SetName,
+			card.CardNumber,
+			card.Rarity,
+			"",
+			strconv.Itoa(card.QuantityAvailable),
+			"",
+			strings.Join(card.ColorIdentity, ""),
+		}
+		if card.FromPriceCents != nil {
+			record[5] = strconv.Itoa(*card.FromPriceCents)
+		}
+		if card.ManaValue != nil {
+			record[7] = *card.ManaValue
+		}
+		for _, format := range formats {
+			record = append(record, strconv.FormatBool(card.IsLegalIn(format)))
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write card info row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,35 @@
+package manapool
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteCardInfoCSV(t *testing.T) {
+	price := 150
+	manaValue := "1"
+	cards := []CardInfo{
+		{Name: "Sol Ring", SetCode: "C21", SetName: "Commander 2021", CardNumber: "263", Rarity: "uncommon",
+			FromPriceCents: &price, QuantityAvailable: 12, ManaValue: &manaValue, LegalFormats: []string{"commander", "Vintage"}},
+		{Name: "Counterspell, Again", ColorIdentity: []string{"U"}, LegalFormats: []string{"legacy"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCardInfoCSV(&buf, cards, nil); err != nil {
+		t.Fatalf("WriteCardInfoCSV() error = %v", err)
+	}
+	want := "name,set_code,set_name,card_number,rarity,from_price_cents,quantity_available,mana_value,color_identity,legal_commander,legal_legacy,legal_vintage\n" +
+		"Sol Ring,C21,Commander 2021,263,uncommon,150,12,1,,true,false,true\n" +
+		"\"Counterspell, Again\",,,,,,0,,U,false,true,false\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteCardInfoCSV(&buf, cards, []string{"Pauper"}); err != nil {
+		t.Fatalf("WriteCardInfoCSV() error = %v", err)
+	}
+	if want := "legal_pauper\nSol Ring"; !bytes.Contains(buf.Bytes(), []byte(want)) {
+		t.Errorf("expected only the requested format column, got\n%s", buf.String())
+	}
+}
@@ -0,0 +1,76 @@
+package manapool
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cardFinishAliases maps ManaPool finish IDs to the finish names used in
+// CardInfo.Finishes.
+var cardFinishAliases = map[string]string{
+	"NF": "nonfoil",
+	"FO": "foil",
+	"EF": "etched",
+}
+
+// ManaValueFloat returns the card's mana value as a number. It reports false
+// if the API returned no mana value or one that is not a number.
+func (c CardInfo) ManaValueFloat() (float64, bool) {
+	return parseOptionalFloat(c.ManaValue)
+}
+
+// SaltinessFloat returns the card's EDHREC saltiness score as a number. It
+// reports false if the card has no score.
+func (c CardInfo) SaltinessFloat() (float64, bool) {
+	return parseOptionalFloat(c.EdhrecSaltiness)
+}
+
+// PowerInt returns the card's power as an integer. It reports false for
+// cards without power and for variable power such as "*" or "1+*".
+func (c CardInfo) PowerInt() (int, bool) {
+	return parseOptionalInt(c.Power)
+}
+
+// DefenseInt returns the card's defense as an integer. It reports false for
+// cards without defense.
+func (c CardInfo) DefenseInt() (int, bool) {
+	return parseOptionalInt(c.Defense)
+}
+
+// IsLegalIn reports whether the card is legal in format, such as "commander"
+// or "modern". The comparison ignores case.
+func (c CardInfo) IsLegalIn(format string) bool {
+	return containsFold(c.LegalFormats, format)
+}
+
+// HasFinish reports whether the card is printed with finish, given either as
+// a finish name ("nonfoil", "foil", "etched") or a ManaPool finish ID ("NF",
+// "FO", "EF"). The comparison ignores case.
+func (c CardInfo) HasFinish(finish string) bool {
+	if name, ok := cardFinishAliases[strings.ToUpper(finish)]; ok {
+		finish = name
+	}
+	return containsFold(c.Finishes, finish)
+}
+
+func parseOptionalFloat(s *string) (float64, bool) {
+	if s == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(*s), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func parseOptionalInt(s *string) (int, bool) {
+	if s == nil {
+		return 0, false
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(*s))
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,51 @@
+package manapool
+
+import "testing"
+
+func TestCardInfo_NumericFields(t *testing.T) {
+	str := func(s string) *string { return &s }
+	card := CardInfo{
+		ManaValue:       str("3.0"),
+		EdhrecSaltiness: str(" 1.52 "),
+		Power:           str("4"),
+		Defense:         str("*"),
+	}
+
+	if v, ok := card.ManaValueFloat(); !ok || v != 3 {
+		t.Errorf("ManaValueFloat() = %v, %v", v, ok)
+	}
+	if v, ok := card.SaltinessFloat(); !ok || v != 1.52 {
+		t.Errorf("SaltinessFloat() = %v, %v", v, ok)
+	}
+	if v, ok := card.PowerInt(); !ok || v != 4 {
+		t.Errorf("PowerInt() = %v, %v", v, ok)
+	}
+	if _, ok := card.DefenseInt(); ok {
+		t.Error("DefenseInt() should not parse \"*\"")
+	}
+	if _, ok := (CardInfo{}).ManaValueFloat(); ok {
+		t.Error("ManaValueFloat() should report false for a missing value")
+	}
+}
+
+func TestCardInfo_Predicates(t *testing.T) {
+	card := CardInfo{LegalFormats: []string{"commander", "Legacy"}, Finishes: []string{"nonfoil", "etched"}}
+
+	tests := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"legal", card.IsLegalIn("COMMANDER"), true},
+		{"legal case-insensitive", card.IsLegalIn("legacy"), true},
+		{"not legal", card.IsLegalIn("modern"), false},
+		{"finish name", card.HasFinish("Etched"), true},
+		{"finish ID", card.HasFinish("NF"), true},
+		{"missing finish ID", card.HasFinish("FO"), false},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_GetCardInfo_Batches(t *testing.T) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CardInfoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+			return
+		}
+		batches = append(batches, req.CardNames)
+
+		// Answer in reverse order to check the result is re-sorted.
+		var cards, notFound []string
+		for i := len(req.CardNames) - 1; i >= 0; i-- {
+			name := req.CardNames[i]
+			if strings.HasPrefix(name, "Missing") {
+				notFound = append(notFound, fmt.Sprintf("%q", name))
+				continue
+			}
+			cards = append(cards, fmt.Sprintf(`{"name":%q}`, name))
+		}
+		_, _ = fmt.Fprintf(w, `{"cards":[%s],"not_found":[%s]}`, strings.Join(cards, ","), strings.Join(notFound, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithCardInfoBatchSize(2))
+	resp, err := client.GetCardInfo(context.Background(), CardInfoRequest{
+		CardNames: []string{"Sol Ring", "Missing One", "Brainstorm", "sol ring", "Counterspell", "Missing Two"},
+	})
+	if err != nil {
+		t.Fatalf("GetCardInfo() error = %v", err)
+	}
+
+	wantBatches := [][]string{{"Sol Ring", "Missing One"}, {"Brainstorm", "Counterspell"}, {"Missing Two"}}
+	if !reflect.DeepEqual(batches, wantBatches) {
+		t.Errorf("batches = %q, want %q", batches, wantBatches)
+	}
+	var names []string
+	for _, card := range resp.Cards {
+		names = append(names, card.Name)
+	}
+	if want := []string{"Sol Ring", "Brainstorm", "Counterspell"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("cards = %q, want %q", names, want)
+	}
+	if want := []string{"Missing One", "Missing Two"}; !reflect.DeepEqual(resp.NotFound, want) {
+		t.Errorf("not found = %q, want %q", resp.NotFound, want)
+	}
+}
+
+func TestClient_GetCardInfo_BatchError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"too many names"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"cards":[],"not_found":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithCardInfoBatchSize(1), WithRetry(0, 0))
+	_, err := client.GetCardInfo(context.Background(), CardInfoRequest{CardNames: []string{"A", "B", "C"}})
+	if err == nil || !strings.Contains(err.Error(), "batch 2-2") {
+		t.Errorf("expected error naming batch 2-2, got %v", err)
+	}
+}
+
+func TestClient_GetCardInfo_SharesConcurrentCalls(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"cards":[{"name":"Sol Ring"},{"name":"Counterspell"}],"not_found":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each caller sorts its own copy of the shared response.
+			info, err := client.GetCardInfo(context.Background(), CardInfoRequest{CardNames: []string{"Counterspell", "Sol Ring"}})
+			if err != nil || len(info.Cards) != 2 || info.Cards[0].Name != "Counterspell" {
+				t.Errorf("GetCardInfo() = %+v, %v", info, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("API calls = %d, want 1", got)
+	}
+}
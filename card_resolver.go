@@ -0,0 +1,281 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultScryfallCollectionURL is the Scryfall card collection endpoint used
+// by ScryfallResolver.
+const DefaultScryfallCollectionURL = "https://api.scryfall.com/cards/collection"
+
+// scryfallCollectionLimit is the maximum number of identifiers Scryfall
+// accepts per collection request.
+const scryfallCollectionLimit = 75
+
+// CardResolver looks up card metadata by name. It is used as a fallback for
+// names ManaPool's card info endpoint cannot find, such as cards from a set
+// released after ManaPool's catalog was last updated.
+type CardResolver interface {
+	// ResolveCards returns metadata for the names it knows and the names it
+	// could not resolve.
+	ResolveCards(ctx context.Context, names []string) (cards []CardInfo, notFound []string, err error)
+}
+
+// ScryfallResolver is a CardResolver backed by the public Scryfall API. Card
+// fields are mapped onto CardInfo: the set code is upper-cased, legal formats
+// include restricted ones, FromPriceCents is Scryfall's lowest USD price and
+// QuantityAvailable is always zero, since the card is not listed on ManaPool.
+//
+// Example:
+//
+//	info, err := client.GetCardInfoWithFallback(ctx, req, &manapool.ScryfallResolver{})
+type ScryfallResolver struct {
+	// HTTPClient is used for requests (default: a client with a 30 second timeout).
+	HTTPClient *http.Client
+
+	// URL is the collection endpoint (default: DefaultScryfallCollectionURL).
+	URL string
+
+	// UserAgent identifies the application to Scryfall, which requires one
+	// (default: the manapool-go user agent).
+	UserAgent string
+}
+
+// scryfallCard is the subset of a Scryfall card object used by ScryfallResolver.
+type scryfallCard struct {
+	Name            string             `json:"name"`
+	Set             string             `json:"set"`
+	SetName         string             `json:"set_name"`
+	CollectorNumber string             `json:"collector_number"`
+	Rarity          string             `json:"rarity"`
+	ReleasedAt      string             `json:"released_at"`
+	Legalities      map[string]string  `json:"legalities"`
+	FlavorName      *string            `json:"flavor_name"`
+	Layout          *string            `json:"layout"`
+	PromoTypes      []string           `json:"promo_types"`
+	Finishes        []string           `json:"finishes"`
+	OracleText      *string            `json:"oracle_text"`
+	ColorIdentity   []string           `json:"color_identity"`
+	Power           *string            `json:"power"`
+	Defense         *string            `json:"defense"`
+	ManaCost        *string            `json:"mana_cost"`
+	CMC             *float64           `json:"cmc"`
+	TypeLine        string             `json:"type_line"`
+	Prices          map[string]*string `json:"prices"`
+	CardFaces       []scryfallCardFace `json:"card_faces"`
+}
+
+// scryfallCardFace is one face of a multi-faced Scryfall card. Double-faced
+// cards keep their mana cost and oracle text only on their faces.
+type scryfallCardFace struct {
+	ManaCost   string `json:"mana_cost"`
+	OracleText string `json:"oracle_text"`
+}
+
+// ResolveCards implements CardResolver.
+func (s *ScryfallResolver) ResolveCards(ctx context.Context, names []string) ([]CardInfo, []string, error) {
+	var cards []CardInfo
+	var notFound []string
+	for start := 0; start < len(names); start += scryfallCollectionLimit {
+		end := start + scryfallCollectionLimit
+		if end > len(names) {
+			end = len(names)
+		}
+		found, missing, err := s.resolveBatch(ctx, names[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+		cards = append(cards, found...)
+		notFound = append(notFound, missing...)
+	}
+	return cards, notFound, nil
+}
+
+func (s *ScryfallResolver) resolveBatch(ctx context.Context, names []string) ([]CardInfo, []string, error) {
+	type identifier struct {
+		Name string `json:"name"`
+	}
+	payload := struct {
+		Identifiers []identifier `json:"identifiers"`
+	}{}
+	for _, name := range names {
+		payload.Identifiers = append(payload.Identifiers, identifier{Name: name})
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode Scryfall request: %w", err)
+	}
+
+	endpoint := s.URL
+	if endpoint == "" {
+		endpoint = DefaultScryfallCollectionURL
+	}
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	userAgent := s.UserAgent
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("manapool-go/%s", Version)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, NewNetworkError("failed to query Scryfall", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, nil, fmt.Errorf("failed to query Scryfall: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		Data     []scryfallCard `json:"data"`
+		NotFound []identifier   `json:"not_found"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode Scryfall response: %w", err)
+	}
+
+	cards := make([]CardInfo, 0, len(result.Data))
+	for _, card := range result.Data {
+		cards = append(cards, card.cardInfo())
+	}
+	notFound := make([]string, 0, len(result.NotFound))
+	for _, id := range result.NotFound {
+		notFound = append(notFound, id.Name)
+	}
+	return cards, notFound, nil
+}
+
+// cardInfo converts a Scryfall card to CardInfo.
+func (c scryfallCard) cardInfo() CardInfo {
+	info := CardInfo{
+		Name:          c.Name,
+		SetCode:       strings.ToUpper(c.Set),
+		SetName:       c.SetName,
+		CardNumber:    c.CollectorNumber,
+		Rarity:        c.Rarity,
+		ReleaseDate:   c.ReleasedAt,
+		LegalFormats:  []string{},
+		FlavorName:    c.FlavorName,
+		Layout:        c.Layout,
+		IsToken:       strings.Contains(strings.ToLower(c.TypeLine), "token") || (c.Layout != nil && *c.Layout == "token"),
+		PromoTypes:    c.PromoTypes,
+		Finishes:      c.Finishes,
+		Text:          c.OracleText,
+		ColorIdentity: c.ColorIdentity,
+		Power:         c.Power,
+		Defense:       c.Defense,
+		ManaCost:      c.ManaCost,
+	}
+	if info.Text == nil || *info.Text == "" {
+		info.Text = c.joinFaces("\n//\n", func(f scryfallCardFace) string { return f.OracleText })
+	}
+	if info.ManaCost == nil || *info.ManaCost == "" {
+		info.ManaCost = c.joinFaces(" // ", func(f scryfallCardFace) string { return f.ManaCost })
+	}
+	for format, status := range c.Legalities {
+		if status == "legal" || status == "restricted" {
+			info.LegalFormats = append(info.LegalFormats, format)
+		}
+	}
+	sort.Strings(info.LegalFormats)
+	if c.CMC != nil {
+		manaValue := strconv.FormatFloat(*c.CMC, 'f', -1, 64)
+		info.ManaValue = &manaValue
+	}
+
+	// Use the lowest USD price across finishes.
+	for _, key := range []string{"usd", "usd_foil", "usd_etched"} {
+		price, ok := c.Prices[key]
+		if !ok || price == nil {
+			continue
+		}
+		cents, err := parseDollarsToCents(*price)
+		if err != nil {
+			continue
+		}
+		if info.FromPriceCents == nil || cents < *info.FromPriceCents {
+			info.FromPriceCents = &cents
+		}
+	}
+	return info
+}
+
+// joinFaces joins the non-empty values field returns for each card face with
+// sep, returning nil if there are none.
+func (c scryfallCard) joinFaces(sep string, field func(scryfallCardFace) string) *string {
+	var values []string
+	for _, face := range c.CardFaces {
+		if value := field(face); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	joined := strings.Join(values, sep)
+	return &joined
+}
+
+// CardFallbackError is returned by GetCardInfoWithFallback when the fallback
+// resolver fails. Response is the GetCardInfo result; its NotFound lists the
+// names the fallback could not resolve.
+type CardFallbackError struct {
+	Response *CardInfoResponse
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *CardFallbackError) Error() string {
+	return fmt.Sprintf("failed to resolve %d cards with fallback: %v", len(e.Response.NotFound), e.Err)
+}
+
+// Unwrap returns the fallback's error.
+func (e *CardFallbackError) Unwrap() error {
+	return e.Err
+}
+
+// GetCardInfoWithFallback calls GetCardInfo and passes the names it could not
+// find to fallback. Cards found by fallback are appended to Cards, and
+// NotFound lists only the names neither source found. If fallback fails, the
+// error is a *CardFallbackError holding the GetCardInfo response.
+func (c *Client) GetCardInfoWithFallback(ctx context.Context, req CardInfoRequest, fallback CardResolver) (*CardInfoResponse, error) {
+	response, err := c.GetCardInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if fallback == nil || len(response.NotFound) == 0 {
+		return response, nil
+	}
+
+	cards, notFound, err := fallback.ResolveCards(ctx, response.NotFound)
+	if err != nil {
+		return nil, &CardFallbackError{Response: response, Err: err}
+	}
+	response.Cards = append(response.Cards, cards...)
+	response.NotFound = notFound
+	if response.NotFound == nil {
+		response.NotFound = []string{}
+	}
+	return response, nil
+}
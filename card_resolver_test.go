@@ -0,0 +1,108 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScryfallResolver(t *testing.T) {
+	scryfall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("User-Agent") == "" {
+			t.Errorf("unexpected request %s with User-Agent %q", r.Method, r.Header.Get("User-Agent"))
+		}
+		var req struct {
+			Identifiers []struct {
+				Name string `json:"name"`
+			} `json:"identifiers"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Identifiers) != 3 {
+			t.Errorf("unexpected identifiers %+v, %v", req, err)
+		}
+		_, _ = w.Write([]byte(`{"data":[{"name":"Brand New Card","set":"new","set_name":"New Set","collector_number":"7",
+			"rarity":"rare","released_at":"2030-01-01","legalities":{"commander":"legal","vintage":"restricted","modern":"not_legal"},
+			"finishes":["nonfoil","foil"],"oracle_text":"Draw a card.","color_identity":["U"],"mana_cost":"{1}{U}","cmc":2.0,
+			"type_line":"Instant","prices":{"usd":"1.50","usd_foil":"0.99","usd_etched":null,"tix":"0.02"}},
+			{"name":"Delver of Secrets // Insectile Aberration","set":"isd","layout":"transform","cmc":1.0,
+			"card_faces":[{"name":"Delver of Secrets","mana_cost":"{U}","oracle_text":"At the beginning of your upkeep, look at the top card of your library."},
+			{"name":"Insectile Aberration","mana_cost":"","oracle_text":"Flying"}]}],
+			"not_found":[{"name":"Nonexistent"}]}`))
+	}))
+	defer scryfall.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"cards":[{"name":"Sol Ring","legal_formats":["commander"]}],"not_found":["Brand New Card","Delver of Secrets","Nonexistent"]}`))
+	}))
+	defer api.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(api.URL+"/"))
+	resp, err := client.GetCardInfoWithFallback(context.Background(),
+		CardInfoRequest{CardNames: []string{"Sol Ring", "Brand New Card", "Delver of Secrets", "Nonexistent"}},
+		&ScryfallResolver{URL: scryfall.URL})
+	if err != nil {
+		t.Fatalf("GetCardInfoWithFallback() error = %v", err)
+	}
+
+	if len(resp.Cards) != 3 || resp.Cards[1].Name != "Brand New Card" {
+		t.Fatalf("cards = %+v", resp.Cards)
+	}
+	card := resp.Cards[1]
+	if card.SetCode != "NEW" || card.CardNumber != "7" || *card.ManaValue != "2" || *card.FromPriceCents != 99 {
+		t.Errorf("unexpected card mapping: %+v", card)
+	}
+	if !reflect.DeepEqual(card.LegalFormats, []string{"commander", "vintage"}) {
+		t.Errorf("legal formats = %v", card.LegalFormats)
+	}
+	if !reflect.DeepEqual(resp.NotFound, []string{"Nonexistent"}) {
+		t.Errorf("not found = %v", resp.NotFound)
+	}
+
+	// Double-faced cards take mana cost and oracle text from their faces.
+	dfc := resp.Cards[2]
+	if StringValue(dfc.ManaCost) != "{U}" || StringValue(dfc.Text) != "At the beginning of your upkeep, look at the top card of your library.\n//\nFlying" {
+		t.Errorf("double-faced card mapping: mana cost %q, text %q", StringValue(dfc.ManaCost), StringValue(dfc.Text))
+	}
+}
+
+func TestClient_GetCardInfoWithFallback_Error(t *testing.T) {
+	scryfall := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer scryfall.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"cards":[{"name":"Sol Ring"}],"not_found":["Brand New Card"]}`))
+	}))
+	defer api.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(api.URL+"/"))
+	resp, err := client.GetCardInfoWithFallback(context.Background(),
+		CardInfoRequest{CardNames: []string{"Sol Ring", "Brand New Card"}}, &ScryfallResolver{URL: scryfall.URL})
+	if resp != nil {
+		t.Errorf("response = %+v, want nil with an error", resp)
+	}
+	var fallbackErr *CardFallbackError
+	if !errors.As(err, &fallbackErr) {
+		t.Fatalf("error = %v, want *CardFallbackError", err)
+	}
+	if len(fallbackErr.Response.Cards) != 1 || !reflect.DeepEqual(fallbackErr.Response.NotFound, []string{"Brand New Card"}) {
+		t.Errorf("partial response = %+v", fallbackErr.Response)
+	}
+}
+
+func TestScryfallResolver_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"details":"slow down"}`))
+	}))
+	defer server.Close()
+
+	_, _, err := (&ScryfallResolver{URL: server.URL}).ResolveCards(context.Background(), []string{"A"})
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Errorf("expected status error, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package manapool
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultCardSuggestions is the number of suggestions returned when a limit
+// of zero is passed to CardNameMatcher.Suggest.
+const DefaultCardSuggestions = 3
+
+// cardNameFolds maps accented and ligature letters found in card names to
+// their plain spelling, so "Lim-Dûl" matches "lim dul".
+var cardNameFolds = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ä': "a", 'ã': "a", 'å': "a",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ó': "o", 'ò': "o", 'ô': "o", 'ö': "o", 'õ': "o", 'ø': "o",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u",
+	'ñ': "n", 'ç': "c", 'ý': "y",
+	'æ': "ae", 'œ': "oe", 'ß': "ss",
+}
+
+// CardNameMatcher suggests card names close to a misspelled one. It compares
+// names after folding case and accents and dropping punctuation, and ranks
+// candidates by edit distance.
+//
+// Example:
+//
+//	matcher := manapool.NewCardNameMatcher(knownNames)
+//	resp, err := client.GetCardInfo(ctx, req)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for name, suggestions := range matcher.SuggestNotFound(resp, 3) {
+//	    fmt.Printf("%q not found; did you mean %s?\n", name, strings.Join(suggestions, " or "))
+//	}
+type CardNameMatcher struct {
+	names      []string
+	normalized [][]rune
+}
+
+// NewCardNameMatcher creates a matcher over the given dictionary of card
+// names. Duplicate names are ignored.
+func NewCardNameMatcher(names []string) *CardNameMatcher {
+	m := &CardNameMatcher{}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := normalizeCardNameForMatch(name)
+		if key == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		m.names = append(m.names, name)
+		m.normalized = append(m.normalized, []rune(key))
+	}
+	return m
+}
+
+// Suggest returns up to limit dictionary names closest to name, best first
+// (default limit: DefaultCardSuggestions). Only names within a few edits,
+// scaled by the length of name, are suggested, so a result may be empty.
+func (m *CardNameMatcher) Suggest(name string, limit int) []string {
+	if limit <= 0 {
+		limit = DefaultCardSuggestions
+	}
+	query := []rune(normalizeCardNameForMatch(name))
+	if len(query) == 0 {
+		return nil
+	}
+	maxDistance := len(query)/5 + 1
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for i, known := range m.normalized {
+		if abs(len(known)-len(query)) > maxDistance {
+			continue
+		}
+		if d := editDistance(query, known); d <= maxDistance {
+			candidates = append(candidates, candidate{name: m.names[i], distance: d})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	suggestions := make([]string, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = c.name
+	}
+	return suggestions
+}
+
+// SuggestNotFound returns suggestions for each of resp's not-found names
+// that has at least one.
+func (m *CardNameMatcher) SuggestNotFound(resp *CardInfoResponse, limit int) map[string][]string {
+	suggestions := make(map[string][]string)
+	if resp == nil {
+		return suggestions
+	}
+	for _, name := range resp.NotFound {
+		if s := m.Suggest(name, limit); len(s) > 0 {
+			suggestions[name] = s
+		}
+	}
+	return suggestions
+}
+
+// Suggest returns up to limit cached card names closest to name. See
+// CardNameMatcher.Suggest.
+func (cc *CardInfoCache) Suggest(name string, limit int) []string {
+	cards := cc.Cards()
+	names := make([]string, len(cards))
+	for i, card := range cards {
+		names[i] = card.Name
+	}
+	return NewCardNameMatcher(names).Suggest(name, limit)
+}
+
+// normalizeCardNameForMatch lower-cases name, folds accents, drops
+// punctuation and collapses whitespace.
+func normalizeCardNameForMatch(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		if fold, ok := cardNameFolds[r]; ok {
+			b.WriteString(fold)
+			space = false
+			continue
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			space = false
+		case unicode.IsSpace(r) || r == '-' || r == '/':
+			if !space && b.Len() > 0 {
+				b.WriteByte(' ')
+				space = true
+			}
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,55 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardNameMatcher_Suggest(t *testing.T) {
+	matcher := NewCardNameMatcher([]string{
+		"Lim-Dûl's Vault", "Lightning Bolt", "Lightning Helix", "Counterspell", "Æther Vial", "Sol Ring", "Sol Ring",
+	})
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"accents and punctuation", "lim dul's vault", []string{"Lim-Dûl's Vault"}},
+		{"ascii ligature", "Aether Vial", []string{"Æther Vial"}},
+		{"typo", "Lightnig Bolt", []string{"Lightning Bolt"}},
+		{"case", "COUNTERSPEL", []string{"Counterspell"}},
+		{"no close match", "Black Lotus", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Suggest(tt.query, 0); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if got := matcher.Suggest("Lightning Helx", 1); !reflect.DeepEqual(got, []string{"Lightning Helix"}) {
+		t.Errorf("Suggest() = %q", got)
+	}
+	if got := matcher.Suggest("Sol Rings", 5); !reflect.DeepEqual(got, []string{"Sol Ring"}) {
+		t.Errorf("duplicates not removed: %q", got)
+	}
+}
+
+func TestCardNameMatcher_SuggestNotFound(t *testing.T) {
+	matcher := NewCardNameMatcher([]string{"Brainstorm", "Ponder"})
+	resp := &CardInfoResponse{NotFound: []string{"Brainstrom", "Totally Unknown"}}
+
+	got := matcher.SuggestNotFound(resp, 1)
+	want := map[string][]string{"Brainstrom": {"Brainstorm"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestNotFound() = %v, want %v", got, want)
+	}
+
+	cache := NewCardInfoCache(nil, 0)
+	cache.Put(CardInfo{Name: "Ponder"})
+	if got := cache.Suggest("Pondr", 1); !reflect.DeepEqual(got, []string{"Ponder"}) {
+		t.Errorf("cache Suggest() = %q", got)
+	}
+}
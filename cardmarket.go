@@ -0,0 +1,484 @@
+package manapool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CardmarketArticle is one row of a Cardmarket (MKM) stock file. Prices on
+// Cardmarket are in euros.
+type CardmarketArticle struct {
+	// ArticleID is Cardmarket's ID for the listing, or 0 for a new one.
+	ArticleID int64
+
+	// ProductID is Cardmarket's product ID (MTGJSON's mcmId).
+	ProductID int64
+
+	Name      string
+	Expansion string
+
+	PriceEuroCents int
+
+	// Language is a Cardmarket language ID, e.g. 1 for English.
+	Language int
+
+	// Condition is a Cardmarket condition code: MT, NM, EX, GD, LP, PL or PO.
+	Condition string
+
+	Foil    bool
+	Signed  bool
+	Altered bool
+
+	// Playset reports that Amount counts sets of four cards.
+	Playset bool
+
+	Comments string
+	Amount   int
+}
+
+// CardmarketProductResolver maps between Cardmarket product IDs and the
+// Scryfall IDs ManaPool singles are identified by. ok is false when the
+// resolver does not know the ID.
+type CardmarketProductResolver interface {
+	ProductID(ctx context.Context, scryfallID string) (productID int64, ok bool, err error)
+	ScryfallID(ctx context.Context, productID int64) (scryfallID string, ok bool, err error)
+}
+
+// CardmarketProducts is an in-memory CardmarketProductResolver. Load one
+// from MTGJSON's identifiers with LoadCardmarketProducts, or build one with
+// Add.
+type CardmarketProducts struct {
+	byScryfall map[string]int64
+	byProduct  map[int64]string
+}
+
+// NewCardmarketProducts creates an empty product mapping.
+func NewCardmarketProducts() *CardmarketProducts {
+	return &CardmarketProducts{
+		byScryfall: make(map[string]int64),
+		byProduct:  make(map[int64]string),
+	}
+}
+
+// LoadCardmarketProducts reads MTGJSON's AllIdentifiers.json (or any MTGJSON
+// file whose "data" maps card UUIDs to cards) and maps every card that has
+// both an mcmId and a scryfallId. Cards are decoded one at a time, so the
+// file is never held in memory; to map TCGplayer IDs from the same file as
+// well, load an IDMap and use IDMap.Cardmarket instead.
+//
+// Example:
+//
+//	file, err := os.Open("AllIdentifiers.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer file.Close()
+//	products, err := manapool.LoadCardmarketProducts(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	items, skipped, err := manapool.FromCardmarketArticles(ctx, articles, products, 1.08)
+func LoadCardmarketProducts(r io.Reader) (*CardmarketProducts, error) {
+	p := NewCardmarketProducts()
+	err := decodeMTGJSONData(r, func(_ string, dec *json.Decoder) error {
+		var card struct {
+			Identifiers struct {
+				McmID      string `json:"mcmId"`
+				ScryfallID string `json:"scryfallId"`
+			} `json:"identifiers"`
+		}
+		if err := dec.Decode(&card); err != nil {
+			return err
+		}
+		id := parseMTGJSONID(card.Identifiers.McmID)
+		if id > 0 && card.Identifiers.ScryfallID != "" {
+			p.Add(id, card.Identifiers.ScryfallID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode MTGJSON identifiers: %w", err)
+	}
+	return p, nil
+}
+
+// Add maps productID to scryfallID in both directions.
+func (p *CardmarketProducts) Add(productID int64, scryfallID string) {
+	scryfallID = strings.ToLower(scryfallID)
+	p.byScryfall[scryfallID] = productID
+	p.byProduct[productID] = scryfallID
+}
+
+// Len returns the number of mapped products.
+func (p *CardmarketProducts) Len() int {
+	return len(p.byProduct)
+}
+
+// ProductID implements CardmarketProductResolver.
+func (p *CardmarketProducts) ProductID(_ context.Context, scryfallID string) (int64, bool, error) {
+	id, ok := p.byScryfall[strings.ToLower(scryfallID)]
+	return id, ok, nil
+}
+
+// ScryfallID implements CardmarketProductResolver.
+func (p *CardmarketProducts) ScryfallID(_ context.Context, productID int64) (string, bool, error) {
+	id, ok := p.byProduct[productID]
+	return id, ok, nil
+}
+
+// ToCardmarketArticles converts ManaPool inventory to Cardmarket articles.
+// usdPerEuro is the exchange rate used to price the articles in euros. Items
+// that are not singles, have no Scryfall ID or whose Scryfall ID the resolver
+// cannot map are returned in skipped.
+func ToCardmarketArticles(ctx context.Context, items []InventoryItem, resolver CardmarketProductResolver, usdPerEuro float64) (articles []CardmarketArticle, skipped []InventoryItem, err error) {
+	if resolver == nil {
+		return nil, nil, NewValidationError("resolver", "resolver cannot be nil")
+	}
+	if usdPerEuro <= 0 || math.IsInf(usdPerEuro, 0) || math.IsNaN(usdPerEuro) {
+		return nil, nil, NewValidationError("usdPerEuro", "exchange rate must be positive")
+	}
+
+	for _, item := range items {
+		single := item.Product.Single
+		if single == nil || single.ScryfallID == "" {
+			skipped = append(skipped, item)
+			continue
+		}
+		productID, ok, err := resolver.ProductID(ctx, single.ScryfallID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve Cardmarket product for %s: %w", single.ScryfallID, err)
+		}
+		if !ok {
+			skipped = append(skipped, item)
+			continue
+		}
+		articles = append(articles, CardmarketArticle{
+			ProductID:      productID,
+			Name:           single.Name,
+			Expansion:      single.Set,
+			PriceEuroCents: int(math.Round(float64(item.PriceCents) / usdPerEuro)),
+			Language:       cardmarketLanguage(single.LanguageID),
+			Condition:      cardmarketCondition(single.ConditionID),
+			Foil:           single.FinishID == "FO" || single.FinishID == "EF",
+			Amount:         item.Quantity,
+		})
+	}
+	return articles, skipped, nil
+}
+
+// FromCardmarketArticles converts Cardmarket articles to ManaPool bulk
+// inventory items keyed by Scryfall ID, ready for
+// CreateInventoryBulkByScryfall. usdPerEuro is the exchange rate used to
+// price the items in US dollars. Playsets become four cards each. Signed or
+// altered articles, which ManaPool cannot list, and articles with an unknown
+// condition or language, or whose product the resolver cannot map, are
+// returned in skipped.
+func FromCardmarketArticles(ctx context.Context, articles []CardmarketArticle, resolver CardmarketProductResolver, usdPerEuro float64) (items []InventoryBulkItemByScryfall, skipped []CardmarketArticle, err error) {
+	if resolver == nil {
+		return nil, nil, NewValidationError("resolver", "resolver cannot be nil")
+	}
+	if usdPerEuro <= 0 || math.IsInf(usdPerEuro, 0) || math.IsNaN(usdPerEuro) {
+		return nil, nil, NewValidationError("usdPerEuro", "exchange rate must be positive")
+	}
+
+	for _, article := range articles {
+		condition := manaPoolConditionFromCardmarket(article.Condition)
+		language := manaPoolLanguageFromCardmarket(article.Language)
+		if article.Signed || article.Altered || condition == "" || language == "" {
+			skipped = append(skipped, article)
+			continue
+		}
+		scryfallID, ok, err := resolver.ScryfallID(ctx, article.ProductID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve Cardmarket product %d: %w", article.ProductID, err)
+		}
+		if !ok {
+			skipped = append(skipped, article)
+			continue
+		}
+
+		quantity, price := article.Amount, article.PriceEuroCents
+		if article.Playset {
+			quantity *= 4
+			price /= 4
+		}
+		finish := "NF"
+		if article.Foil {
+			finish = "FO"
+		}
+		items = append(items, InventoryBulkItemByScryfall{
+			ScryfallID:  scryfallID,
+			LanguageID:  language,
+			FinishID:    finish,
+			ConditionID: condition,
+			PriceCents:  int(math.Round(float64(price) * usdPerEuro)),
+			Quantity:    quantity,
+		})
+	}
+	return items, skipped, nil
+}
+
+// cardmarketCSVHeader is the header WriteCardmarketCSV writes, matching
+// Cardmarket's stock export.
+var cardmarketCSVHeader = []string{
+	"idArticle", "idProduct", "English Name", "Exp.", "Price", "Language",
+	"Condition", "Foil?", "Signed?", "Playset?", "Altered?", "Comments", "Amount",
+}
+
+// WriteCardmarketCSV writes articles as a semicolon-separated stock file in
+// the layout of Cardmarket's stock export, which its stock upload accepts.
+// Prices are written in euros, e.g. "4.99", and flags as "X" or blank.
+func WriteCardmarketCSV(w io.Writer, articles []CardmarketArticle) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	if err := writer.Write(cardmarketCSVHeader); err != nil {
+		return fmt.Errorf("failed to write Cardmarket header: %w", err)
+	}
+	flag := func(b bool) string {
+		if b {
+			return "X"
+		}
+		return ""
+	}
+	for _, article := range articles {
+		articleID := ""
+		if article.ArticleID > 0 {
+			articleID = strconv.FormatInt(article.ArticleID, 10)
+		}
+		record := []string{
+			articleID,
+			strconv.FormatInt(article.ProductID, 10),
+			article.Name,
+			article.Expansion,
+			fmt.Sprintf("%d.%02d", article.PriceEuroCents/100, article.PriceEuroCents%100),
+			strconv.Itoa(article.Language),
+			article.Condition,
+			flag(article.Foil),
+			flag(article.Signed),
+			flag(article.Playset),
+			flag(article.Altered),
+			article.Comments,
+			strconv.Itoa(article.Amount),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write Cardmarket row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCardmarketCSV reads a Cardmarket stock file with a header row, such as
+// Cardmarket's stock export or a file written by WriteCardmarketCSV. Both
+// semicolon- and comma-separated files are accepted. Headers are matched
+// case-insensitively; only idProduct is required. Languages may be given as
+// Cardmarket IDs or English names, prices in euros with a decimal point or
+// comma, and flags as "X", "1", "yes" or "true". A blank amount means one.
+func ReadCardmarketCSV(r io.Reader) ([]CardmarketArticle, error) {
+	br := bufio.NewReader(r)
+	reader := newCSVReader(br)
+	// Cardmarket exports separate fields with semicolons; spreadsheets
+	// re-saving them usually switch to commas.
+	if head, _ := br.Peek(4096); len(head) > 0 {
+		if line, _, _ := bytes.Cut(head, []byte("\n")); bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+			reader.Comma = ';'
+		}
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, NewValidationError("csv", "Cardmarket CSV is empty")
+		}
+		return nil, fmt.Errorf("failed to read Cardmarket header: %w", err)
+	}
+
+	column := func(names ...string) int {
+		col, _ := buylistColumn(header, "", names)
+		return col
+	}
+	productCol := column("idProduct", "product id")
+	if productCol < 0 {
+		return nil, NewValidationError("csv", "Cardmarket CSV has no idProduct column")
+	}
+	articleCol := column("idArticle", "article id")
+	nameCol := column("English Name", "name")
+	expansionCol := column("Exp. Name", "Exp.", "expansion")
+	priceCol := column("Price")
+	languageCol := column("Language")
+	conditionCol := column("Condition")
+	foilCol := column("Foil?", "foil")
+	signedCol := column("Signed?", "signed")
+	playsetCol := column("Playset?", "playset")
+	alteredCol := column("Altered?", "altered")
+	commentsCol := column("Comments")
+	amountCol := column("Amount", "quantity")
+
+	var articles []CardmarketArticle
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Cardmarket line %d: %w", line, err)
+		}
+		field := func(col int) string {
+			if col < 0 || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+		flag := func(col int) bool {
+			switch strings.ToLower(field(col)) {
+			case "x", "1", "yes", "true":
+				return true
+			}
+			return false
+		}
+
+		id := field(productCol)
+		if id == "" {
+			continue
+		}
+		article := CardmarketArticle{
+			Name:      field(nameCol),
+			Expansion: field(expansionCol),
+			Language:  1,
+			Condition: strings.ToUpper(field(conditionCol)),
+			Foil:      flag(foilCol),
+			Signed:    flag(signedCol),
+			Playset:   flag(playsetCol),
+			Altered:   flag(alteredCol),
+			Comments:  field(commentsCol),
+			Amount:    1,
+		}
+		if article.ProductID, err = strconv.ParseInt(id, 10, 64); err != nil || article.ProductID <= 0 {
+			return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid idProduct %q", line, id))
+		}
+		if articleID := field(articleCol); articleID != "" {
+			if article.ArticleID, err = strconv.ParseInt(articleID, 10, 64); err != nil {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid idArticle %q", line, articleID))
+			}
+		}
+		if price := field(priceCol); price != "" {
+			euros := strings.TrimSpace(strings.TrimPrefix(price, "€"))
+			if !strings.Contains(euros, ".") {
+				// Cardmarket writes decimal commas in some locales.
+				euros = strings.Replace(euros, ",", ".", 1)
+			}
+			if article.PriceEuroCents, err = parseDollarsToCents(euros); err != nil {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid price %q", line, price))
+			}
+		}
+		if language := field(languageCol); language != "" {
+			if article.Language = parseCardmarketLanguage(language); article.Language == 0 {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: unknown language %q", line, language))
+			}
+		}
+		if amount := field(amountCol); amount != "" {
+			if article.Amount, err = strconv.Atoi(amount); err != nil || article.Amount < 0 {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid amount %q", line, amount))
+			}
+		}
+
+		articles = append(articles, article)
+	}
+	return articles, nil
+}
+
+// cardmarketLanguages lists Cardmarket's language IDs in order, starting at
+// 1, with the ManaPool language ID and Cardmarket name of each.
+var cardmarketLanguages = []struct{ id, name string }{
+	{"EN", "English"},
+	{"FR", "French"},
+	{"DE", "German"},
+	{"ES", "Spanish"},
+	{"IT", "Italian"},
+	{"ZHS", "S-Chinese"},
+	{"JA", "Japanese"},
+	{"PT", "Portuguese"},
+	{"RU", "Russian"},
+	{"KO", "Korean"},
+	{"ZHT", "T-Chinese"},
+}
+
+// cardmarketLanguage returns the Cardmarket language ID for a ManaPool
+// language ID, defaulting to English.
+func cardmarketLanguage(languageID string) int {
+	for i, language := range cardmarketLanguages {
+		if strings.EqualFold(languageID, language.id) {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// manaPoolLanguageFromCardmarket returns the ManaPool language ID for a
+// Cardmarket language ID, or "" if it is not known.
+func manaPoolLanguageFromCardmarket(language int) string {
+	if language < 1 || language > len(cardmarketLanguages) {
+		return ""
+	}
+	return cardmarketLanguages[language-1].id
+}
+
+// parseCardmarketLanguage parses a Cardmarket language ID or English name,
+// returning 0 if it is not known.
+func parseCardmarketLanguage(s string) int {
+	if id, err := strconv.Atoi(s); err == nil {
+		if manaPoolLanguageFromCardmarket(id) == "" {
+			return 0
+		}
+		return id
+	}
+	for i, language := range cardmarketLanguages {
+		if strings.EqualFold(s, language.name) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// cardmarketConditions maps ManaPool condition IDs to Cardmarket grades.
+// Cardmarket grades more strictly, so each ManaPool condition maps one grade
+// down from its literal name: Lightly Played is Excellent, and so on.
+var cardmarketConditions = map[string]string{
+	"NM":  "NM",
+	"LP":  "EX",
+	"MP":  "GD",
+	"HP":  "PL",
+	"DMG": "PO",
+}
+
+func cardmarketCondition(conditionID string) string {
+	return cardmarketConditions[strings.ToUpper(conditionID)]
+}
+
+// manaPoolConditionFromCardmarket maps a Cardmarket grade to a ManaPool
+// condition ID, or "" if it is not known. Mint is listed as Near Mint and
+// Cardmarket's Light Played as Heavily Played.
+func manaPoolConditionFromCardmarket(condition string) string {
+	switch strings.ToUpper(strings.TrimSpace(condition)) {
+	case "MT", "NM":
+		return "NM"
+	case "EX":
+		return "LP"
+	case "GD":
+		return "MP"
+	case "LP", "PL":
+		return "HP"
+	case "PO":
+		return "DMG"
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testCardmarketProducts() *CardmarketProducts {
+	p := NewCardmarketProducts()
+	p.Add(5001, solRingScryfallID)
+	p.Add(5002, boltScryfallID)
+	return p
+}
+
+func TestLoadCardmarketProducts(t *testing.T) {
+	input := `{"meta": {"version": "5.2.2"}, "data": {
+		"uuid-1": {"name": "Sol Ring", "identifiers": {"mcmId": "5001", "scryfallId": "` + strings.ToUpper(solRingScryfallID) + `"}},
+		"uuid-2": {"name": "Lightning Bolt", "identifiers": {"scryfallId": "` + boltScryfallID + `"}},
+		"uuid-3": {"name": "Token", "identifiers": {"mcmId": "5003"}}
+	}}`
+	products, err := LoadCardmarketProducts(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadCardmarketProducts() error = %v", err)
+	}
+	if products.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", products.Len())
+	}
+	if id, ok, _ := products.ScryfallID(context.Background(), 5001); !ok || id != solRingScryfallID {
+		t.Errorf("ScryfallID() = %q, %v", id, ok)
+	}
+	if id, ok, _ := products.ProductID(context.Background(), solRingScryfallID); !ok || id != 5001 {
+		t.Errorf("ProductID() = %d, %v", id, ok)
+	}
+
+	if _, err := LoadCardmarketProducts(strings.NewReader("[")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestToCardmarketArticles(t *testing.T) {
+	items := []InventoryItem{
+		{ID: "a", PriceCents: 216, Quantity: 2, Product: Product{Single: &Single{ScryfallID: solRingScryfallID, Name: "Sol Ring", Set: "C21", LanguageID: "DE", ConditionID: "LP", FinishID: "EF"}}},
+		{ID: "b", PriceCents: 100, Quantity: 1, Product: Product{Single: &Single{ScryfallID: "unknown", ConditionID: "NM"}}},
+		{ID: "c", PriceCents: 100, Quantity: 1, Product: Product{Sealed: &Sealed{}}},
+	}
+	articles, skipped, err := ToCardmarketArticles(context.Background(), items, testCardmarketProducts(), 1.08)
+	if err != nil {
+		t.Fatalf("ToCardmarketArticles() error = %v", err)
+	}
+	if len(articles) != 1 || len(skipped) != 2 {
+		t.Fatalf("got %d articles, %d skipped; want 1, 2", len(articles), len(skipped))
+	}
+	want := CardmarketArticle{ProductID: 5001, Name: "Sol Ring", Expansion: "C21", PriceEuroCents: 200, Language: 3, Condition: "EX", Foil: true, Amount: 2}
+	if articles[0] != want {
+		t.Errorf("article = %+v, want %+v", articles[0], want)
+	}
+
+	var validationErr *ValidationError
+	if _, _, err := ToCardmarketArticles(context.Background(), items, nil, 1.08); !errors.As(err, &validationErr) {
+		t.Errorf("nil resolver error = %v, want ValidationError", err)
+	}
+	if _, _, err := ToCardmarketArticles(context.Background(), items, testCardmarketProducts(), 0); !errors.As(err, &validationErr) {
+		t.Errorf("zero rate error = %v, want ValidationError", err)
+	}
+}
+
+func TestFromCardmarketArticles(t *testing.T) {
+	articles := []CardmarketArticle{
+		{ProductID: 5001, PriceEuroCents: 200, Language: 7, Condition: "MT", Foil: true, Amount: 1},
+		{ProductID: 5002, PriceEuroCents: 400, Language: 1, Condition: "GD", Playset: true, Amount: 2},
+		{ProductID: 5001, PriceEuroCents: 200, Language: 1, Condition: "NM", Signed: true, Amount: 1},
+		{ProductID: 5001, PriceEuroCents: 200, Language: 99, Condition: "NM", Amount: 1},
+		{ProductID: 5001, PriceEuroCents: 200, Language: 1, Condition: "??", Amount: 1},
+		{ProductID: 9999, PriceEuroCents: 200, Language: 1, Condition: "NM", Amount: 1},
+	}
+	items, skipped, err := FromCardmarketArticles(context.Background(), articles, testCardmarketProducts(), 1.1)
+	if err != nil {
+		t.Fatalf("FromCardmarketArticles() error = %v", err)
+	}
+	if len(items) != 2 || len(skipped) != 4 {
+		t.Fatalf("got %d items, %d skipped; want 2, 4", len(items), len(skipped))
+	}
+	want := InventoryBulkItemByScryfall{ScryfallID: solRingScryfallID, LanguageID: "JA", FinishID: "FO", ConditionID: "NM", PriceCents: 220, Quantity: 1}
+	if items[0] != want {
+		t.Errorf("items[0] = %+v, want %+v", items[0], want)
+	}
+	want = InventoryBulkItemByScryfall{ScryfallID: boltScryfallID, LanguageID: "EN", FinishID: "NF", ConditionID: "MP", PriceCents: 110, Quantity: 8}
+	if items[1] != want {
+		t.Errorf("items[1] = %+v, want %+v", items[1], want)
+	}
+}
+
+type failingProductResolver struct{}
+
+func (failingProductResolver) ProductID(context.Context, string) (int64, bool, error) {
+	return 0, false, errors.New("lookup failed")
+}
+
+func (failingProductResolver) ScryfallID(context.Context, int64) (string, bool, error) {
+	return "", false, errors.New("lookup failed")
+}
+
+func TestCardmarketConverters_ResolverError(t *testing.T) {
+	ctx := context.Background()
+	items := []InventoryItem{{Product: Product{Single: &Single{ScryfallID: solRingScryfallID}}}}
+	if _, _, err := ToCardmarketArticles(ctx, items, failingProductResolver{}, 1); err == nil {
+		t.Error("ToCardmarketArticles() expected resolver error")
+	}
+	articles := []CardmarketArticle{{ProductID: 5001, Language: 1, Condition: "NM"}}
+	if _, _, err := FromCardmarketArticles(ctx, articles, failingProductResolver{}, 1); err == nil {
+		t.Error("FromCardmarketArticles() expected resolver error")
+	}
+}
+
+func TestCardmarketCSV_RoundTrip(t *testing.T) {
+	articles := []CardmarketArticle{
+		{ArticleID: 700, ProductID: 5001, Name: "Sol Ring", Expansion: "C21", PriceEuroCents: 199, Language: 1, Condition: "NM", Foil: true, Comments: "fresh; from pack", Amount: 3},
+		{ProductID: 5002, Name: "Lightning Bolt", PriceEuroCents: 5, Language: 10, Condition: "PO", Playset: true, Signed: true, Altered: true, Amount: 1},
+	}
+	var buf bytes.Buffer
+	if err := WriteCardmarketCSV(&buf, articles); err != nil {
+		t.Fatalf("WriteCardmarketCSV() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "idArticle;idProduct;") || !strings.Contains(buf.String(), "700;5001;Sol Ring;C21;1.99;1;NM;X;;;;") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	got, err := ReadCardmarketCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadCardmarketCSV() error = %v", err)
+	}
+	if len(got) != len(articles) {
+		t.Fatalf("got %d articles, want %d", len(got), len(articles))
+	}
+	for i := range articles {
+		if got[i] != articles[i] {
+			t.Errorf("article %d = %+v, want %+v", i, got[i], articles[i])
+		}
+	}
+}
+
+func TestReadCardmarketCSV_CardmarketExport(t *testing.T) {
+	input := "\ufeff\"idArticle\";\"idProduct\";\"English Name\";\"Local Name\";\"Exp.\";\"Exp. Name\";\"Price\";\"Language\";\"Condition\";\"Foil?\";\"Signed?\";\"Playset?\";\"Altered?\";\"Comments\";\"Amount\"\n" +
+		"\"123\";\"5001\";\"Sol Ring\";\"Sol Ring\";\"1234\";\"Commander 2021\";\"1,50\";\"German\";\"ex\";\"\";\"\";\"\";\"\";\"\";\"2\"\n" +
+		"\"124\";\"\";\"Blank\";\"\";\"\";\"\";\"1\";\"1\";\"NM\";\"\";\"\";\"\";\"\";\"\";\"1\"\n"
+	articles, err := ReadCardmarketCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCardmarketCSV() error = %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("got %d articles, want 1", len(articles))
+	}
+	want := CardmarketArticle{ArticleID: 123, ProductID: 5001, Name: "Sol Ring", Expansion: "Commander 2021", PriceEuroCents: 150, Language: 3, Condition: "EX", Amount: 2}
+	if articles[0] != want {
+		t.Errorf("article = %+v, want %+v", articles[0], want)
+	}
+}
+
+func TestReadCardmarketCSV_CommaSeparated(t *testing.T) {
+	input := "idProduct,Price,Foil?\n5002,\"1,234.50\",1\n"
+	articles, err := ReadCardmarketCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCardmarketCSV() error = %v", err)
+	}
+	want := CardmarketArticle{ProductID: 5002, PriceEuroCents: 123450, Language: 1, Foil: true, Amount: 1}
+	if len(articles) != 1 || articles[0] != want {
+		t.Errorf("articles = %+v, want [%+v]", articles, want)
+	}
+}
+
+func TestReadCardmarketCSV_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":           "",
+		"no product":      "Price;Amount\n1.00;1\n",
+		"invalid product": "idProduct;Amount\nabc;1\n",
+		"invalid article": "idArticle;idProduct\nabc;5001\n",
+		"invalid price":   "idProduct;Price\n5001;free\n",
+		"invalid amount":  "idProduct;Amount\n5001;-1\n",
+		"bad language":    "idProduct;Language\n5001;Klingon\n",
+		"bad language id": "idProduct;Language\n5001;42\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var validationErr *ValidationError
+			if _, err := ReadCardmarketCSV(strings.NewReader(input)); !errors.As(err, &validationErr) {
+				t.Errorf("ReadCardmarketCSV() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
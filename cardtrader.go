@@ -0,0 +1,381 @@
+package manapool
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CardTraderProduct is a product in CardTrader's inventory format. The JSON
+// field names follow CardTrader's product export, so an export can be decoded
+// into a []CardTraderProduct directly; ReadCardTraderCSV and
+// WriteCardTraderCSV handle the CSV form.
+type CardTraderProduct struct {
+	BlueprintID int64 `json:"blueprint_id"`
+
+	// Name is informational; products are matched by BlueprintID.
+	Name string `json:"name_en,omitempty"`
+
+	Quantity   int `json:"quantity"`
+	PriceCents int `json:"price_cents"`
+
+	// PriceCurrency is the ISO currency code of PriceCents (default: USD).
+	PriceCurrency string `json:"price_currency,omitempty"`
+
+	Properties CardTraderProperties `json:"properties_hash"`
+}
+
+// CardTraderProperties holds the Magic-specific properties of a product.
+type CardTraderProperties struct {
+	// Condition is a CardTrader condition name such as "Near Mint".
+	Condition string `json:"condition,omitempty"`
+
+	// Language is a CardTrader language code such as "en" or "jp".
+	Language string `json:"mtg_language,omitempty"`
+
+	Foil bool `json:"mtg_foil"`
+}
+
+// CardTraderBlueprintResolver maps between CardTrader blueprint IDs and the
+// Scryfall IDs ManaPool singles are identified by. ok is false when the
+// resolver does not know the ID.
+type CardTraderBlueprintResolver interface {
+	BlueprintID(ctx context.Context, scryfallID string) (blueprintID int64, ok bool, err error)
+	ScryfallID(ctx context.Context, blueprintID int64) (scryfallID string, ok bool, err error)
+}
+
+// CardTraderBlueprints is an in-memory CardTraderBlueprintResolver. Load
+// one from CardTrader's blueprint export with LoadCardTraderBlueprints, or
+// build one with Add.
+type CardTraderBlueprints struct {
+	byScryfall  map[string]int64
+	byBlueprint map[int64]string
+}
+
+// NewCardTraderBlueprints creates an empty blueprint mapping.
+func NewCardTraderBlueprints() *CardTraderBlueprints {
+	return &CardTraderBlueprints{
+		byScryfall:  make(map[string]int64),
+		byBlueprint: make(map[int64]string),
+	}
+}
+
+// LoadCardTraderBlueprints reads CardTrader's blueprint export, a JSON array
+// of blueprints, and maps each one that has a Scryfall ID.
+//
+// Example:
+//
+//	blueprints, err := manapool.LoadCardTraderBlueprints(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	products, skipped, err := manapool.ToCardTraderProducts(ctx, inventory, blueprints)
+func LoadCardTraderBlueprints(r io.Reader) (*CardTraderBlueprints, error) {
+	var blueprints []struct {
+		ID         int64  `json:"id"`
+		ScryfallID string `json:"scryfall_id"`
+	}
+	if err := json.NewDecoder(r).Decode(&blueprints); err != nil {
+		return nil, fmt.Errorf("failed to decode CardTrader blueprints: %w", err)
+	}
+
+	b := NewCardTraderBlueprints()
+	for _, blueprint := range blueprints {
+		if blueprint.ID > 0 && blueprint.ScryfallID != "" {
+			b.Add(blueprint.ID, blueprint.ScryfallID)
+		}
+	}
+	return b, nil
+}
+
+// Add maps blueprintID to scryfallID in both directions.
+func (b *CardTraderBlueprints) Add(blueprintID int64, scryfallID string) {
+	scryfallID = strings.ToLower(scryfallID)
+	b.byScryfall[scryfallID] = blueprintID
+	b.byBlueprint[blueprintID] = scryfallID
+}
+
+// Len returns the number of mapped blueprints.
+func (b *CardTraderBlueprints) Len() int {
+	return len(b.byBlueprint)
+}
+
+// BlueprintID implements CardTraderBlueprintResolver.
+func (b *CardTraderBlueprints) BlueprintID(_ context.Context, scryfallID string) (int64, bool, error) {
+	id, ok := b.byScryfall[strings.ToLower(scryfallID)]
+	return id, ok, nil
+}
+
+// ScryfallID implements CardTraderBlueprintResolver.
+func (b *CardTraderBlueprints) ScryfallID(_ context.Context, blueprintID int64) (string, bool, error) {
+	id, ok := b.byBlueprint[blueprintID]
+	return id, ok, nil
+}
+
+// ToCardTraderProducts converts ManaPool inventory to CardTrader products
+// priced in USD. Items that are not singles, have no Scryfall ID or whose
+// Scryfall ID the resolver cannot map are returned in skipped.
+func ToCardTraderProducts(ctx context.Context, items []InventoryItem, resolver CardTraderBlueprintResolver) (products []CardTraderProduct, skipped []InventoryItem, err error) {
+	if resolver == nil {
+		return nil, nil, NewValidationError("resolver", "resolver cannot be nil")
+	}
+
+	for _, item := range items {
+		single := item.Product.Single
+		if single == nil || single.ScryfallID == "" {
+			skipped = append(skipped, item)
+			continue
+		}
+		blueprintID, ok, err := resolver.BlueprintID(ctx, single.ScryfallID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve blueprint for %s: %w", single.ScryfallID, err)
+		}
+		if !ok {
+			skipped = append(skipped, item)
+			continue
+		}
+		products = append(products, CardTraderProduct{
+			BlueprintID:   blueprintID,
+			Name:          single.Name,
+			Quantity:      item.Quantity,
+			PriceCents:    item.PriceCents,
+			PriceCurrency: "USD",
+			Properties: CardTraderProperties{
+				Condition: cardTraderCondition(single.ConditionID),
+				Language:  cardTraderLanguage(single.LanguageID),
+				Foil:      single.FinishID == "FO" || single.FinishID == "EF",
+			},
+		})
+	}
+	return products, skipped, nil
+}
+
+// FromCardTraderProducts converts CardTrader products to ManaPool bulk
+// inventory items keyed by Scryfall ID, ready for
+// CreateInventoryBulkByScryfall. Products priced in a currency other than
+// USD, with an unknown condition, or whose blueprint the resolver cannot map
+// are returned in skipped; convert prices to USD first to include them. Foil
+// products map to the FO finish, since CardTrader does not tell foil and
+// etched apart.
+func FromCardTraderProducts(ctx context.Context, products []CardTraderProduct, resolver CardTraderBlueprintResolver) (items []InventoryBulkItemByScryfall, skipped []CardTraderProduct, err error) {
+	if resolver == nil {
+		return nil, nil, NewValidationError("resolver", "resolver cannot be nil")
+	}
+
+	for _, product := range products {
+		condition := manaPoolCondition(product.Properties.Condition)
+		currency := strings.ToUpper(product.PriceCurrency)
+		if condition == "" || (currency != "" && currency != "USD") {
+			skipped = append(skipped, product)
+			continue
+		}
+		scryfallID, ok, err := resolver.ScryfallID(ctx, product.BlueprintID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to resolve blueprint %d: %w", product.BlueprintID, err)
+		}
+		if !ok {
+			skipped = append(skipped, product)
+			continue
+		}
+		finish := "NF"
+		if product.Properties.Foil {
+			finish = "FO"
+		}
+		items = append(items, InventoryBulkItemByScryfall{
+			ScryfallID:  scryfallID,
+			LanguageID:  manaPoolLanguage(product.Properties.Language),
+			FinishID:    finish,
+			ConditionID: condition,
+			PriceCents:  product.PriceCents,
+			Quantity:    product.Quantity,
+		})
+	}
+	return items, skipped, nil
+}
+
+// cardTraderCSVHeader is the header WriteCardTraderCSV writes.
+var cardTraderCSVHeader = []string{"blueprint_id", "name", "quantity", "price", "currency", "condition", "language", "foil"}
+
+// WriteCardTraderCSV writes products as CSV with the columns blueprint_id,
+// name, quantity, price, currency, condition, language and foil. Prices are
+// written in currency units, e.g. "4.99".
+func WriteCardTraderCSV(w io.Writer, products []CardTraderProduct) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(cardTraderCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CardTrader header: %w", err)
+	}
+	for _, product := range products {
+		currency := product.PriceCurrency
+		if currency == "" {
+			currency = "USD"
+		}
+		record := []string{
+			strconv.FormatInt(product.BlueprintID, 10),
+			product.Name,
+			strconv.Itoa(product.Quantity),
+			fmt.Sprintf("%d.%02d", product.PriceCents/100, product.PriceCents%100),
+			currency,
+			product.Properties.Condition,
+			product.Properties.Language,
+			strconv.FormatBool(product.Properties.Foil),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CardTrader row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadCardTraderCSV reads products from CSV with a header row, as written by
+// WriteCardTraderCSV. Headers are matched case-insensitively and the
+// CardTrader API names (mtg_language, mtg_foil, price_cents) are accepted
+// too. Only blueprint_id is required; a blank quantity means one copy.
+func ReadCardTraderCSV(r io.Reader) ([]CardTraderProduct, error) {
+	reader := newCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, NewValidationError("csv", "CardTrader CSV is empty")
+		}
+		return nil, fmt.Errorf("failed to read CardTrader header: %w", err)
+	}
+
+	column := func(names ...string) int {
+		col, _ := buylistColumn(header, "", names)
+		return col
+	}
+	idCol := column("blueprint_id", "blueprint id")
+	if idCol < 0 {
+		return nil, NewValidationError("csv", "CardTrader CSV has no blueprint_id column")
+	}
+	nameCol := column("name", "name_en")
+	qtyCol := column("quantity", "qty")
+	priceCol := column("price")
+	centsCol := column("price_cents")
+	currencyCol := column("currency", "price_currency")
+	conditionCol := column("condition")
+	languageCol := column("language", "mtg_language")
+	foilCol := column("foil", "mtg_foil")
+
+	var products []CardTraderProduct
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CardTrader line %d: %w", line, err)
+		}
+		field := func(col int) string {
+			if col < 0 || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+
+		id := field(idCol)
+		if id == "" {
+			continue
+		}
+		product := CardTraderProduct{
+			Name:          field(nameCol),
+			Quantity:      1,
+			PriceCurrency: strings.ToUpper(field(currencyCol)),
+			Properties: CardTraderProperties{
+				Condition: field(conditionCol),
+				Language:  field(languageCol),
+			},
+		}
+		if product.BlueprintID, err = strconv.ParseInt(id, 10, 64); err != nil || product.BlueprintID <= 0 {
+			return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid blueprint_id %q", line, id))
+		}
+		if qty := field(qtyCol); qty != "" {
+			if product.Quantity, err = strconv.Atoi(qty); err != nil || product.Quantity < 0 {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid quantity %q", line, qty))
+			}
+		}
+		if cents := field(centsCol); cents != "" {
+			if product.PriceCents, err = strconv.Atoi(cents); err != nil || product.PriceCents < 0 {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid price_cents %q", line, cents))
+			}
+		} else if price := field(priceCol); price != "" {
+			if product.PriceCents, err = parseDollarsToCents(price); err != nil {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid price %q", line, price))
+			}
+		}
+		if foil := strings.ToLower(field(foilCol)); foil != "" {
+			product.Properties.Foil = foil == "true" || foil == "yes" || foil == "1" || foil == "foil"
+		}
+
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// cardTraderConditions maps ManaPool condition IDs to CardTrader conditions.
+var cardTraderConditions = map[string]string{
+	"NM":  "Near Mint",
+	"LP":  "Slightly Played",
+	"MP":  "Moderately Played",
+	"HP":  "Played",
+	"DMG": "Poor",
+}
+
+func cardTraderCondition(conditionID string) string {
+	return cardTraderConditions[strings.ToUpper(conditionID)]
+}
+
+// manaPoolCondition maps a CardTrader condition to a ManaPool condition ID,
+// or "" if it is not recognized. Mint is listed as Near Mint.
+func manaPoolCondition(condition string) string {
+	switch strings.ToLower(strings.TrimSpace(condition)) {
+	case "mint", "near mint":
+		return "NM"
+	case "slightly played":
+		return "LP"
+	case "moderately played":
+		return "MP"
+	case "played", "heavily played":
+		return "HP"
+	case "poor":
+		return "DMG"
+	}
+	return ""
+}
+
+// cardTraderLanguages lists the ManaPool language IDs whose CardTrader code
+// is not simply the lower-cased ID.
+var cardTraderLanguages = map[string]string{
+	"JA":  "jp",
+	"KO":  "kr",
+	"ZHS": "zh-CN",
+	"ZHT": "zh-TW",
+}
+
+func cardTraderLanguage(languageID string) string {
+	languageID = strings.ToUpper(languageID)
+	if code, ok := cardTraderLanguages[languageID]; ok {
+		return code
+	}
+	return strings.ToLower(languageID)
+}
+
+// manaPoolLanguage maps a CardTrader language code to a ManaPool language
+// ID. An empty code is English.
+func manaPoolLanguage(code string) string {
+	if code == "" {
+		return "EN"
+	}
+	for id, cardTrader := range cardTraderLanguages {
+		if strings.EqualFold(code, cardTrader) {
+			return id
+		}
+	}
+	return strings.ToUpper(code)
+}
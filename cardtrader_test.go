@@ -0,0 +1,203 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	solRingScryfallID = "1b5a6b1c-8a4e-4e2a-9b0e-1f1c2d3e4f50"
+	boltScryfallID    = "2c6b7c2d-9b5f-4f3b-8c1f-2a2d3e4f5a61"
+)
+
+func testBlueprints() *CardTraderBlueprints {
+	b := NewCardTraderBlueprints()
+	b.Add(1001, solRingScryfallID)
+	b.Add(1002, strings.ToUpper(boltScryfallID))
+	return b
+}
+
+func TestLoadCardTraderBlueprints(t *testing.T) {
+	input := `[
+		{"id": 1001, "name": "Sol Ring", "scryfall_id": "` + solRingScryfallID + `"},
+		{"id": 1003, "name": "Booster Box"},
+		{"id": 1002, "name": "Lightning Bolt", "scryfall_id": "` + boltScryfallID + `"}
+	]`
+	blueprints, err := LoadCardTraderBlueprints(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadCardTraderBlueprints() error = %v", err)
+	}
+	if blueprints.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", blueprints.Len())
+	}
+	if id, ok, _ := blueprints.BlueprintID(context.Background(), strings.ToUpper(solRingScryfallID)); !ok || id != 1001 {
+		t.Errorf("BlueprintID() = %d, %v", id, ok)
+	}
+	if _, ok, _ := blueprints.ScryfallID(context.Background(), 1003); ok {
+		t.Error("blueprint without a Scryfall ID should not be mapped")
+	}
+
+	if _, err := LoadCardTraderBlueprints(strings.NewReader("{")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestToCardTraderProducts(t *testing.T) {
+	items := []InventoryItem{
+		{ID: "a", PriceCents: 150, Quantity: 3, Product: Product{Single: &Single{ScryfallID: solRingScryfallID, Name: "Sol Ring", LanguageID: "JA", ConditionID: "LP", FinishID: "FO"}}},
+		{ID: "b", PriceCents: 99, Quantity: 1, Product: Product{Single: &Single{ScryfallID: boltScryfallID, Name: "Lightning Bolt", LanguageID: "EN", ConditionID: "NM", FinishID: "NF"}}},
+		{ID: "c", PriceCents: 500, Quantity: 1, Product: Product{Single: &Single{ScryfallID: "unknown", ConditionID: "NM"}}},
+		{ID: "d", PriceCents: 9999, Quantity: 1, Product: Product{Sealed: &Sealed{}}},
+	}
+	products, skipped, err := ToCardTraderProducts(context.Background(), items, testBlueprints())
+	if err != nil {
+		t.Fatalf("ToCardTraderProducts() error = %v", err)
+	}
+	if len(products) != 2 || len(skipped) != 2 {
+		t.Fatalf("got %d products, %d skipped; want 2, 2", len(products), len(skipped))
+	}
+	want := CardTraderProduct{
+		BlueprintID: 1001, Name: "Sol Ring", Quantity: 3, PriceCents: 150, PriceCurrency: "USD",
+		Properties: CardTraderProperties{Condition: "Slightly Played", Language: "jp", Foil: true},
+	}
+	if products[0] != want {
+		t.Errorf("products[0] = %+v, want %+v", products[0], want)
+	}
+	if p := products[1].Properties; p.Condition != "Near Mint" || p.Language != "en" || p.Foil {
+		t.Errorf("products[1].Properties = %+v", p)
+	}
+	if skipped[0].ID != "c" || skipped[1].ID != "d" {
+		t.Errorf("skipped = %s, %s; want c, d", skipped[0].ID, skipped[1].ID)
+	}
+
+	var validationErr *ValidationError
+	if _, _, err := ToCardTraderProducts(context.Background(), items, nil); !errors.As(err, &validationErr) {
+		t.Errorf("nil resolver error = %v, want ValidationError", err)
+	}
+}
+
+func TestFromCardTraderProducts(t *testing.T) {
+	products := []CardTraderProduct{
+		{BlueprintID: 1001, Quantity: 2, PriceCents: 175, Properties: CardTraderProperties{Condition: "Mint", Language: "zh-TW", Foil: true}},
+		{BlueprintID: 1002, Quantity: 4, PriceCents: 50, PriceCurrency: "usd", Properties: CardTraderProperties{Condition: "Played"}},
+		{BlueprintID: 1001, Quantity: 1, PriceCents: 120, PriceCurrency: "EUR", Properties: CardTraderProperties{Condition: "Near Mint"}},
+		{BlueprintID: 1001, Quantity: 1, PriceCents: 120, Properties: CardTraderProperties{Condition: "Graded"}},
+		{BlueprintID: 9999, Quantity: 1, PriceCents: 120, Properties: CardTraderProperties{Condition: "Near Mint"}},
+	}
+	items, skipped, err := FromCardTraderProducts(context.Background(), products, testBlueprints())
+	if err != nil {
+		t.Fatalf("FromCardTraderProducts() error = %v", err)
+	}
+	if len(items) != 2 || len(skipped) != 3 {
+		t.Fatalf("got %d items, %d skipped; want 2, 3", len(items), len(skipped))
+	}
+	want := InventoryBulkItemByScryfall{ScryfallID: solRingScryfallID, LanguageID: "ZHT", FinishID: "FO", ConditionID: "NM", PriceCents: 175, Quantity: 2}
+	if items[0] != want {
+		t.Errorf("items[0] = %+v, want %+v", items[0], want)
+	}
+	want = InventoryBulkItemByScryfall{ScryfallID: boltScryfallID, LanguageID: "EN", FinishID: "NF", ConditionID: "HP", PriceCents: 50, Quantity: 4}
+	if items[1] != want {
+		t.Errorf("items[1] = %+v, want %+v", items[1], want)
+	}
+}
+
+type failingBlueprintResolver struct{}
+
+func (failingBlueprintResolver) BlueprintID(context.Context, string) (int64, bool, error) {
+	return 0, false, errors.New("lookup failed")
+}
+
+func (failingBlueprintResolver) ScryfallID(context.Context, int64) (string, bool, error) {
+	return "", false, errors.New("lookup failed")
+}
+
+func TestCardTraderConverters_ResolverError(t *testing.T) {
+	ctx := context.Background()
+	items := []InventoryItem{{Product: Product{Single: &Single{ScryfallID: solRingScryfallID}}}}
+	if _, _, err := ToCardTraderProducts(ctx, items, failingBlueprintResolver{}); err == nil {
+		t.Error("ToCardTraderProducts() expected resolver error")
+	}
+	products := []CardTraderProduct{{BlueprintID: 1001, Properties: CardTraderProperties{Condition: "Near Mint"}}}
+	if _, _, err := FromCardTraderProducts(ctx, products, failingBlueprintResolver{}); err == nil {
+		t.Error("FromCardTraderProducts() expected resolver error")
+	}
+}
+
+func TestCardTraderCSV_RoundTrip(t *testing.T) {
+	products := []CardTraderProduct{
+		{BlueprintID: 1001, Name: "Sol Ring", Quantity: 3, PriceCents: 1505, PriceCurrency: "USD", Properties: CardTraderProperties{Condition: "Near Mint", Language: "en", Foil: true}},
+		{BlueprintID: 1002, Name: "Lightning Bolt, Beta", Quantity: 1, PriceCents: 7, Properties: CardTraderProperties{Condition: "Poor", Language: "jp"}},
+	}
+	var buf bytes.Buffer
+	if err := WriteCardTraderCSV(&buf, products); err != nil {
+		t.Fatalf("WriteCardTraderCSV() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "1001,Sol Ring,3,15.05,USD,Near Mint,en,true") {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	got, err := ReadCardTraderCSV(&buf)
+	if err != nil {
+		t.Fatalf("ReadCardTraderCSV() error = %v", err)
+	}
+	products[1].PriceCurrency = "USD"
+	if len(got) != len(products) {
+		t.Fatalf("got %d products, want %d", len(got), len(products))
+	}
+	for i := range products {
+		if got[i] != products[i] {
+			t.Errorf("product %d = %+v, want %+v", i, got[i], products[i])
+		}
+	}
+}
+
+func TestReadCardTraderCSV_APIHeaders(t *testing.T) {
+	input := "\ufeffBlueprint_ID,price_cents,mtg_language,mtg_foil,Condition\n" +
+		"1001,250,kr,yes,Slightly Played\n" +
+		",100,en,no,Near Mint\n"
+	products, err := ReadCardTraderCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCardTraderCSV() error = %v", err)
+	}
+	if len(products) != 1 {
+		t.Fatalf("got %d products, want 1", len(products))
+	}
+	want := CardTraderProduct{BlueprintID: 1001, Quantity: 1, PriceCents: 250, Properties: CardTraderProperties{Condition: "Slightly Played", Language: "kr", Foil: true}}
+	if products[0] != want {
+		t.Errorf("product = %+v, want %+v", products[0], want)
+	}
+}
+
+func TestReadCardTraderCSV_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":             "",
+		"no blueprint":      "name,quantity\nSol Ring,1\n",
+		"invalid blueprint": "blueprint_id\nabc\n",
+		"invalid quantity":  "blueprint_id,quantity\n1001,-2\n",
+		"invalid price":     "blueprint_id,price\n1001,free\n",
+		"invalid cents":     "blueprint_id,price_cents\n1001,1.5\n",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			var validationErr *ValidationError
+			if _, err := ReadCardTraderCSV(strings.NewReader(input)); !errors.As(err, &validationErr) {
+				t.Errorf("ReadCardTraderCSV() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestCardTraderProduct_JSON(t *testing.T) {
+	input := `[{"blueprint_id":1001,"name_en":"Sol Ring","quantity":2,"price_cents":150,"price_currency":"USD","properties_hash":{"condition":"Near Mint","mtg_language":"en","mtg_foil":false}}]`
+	var products []CardTraderProduct
+	if err := json.Unmarshal([]byte(input), &products); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(products) != 1 || products[0].BlueprintID != 1001 || products[0].Properties.Condition != "Near Mint" {
+		t.Errorf("products = %+v", products)
+	}
+}
@@ -0,0 +1,199 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckoutStep identifies a stage of the Checkout workflow.
+type CheckoutStep string
+
+// Checkout steps, in order.
+const (
+	CheckoutStepOptimize     CheckoutStep = "optimize"
+	CheckoutStepPendingOrder CheckoutStep = "pending_order"
+	CheckoutStepConfirm      CheckoutStep = "confirm"
+	CheckoutStepPurchase     CheckoutStep = "purchase"
+)
+
+// CheckoutError reports the step at which Checkout failed.
+type CheckoutError struct {
+	Step CheckoutStep
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *CheckoutError) Error() string {
+	return fmt.Sprintf("checkout failed at %s: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CheckoutError) Unwrap() error {
+	return e.Err
+}
+
+// CheckoutOptions controls Checkout.
+type CheckoutOptions struct {
+	// Purchase holds the payment method and addresses used to buy the pending
+	// order. Its shipping address is also sent with the pending order so the
+	// confirmed totals include the correct shipping and tax.
+	Purchase PurchasePendingOrderRequest
+
+	// ShippingOverrides maps seller IDs to shipping methods for the pending order.
+	ShippingOverrides map[string]string
+
+	// Confirm, if set, is called with the pending order before purchase.
+	// Returning false stops checkout without purchasing.
+	Confirm func(ctx context.Context, order *PendingOrder) (bool, error)
+
+	// DryRun stops after the pending order is created and confirmed.
+	DryRun bool
+
+	// Session, if set, records each completed step.
+	Session *PurchaseSession
+}
+
+// CheckoutResult is the outcome of Checkout. Fields are filled in as steps
+// complete, so a failed checkout still reports what was done.
+type CheckoutResult struct {
+	Cart         *OptimizedCart
+	PendingOrder *PendingOrder
+
+	// Declined reports that Confirm returned false.
+	Declined bool
+
+	// Purchased reports that the pending order was bought.
+	Purchased bool
+}
+
+// Checkout optimizes a cart, turns it into a pending order, asks for
+// confirmation and purchases it. The cart, payment method and addresses are
+// validated before anything is created. Errors are returned as *CheckoutError
+// along with the partial result; if the failure happens after the pending
+// order was created, the order remains pending and can be retried with
+// PurchasePendingOrder using PendingOrder.ID.
+//
+// Example:
+//
+//	result, err := client.Checkout(ctx, req, manapool.CheckoutOptions{
+//	    Purchase: purchase,
+//	    Confirm: func(ctx context.Context, order *manapool.PendingOrder) (bool, error) {
+//	        fmt.Printf("Total: $%.2f. Buy? ", float64(order.Totals.TotalCents)/100)
+//	        var answer string
+//	        fmt.Scanln(&answer)
+//	        return answer == "y", nil
+//	    },
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if result.Purchased {
+//	    fmt.Println("Order:", result.PendingOrder.Order.ID)
+//	}
+func (c *Client) Checkout(ctx context.Context, req OptimizerRequest, opts CheckoutOptions) (*CheckoutResult, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	if !opts.DryRun && opts.Purchase.PaymentMethod == "" {
+		return nil, NewValidationError("payment_method", "payment method is required unless DryRun is set")
+	}
+
+	// Check every address before the pending order is created, so a bad
+	// purchase request does not leave a pending order behind.
+	purchase := opts.Purchase
+	if !opts.DryRun {
+		if err := c.validatePurchaseAddress(ctx, "billing_address", &purchase.BillingAddress); err != nil {
+			return nil, err
+		}
+		if err := c.validatePurchaseAddress(ctx, "shipping_address", &purchase.ShippingAddress); err != nil {
+			return nil, err
+		}
+	} else if purchase.ShippingAddress != (Address{}) {
+		if err := c.validateAddress(ctx, "shipping_address", &purchase.ShippingAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CheckoutResult{}
+	record := func(step CheckoutStep, detail string) {
+		if opts.Session != nil {
+			opts.Session.Record(string(step), detail)
+		}
+	}
+	fail := func(step CheckoutStep, err error) (*CheckoutResult, error) {
+		record(step, "failed: "+err.Error())
+		return result, &CheckoutError{Step: step, Err: err}
+	}
+
+	if opts.Session != nil {
+		opts.Session.Request = &req
+	}
+	cart, err := c.OptimizeCart(ctx, req)
+	if err != nil {
+		return fail(CheckoutStepOptimize, err)
+	}
+	if len(cart.Cart) == 0 {
+		return fail(CheckoutStepOptimize, fmt.Errorf("optimizer returned an empty cart"))
+	}
+	result.Cart = cart
+	if opts.Session != nil {
+		opts.Session.Cart = cart
+	}
+	record(CheckoutStepOptimize, fmt.Sprintf("%d listings, total %d cents", len(cart.Cart), cart.Totals.TotalCents))
+
+	pendingReq := PendingOrderRequest{
+		ShippingOverrides: opts.ShippingOverrides,
+		LineItems:         cart.LineItems(),
+	}
+	if purchase.ShippingAddress != (Address{}) {
+		address := purchase.ShippingAddress
+		pendingReq.ShippingAddress = &address
+		pendingReq.TaxAddress = &address
+	}
+	if opts.Session != nil {
+		opts.Session.PendingOrderRequest = &pendingReq
+	}
+
+	pending, err := c.CreatePendingOrder(ctx, pendingReq)
+	if err != nil {
+		return fail(CheckoutStepPendingOrder, err)
+	}
+	result.PendingOrder = pending
+	if opts.Session != nil {
+		opts.Session.PendingOrder = pending
+	}
+	record(CheckoutStepPendingOrder, fmt.Sprintf("pending order %s, total %d cents", pending.ID, pending.Totals.TotalCents))
+
+	if opts.Confirm != nil {
+		ok, err := opts.Confirm(ctx, pending)
+		if err != nil {
+			return fail(CheckoutStepConfirm, err)
+		}
+		if !ok {
+			result.Declined = true
+			record(CheckoutStepConfirm, "declined")
+			return result, nil
+		}
+		record(CheckoutStepConfirm, "confirmed")
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	purchased, err := c.purchasePendingOrder(ctx, pending.ID, purchase)
+	if err != nil {
+		return fail(CheckoutStepPurchase, err)
+	}
+	result.PendingOrder = purchased
+	result.Purchased = true
+	if opts.Session != nil {
+		opts.Session.PendingOrder = purchased
+	}
+	detail := "purchased"
+	if purchased.Order != nil {
+		detail = "purchased as order " + purchased.Order.ID
+	}
+	record(CheckoutStepPurchase, detail)
+
+	return result, nil
+}
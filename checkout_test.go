@@ -0,0 +1,180 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newCheckoutTestServer(t *testing.T, purchaseStatus int, purchases *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/buyer/optimizer":
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv-1","quantity_selected":2},{"inventory_id":"inv-2","quantity_selected":1}],"totals":{"subtotal_cents":1000,"shipping_cents":500,"total_cents":1500,"seller_count":2}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/buyer/orders/pending-orders":
+			var req PendingOrderRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode pending order request: %v", err)
+			}
+			if len(req.LineItems) != 2 || req.LineItems[0].InventoryID != "inv-1" || req.LineItems[0].QuantitySelected != 2 {
+				t.Errorf("unexpected line items: %+v", req.LineItems)
+			}
+			if req.ShippingAddress == nil || req.TaxAddress == nil || req.ShippingAddress.City != "Portland" {
+				t.Errorf("addresses not sent: %+v", req)
+			}
+			_, _ = w.Write([]byte(`{"id":"po-1","line_items":[],"status":"pending","totals":{"subtotal_cents":1000,"shipping_cents":500,"tax_cents":100,"total_cents":1600},"order":null}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/buyer/orders/pending-orders/po-1/purchase":
+			*purchases++
+			w.WriteHeader(purchaseStatus)
+			if purchaseStatus != http.StatusOK {
+				_, _ = w.Write([]byte(`{"message":"insufficient credit"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"po-1","line_items":[],"status":"completed","totals":{"subtotal_cents":1000,"shipping_cents":500,"tax_cents":100,"total_cents":1600},"order":{"id":"order-1"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestClient_Checkout(t *testing.T) {
+	ctx := context.Background()
+	req := OptimizerRequest{Cart: []OptimizerCartItem{{Type: "mtg_single", Name: "Card", QuantityRequested: 3}}}
+	purchase := PurchasePendingOrderRequest{
+		PaymentMethod:   "user_credit",
+		BillingAddress:  Address{Name: "Jane Doe", Line1: "1 Main St", City: "Portland", State: "OR", PostalCode: "97201", Country: "US"},
+		ShippingAddress: Address{Name: "Jane Doe", Line1: "1 Main St", City: "Portland", State: "OR", PostalCode: "97201", Country: "US"},
+	}
+
+	t.Run("purchases after confirmation", func(t *testing.T) {
+		var purchases int
+		server := newCheckoutTestServer(t, http.StatusOK, &purchases)
+		defer server.Close()
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+
+		session := &PurchaseSession{}
+		var confirmedTotal int
+		result, err := client.Checkout(ctx, req, CheckoutOptions{
+			Purchase: purchase,
+			Session:  session,
+			Confirm: func(ctx context.Context, order *PendingOrder) (bool, error) {
+				confirmedTotal = order.Totals.TotalCents
+				return true, nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("Checkout() error = %v", err)
+		}
+		if !result.Purchased || result.Declined || result.PendingOrder.Order.ID != "order-1" || purchases != 1 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+		if confirmedTotal != 1600 {
+			t.Errorf("confirm saw total %d, want 1600", confirmedTotal)
+		}
+		if len(session.Events) != 4 || session.Cart == nil || session.PendingOrder.Status != "completed" {
+			t.Errorf("session not updated: %+v", session)
+		}
+	})
+
+	t.Run("declined and dry run", func(t *testing.T) {
+		var purchases int
+		server := newCheckoutTestServer(t, http.StatusOK, &purchases)
+		defer server.Close()
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+
+		result, err := client.Checkout(ctx, req, CheckoutOptions{
+			Purchase: purchase,
+			Confirm:  func(context.Context, *PendingOrder) (bool, error) { return false, nil },
+		})
+		if err != nil || !result.Declined || result.Purchased {
+			t.Fatalf("declined checkout = %+v, %v", result, err)
+		}
+
+		result, err = client.Checkout(ctx, req, CheckoutOptions{
+			Purchase: PurchasePendingOrderRequest{ShippingAddress: purchase.ShippingAddress},
+			DryRun:   true,
+		})
+		if err != nil || result.Purchased || result.PendingOrder.ID != "po-1" {
+			t.Fatalf("dry run checkout = %+v, %v", result, err)
+		}
+		if purchases != 0 {
+			t.Errorf("purchases = %d, want 0", purchases)
+		}
+	})
+
+	t.Run("purchase failure", func(t *testing.T) {
+		var purchases int
+		server := newCheckoutTestServer(t, http.StatusPaymentRequired, &purchases)
+		defer server.Close()
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+
+		result, err := client.Checkout(ctx, req, CheckoutOptions{Purchase: purchase})
+		var checkoutErr *CheckoutError
+		if !errors.As(err, &checkoutErr) || checkoutErr.Step != CheckoutStepPurchase {
+			t.Fatalf("expected purchase CheckoutError, got %v", err)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusPaymentRequired {
+			t.Errorf("expected wrapped APIError, got %v", err)
+		}
+		if result.PendingOrder == nil || result.PendingOrder.ID != "po-1" || result.Purchased {
+			t.Errorf("partial result missing pending order: %+v", result)
+		}
+	})
+
+	t.Run("confirm error", func(t *testing.T) {
+		var purchases int
+		server := newCheckoutTestServer(t, http.StatusOK, &purchases)
+		defer server.Close()
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+
+		_, err := client.Checkout(ctx, req, CheckoutOptions{
+			Purchase: purchase,
+			Confirm:  func(context.Context, *PendingOrder) (bool, error) { return false, errors.New("prompt closed") },
+		})
+		var checkoutErr *CheckoutError
+		if !errors.As(err, &checkoutErr) || checkoutErr.Step != CheckoutStepConfirm {
+			t.Fatalf("expected confirm CheckoutError, got %v", err)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		client := NewClient("test-token", "test@example.com")
+		var valErr *ValidationError
+		if _, err := client.Checkout(ctx, OptimizerRequest{}, CheckoutOptions{DryRun: true}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty cart, got %v", err)
+		}
+		if _, err := client.Checkout(ctx, req, CheckoutOptions{}); !errors.As(err, &valErr) || valErr.Field != "payment_method" {
+			t.Errorf("expected payment_method ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("invalid address creates nothing", func(t *testing.T) {
+		var requests int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+
+		bad := purchase
+		bad.BillingAddress.Name = ""
+		var valErr *ValidationError
+		if _, err := client.Checkout(ctx, req, CheckoutOptions{Purchase: bad}); !errors.As(err, &valErr) || valErr.Field != "billing_address.name" {
+			t.Errorf("expected billing_address.name ValidationError, got %v", err)
+		}
+		bad = purchase
+		bad.ShippingAddress.City = ""
+		if _, err := client.Checkout(ctx, req, CheckoutOptions{Purchase: bad}); !errors.As(err, &valErr) || valErr.Field != "shipping_address.city" {
+			t.Errorf("expected shipping_address.city ValidationError, got %v", err)
+		}
+		if requests != 0 {
+			t.Errorf("requests = %d, want 0", requests)
+		}
+	})
+}
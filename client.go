@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/repricah/manapool/internal/singleflight"
 	"golang.org/x/time/rate"
 )
 
@@ -33,6 +39,10 @@ const (
 	// DefaultInitialBackoff is the default initial backoff duration for retries.
 	DefaultInitialBackoff = 1 * time.Second
 
+	// DefaultConcurrency is the default number of requests batch operations
+	// such as ApplyReprice keep in flight.
+	DefaultConcurrency = 4
+
 	// Version is the library version.
 	Version = "0.2.0"
 )
@@ -47,12 +57,19 @@ type APIClient interface {
 	GetSellerInventory(ctx context.Context, opts InventoryOptions) (*InventoryResponse, error)
 
 	// GetInventoryByTCGPlayerID retrieves a specific inventory item by TCGPlayer SKU.
-	GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID string) (*InventoryItem, error)
+	GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int64) (*InventoryItem, error)
 }
 
 // Client is the Manapool API client.
 // It implements the APIClient interface.
+//
+// A Client is safe for concurrent use. Options apply when the client is
+// created; use SetRateLimit, SetRetry, SetUserAgent and SetLogger to change
+// a client that is already in use.
 type Client struct {
+	// mu guards the settings that can be changed after creation
+	mu sync.RWMutex
+
 	// httpClient is the HTTP client used for making requests
 	httpClient *http.Client
 
@@ -79,6 +96,35 @@ type Client struct {
 
 	// logger is used for debug and error logging
 	logger Logger
+
+	// addressValidator checks addresses before buyer order requests (optional)
+	addressValidator AddressValidator
+
+	// cardInfoBatchSize is the maximum number of card names per card info request
+	cardInfoBatchSize int
+
+	// strictDecoding rejects responses with fields the response type does not declare
+	strictDecoding bool
+
+	// logSchemaDrift logs fields the response type does not declare with Debugf
+	logSchemaDrift bool
+
+	// concurrency is the number of requests batch operations keep in flight
+	concurrency int
+
+	// timestampLayout is the format of request times (empty: RFC3339Nano)
+	timestampLayout string
+
+	// listingCalls, cardInfoCalls and priceCalls share one API call among
+	// concurrent callers making the same lookup
+	listingCalls  singleflight.Group[*InventoryItemResponse]
+	cardInfoCalls singleflight.Group[*CardInfoResponse]
+	priceCalls    singleflight.Group[any]
+
+	// requests and requestErrors count API requests and those that failed,
+	// for APIStats
+	requests      atomic.Int64
+	requestErrors atomic.Int64
 }
 
 // Logger is an interface for logging.
@@ -112,14 +158,16 @@ func NewClient(authToken, email string, opts ...ClientOption) *Client {
 		httpClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
-		baseURL:        DefaultBaseURL,
-		authToken:      authToken,
-		email:          email,
-		rateLimiter:    rate.NewLimiter(DefaultRateLimit, DefaultRateBurst),
-		maxRetries:     DefaultMaxRetries,
-		initialBackoff: DefaultInitialBackoff,
-		userAgent:      fmt.Sprintf("manapool-go/%s", Version),
-		logger:         &noopLogger{},
+		baseURL:           DefaultBaseURL,
+		authToken:         authToken,
+		email:             email,
+		rateLimiter:       rate.NewLimiter(DefaultRateLimit, DefaultRateBurst),
+		maxRetries:        DefaultMaxRetries,
+		initialBackoff:    DefaultInitialBackoff,
+		userAgent:         fmt.Sprintf("manapool-go/%s", Version),
+		logger:            &noopLogger{},
+		cardInfoBatchSize: DefaultCardInfoBatchSize,
+		concurrency:       DefaultConcurrency,
 	}
 
 	// Apply options
@@ -130,121 +178,275 @@ func NewClient(authToken, email string, opts ...ClientOption) *Client {
 	return client
 }
 
+// MustNewClient is like NewClient but panics when authToken or email is
+// blank. Use it where missing credentials are a programming or deployment
+// error, such as when building a client from required configuration.
+//
+// Example:
+//
+//	client := manapool.MustNewClient(os.Getenv("MANAPOOL_TOKEN"), os.Getenv("MANAPOOL_EMAIL"))
+func MustNewClient(authToken, email string, opts ...ClientOption) *Client {
+	client := NewClient(authToken, email, opts...)
+	if err := client.Validate(); err != nil {
+		panic("manapool: " + err.Error())
+	}
+	return client
+}
+
+// Validate checks that the client has an auth token and email. It does not
+// contact the API; use WhoAmI to confirm the credentials are accepted.
+// Public endpoints such as price lists work without credentials.
+func (c *Client) Validate() error {
+	if strings.TrimSpace(c.authToken) == "" {
+		return NewValidationError("auth_token", "auth token cannot be empty")
+	}
+	if strings.TrimSpace(c.email) == "" {
+		return NewValidationError("email", "email cannot be empty")
+	}
+	return nil
+}
+
 // doRequest executes an HTTP request with rate limiting, retries, and error handling.
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, params url.Values) (*http.Response, error) {
 	return c.doRequestWithBody(ctx, method, endpoint, params, nil, "")
 }
 
 func (c *Client) doRequestWithBody(ctx context.Context, method, endpoint string, params url.Values, body io.Reader, contentType string) (*http.Response, error) {
+	return c.sendRequest(ctx, method, endpoint, params.Encode(), body, contentType, nil)
+}
+
+// doQueryRequest is doRequest for a query built with newQuery. It encodes
+// and releases q; a nil q sends no query.
+func (c *Client) doQueryRequest(ctx context.Context, method, endpoint string, q *query) (*http.Response, error) {
+	return c.sendRequest(ctx, method, endpoint, q.encode(), nil, "", nil)
+}
+
+// sendRequest sends a request with the encoded query rawQuery and any extra
+// header fields, retrying network and server errors.
+func (c *Client) sendRequest(ctx context.Context, method, endpoint, rawQuery string, body io.Reader, contentType string, header http.Header) (*http.Response, error) {
+	ctx, correlationID := ensureCorrelationID(ctx)
+	c.requests.Add(1)
+	networkError := func(message string, err error) error {
+		c.requestErrors.Add(1)
+		netErr := NewNetworkError(message, err)
+		netErr.CorrelationID = correlationID
+		return netErr
+	}
+	// The transport closes the body once the request is sent; close it
+	// ourselves when returning before that, releasing pooled buffers.
+	closeBody := func() {
+		if closer, ok := body.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+
 	// Wait for rate limiter
 	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return nil, NewNetworkError("rate limiter error", err)
+		closeBody()
+		return nil, networkError("rate limiter error", err)
 	}
 
+	cfg := c.config()
+	logger := withCorrelation(cfg.logger, correlationID)
+
 	// Build URL
 	reqURL := c.baseURL + strings.TrimPrefix(endpoint, "/")
-	if len(params) > 0 {
-		reqURL = reqURL + "?" + params.Encode()
+	if rawQuery != "" {
+		reqURL = reqURL + "?" + rawQuery
 	}
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
-		return nil, NewNetworkError("failed to create request", err)
+		closeBody()
+		return nil, networkError("failed to create request", err)
+	}
+	if sized, ok := body.(interface{ Len() int }); ok && req.ContentLength == 0 {
+		req.ContentLength = int64(sized.Len())
+	}
+	if pooled, ok := body.(*pooledBody); ok {
+		// Each attempt sends its own reader over the pooled buffer, which
+		// goes back to the pool once the transport has closed them all.
+		defer pooled.Close()
+		req.GetBody = pooled.Open
+		if req.Body, err = pooled.Open(); err != nil {
+			return nil, networkError("failed to create request", err)
+		}
 	}
 
 	// Add headers
 	req.Header.Set("X-ManaPool-Access-Token", c.authToken)
 	req.Header.Set("X-ManaPool-Email", c.email)
-	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("User-Agent", cfg.userAgent)
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set(CorrelationIDHeader, correlationID)
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
 	// Execute with retries
 	var resp *http.Response
-	backoff := c.initialBackoff
-
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
-		c.logger.Debugf("API request: %s %s (attempt %d/%d)", method, reqURL, attempt+1, c.maxRetries+1)
+	backoff := cfg.initialBackoff
+	started := time.Now()
+	attempts := 0
+
+	for attempt := 0; attempt <= cfg.maxRetries; attempt++ {
+		attempts++
+		logger.Debugf("API request: %s %s (attempt %d/%d)", method, reqURL, attempt+1, cfg.maxRetries+1)
+		if attempt > 0 && req.GetBody != nil {
+			// The previous attempt consumed the body; send it again from the start.
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, networkError("failed to rewind request body", err)
+			}
+		}
 
 		resp, err = c.httpClient.Do(req)
 		if err != nil {
-			c.logger.Errorf("Request failed (attempt %d/%d): %v", attempt+1, c.maxRetries+1, err)
+			logger.Errorf("Request failed (attempt %d/%d): %v", attempt+1, cfg.maxRetries+1, err)
 
 			// Don't retry on context errors
 			if ctx.Err() != nil {
-				return nil, NewNetworkError("request cancelled", ctx.Err())
+				return nil, networkError("request cancelled", ctx.Err())
 			}
 
 			// Retry on network errors
-			if attempt < c.maxRetries {
+			if attempt < cfg.maxRetries {
 				time.Sleep(backoff)
 				backoff *= 2
 				continue
 			}
 
-			return nil, NewNetworkError("request failed after retries", err)
+			return nil, networkError("request failed after retries", err)
 		}
 
 		// Success or non-retryable error
-		if resp.StatusCode < 500 || attempt == c.maxRetries {
+		if resp.StatusCode < 500 || attempt == cfg.maxRetries {
 			break
 		}
 
 		// Server error - retry
-		c.logger.Errorf("Server error %d (attempt %d/%d), retrying...", resp.StatusCode, attempt+1, c.maxRetries+1)
+		logger.Errorf("Server error %d (attempt %d/%d), retrying...", resp.StatusCode, attempt+1, cfg.maxRetries+1)
 		_ = resp.Body.Close()
 		time.Sleep(backoff)
 		backoff *= 2
 	}
 
+	if resp.StatusCode >= http.StatusBadRequest {
+		c.requestErrors.Add(1)
+	}
+	recordResponseMeta(ctx, resp, attempts, started, time.Now())
 	return resp, nil
 }
 
+// endpointPath formats an endpoint path, escaping each parameter with
+// url.PathEscape so IDs containing slashes, spaces or "?" stay within their
+// path segment.
+//
+// Example:
+//
+//	endpointPath("/seller/orders/%s/reports", id)
+func endpointPath(format string, params ...string) string {
+	escaped := make([]interface{}, len(params))
+	for i, param := range params {
+		escaped[i] = url.PathEscape(param)
+	}
+	return fmt.Sprintf(format, escaped...)
+}
+
 func (c *Client) doJSONRequest(ctx context.Context, method, endpoint string, params url.Values, payload interface{}) (*http.Response, error) {
 	var body io.Reader
 	if payload != nil {
-		buf := &bytes.Buffer{}
+		buf := getBuffer()
 		encoder := json.NewEncoder(buf)
 		if err := encoder.Encode(payload); err != nil {
+			putBuffer(buf)
 			return nil, NewNetworkError("failed to encode request body", err)
 		}
-		body = buf
+		body = newPooledBody(buf)
 	}
 
 	return c.doRequestWithBody(ctx, method, endpoint, params, body, "application/json")
 }
 
 // decodeResponse decodes a JSON response and handles HTTP errors.
+// Successful responses are decoded straight from the connection. The body is
+// only copied into memory as a whole, and formatted, when a logger set with
+// WithLogger needs it or the request failed.
 func (c *Client) decodeResponse(resp *http.Response, v interface{}) error {
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
+	cfg := c.config()
+	correlationID := correlationIDOf(resp)
+	logger := withCorrelation(cfg.logger, correlationID)
+	_, quiet := cfg.logger.(*noopLogger)
+	failed := resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices
+	readError := func(err error) error {
+		netErr := NewNetworkError("failed to read response body", err)
+		netErr.CorrelationID = correlationID
+		return netErr
+	}
+
+	if quiet && !failed {
+		if v == nil {
+			return nil
+		}
+		body := &trackingReader{r: resp.Body}
+		decoder := json.NewDecoder(body)
+		if cfg.strictDecoding {
+			decoder.DisallowUnknownFields()
+		}
+		err := decoder.Decode(v)
+		if body.err != nil && !errors.Is(body.err, io.EOF) {
+			return readError(body.err)
+		}
+		if errors.Is(err, io.EOF) {
+			// Empty body
+			return nil
+		}
+		if err != nil {
+			if cfg.strictDecoding {
+				err = unknownFieldError(err)
+			}
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+		// Drain what is left so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+		return nil
+	}
+
 	// Read body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return NewNetworkError("failed to read response body", err)
+		return readError(err)
 	}
 
-	c.logger.Debugf("API response: status=%d, body=%s", resp.StatusCode, string(body))
+	logger.Debugf("API response: status=%d, body=%s", resp.StatusCode, string(body))
 
 	// Check status code
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+	if failed {
 		apiErr := &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(body),
-			Response:   resp,
+			StatusCode:    resp.StatusCode,
+			Message:       string(body),
+			RequestID:     resp.Header.Get("X-Request-Id"),
+			CorrelationID: correlationID,
+			Response:      resp,
 		}
 
 		// Try to extract a better error message from JSON
 		var errorResp struct {
-			Error   string `json:"error"`
-			Message string `json:"message"`
+			Error   string          `json:"error"`
+			Message string          `json:"message"`
+			Details json.RawMessage `json:"details"`
+			Errors  json.RawMessage `json:"errors"`
 		}
 		if json.Unmarshal(body, &errorResp) == nil {
+			apiErr.Details = errorResp.Details
+			apiErr.Fields = append(parseFieldErrors(errorResp.Details), parseFieldErrors(errorResp.Errors)...)
 			if errorResp.Error != "" {
 				apiErr.Message = errorResp.Error
 			} else if errorResp.Message != "" {
@@ -252,15 +454,85 @@ func (c *Client) decodeResponse(resp *http.Response, v interface{}) error {
 			}
 		}
 
+		// An empty token or email is the usual cause of a 401; say so
+		// rather than leaving the caller to guess.
+		if apiErr.IsUnauthorized() {
+			if err := c.Validate(); err != nil {
+				apiErr.Message = fmt.Sprintf("%s (client credentials missing: %s)", apiErr.Message, err.(*ValidationError).Message)
+			}
+		}
+
 		return apiErr
 	}
 
 	// Decode JSON
-	if v != nil && len(body) > 0 {
-		if err := json.Unmarshal(body, v); err != nil {
+	if v == nil || len(body) == 0 {
+		return nil
+	}
+	if cfg.strictDecoding {
+		if err := decodeStrict(body, v); err != nil {
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
+		return nil
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if cfg.logSchemaDrift && reflect.TypeOf(v).Kind() == reflect.Pointer {
+		// Decode again into a fresh value to report schema drift without
+		// failing the call.
+		fresh := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+		var unknown *UnknownFieldError
+		if err := decodeStrict(body, fresh); errors.As(err, &unknown) {
+			endpoint := ""
+			if resp.Request != nil {
+				endpoint = resp.Request.Method + " " + resp.Request.URL.Path
+			}
+			logger.Debugf("API response %s: %v", endpoint, unknown)
+		}
 	}
 
 	return nil
 }
+
+// maxDrainBytes is how much of an unread response body is discarded before
+// closing it, so the connection can be reused.
+const maxDrainBytes = 64 << 10
+
+// trackingReader records the first error from its underlying reader, so
+// read failures can be told apart from malformed JSON.
+type trackingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (t *trackingReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err != nil && t.err == nil {
+		t.err = err
+	}
+	return n, err
+}
+
+// decodeStrict decodes body into v, returning an UnknownFieldError for the
+// first field v does not declare.
+func decodeStrict(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	return unknownFieldError(decoder.Decode(v))
+}
+
+// unknownFieldError converts the error a decoder with DisallowUnknownFields
+// returns for an unknown field into an UnknownFieldError. Other errors are
+// returned unchanged.
+func unknownFieldError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if name, err := strconv.Unquote(field); err == nil {
+			return &UnknownFieldError{Field: name}
+		}
+	}
+	return err
+}
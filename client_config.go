@@ -0,0 +1,90 @@
+package manapool
+
+import (
+	"time"
+
+	"github.com/repricah/manapool/internal/parallel"
+	"golang.org/x/time/rate"
+)
+
+// clientConfig is a snapshot of the client settings that can change at
+// runtime. Each request reads one snapshot so a concurrent setter never
+// applies halfway through a request.
+type clientConfig struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	userAgent      string
+	logger         Logger
+	strictDecoding bool
+	logSchemaDrift bool
+}
+
+// config returns a snapshot of the client's runtime settings.
+func (c *Client) config() clientConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return clientConfig{
+		maxRetries:     c.maxRetries,
+		initialBackoff: c.initialBackoff,
+		userAgent:      c.userAgent,
+		logger:         c.logger,
+		strictDecoding: c.strictDecoding,
+		logSchemaDrift: c.logSchemaDrift,
+	}
+}
+
+// currentLogger returns the logger requests are currently using.
+func (c *Client) currentLogger() Logger {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.logger
+}
+
+// SetRateLimit changes the request rate of a client that is already in use.
+// It is safe to call while requests are in flight; requests waiting on the
+// limiter pick up the new rate.
+//
+// Example:
+//
+//	// Slow down after the API starts returning 429s
+//	client.SetRateLimit(2, 1)
+func (c *Client) SetRateLimit(requestsPerSecond float64, burst int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimiter.SetLimit(rate.Limit(requestsPerSecond))
+	c.rateLimiter.SetBurst(burst)
+}
+
+// SetRetry changes the retry behavior of a client that is already in use.
+// Requests already in flight keep the settings they started with.
+func (c *Client) SetRetry(maxRetries int, initialBackoff time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxRetries = maxRetries
+	c.initialBackoff = initialBackoff
+}
+
+// SetUserAgent changes the User-Agent header sent by subsequent requests.
+func (c *Client) SetUserAgent(userAgent string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userAgent = userAgent
+}
+
+// SetLogger replaces the client's logger. A nil logger discards all log
+// messages.
+func (c *Client) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = &noopLogger{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logger = logger
+}
+
+// parallelOptions returns the worker pool settings for batch operations. The
+// pool does not wait on the rate limiter itself: each request already does,
+// so extra workers queue on the limiter rather than exceed the rate.
+func (c *Client) parallelOptions(stopOnError bool) parallel.Options {
+	return parallel.Options{Concurrency: c.concurrency, StopOnError: stopOnError}
+}
@@ -0,0 +1,82 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestClient_Setters(t *testing.T) {
+	client := NewClient("test-token", "test@example.com")
+	logger := &testLogger{}
+
+	client.SetRateLimit(2, 3)
+	client.SetRetry(7, time.Millisecond)
+	client.SetUserAgent("custom-agent")
+	client.SetLogger(logger)
+
+	if client.rateLimiter.Limit() != rate.Limit(2) || client.rateLimiter.Burst() != 3 {
+		t.Errorf("rate limiter = %v/%d, want 2/3", client.rateLimiter.Limit(), client.rateLimiter.Burst())
+	}
+	cfg := client.config()
+	if cfg.maxRetries != 7 || cfg.initialBackoff != time.Millisecond {
+		t.Errorf("retry = %d/%v, want 7/1ms", cfg.maxRetries, cfg.initialBackoff)
+	}
+	if cfg.userAgent != "custom-agent" {
+		t.Errorf("userAgent = %q, want custom-agent", cfg.userAgent)
+	}
+	if cfg.logger != logger {
+		t.Error("SetLogger did not set logger")
+	}
+
+	client.SetLogger(nil)
+	if _, ok := client.config().logger.(*noopLogger); !ok {
+		t.Error("SetLogger(nil) should install the no-op logger")
+	}
+}
+
+func TestClient_SettersConcurrentWithRequests(t *testing.T) {
+	var mu sync.Mutex
+	agents := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agents[r.UserAgent()] = true
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRateLimit(1000, 10))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetSellerAccount(context.Background()); err != nil {
+				t.Errorf("GetSellerAccount() error = %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			client.SetRateLimit(1000, 10)
+			client.SetRetry(1, time.Millisecond)
+			client.SetUserAgent("agent-b")
+			client.SetLogger(nil)
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for agent := range agents {
+		if agent != "agent-b" && agent != "manapool-go/"+Version {
+			t.Errorf("unexpected User-Agent %q", agent)
+		}
+	}
+}
@@ -683,3 +683,124 @@ func TestClient_decodeResponse_EmptyBody(t *testing.T) {
 		t.Fatalf("decodeResponse with empty body and nil target should not error, got: %v", err)
 	}
 }
+
+func TestClient_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		email     string
+		wantField string
+	}{
+		{name: "valid", token: "test-token", email: "test@example.com"},
+		{name: "empty token", token: "", email: "test@example.com", wantField: "auth_token"},
+		{name: "blank email", token: "test-token", email: "  ", wantField: "email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewClient(tt.token, tt.email).Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) || valErr.Field != tt.wantField {
+				t.Errorf("Validate() error = %v, want field %q", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestMustNewClient(t *testing.T) {
+	if client := MustNewClient("test-token", "test@example.com"); client == nil {
+		t.Fatal("MustNewClient() returned nil")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustNewClient() with empty credentials did not panic")
+		}
+	}()
+	MustNewClient("", "")
+}
+
+func TestClient_UnauthorizedWithoutCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("", "test@example.com", WithBaseURL(server.URL+"/"))
+	_, err := client.GetSellerAccount(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		t.Fatalf("expected 401 APIError, got %v", err)
+	}
+	if !strings.Contains(apiErr.Message, "auth token cannot be empty") {
+		t.Errorf("Message = %q, want mention of missing auth token", apiErr.Message)
+	}
+}
+
+func TestEndpointPath(t *testing.T) {
+	got := endpointPath("/seller/inventory/product/%s/%s", "mtg_single", "a/b c?d#e%f")
+	want := "/seller/inventory/product/mtg_single/a%2Fb%20c%3Fd%23e%25f"
+	if got != want {
+		t.Errorf("endpointPath() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_PathParametersEscaped(t *testing.T) {
+	const hostile = "../a/b c?x=1#y"
+	const escaped = "..%2Fa%2Fb%20c%3Fx=1%23y"
+
+	var gotPath, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want string
+	}{
+		{"GetOrder", func() error { _, err := client.GetOrder(ctx, hostile); return err }, "/orders/" + escaped},
+		{"GetSellerOrderReports", func() error { _, err := client.GetSellerOrderReports(ctx, hostile); return err }, "/seller/orders/" + escaped + "/reports"},
+		{"GetBuyerOrder", func() error { _, err := client.GetBuyerOrder(ctx, hostile); return err }, "/buyer/orders/" + escaped},
+		{"GetPendingOrder", func() error { _, err := client.GetPendingOrder(ctx, hostile); return err }, "/buyer/orders/pending-orders/" + escaped},
+		{"GetWebhook", func() error { _, err := client.GetWebhook(ctx, hostile); return err }, "/webhooks/" + escaped},
+		{"DeleteWebhook", func() error { return client.DeleteWebhook(ctx, hostile) }, "/webhooks/" + escaped},
+		{"GetInventoryListing", func() error { _, err := client.GetInventoryListing(ctx, hostile); return err }, "/inventory/listings/" + escaped},
+		{"GetSellerInventoryByProduct", func() error {
+			_, err := client.GetSellerInventoryByProduct(ctx, ProductTypeSingle, hostile)
+			return err
+		}, "/seller/inventory/product/mtg_single/" + escaped},
+		{"GetSellerInventoryByScryfall", func() error {
+			_, err := client.GetSellerInventoryByScryfall(ctx, hostile, InventoryByScryfallOptions{})
+			return err
+		}, "/seller/inventory/scryfall_id/" + escaped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, gotQuery = "", ""
+			if err := tt.call(); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if gotPath != tt.want {
+				t.Errorf("path = %q, want %q", gotPath, tt.want)
+			}
+			if strings.Contains(gotQuery, "x=1") {
+				t.Errorf("path parameter leaked into query %q", gotQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package manapool
+
+// Cloner is implemented by request types that can return a deep copy of
+// themselves.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// CloneItems returns a deep copy of items, cloning each element, so the copy
+// can be modified without affecting the original. It returns nil for a nil
+// slice.
+//
+// Example:
+//
+//	retry := manapool.CloneItems(items)
+//	for i := range retry {
+//	    retry[i].PriceCents -= 10
+//	}
+//	_, err := client.CreateInventoryBulkBySKU(ctx, retry)
+func CloneItems[T Cloner[T]](items []T) []T {
+	if items == nil {
+		return nil
+	}
+	clone := make([]T, len(items))
+	for i, item := range items {
+		clone[i] = item.Clone()
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the request, including its cart and seller
+// and country filters.
+//
+// Example:
+//
+//	relaxed := req.Clone()
+//	relaxed.ShipFromCountries = nil
+//	cart, err := client.OptimizeCart(ctx, relaxed)
+func (r OptimizerRequest) Clone() OptimizerRequest {
+	r.Cart = CloneItems(r.Cart)
+	r.ExcludeSellerIDs = cloneStrings(r.ExcludeSellerIDs)
+	r.AllowSellerIDs = cloneStrings(r.AllowSellerIDs)
+	r.ShipFromCountries = cloneStrings(r.ShipFromCountries)
+	return r
+}
+
+// Clone returns a deep copy of the cart item.
+func (i OptimizerCartItem) Clone() OptimizerCartItem {
+	i.IsToken = clonePtr(i.IsToken)
+	i.IncludeNonSanctionedLegal = clonePtr(i.IncludeNonSanctionedLegal)
+	i.MTGJsonID = clonePtr(i.MTGJsonID)
+	i.LanguageIDs = cloneStrings(i.LanguageIDs)
+	i.FinishIDs = cloneStrings(i.FinishIDs)
+	i.ConditionIDs = cloneStrings(i.ConditionIDs)
+	if i.TCGPlayerSKUIds != nil {
+		i.TCGPlayerSKUIds = append([]int64(nil), i.TCGPlayerSKUIds...)
+	}
+	i.ProductIDs = cloneStrings(i.ProductIDs)
+	i.Index = clonePtr(i.Index)
+	return i
+}
+
+// Clone returns a deep copy of the request, including its shipping overrides,
+// line items and addresses.
+//
+// Example:
+//
+//	update := pending.Clone()
+//	update.ShippingOverrides["seller-1"] = string(manapool.ShippingMethodGroundAdvantage)
+//	_, err := client.UpdatePendingOrder(ctx, id, update)
+func (r PendingOrderRequest) Clone() PendingOrderRequest {
+	if r.ShippingOverrides != nil {
+		overrides := make(map[string]string, len(r.ShippingOverrides))
+		for seller, method := range r.ShippingOverrides {
+			overrides[seller] = method
+		}
+		r.ShippingOverrides = overrides
+	}
+	r.LineItems = CloneItems(r.LineItems)
+	r.TaxAddress = cloneAddress(r.TaxAddress)
+	r.ShippingAddress = cloneAddress(r.ShippingAddress)
+	return r
+}
+
+// Clone returns a copy of the line item.
+func (i PendingOrderLineItem) Clone() PendingOrderLineItem { return i }
+
+// Clone returns a deep copy of the address.
+func (a Address) Clone() Address {
+	a.Line2 = clonePtr(a.Line2)
+	a.Line3 = clonePtr(a.Line3)
+	return a
+}
+
+// Clone returns a copy of the bulk item.
+func (i InventoryBulkItemBySKU) Clone() InventoryBulkItemBySKU { return i }
+
+// Clone returns a copy of the bulk item.
+func (i InventoryBulkItemByProduct) Clone() InventoryBulkItemByProduct { return i }
+
+// Clone returns a copy of the bulk item.
+func (i InventoryBulkItemByScryfall) Clone() InventoryBulkItemByScryfall { return i }
+
+// Clone returns a deep copy of the bulk item.
+func (i InventoryBulkItemByTCGPlayerID) Clone() InventoryBulkItemByTCGPlayerID {
+	i.FinishID = clonePtr(i.FinishID)
+	i.ConditionID = clonePtr(i.ConditionID)
+	return i
+}
+
+func cloneAddress(a *Address) *Address {
+	if a == nil {
+		return nil
+	}
+	clone := a.Clone()
+	return &clone
+}
+
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string(nil), values...)
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,112 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptimizerRequest_Clone(t *testing.T) {
+	orig := OptimizerRequest{
+		Cart: []OptimizerCartItem{{
+			Type:              "mtg_single",
+			Name:              "Sol Ring",
+			IsToken:           Bool(false),
+			MTGJsonID:         String("abc"),
+			FinishIDs:         []string{"NF"},
+			TCGPlayerSKUIds:   []int64{1},
+			QuantityRequested: 1,
+			Index:             Int(0),
+		}},
+		ExcludeSellerIDs:  []string{"seller-1"},
+		ShipFromCountries: []string{CountryUS},
+	}
+
+	clone := orig.Clone()
+	if !reflect.DeepEqual(clone, orig) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, orig)
+	}
+
+	clone.Cart[0].Name = "Mana Crypt"
+	*clone.Cart[0].IsToken = true
+	*clone.Cart[0].MTGJsonID = "def"
+	clone.Cart[0].FinishIDs[0] = "FO"
+	clone.Cart[0].TCGPlayerSKUIds[0] = 2
+	*clone.Cart[0].Index = 5
+	clone.ExcludeSellerIDs[0] = "seller-2"
+	clone.ShipFromCountries = append(clone.ShipFromCountries, CountryCA)
+
+	item := orig.Cart[0]
+	if item.Name != "Sol Ring" || *item.IsToken || *item.MTGJsonID != "abc" || item.FinishIDs[0] != "NF" ||
+		item.TCGPlayerSKUIds[0] != 1 || *item.Index != 0 {
+		t.Errorf("modifying the clone changed the original cart item: %+v", item)
+	}
+	if orig.ExcludeSellerIDs[0] != "seller-1" || len(orig.ShipFromCountries) != 1 {
+		t.Errorf("modifying the clone changed the original filters: %+v", orig)
+	}
+}
+
+func TestOptimizerRequest_CloneKeepsNil(t *testing.T) {
+	clone := OptimizerRequest{}.Clone()
+	if clone.Cart != nil || clone.ExcludeSellerIDs != nil || clone.AllowSellerIDs != nil || clone.ShipFromCountries != nil {
+		t.Errorf("Clone() of empty request = %+v, want nil slices", clone)
+	}
+}
+
+func TestPendingOrderRequest_Clone(t *testing.T) {
+	orig := PendingOrderRequest{
+		ShippingOverrides: map[string]string{"seller-1": "first_class"},
+		LineItems:         []PendingOrderLineItem{{InventoryID: "inv-1", QuantitySelected: 1}},
+		ShippingAddress:   &Address{Name: "Jane Doe", Line1: "1 Main St", Line2: String("Apt 2")},
+	}
+
+	clone := orig.Clone()
+	if !reflect.DeepEqual(clone, orig) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, orig)
+	}
+
+	clone.ShippingOverrides["seller-1"] = "ground_advantage"
+	clone.LineItems[0].QuantitySelected = 4
+	clone.ShippingAddress.Line1 = "2 Main St"
+	*clone.ShippingAddress.Line2 = "Apt 3"
+
+	if orig.ShippingOverrides["seller-1"] != "first_class" {
+		t.Error("modifying the clone changed the original shipping overrides")
+	}
+	if orig.LineItems[0].QuantitySelected != 1 {
+		t.Error("modifying the clone changed the original line items")
+	}
+	if orig.ShippingAddress.Line1 != "1 Main St" || *orig.ShippingAddress.Line2 != "Apt 2" {
+		t.Errorf("modifying the clone changed the original address: %+v", orig.ShippingAddress)
+	}
+	if clone.TaxAddress != nil {
+		t.Error("Clone() should keep a nil tax address nil")
+	}
+}
+
+func TestCloneItems(t *testing.T) {
+	if CloneItems[InventoryBulkItemBySKU](nil) != nil {
+		t.Error("CloneItems(nil) should return nil")
+	}
+
+	skus := []InventoryBulkItemBySKU{{TCGPlayerSKU: 1, PriceCents: 100, Quantity: 1}}
+	skuClone := CloneItems(skus)
+	skuClone[0].PriceCents = 90
+	if skus[0].PriceCents != 100 {
+		t.Error("modifying the cloned SKU items changed the original")
+	}
+
+	finish := "NF"
+	items := []InventoryBulkItemByTCGPlayerID{{TCGPlayerID: 1, FinishID: &finish, ConditionID: String("NM")}}
+	clone := CloneItems(items)
+	*clone[0].FinishID = "FO"
+	*clone[0].ConditionID = "LP"
+	if finish != "NF" || *items[0].ConditionID != "NM" {
+		t.Error("modifying the cloned TCGPlayer items changed the original")
+	}
+
+	products := CloneItems([]InventoryBulkItemByProduct{{ProductType: ProductTypeSingle, ProductID: "p1"}})
+	scryfall := CloneItems([]InventoryBulkItemByScryfall{{ScryfallID: "s1"}})
+	if products[0].ProductID != "p1" || scryfall[0].ScryfallID != "s1" {
+		t.Error("CloneItems() did not copy item values")
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Completion scripts ask the hidden __complete command for candidates, so
+// they always match the installed binary's commands and flags.
+const (
+	bashCompletion = `# bash completion for manapool
+_manapool() {
+    local IFS=$'\n'
+    COMPREPLY=($(manapool __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _manapool manapool
+`
+
+	zshCompletion = `#compdef manapool
+_manapool() {
+    local -a candidates
+    candidates=("${(@f)$(manapool __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    if (( ${#candidates[@]} )) && [[ -n ${candidates[1]} ]]; then
+        compadd -a candidates
+    else
+        _files
+    fi
+}
+compdef _manapool manapool
+`
+
+	fishCompletion = `# fish completion for manapool
+complete -c manapool -f -a '(manapool __complete (commandline -opc)[2..-1] (commandline -ct) 2>/dev/null)'
+`
+)
+
+// runCompletion implements "manapool completion".
+func runCompletion(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("completion", "bash|zsh|fish")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one of bash, zsh or fish")
+	}
+	var script string
+	switch positional[0] {
+	case "bash":
+		script = bashCompletion
+	case "zsh":
+		script = zshCompletion
+	case "fish":
+		script = fishCompletion
+	default:
+		return usagef("unsupported shell %q", positional[0])
+	}
+	_, err = io.WriteString(a.stdout, script)
+	return err
+}
+
+// runComplete implements the hidden "__complete" command. args are the
+// words after "manapool", the last being the word under the cursor, and the
+// matching candidates are printed one per line.
+func runComplete(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	current := args[len(args)-1]
+	words := args[:len(args)-1]
+
+	for _, candidate := range a.completions(ctx, words, current) {
+		if strings.HasPrefix(candidate, current) {
+			if _, err := fmt.Fprintln(a.stdout, candidate); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// completions returns the candidates for the word after words.
+func (a *app) completions(ctx context.Context, words []string, current string) []string {
+	// Skip global flags.
+	for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+		if words[0] == "--profile" || words[0] == "-profile" {
+			if len(words) == 1 {
+				return a.profileNames()
+			}
+			words = words[1:]
+		}
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		if strings.HasPrefix(current, "-") {
+			return []string{"--profile"}
+		}
+		var names []string
+		for _, cmd := range commands() {
+			if !cmd.hidden {
+				names = append(names, cmd.name)
+			}
+		}
+		return append(names, "help")
+	}
+
+	var cmd *command
+	for _, c := range commands() {
+		if c.name == words[0] && !c.hidden {
+			cmd = &c
+			break
+		}
+	}
+	if cmd == nil {
+		return nil
+	}
+	args := words[1:]
+
+	// Complete the subcommand before anything else.
+	if len(cmd.subcommands) > 0 && len(args) == 0 && !strings.HasPrefix(current, "-") {
+		return cmd.subcommands
+	}
+
+	fs := a.commandFlags(ctx, *cmd, args)
+	if fs == nil {
+		return nil
+	}
+	if len(args) > 0 {
+		if values := a.flagValues(fs, args[len(args)-1]); values != nil {
+			return values
+		}
+	}
+	if !strings.HasPrefix(current, "-") {
+		return nil
+	}
+	var flags []string
+	fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, "--"+f.Name)
+	})
+	sort.Strings(flags)
+	return flags
+}
+
+// commandFlags runs cmd with -h and returns the flag set it created, without
+// output or side effects.
+func (a *app) commandFlags(ctx context.Context, cmd command, args []string) *flag.FlagSet {
+	probe := &app{stdout: io.Discard, stderr: io.Discard, getenv: a.getenv, configDir: a.configDir}
+	var probeArgs []string
+	if len(cmd.subcommands) > 0 && len(args) > 0 {
+		probeArgs = append(probeArgs, args[0])
+	}
+	_ = cmd.run(ctx, probe, append(probeArgs, "-h"))
+	return probe.flags
+}
+
+// flagValues returns candidates for the value of the flag named by prev, or
+// nil if prev is not a flag taking a value.
+func (a *app) flagValues(fs *flag.FlagSet, prev string) []string {
+	if !strings.HasPrefix(prev, "-") || strings.Contains(prev, "=") {
+		return nil
+	}
+	f := fs.Lookup(strings.TrimLeft(prev, "-"))
+	if f == nil {
+		return nil
+	}
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return nil
+	}
+	switch f.Name {
+	case "profile":
+		return a.profileNames()
+	case "output":
+		return outputFormats(f.Usage)
+	case "format":
+		if output := fs.Lookup("output"); output != nil && f.Usage == "alias for --output" {
+			return outputFormats(output.Usage)
+		}
+		return outputFormats(f.Usage)
+	}
+	return []string{}
+}
+
+// outputFormats extracts the formats listed in an --output or --format
+// flag's usage.
+func outputFormats(usage string) []string {
+	_, list, ok := strings.Cut(usage, ": ")
+	if !ok {
+		return []string{}
+	}
+	return strings.Split(list, ", ")
+}
+
+// profileNames returns the saved profile names.
+func (a *app) profileNames() []string {
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompletionScripts(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		stdout, stderr, code := runCLI(t, "", "completion", shell)
+		if code != 0 || !strings.Contains(stdout, "manapool __complete") {
+			t.Errorf("completion %s = %d, %q, %q", shell, code, stdout, stderr)
+		}
+	}
+	if _, _, code := runCLI(t, "", "completion", "powershell"); code != 2 {
+		t.Errorf("unsupported shell exit = %d, want 2", code)
+	}
+	if stdout, _, _ := runCLI(t, "", "help"); strings.Contains(stdout, "__complete") {
+		t.Errorf("help lists hidden command:\n%s", stdout)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	complete := func(words ...string) []string {
+		t.Helper()
+		stdout, stderr, code := runCLI(t, "", append([]string{"__complete"}, words...)...)
+		if code != 0 {
+			t.Fatalf("__complete %q = %d, %q", words, code, stderr)
+		}
+		if stdout == "" {
+			return nil
+		}
+		return strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	}
+
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"or"}, []string{"orders"}},
+		{[]string{"--profile", "x", "pr"}, []string{"prices"}},
+		{[]string{"orders", ""}, []string{"list", "show", "fulfill"}},
+		{[]string{"orders", "list", "--un"}, []string{"--unfulfilled"}},
+		{[]string{"orders", "list", "--output", ""}, []string{"table", "json", "ndjson", "csv"}},
+		{[]string{"prices", "singles", "--format", "n"}, []string{"ndjson"}},
+		{[]string{"reprice", "--f"}, []string{"--floor"}},
+		{[]string{"reprice", "--floor", ""}, nil},
+		{[]string{"optimize", "deck.txt"}, nil},
+		{[]string{"bogus", "--"}, nil},
+	}
+	for _, tt := range tests {
+		if got := complete(tt.words...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
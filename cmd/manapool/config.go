@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// profile holds the credentials for one account or environment.
+type profile struct {
+	Token   string `json:"token"`
+	Email   string `json:"email"`
+	BaseURL string `json:"base_url,omitempty"`
+}
+
+// config is the CLI configuration file.
+type config struct {
+	// Default names the profile used when none is selected.
+	Default  string             `json:"default,omitempty"`
+	Profiles map[string]profile `json:"profiles"`
+}
+
+// configPath returns the configuration file path,
+// $XDG_CONFIG_HOME/manapool/config.json or its platform equivalent.
+func (a *app) configPath() (string, error) {
+	dir, err := a.configDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "manapool", "config.json"), nil
+}
+
+// loadConfig reads the configuration file. A missing file is an empty config.
+func (a *app) loadConfig() (*config, error) {
+	path, err := a.configPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{Profiles: map[string]profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]profile{}
+	}
+	return cfg, nil
+}
+
+// saveConfig writes the configuration file, readable only by the user
+// since it holds API tokens.
+func (a *app) saveConfig(cfg *config) error {
+	path, err := a.configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// runConfig implements "manapool config".
+func runConfig(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return usagef("expected a subcommand: set, list, use, delete or path")
+	}
+	switch args[0] {
+	case "set":
+		return runConfigSet(a, args[1:])
+	case "list":
+		return runConfigList(a, args[1:])
+	case "use":
+		return runConfigUse(a, args[1:])
+	case "delete":
+		return runConfigDelete(a, args[1:])
+	case "path":
+		path, err := a.configPath()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(a.stdout, path)
+		return err
+	default:
+		return usagef("unknown config subcommand %q", args[0])
+	}
+}
+
+// runConfigSet implements "manapool config set".
+func runConfigSet(a *app, args []string) error {
+	fs := a.newFlagSet("config set", "<profile> [--token token] [--email email] [--base-url url]")
+	token := fs.String("token", "", "API access token")
+	email := fs.String("email", "", "account email address")
+	baseURL := fs.String("base-url", "", "API base URL (default: production)")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || positional[0] == "" {
+		return usagef("expected one profile name")
+	}
+	name := positional[0]
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	p := cfg.Profiles[name]
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "token":
+			p.Token = *token
+		case "email":
+			p.Email = *email
+		case "base-url":
+			p.BaseURL = *baseURL
+		}
+	})
+	if p.Token == "" || p.Email == "" {
+		return usagef("profile %q needs --token and --email", name)
+	}
+	cfg.Profiles[name] = p
+	if cfg.Default == "" {
+		cfg.Default = name
+	}
+	if err := a.saveConfig(cfg); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(a.stdout, "Saved profile %q\n", name)
+	return err
+}
+
+// profileRow is a profile as listed by "manapool config list". Tokens are
+// never listed.
+type profileRow struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	BaseURL string `json:"base_url"`
+	Default bool   `json:"default"`
+}
+
+// runConfigList implements "manapool config list".
+func runConfigList(a *app, args []string) error {
+	fs := a.newFlagSet("config list", "[--output table|json|csv]")
+	formats := []string{formatTable, formatJSON, formatCSV}
+	output := outputFlag(fs, formatTable, formats...)
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	rows := make([]profileRow, 0, len(cfg.Profiles))
+	for name, p := range cfg.Profiles {
+		rows = append(rows, profileRow{Name: name, Email: p.Email, BaseURL: p.BaseURL, Default: name == cfg.Default})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Name < rows[j].Name })
+	return writeRecords(a.stdout, *output, rows)
+}
+
+// runConfigUse implements "manapool config use".
+func runConfigUse(a *app, args []string) error {
+	fs := a.newFlagSet("config use", "<profile>")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one profile name")
+	}
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[positional[0]]; !ok {
+		return fmt.Errorf("profile %q does not exist", positional[0])
+	}
+	cfg.Default = positional[0]
+	return a.saveConfig(cfg)
+}
+
+// runConfigDelete implements "manapool config delete".
+func runConfigDelete(a *app, args []string) error {
+	fs := a.newFlagSet("config delete", "<profile>")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one profile name")
+	}
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[positional[0]]; !ok {
+		return fmt.Errorf("profile %q does not exist", positional[0])
+	}
+	delete(cfg.Profiles, positional[0])
+	if cfg.Default == positional[0] {
+		cfg.Default = ""
+	}
+	return a.saveConfig(cfg)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigProfiles(t *testing.T) {
+	var gotToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-ManaPool-Access-Token")
+		_, _ = w.Write([]byte(`{"orders":[]}`))
+	}))
+	defer server.Close()
+
+	configDir := t.TempDir()
+	env := map[string]string{}
+	run := func(args ...string) (string, string, int) {
+		var out, errOut bytes.Buffer
+		a := &app{
+			stdout:    &out,
+			stderr:    &errOut,
+			getenv:    func(key string) string { return env[key] },
+			configDir: func() (string, error) { return configDir, nil },
+		}
+		code := a.run(context.Background(), args)
+		return out.String(), errOut.String(), code
+	}
+
+	if _, stderr, code := run("orders", "list"); code != 1 || !strings.Contains(stderr, "no credentials") {
+		t.Fatalf("without profiles = %d, %q", code, stderr)
+	}
+	for _, args := range [][]string{
+		{"config", "set", "work", "--token", "work-token", "--email", "work@example.com", "--base-url", server.URL + "/"},
+		{"config", "set", "home", "--token", "home-token", "--email", "home@example.com", "--base-url", server.URL + "/"},
+	} {
+		if _, stderr, code := run(args...); code != 0 {
+			t.Fatalf("%q = %d, %q", args, code, stderr)
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(configDir, "manapool", "config.json"))
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("config file = %v, %v", info, err)
+	}
+
+	stdout, _, _ := run("config", "list", "--output", "csv")
+	want := "name,email,base_url,default\nhome,home@example.com," + server.URL + "/,false\nwork,work@example.com," + server.URL + "/,true\n"
+	if stdout != want {
+		t.Errorf("list = %q, want %q", stdout, want)
+	}
+
+	// The first profile saved is the default.
+	if _, stderr, code := run("orders", "list"); code != 0 || gotToken != "work-token" {
+		t.Fatalf("default profile = %d, %q, token %q", code, stderr, gotToken)
+	}
+	// --profile works before and after the command.
+	if _, _, code := run("--profile", "home", "orders", "list"); code != 0 || gotToken != "home-token" {
+		t.Errorf("global --profile = %d, token %q", code, gotToken)
+	}
+	if _, _, code := run("orders", "list", "--profile=work"); code != 0 || gotToken != "work-token" {
+		t.Errorf("command --profile = %d, token %q", code, gotToken)
+	}
+	env["MANAPOOL_PROFILE"] = "home"
+	if _, _, code := run("orders", "list"); code != 0 || gotToken != "home-token" {
+		t.Errorf("MANAPOOL_PROFILE = %d, token %q", code, gotToken)
+	}
+	delete(env, "MANAPOOL_PROFILE")
+
+	if _, _, code := run("config", "use", "home"); code != 0 {
+		t.Fatalf("use exit = %d", code)
+	}
+	if _, _, code := run("orders", "list"); code != 0 || gotToken != "home-token" {
+		t.Errorf("after use = %d, token %q", code, gotToken)
+	}
+	if _, _, code := run("config", "delete", "home"); code != 0 {
+		t.Fatalf("delete exit = %d", code)
+	}
+	if _, stderr, code := run("--profile", "home", "orders", "list"); code != 1 || !strings.Contains(stderr, `profile "home" does not exist`) {
+		t.Errorf("deleted profile = %d, %q", code, stderr)
+	}
+	if _, _, code := run("config", "set", "partial", "--token", "x"); code != 2 {
+		t.Errorf("set without email exit = %d, want 2", code)
+	}
+}
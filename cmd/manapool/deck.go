@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// Exit statuses of "manapool deck validate", so CI jobs can tell an invalid
+// deck from a failure to validate it. Usage errors exit with 2 as usual.
+const (
+	deckExitInvalid = 1
+	deckExitError   = 3
+)
+
+// runDeck implements "manapool deck".
+func runDeck(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return usagef("expected a subcommand: validate")
+	}
+	switch args[0] {
+	case "validate":
+		return runDeckValidate(ctx, a, args[1:])
+	default:
+		return usagef("unknown deck subcommand %q", args[0])
+	}
+}
+
+// runDeckValidate implements "manapool deck validate". It exits with 0 for a
+// valid deck, 1 for an invalid one and 3 if the deck could not be validated.
+func runDeckValidate(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("deck validate", "<decklist> [--format commander] [--sideboard] [--output table|json|csv]")
+	format := fs.String("format", manapool.DeckFormatCommander, "deck format: "+manapool.DeckFormatCommander)
+	sideboard := fs.Bool("sideboard", false, "include sideboard cards")
+	formats := []string{formatTable, formatJSON, formatCSV}
+	output := outputFlag(fs, formatTable, formats...)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one decklist file")
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	// The deck endpoint only validates commander decks.
+	if *format != manapool.DeckFormatCommander {
+		return usagef("unsupported deck format %q: only %s decks can be validated", *format, manapool.DeckFormatCommander)
+	}
+
+	deck, err := readDecklist(positional[0], manapool.DecklistOptions{IncludeSideboard: *sideboard})
+	if err != nil {
+		return &exitError{code: deckExitError, err: err}
+	}
+	client, err := a.client()
+	if err != nil {
+		return &exitError{code: deckExitError, err: err}
+	}
+	resp, err := client.CreateDeck(ctx, *deck)
+	if err != nil {
+		return &exitError{code: deckExitError, err: err}
+	}
+
+	problems := deckProblems(resp.Details)
+	switch *output {
+	case formatJSON:
+		err = writeJSON(a.stdout, resp)
+	case formatCSV:
+		err = writeRecords(a.stdout, formatCSV, problems)
+	default:
+		err = printDeckValidation(a, positional[0], resp, problems)
+	}
+	if err != nil {
+		return &exitError{code: deckExitError, err: err}
+	}
+	if !resp.Valid {
+		return &exitError{code: deckExitInvalid, err: fmt.Errorf("%s is not a valid %s deck: %d problems", positional[0], *format, len(problems))}
+	}
+	return nil
+}
+
+// deckProblem is one reason a deck failed validation.
+type deckProblem struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Detail string `json:"detail"`
+}
+
+// deckProblems lists the violations in d, grouped by kind.
+func deckProblems(d manapool.DeckValidationDetails) []deckProblem {
+	problems := []deckProblem{}
+	for _, name := range d.CardsNotFound {
+		problems = append(problems, deckProblem{Kind: "not-found", Name: name, Detail: "card not found"})
+	}
+	for _, name := range d.IllegalCards {
+		problems = append(problems, deckProblem{Kind: "illegal", Name: name, Detail: "not legal in commander"})
+	}
+	for _, v := range d.QuantityViolations {
+		problems = append(problems, deckProblem{Kind: "quantity", Name: v.Name, Detail: fmt.Sprintf("%d copies, at most %d allowed", v.Quantity, v.MaxAllowed)})
+	}
+	for _, v := range d.ColorIdentityViolations {
+		problems = append(problems, deckProblem{Kind: "color-identity", Name: v.Name,
+			Detail: fmt.Sprintf("colors %s outside commander identity %s", colorList(v.CardColors), colorList(v.CommanderColors))})
+	}
+	for _, name := range d.PartnerViolations {
+		problems = append(problems, deckProblem{Kind: "partner", Name: name, Detail: "invalid commander pairing"})
+	}
+	return problems
+}
+
+// colorList formats a color identity such as ["U","R"] as "{U}{R}", or
+// "colorless" when empty.
+func colorList(colors []string) string {
+	if len(colors) == 0 {
+		return "colorless"
+	}
+	return "{" + strings.Join(colors, "}{") + "}"
+}
+
+// printDeckValidation writes a human-readable validation report.
+func printDeckValidation(a *app, path string, resp *manapool.DeckCreateResponse, problems []deckProblem) error {
+	status := "valid"
+	if !resp.Valid {
+		status = "not valid"
+	}
+	_, err := fmt.Fprintf(a.stdout, "%s: %s (%d cards, %d commanders)\n", path, status, resp.Details.TotalCardCount, resp.Details.CommanderCount)
+	if err != nil {
+		return err
+	}
+	if resp.BuyURL != "" {
+		_, _ = fmt.Fprintf(a.stdout, "Buy: %s\n", resp.BuyURL)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(a.stdout)
+	return writeRecords(a.stdout, formatTable, problems)
+}
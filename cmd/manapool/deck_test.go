@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestDeckValidate(t *testing.T) {
+	valid := true
+	var got manapool.DeckCreateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/deck" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode deck: %v", err)
+		}
+		if valid {
+			_, _ = w.Write([]byte(`{"valid":true,"buy_url":"https://manapool.com/buy/1","details":{"commander_count":1,"total_card_count":100}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"valid":false,"details":{"commander_count":1,"total_card_count":100,
+			"illegal_cards":["Black Lotus"],"cards_not_found":["Bogus Card"],
+			"quantity_violations":[{"name":"Sol Ring","quantity":2,"max_allowed":1}],
+			"color_identity_violations":[{"name":"Counterspell","card_colors":["U"],"commander_colors":["R"]}]}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "deck.txt")
+	if err := os.WriteFile(path, []byte("Commander\n1 Krenko, Mob Boss\n\nDeck\n2 Sol Ring\n1 Black Lotus\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCLI(t, server.URL, "deck", "validate", path)
+	if code != 0 {
+		t.Fatalf("valid exit = %d, stderr = %q", code, stderr)
+	}
+	if want := path + ": valid (100 cards, 1 commanders)\nBuy: https://manapool.com/buy/1\n"; stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+	if len(got.CommanderNames) != 1 || got.CommanderNames[0] != "Krenko, Mob Boss" || len(got.OtherCards) != 2 {
+		t.Errorf("request = %+v", got)
+	}
+
+	valid = false
+	stdout, stderr, code = runCLI(t, server.URL, "deck", "validate", path)
+	if code != deckExitInvalid || !strings.Contains(stderr, "not a valid commander deck: 4 problems") {
+		t.Errorf("invalid exit = %d, stderr = %q", code, stderr)
+	}
+	for _, want := range []string{"not valid", "Bogus Card", "2 copies, at most 1 allowed", "colors {U} outside commander identity {R}"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout missing %q:\n%s", want, stdout)
+		}
+	}
+
+	stdout, _, code = runCLI(t, server.URL, "deck", "validate", path, "--output", "csv")
+	if code != deckExitInvalid || !strings.HasPrefix(stdout, "kind,name,detail\nnot-found,Bogus Card,card not found\nillegal,Black Lotus,") {
+		t.Errorf("csv = %d, %q", code, stdout)
+	}
+
+	if _, _, code := runCLI(t, server.URL, "deck", "validate", filepath.Join(t.TempDir(), "missing.txt")); code != deckExitError {
+		t.Errorf("missing file exit = %d, want %d", code, deckExitError)
+	}
+}
+
+func TestDeckValidate_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"deck"},
+		{"deck", "build"},
+		{"deck", "validate"},
+		{"deck", "validate", "deck.txt", "--format", "modern"},
+	} {
+		if _, _, code := runCLI(t, "", args...); code != 2 {
+			t.Errorf("%q exit = %d, want 2", args, code)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Command manapool is a command-line client for the Manapool API.
+//
+// Credentials come from a named profile saved with "manapool config set",
+// selected with --profile or MANAPOOL_PROFILE, or else from the
+// MANAPOOL_TOKEN and MANAPOOL_EMAIL environment variables, or else from the
+// default profile. MANAPOOL_BASE_URL overrides the API base URL, which is
+// useful against a mock server.
+//
+// Usage:
+//
+//	manapool [--profile name] <command> [arguments]
+//
+// Run "manapool help" for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/repricah/manapool"
+)
+
+// command is a CLI subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+
+	// subcommands lists the words accepted as the first argument, for
+	// shell completion.
+	subcommands []string
+
+	// hidden commands are left out of help.
+	hidden bool
+}
+
+// commands returns the available subcommands in the order help lists them.
+func commands() []command {
+	return []command{
+		{name: "completion", summary: "print a shell completion script for bash, zsh or fish", run: runCompletion,
+			subcommands: []string{"bash", "zsh", "fish"}},
+		{name: "config", summary: "manage credential profiles", run: runConfig,
+			subcommands: []string{"set", "list", "use", "delete", "path"}},
+		{name: "deck", summary: "validate a commander decklist", run: runDeck,
+			subcommands: []string{"validate"}},
+		{name: "optimize", summary: "price a decklist with the cart optimizer and optionally create a pending order", run: runOptimize},
+		{name: "orders", summary: "list, show and fulfill seller orders", run: runOrders,
+			subcommands: []string{"list", "show", "fulfill"}},
+		{name: "prices", summary: "export market prices for singles, variants or sealed products", run: runPrices,
+			subcommands: []string{"singles", "variants", "sealed"}},
+		{name: "reprice", summary: "reprice inventory against market prices", run: runReprice},
+		{name: "watch", summary: "watch for new orders and run a notification command", run: runWatch,
+			subcommands: []string{"orders"}},
+		{name: "__complete", summary: "print completion candidates", run: runComplete, hidden: true},
+	}
+}
+
+// app holds the CLI's inputs and outputs so commands can be run in tests.
+type app struct {
+	stdout    io.Writer
+	stderr    io.Writer
+	getenv    func(string) string
+	configDir func() (string, error)
+
+	// profile is the profile selected with --profile.
+	profile string
+
+	// flags is the flag set most recently created by newFlagSet, which
+	// shell completion inspects.
+	flags *flag.FlagSet
+}
+
+// usageError reports invalid command-line arguments. It exits with status 2.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...any) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// exitError makes a command exit with a specific status, for commands whose
+// exit codes are part of their interface.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv, configDir: os.UserConfigDir}
+	os.Exit(a.run(ctx, os.Args[1:]))
+}
+
+// run executes the command named by args[0] and returns the exit status.
+func (a *app) run(ctx context.Context, args []string) int {
+	args, err := a.parseGlobalFlags(args)
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "manapool: %v\n", err)
+		return 2
+	}
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		a.usage(a.stdout)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(ctx, a, args[1:])
+		var usageErr *usageError
+		var exitErr *exitError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.As(err, &usageErr):
+			_, _ = fmt.Fprintf(a.stderr, "manapool %s: %v\n", cmd.name, err)
+			return 2
+		case errors.As(err, &exitErr):
+			_, _ = fmt.Fprintf(a.stderr, "manapool %s: %v\n", cmd.name, err)
+			return exitErr.code
+		default:
+			_, _ = fmt.Fprintf(a.stderr, "manapool %s: %v\n", cmd.name, err)
+			return 1
+		}
+	}
+
+	_, _ = fmt.Fprintf(a.stderr, "manapool: unknown command %q\n", args[0])
+	a.usage(a.stderr)
+	return 2
+}
+
+// parseGlobalFlags consumes a leading --profile flag, which may also be
+// given after the command name.
+func (a *app) parseGlobalFlags(args []string) ([]string, error) {
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		if !strings.HasPrefix(args[0], "-") || name != "profile" {
+			return args, nil
+		}
+		if !hasValue {
+			if len(args) < 2 {
+				return nil, errors.New("flag needs an argument: --profile")
+			}
+			value, args = args[1], args[1:]
+		}
+		a.profile = value
+		args = args[1:]
+	}
+	return args, nil
+}
+
+func (a *app) usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Usage: manapool [--profile name] <command> [arguments]")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands() {
+		if !cmd.hidden {
+			_, _ = fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+		}
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, `Run "manapool <command> -h" for command options.`)
+}
+
+// client creates an API client from the selected profile or the environment.
+func (a *app) client() (*manapool.Client, error) {
+	creds, err := a.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []manapool.ClientOption
+	if baseURL := a.getenv("MANAPOOL_BASE_URL"); baseURL != "" {
+		opts = append(opts, manapool.WithBaseURL(baseURL))
+	} else if creds.BaseURL != "" {
+		opts = append(opts, manapool.WithBaseURL(creds.BaseURL))
+	}
+	return manapool.NewClient(creds.Token, creds.Email, opts...), nil
+}
+
+// credentials resolves the credentials to use: the profile named by
+// --profile or MANAPOOL_PROFILE, then MANAPOOL_TOKEN and MANAPOOL_EMAIL,
+// then the default profile.
+func (a *app) credentials() (profile, error) {
+	name := a.profile
+	if name == "" {
+		name = a.getenv("MANAPOOL_PROFILE")
+	}
+	if name == "" {
+		token := strings.TrimSpace(a.getenv("MANAPOOL_TOKEN"))
+		email := strings.TrimSpace(a.getenv("MANAPOOL_EMAIL"))
+		if token != "" && email != "" {
+			return profile{Token: token, Email: email}, nil
+		}
+	}
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return profile{}, err
+	}
+	if name == "" {
+		name = cfg.Default
+	}
+	if name == "" {
+		return profile{}, errors.New(`no credentials: run "manapool config set" or set MANAPOOL_TOKEN and MANAPOOL_EMAIL`)
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return profile{}, fmt.Errorf("profile %q does not exist", name)
+	}
+	return p, nil
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors instead
+// of exiting.
+func (a *app) newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("manapool "+name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.profile, "profile", a.profile, "credential profile to use")
+	a.flags = fs
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(a.stderr, "Usage: manapool %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args allowing flags after positional arguments, as in
+// "manapool prices singles --format csv", and returns the positional ones.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{msg: err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// openOutput returns a writer for path, or stdout when path is empty or "-".
+// The returned close function must be called and reports write errors.
+func (a *app) openOutput(path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return a.stdout, func() error { return nil }, nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, file.Close, nil
+}
+
+// errStopIteration stops an iteration callback early without failing.
+var errStopIteration = errors.New("stop iteration")
+
+func isStopIteration(err error) bool {
+	return errors.Is(err, errStopIteration)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// runCLI runs the CLI against baseURL and returns its output and exit status.
+func runCLI(t *testing.T, baseURL string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	env := map[string]string{
+		"MANAPOOL_TOKEN":    "test-token",
+		"MANAPOOL_EMAIL":    "test@example.com",
+		"MANAPOOL_BASE_URL": baseURL + "/",
+	}
+	configDir := t.TempDir()
+	a := &app{
+		stdout:    &out,
+		stderr:    &errOut,
+		getenv:    func(key string) string { return env[key] },
+		configDir: func() (string, error) { return configDir, nil },
+	}
+	code = a.run(context.Background(), args)
+	return out.String(), errOut.String(), code
+}
+
+func TestRun_Usage(t *testing.T) {
+	stdout, _, code := runCLI(t, "", "help")
+	if code != 0 || !strings.Contains(stdout, "prices") {
+		t.Errorf("help = %d, %q", code, stdout)
+	}
+	if _, stderr, code := runCLI(t, "", "bogus"); code != 2 || !strings.Contains(stderr, `unknown command "bogus"`) {
+		t.Errorf("unknown command = %d, %q", code, stderr)
+	}
+	if _, _, code := runCLI(t, ""); code != 2 {
+		t.Errorf("no command exit = %d, want 2", code)
+	}
+}
+
+func TestRun_MissingCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	var errOut bytes.Buffer
+	a := &app{
+		stdout:    &bytes.Buffer{},
+		stderr:    &errOut,
+		getenv:    func(string) string { return "" },
+		configDir: func() (string, error) { return t.TempDir(), nil },
+	}
+	if code := a.run(context.Background(), []string{"prices", "singles"}); code != 1 {
+		t.Errorf("exit = %d, want 1", code)
+	}
+	if !strings.Contains(errOut.String(), "MANAPOOL_TOKEN") {
+		t.Errorf("stderr = %q", errOut.String())
+	}
+}
+
+func TestParseFlags_Interspersed(t *testing.T) {
+	a := &app{stderr: &bytes.Buffer{}}
+	fs := a.newFlagSet("test", "")
+	format := fs.String("format", "json", "")
+	positional, err := parseFlags(fs, []string{"one", "--format", "csv", "two"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if *format != "csv" || len(positional) != 2 || positional[0] != "one" || positional[1] != "two" {
+		t.Errorf("format = %q, positional = %q", *format, positional)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/repricah/manapool"
+)
+
+// runOptimize implements "manapool optimize".
+//
+// The optimizer reports only inventory IDs and a seller count, and listings
+// do not name their seller, so the breakdown is per card with cart totals
+// rather than per seller.
+func runOptimize(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("optimize", "<decklist> [--budget cents] [--ship-to country] [--model model] [--skip-basics] [--sideboard] [--checkout] [--output table|json|csv]")
+	budget := fs.Int("budget", 0, "fail if the cart total exceeds this many cents")
+	shipTo := fs.String("ship-to", "", "destination country code, such as US")
+	model := fs.String("model", "", "optimizer model")
+	skipBasics := fs.Bool("skip-basics", false, "leave basic lands out of the cart")
+	sideboard := fs.Bool("sideboard", false, "include sideboard cards")
+	checkout := fs.Bool("checkout", false, "create a pending order from the optimized cart")
+	formats := []string{formatTable, formatJSON, formatCSV}
+	output := outputFlag(fs, formatTable, formats...)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one decklist file")
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	if *budget < 0 {
+		return usagef("budget cannot be negative")
+	}
+
+	deck, err := readDecklist(positional[0], manapool.DecklistOptions{IncludeSideboard: *sideboard})
+	if err != nil {
+		return err
+	}
+	req := deck.OptimizerRequest(manapool.DeckOrderOptions{
+		SkipBasicLands:     *skipBasics,
+		Model:              *model,
+		DestinationCountry: *shipTo,
+	})
+	if len(req.Cart) == 0 {
+		return fmt.Errorf("decklist %s has no cards to buy", positional[0])
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	cart, err := client.OptimizeCart(ctx, req)
+	if err != nil {
+		return err
+	}
+	lines, err := cartLines(ctx, client, cart)
+	if err != nil {
+		return err
+	}
+
+	overBudget := *budget > 0 && cart.Totals.TotalCents > *budget
+	var pending *manapool.PendingOrder
+	if *checkout && !overBudget {
+		pending, err = client.CreatePendingOrder(ctx, manapool.PendingOrderRequest{LineItems: cart.LineItems()})
+		if err != nil {
+			return err
+		}
+	}
+
+	switch *output {
+	case formatJSON:
+		err = writeJSON(a.stdout, optimizeResult{Lines: lines, Totals: cart.Totals, PendingOrder: pending})
+	case formatCSV:
+		err = writeRecords(a.stdout, formatCSV, lines)
+	default:
+		err = a.printCart(lines, cart.Totals, pending)
+	}
+	if err != nil {
+		return err
+	}
+	if overBudget {
+		return fmt.Errorf("cart total %s exceeds budget %s", formatCents(cart.Totals.TotalCents), formatCents(*budget))
+	}
+	return nil
+}
+
+// cartLine is one listing selected by the optimizer.
+type cartLine struct {
+	InventoryID string `json:"inventory_id"`
+	Name        string `json:"name"`
+	Set         string `json:"set"`
+	Condition   string `json:"condition"`
+	Quantity    int    `json:"quantity"`
+	PriceCents  int    `json:"price_cents"`
+	CostCents   int    `json:"cost_cents"`
+}
+
+// optimizeResult is the JSON output of "manapool optimize".
+type optimizeResult struct {
+	Lines        []cartLine                   `json:"lines"`
+	Totals       manapool.OptimizedCartTotals `json:"totals"`
+	PendingOrder *manapool.PendingOrder       `json:"pending_order,omitempty"`
+}
+
+// readDecklist parses the decklist at path.
+func readDecklist(path string, opts manapool.DecklistOptions) (*manapool.DeckCreateRequest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return manapool.ParseDecklist(file, opts)
+}
+
+// cartLines looks up the listings selected for cart and returns them sorted by name.
+func cartLines(ctx context.Context, client *manapool.Client, cart *manapool.OptimizedCart) ([]cartLine, error) {
+	selected := make(map[string]int, len(cart.Cart))
+	ids := make([]string, 0, len(cart.Cart))
+	for _, item := range cart.Cart {
+		if _, ok := selected[item.InventoryID]; !ok {
+			ids = append(ids, item.InventoryID)
+		}
+		selected[item.InventoryID] += item.QuantitySelected
+	}
+	listings, err := client.GetInventoryListings(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]cartLine, 0, len(listings.InventoryItems))
+	for _, item := range listings.InventoryItems {
+		line := cartLine{
+			InventoryID: item.ID,
+			Name:        listingName(item),
+			Quantity:    selected[item.ID],
+			PriceCents:  item.PriceCents,
+			CostCents:   item.PriceCents * selected[item.ID],
+		}
+		if item.Product.Single != nil {
+			line.Set, line.Condition = item.Product.Single.Set, item.Product.Single.ConditionName()
+		} else if item.Product.Sealed != nil {
+			line.Set = item.Product.Sealed.Set
+		}
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Name < lines[j].Name })
+	return lines, nil
+}
+
+// printCart prints cart lines and totals as a table.
+func (a *app) printCart(lines []cartLine, totals manapool.OptimizedCartTotals, pending *manapool.PendingOrder) error {
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CARD\tSET\tCONDITION\tQTY\tPRICE\tCOST")
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", line.Name, line.Set, line.Condition, line.Quantity,
+			formatCents(line.PriceCents), formatCents(line.CostCents))
+	}
+	_, _ = fmt.Fprintln(tw)
+	_, _ = fmt.Fprintf(tw, "Subtotal\t%s\n", formatCents(totals.SubtotalCents))
+	_, _ = fmt.Fprintf(tw, "Shipping\t%s\n", formatCents(totals.ShippingCents))
+	_, _ = fmt.Fprintf(tw, "Total\t%s\n", formatCents(totals.TotalCents))
+	_, _ = fmt.Fprintf(tw, "Sellers\t%d\n", totals.SellerCount)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if pending != nil {
+		_, err := fmt.Fprintf(a.stdout, "\nPending order %s created (%s total)\n", pending.ID, formatCents(pending.Totals.TotalCents))
+		return err
+	}
+	return nil
+}
+
+// listingName returns the product name of a listing.
+func listingName(item manapool.InventoryItem) string {
+	switch {
+	case item.Product.Single != nil:
+		return item.Product.Single.Name
+	case item.Product.Sealed != nil:
+		return item.Product.Sealed.Name
+	}
+	return item.ID
+}
+
+// formatCents formats an amount in cents as dollars.
+func formatCents(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
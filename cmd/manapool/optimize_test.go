@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/repricah/manapool"
+)
+
+func TestOptimize(t *testing.T) {
+	var optimized manapool.OptimizerRequest
+	pendingCreated := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/optimizer":
+			if err := json.NewDecoder(r.Body).Decode(&optimized); err != nil {
+				t.Errorf("decode optimizer request: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv-1","quantity_selected":1},{"inventory_id":"inv-2","quantity_selected":2}],"totals":{"subtotal_cents":450,"shipping_cents":199,"total_cents":649,"seller_count":2}}`))
+		case "/inventory/listings":
+			_, _ = w.Write([]byte(`{"inventory_items":[
+				{"id":"inv-2","price_cents":150,"product":{"single":{"name":"Counterspell","set":"7ED","condition_id":"NM","finish_id":"NF"}}},
+				{"id":"inv-1","price_cents":150,"product":{"single":{"name":"Brainstorm","set":"ICE","condition_id":"LP","finish_id":"FO"}}}]}`))
+		case "/buyer/orders/pending-orders":
+			pendingCreated = true
+			_, _ = w.Write([]byte(`{"id":"po-1","status":"pending","line_items":[],"totals":{"total_cents":649}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	deck := filepath.Join(t.TempDir(), "deck.txt")
+	if err := os.WriteFile(deck, []byte("1 Brainstorm\n2 Counterspell\n10 Island\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := runCLI(t, server.URL, "optimize", deck, "--ship-to", "US", "--skip-basics")
+	if code != 0 {
+		t.Fatalf("exit = %d, stderr = %q", code, stderr)
+	}
+	if len(optimized.Cart) != 2 || optimized.DestinationCountry != "US" {
+		t.Errorf("optimizer request = %+v", optimized)
+	}
+	for _, want := range []string{"Brainstorm", "Lightly Played Foil", "Counterspell  7ED", "$3.00", "Total", "$6.49", "Sellers"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+	if strings.Index(stdout, "Brainstorm") > strings.Index(stdout, "Counterspell") {
+		t.Errorf("listings not sorted by name:\n%s", stdout)
+	}
+	if pendingCreated {
+		t.Error("pending order created without --checkout")
+	}
+
+	_, stderr, code = runCLI(t, server.URL, "optimize", deck, "--budget", "500", "--checkout")
+	if code != 1 || !strings.Contains(stderr, "exceeds budget $5.00") || pendingCreated {
+		t.Errorf("over budget = %d, %q, pending %v", code, stderr, pendingCreated)
+	}
+
+	stdout, _, code = runCLI(t, server.URL, "optimize", deck, "--budget", "1000", "--checkout")
+	if code != 0 || !pendingCreated || !strings.Contains(stdout, "Pending order po-1 created ($6.49 total)") {
+		t.Errorf("checkout = %d, %q", code, stdout)
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	for cents, want := range map[int]string{0: "$0.00", 5: "$0.05", 1234: "$12.34", -250: "-$2.50"} {
+		if got := formatCents(cents); got != want {
+			t.Errorf("formatCents(%d) = %q, want %q", cents, got, want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// runOrders implements "manapool orders".
+func runOrders(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return usagef("expected a subcommand: list, show or fulfill")
+	}
+	switch args[0] {
+	case "list":
+		return runOrdersList(ctx, a, args[1:])
+	case "show":
+		return runOrdersShow(ctx, a, args[1:])
+	case "fulfill":
+		return runOrdersFulfill(ctx, a, args[1:])
+	default:
+		return usagef("unknown orders subcommand %q", args[0])
+	}
+}
+
+// runOrdersList implements "manapool orders list".
+func runOrdersList(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("orders list", "[--unfulfilled] [--since time|duration] [--label label] [--limit n] [--output table|json|ndjson|csv]")
+	unfulfilled := fs.Bool("unfulfilled", false, "only list orders that have not been fulfilled")
+	since := fs.String("since", "", "only list orders created after an RFC 3339 time or a duration ago, such as 72h")
+	label := fs.String("label", "", "only list the order with this label")
+	limit := fs.Int("limit", 0, "maximum number of orders to list (default: all)")
+	formats := []string{formatTable, formatJSON, formatNDJSON, formatCSV}
+	output := outputFlag(fs, formatTable, formats...)
+	fs.StringVar(output, "format", formatTable, "alias for --output")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	if *limit < 0 {
+		return usagef("limit cannot be negative")
+	}
+
+	opts := manapool.OrdersOptions{Label: *label}
+	if *unfulfilled {
+		opts.IsUnfulfilled = unfulfilled
+	}
+	if *since != "" {
+		t, err := parseSince(*since, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.Since = manapool.Time(t)
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	orders := []manapool.OrderSummary{}
+	err = client.IterateSellerOrders(ctx, opts, func(order *manapool.OrderSummary) error {
+		orders = append(orders, *order)
+		if *limit > 0 && len(orders) >= *limit {
+			return errStopIteration
+		}
+		return nil
+	})
+	if err != nil && !isStopIteration(err) {
+		return err
+	}
+	return writeRecords(a.stdout, *output, orders)
+}
+
+// runOrdersShow implements "manapool orders show".
+func runOrdersShow(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("orders show", "<order-id> [--output json|table|csv]")
+	formats := []string{formatJSON, formatTable, formatCSV}
+	output := outputFlag(fs, formatJSON, formats...)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one order ID")
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetSellerOrder(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	if *output == formatJSON {
+		return writeJSON(a.stdout, resp.Order)
+	}
+	return writeRecords(a.stdout, *output, orderItemRows(resp.Order))
+}
+
+// orderItemRow is one line of an order for table and CSV output.
+type orderItemRow struct {
+	OrderID    string `json:"order_id"`
+	Label      string `json:"label"`
+	Name       string `json:"name"`
+	Set        string `json:"set"`
+	Condition  string `json:"condition"`
+	Quantity   int    `json:"quantity"`
+	PriceCents int    `json:"price_cents"`
+}
+
+// orderItemRows flattens an order into one row per item.
+func orderItemRows(order manapool.OrderDetails) []orderItemRow {
+	rows := make([]orderItemRow, 0, len(order.Items))
+	for _, item := range order.Items {
+		row := orderItemRow{OrderID: order.ID, Label: order.Label, Quantity: item.Quantity, PriceCents: item.PriceCents}
+		switch {
+		case item.Product.Single != nil:
+			row.Name, row.Set, row.Condition = item.Product.Single.Name, item.Product.Single.Set, item.Product.Single.ConditionName()
+		case item.Product.Sealed != nil:
+			row.Name, row.Set = item.Product.Sealed.Name, item.Product.Sealed.Set
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// runOrdersFulfill implements "manapool orders fulfill".
+func runOrdersFulfill(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("orders fulfill", "<order-id> [--tracking number] [--carrier name] [--tracking-url url] [--status status] [--output table|json]")
+	tracking := fs.String("tracking", "", "tracking number")
+	carrier := fs.String("carrier", "", "shipping carrier, such as USPS")
+	trackingURL := fs.String("tracking-url", "", "tracking URL")
+	status := fs.String("status", string(manapool.FulfillmentStatusShipped), "fulfillment status")
+	formats := []string{formatTable, formatJSON}
+	output := outputFlag(fs, formatTable, formats...)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one order ID")
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	if !manapool.FulfillmentStatus(*status).IsValid() {
+		return usagef("unknown fulfillment status %q", *status)
+	}
+
+	req := manapool.OrderFulfillmentRequest{Status: status}
+	if *tracking != "" {
+		req.TrackingNumber = tracking
+	}
+	if *carrier != "" {
+		req.TrackingCompany = carrier
+	}
+	if *trackingURL != "" {
+		req.TrackingURL = trackingURL
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.UpdateSellerOrderFulfillment(ctx, positional[0], req)
+	if err != nil {
+		return err
+	}
+	if *output == formatJSON {
+		return writeJSON(a.stdout, resp.Fulfillment)
+	}
+	_, err = fmt.Fprintf(a.stdout, "Order %s marked %s\n", positional[0], *status)
+	return err
+}
+
+// parseSince parses an RFC 3339 time, a date, or a duration before now.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, usagef("invalid time %q: use RFC 3339, YYYY-MM-DD or a duration such as 72h", value)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func TestOrders(t *testing.T) {
+	var fulfillment manapool.OrderFulfillmentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/seller/orders":
+			if r.URL.Query().Get("is_unfulfilled") != "true" {
+				t.Errorf("query = %s, want is_unfulfilled=true", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("offset") != "0" {
+				_, _ = w.Write([]byte(`{"orders":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"orders":[{"id":"o1","label":"A1","total_cents":500,"created_at":"2025-01-02T03:04:05Z"},{"id":"o2","label":"A2","total_cents":700}]}`))
+		case r.URL.Path == "/seller/orders/o1":
+			_, _ = w.Write([]byte(`{"order":{"id":"o1","label":"A1","buyer_id":"b1"}}`))
+		case r.URL.Path == "/seller/orders/o1/fulfillment" && r.Method == http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&fulfillment); err != nil {
+				t.Errorf("decode fulfillment: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"fulfillment":{"status":"shipped"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	stdout, stderr, code := runCLI(t, server.URL, "orders", "list", "--unfulfilled", "--format", "csv")
+	if code != 0 {
+		t.Fatalf("list exit = %d, stderr = %q", code, stderr)
+	}
+	want := "id,created_at,label,total_cents,shipping_method,latest_fulfillment_status\no1,2025-01-02T03:04:05Z,A1,500,,\no2,,A2,700,,\n"
+	if stdout != want {
+		t.Errorf("list csv = %q, want %q", stdout, want)
+	}
+
+	stdout, _, _ = runCLI(t, server.URL, "orders", "list", "--unfulfilled", "--limit", "1", "--format", "ndjson")
+	if strings.Count(stdout, "\n") != 1 {
+		t.Errorf("limited list = %q", stdout)
+	}
+
+	stdout, _, code = runCLI(t, server.URL, "orders", "show", "o1")
+	if code != 0 || !strings.Contains(stdout, `"buyer_id": "b1"`) {
+		t.Errorf("show = %d, %q", code, stdout)
+	}
+
+	stdout, stderr, code = runCLI(t, server.URL, "orders", "fulfill", "o1", "--tracking", "9400", "--carrier", "USPS")
+	if code != 0 || stdout != "Order o1 marked shipped\n" {
+		t.Fatalf("fulfill = %d, %q, %q", code, stdout, stderr)
+	}
+	if fulfillment.Status == nil || *fulfillment.Status != "shipped" || fulfillment.TrackingNumber == nil || *fulfillment.TrackingNumber != "9400" ||
+		fulfillment.TrackingCompany == nil || *fulfillment.TrackingCompany != "USPS" || fulfillment.TrackingURL != nil {
+		t.Errorf("fulfillment = %+v", fulfillment)
+	}
+}
+
+func TestOrders_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"orders"},
+		{"orders", "cancel"},
+		{"orders", "show"},
+		{"orders", "fulfill", "o1", "--status", "lost"},
+		{"orders", "list", "--since", "yesterday"},
+	} {
+		if _, _, code := runCLI(t, "", args...); code != 2 {
+			t.Errorf("%q exit = %d, want 2", args, code)
+		}
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"48h":                  now.Add(-48 * time.Hour),
+		"2025-03-01":           time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2025-03-01T10:00:00Z": time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+	for value, want := range tests {
+		got, err := parseSince(value, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseSince(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+}
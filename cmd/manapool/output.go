@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats.
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+	formatTable  = "table"
+)
+
+// outputFlag registers --output on fs with the given default and allowed
+// formats. Check the parsed value with checkOutput.
+func outputFlag(fs *flag.FlagSet, def string, formats ...string) *string {
+	return fs.String("output", def, "output format: "+strings.Join(formats, ", "))
+}
+
+// checkOutput returns a usage error if output is not one of formats.
+func checkOutput(output string, formats ...string) error {
+	for _, format := range formats {
+		if output == format {
+			return nil
+		}
+	}
+	return usagef("unknown output format %q: use %s", output, strings.Join(formats, ", "))
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeRecords writes records, a slice of structs, in format. JSON writes
+// the slice as one indented array, NDJSON one object per line, and CSV and
+// table one row per record with the JSON field names as the header.
+func writeRecords(w io.Writer, format string, records any) error {
+	rows := reflect.ValueOf(records)
+	rw, err := newRecordWriter(w, format, rows.Type().Elem())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < rows.Len(); i++ {
+		if err := rw.write(rows.Index(i)); err != nil {
+			return err
+		}
+	}
+	return rw.close()
+}
+
+// recordWriter writes records of one struct type one at a time, in the
+// formats of writeRecords. Table output is buffered until close, since
+// columns are aligned across all rows.
+type recordWriter struct {
+	write func(row reflect.Value) error
+	close func() error
+}
+
+// newRecordWriter returns a recordWriter for records of type t in format.
+func newRecordWriter(w io.Writer, format string, t reflect.Type) (*recordWriter, error) {
+	switch format {
+	case formatJSON:
+		return newJSONRecordWriter(w), nil
+	case formatNDJSON:
+		encoder := json.NewEncoder(w)
+		return &recordWriter{
+			write: func(row reflect.Value) error { return encoder.Encode(row.Interface()) },
+			close: func() error { return nil },
+		}, nil
+	case formatCSV:
+		return newCSVRecordWriter(w, t), nil
+	case formatTable:
+		return newTableRecordWriter(w, t), nil
+	default:
+		return nil, usagef("unknown format %q", format)
+	}
+}
+
+// newJSONRecordWriter writes records as an indented JSON array.
+func newJSONRecordWriter(w io.Writer) *recordWriter {
+	count := 0
+	return &recordWriter{
+		write: func(row reflect.Value) error {
+			data, err := json.MarshalIndent(row.Interface(), "  ", "  ")
+			if err != nil {
+				return err
+			}
+			sep := ",\n  "
+			if count == 0 {
+				sep = "[\n  "
+			}
+			count++
+			if _, err := io.WriteString(w, sep); err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		},
+		close: func() error {
+			end := "\n]\n"
+			if count == 0 {
+				end = "[]\n"
+			}
+			_, err := io.WriteString(w, end)
+			return err
+		},
+	}
+}
+
+// newCSVRecordWriter writes records as CSV. Nil pointers are written as
+// empty cells and nested values as JSON.
+func newCSVRecordWriter(w io.Writer, t reflect.Type) *recordWriter {
+	fields := csvFields(t)
+	writer := csv.NewWriter(w)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.name
+	}
+	wroteHeader := false
+	writeHeader := func() error {
+		if wroteHeader {
+			return nil
+		}
+		wroteHeader = true
+		return writer.Write(header)
+	}
+	return &recordWriter{
+		write: func(row reflect.Value) error {
+			if err := writeHeader(); err != nil {
+				return err
+			}
+			record, err := csvRecord(row, fields)
+			if err != nil {
+				return err
+			}
+			return writer.Write(record)
+		},
+		close: func() error {
+			if err := writeHeader(); err != nil {
+				return err
+			}
+			writer.Flush()
+			return writer.Error()
+		},
+	}
+}
+
+// newTableRecordWriter writes records as an aligned text table.
+func newTableRecordWriter(w io.Writer, t reflect.Type) *recordWriter {
+	fields := csvFields(t)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = strings.ToUpper(strings.ReplaceAll(f.name, "_", " "))
+	}
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	return &recordWriter{
+		write: func(row reflect.Value) error {
+			cells, err := csvRecord(row, fields)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+			return nil
+		},
+		close: tw.Flush,
+	}
+}
+
+// csvRecord formats the fields of row as CSV cells.
+func csvRecord(row reflect.Value, fields []csvField) ([]string, error) {
+	record := make([]string, len(fields))
+	for i, f := range fields {
+		cell, err := csvCell(row.FieldByIndex(f.index))
+		if err != nil {
+			return nil, err
+		}
+		record[i] = cell
+	}
+	return record, nil
+}
+
+type csvField struct {
+	name  string
+	index []int
+}
+
+// csvFields returns the exported fields of t named by their JSON tags.
+func csvFields(t reflect.Type) []csvField {
+	var fields []csvField
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, csvField{name: name, index: f.Index})
+	}
+	return fields
+}
+
+// csvCell formats a field value for CSV.
+func csvCell(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if t, ok := v.Interface().(interface{ UTC() time.Time }); ok {
+		if utc := t.UTC(); !utc.IsZero() {
+			return utc.Format(time.RFC3339), nil
+		}
+		return "", nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode CSV cell: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+func TestWriteRecords(t *testing.T) {
+	type row struct {
+		Name    string              `json:"name"`
+		Price   *int                `json:"price_cents"`
+		Created manapool.Timestamp  `json:"created_at"`
+		Tags    []string            `json:"tags"`
+		Skipped string              `json:"-"`
+		Meta    *manapool.Timestamp `json:"meta,omitempty"`
+	}
+	price := 150
+	rows := []row{
+		{Name: "Sol Ring", Price: &price, Created: manapool.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}, Tags: []string{"a"}},
+		{Name: "Island"},
+	}
+
+	tests := map[string]string{
+		formatCSV:    "name,price_cents,created_at,tags,meta\nSol Ring,150,2025-01-02T03:04:05Z,\"[\"\"a\"\"]\",\nIsland,,,null,\n",
+		formatTable:  "NAME      PRICE CENTS  CREATED AT            TAGS   META\nSol Ring  150          2025-01-02T03:04:05Z  [\"a\"]  \nIsland                                       null   \n",
+		formatNDJSON: "{\"name\":\"Sol Ring\",\"price_cents\":150,\"created_at\":\"2025-01-02T03:04:05Z\",\"tags\":[\"a\"]}\n{\"name\":\"Island\",\"price_cents\":null,\"created_at\":null,\"tags\":null}\n",
+	}
+	for format, want := range tests {
+		var buf bytes.Buffer
+		if err := writeRecords(&buf, format, rows); err != nil {
+			t.Fatalf("writeRecords(%s) error = %v", format, err)
+		}
+		if buf.String() != want {
+			t.Errorf("writeRecords(%s) =\n%q\nwant\n%q", format, buf.String(), want)
+		}
+	}
+	for _, records := range []any{rows, []row{}} {
+		var got, want bytes.Buffer
+		if err := writeRecords(&got, formatJSON, records); err != nil {
+			t.Fatalf("writeRecords(json) error = %v", err)
+		}
+		_ = writeJSON(&want, records)
+		if got.String() != want.String() {
+			t.Errorf("writeRecords(json) =\n%s\nwant\n%s", got.String(), want.String())
+		}
+	}
+	if err := writeRecords(&bytes.Buffer{}, "xml", rows); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
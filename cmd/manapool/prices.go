@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/repricah/manapool"
+)
+
+// priceRecordTypes maps each price export to the type of its records.
+var priceRecordTypes = map[manapool.PriceExport]reflect.Type{
+	manapool.PriceExportSingles:  reflect.TypeOf(manapool.SinglePriceListing{}),
+	manapool.PriceExportVariants: reflect.TypeOf(manapool.VariantPriceListing{}),
+	manapool.PriceExportSealed:   reflect.TypeOf(manapool.SealedPriceListing{}),
+}
+
+// runPrices implements "manapool prices". The export is downloaded to a
+// temporary file and its records are decoded and written one at a time, so
+// the whole export is never held in memory; table output is the exception,
+// since its columns are aligned across every row.
+func runPrices(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("prices", "singles|variants|sealed [--output json|ndjson|csv|table] [--out file]")
+	formats := []string{formatJSON, formatNDJSON, formatCSV, formatTable}
+	output := outputFlag(fs, formatJSON, formats...)
+	fs.StringVar(output, "format", formatJSON, "alias for --output")
+	out := fs.String("out", "", "write to file instead of stdout")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("expected one of singles, variants or sealed")
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	export := manapool.PriceExport(positional[0])
+	recordType, ok := priceRecordTypes[export]
+	if !ok {
+		return usagef("unknown price export %q", positional[0])
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "manapool-prices-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, string(export)+".json")
+	if _, err := client.DownloadPricesToFile(ctx, export, path, manapool.DownloadOptions{}); err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	w, closeOutput, err := a.openOutput(*out)
+	if err != nil {
+		return err
+	}
+	if err := copyPriceRecords(w, *output, file, recordType); err != nil {
+		_ = closeOutput()
+		return fmt.Errorf("failed to write prices: %w", err)
+	}
+	return closeOutput()
+}
+
+// copyPriceRecords decodes the records in the "data" array of a price
+// export one at a time and writes them to w in format.
+func copyPriceRecords(w io.Writer, format string, r io.Reader, recordType reflect.Type) error {
+	rw, err := newRecordWriter(w, format, recordType)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key != "data" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			record := reflect.New(recordType)
+			if err := dec.Decode(record.Interface()); err != nil {
+				return err
+			}
+			if err := rw.write(record.Elem()); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return rw.close()
+}
+
+// expectDelim reads the next token from dec and checks that it is delim.
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("invalid price export: expected %q, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newPricesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/prices/singles":
+			_, _ = w.Write([]byte(`{"meta":{"as_of":"2025-01-01T00:00:00Z"},"data":[{"name":"Sol Ring","set_code":"C21","available_quantity":3,"price_cents":150},{"name":"Island","set_code":"LEA","available_quantity":1,"price_cents":null}]}`))
+		case "/prices/sealed":
+			_, _ = w.Write([]byte(`{"meta":{"as_of":"2025-01-01T00:00:00Z"},"data":[{"name":"Booster","product_id":"p1","low_price":499,"available_quantity":2}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestPrices(t *testing.T) {
+	server := newPricesServer(t)
+	defer server.Close()
+
+	stdout, stderr, code := runCLI(t, server.URL, "prices", "singles", "--format", "csv")
+	if code != 0 {
+		t.Fatalf("exit = %d, stderr = %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "url,name,set_code,") {
+		t.Fatalf("csv = %q", stdout)
+	}
+	if !strings.Contains(lines[1], "Sol Ring,C21,") || !strings.Contains(lines[1], ",3,150,") || !strings.Contains(lines[2], ",1,,") {
+		t.Errorf("rows = %q", lines[1:])
+	}
+
+	stdout, _, code = runCLI(t, server.URL, "prices", "--format=ndjson", "sealed")
+	if code != 0 || strings.Count(stdout, "\n") != 1 || !strings.Contains(stdout, `"low_price":499`) {
+		t.Errorf("ndjson = %d, %q", code, stdout)
+	}
+
+	out := filepath.Join(t.TempDir(), "singles.json")
+	if _, stderr, code := runCLI(t, server.URL, "prices", "singles", "--out", out); code != 0 {
+		t.Fatalf("exit = %d, stderr = %q", code, stderr)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil || !strings.Contains(string(data), `"name": "Sol Ring"`) {
+		t.Errorf("json file = %q, %v", data, err)
+	}
+}
+
+func TestPrices_Errors(t *testing.T) {
+	server := newPricesServer(t)
+	defer server.Close()
+
+	if _, _, code := runCLI(t, server.URL, "prices", "tokens"); code != 2 {
+		t.Errorf("unknown export exit = %d, want 2", code)
+	}
+	if _, _, code := runCLI(t, server.URL, "prices", "singles", "--format", "xml"); code != 2 {
+		t.Errorf("unknown format exit = %d, want 2", code)
+	}
+	if _, _, code := runCLI(t, server.URL, "prices", "variants"); code != 1 {
+		t.Errorf("API error exit = %d, want 1", code)
+	}
+}
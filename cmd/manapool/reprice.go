@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/repricah/manapool"
+)
+
+// runReprice implements "manapool reprice".
+func runReprice(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("reprice", "[--rule match-low] [--undercut cents] [--floor cents] [--ceiling cents] [--dry-run] [--output table|json|csv]")
+	rule := fs.String("rule", string(manapool.RepriceMatchLow), "pricing rule: match-low")
+	undercut := fs.Int("undercut", 0, "cents below the market low price")
+	floor := fs.Int("floor", 0, "lowest price in cents")
+	ceiling := fs.Int("ceiling", 0, "highest price in cents (default: no limit)")
+	dryRun := fs.Bool("dry-run", false, "print the change plan without applying it")
+	formats := []string{formatTable, formatJSON, formatCSV}
+	output := outputFlag(fs, formatTable, formats...)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	opts := manapool.RepriceOptions{
+		Rule:          manapool.RepriceRule(*rule),
+		UndercutCents: *undercut,
+		FloorCents:    *floor,
+		CeilingCents:  *ceiling,
+	}
+	if err := opts.Validate(); err != nil {
+		return usagef("%v", err)
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	changes, err := client.PlanInventoryReprice(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := a.printPriceChanges(*output, changes); err != nil {
+		return err
+	}
+
+	// Keep stdout parseable when it carries JSON or CSV.
+	status := a.stdout
+	if *output != formatTable {
+		status = a.stderr
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if *dryRun {
+		_, err := fmt.Fprintf(status, "\nDry run: %d listings would be repriced.\n", len(changes))
+		return err
+	}
+	updated, err := client.ApplyReprice(ctx, changes)
+	if err != nil {
+		return fmt.Errorf("repriced %d of %d listings: %w", updated, len(changes), err)
+	}
+	_, err = fmt.Fprintf(status, "\nRepriced %d listings.\n", updated)
+	return err
+}
+
+// priceChangeRow is a planned price change for JSON and CSV output.
+type priceChangeRow struct {
+	ProductType    string `json:"product_type"`
+	ProductID      string `json:"product_id"`
+	Name           string `json:"name"`
+	Quantity       int    `json:"quantity"`
+	MarketLowCents int    `json:"market_low_cents"`
+	OldPriceCents  int    `json:"old_price_cents"`
+	NewPriceCents  int    `json:"new_price_cents"`
+}
+
+// printPriceChanges writes the change plan in output format.
+func (a *app) printPriceChanges(output string, changes []manapool.PriceChange) error {
+	if output != formatTable {
+		rows := make([]priceChangeRow, len(changes))
+		for i, change := range changes {
+			rows[i] = priceChangeRow{
+				ProductType:    change.Item.ProductType.String(),
+				ProductID:      change.Item.ProductID,
+				Name:           listingName(change.Item),
+				Quantity:       change.Item.Quantity,
+				MarketLowCents: change.MarketLowCents,
+				OldPriceCents:  change.Item.PriceCents,
+				NewPriceCents:  change.NewPriceCents,
+			}
+		}
+		return writeRecords(a.stdout, output, rows)
+	}
+
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(a.stdout, "No price changes.")
+		return err
+	}
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PRODUCT\tQTY\tMARKET LOW\tOLD\tNEW\tCHANGE")
+	for _, change := range changes {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", listingName(change.Item), change.Item.Quantity,
+			formatCents(change.MarketLowCents), formatCents(change.Item.PriceCents),
+			formatCents(change.NewPriceCents), formatCents(change.DeltaCents()))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReprice(t *testing.T) {
+	updates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/seller/inventory":
+			_, _ = w.Write([]byte(`{"inventory":[{"id":"i1","product_type":"mtg_single","product_id":"p1","price_cents":200,"quantity":3,"product":{"single":{"name":"Sol Ring"}}}],"pagination":{"total":1,"returned":1}}`))
+		case r.URL.Path == "/prices/variants":
+			_, _ = w.Write([]byte(`{"data":[{"product_type":"mtg_single","product_id":"p1","low_price":150}]}`))
+		case r.URL.Path == "/seller/inventory/product/mtg_single/p1" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"inventory":{"id":"i1","product_type":"mtg_single","product_id":"p1","price_cents":200,"quantity":3}}`))
+		case r.URL.Path == "/seller/inventory/product/mtg_single/p1" && r.Method == http.MethodPut:
+			updates++
+			_, _ = w.Write([]byte(`{"inventory":{"id":"i1"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	stdout, stderr, code := runCLI(t, server.URL, "reprice", "--rule", "match-low", "--undercut", "5", "--floor", "25", "--dry-run")
+	if code != 0 {
+		t.Fatalf("exit = %d, stderr = %q", code, stderr)
+	}
+	for _, want := range []string{"Sol Ring", "$1.50", "$2.00", "$1.45", "-$0.55", "Dry run: 1 listings would be repriced."} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+	if updates != 0 {
+		t.Fatalf("dry run applied %d updates", updates)
+	}
+
+	stdout, _, code = runCLI(t, server.URL, "reprice", "--undercut", "5")
+	if code != 0 || updates != 1 || !strings.Contains(stdout, "Repriced 1 listings.") {
+		t.Errorf("apply = %d, updates = %d, %q", code, updates, stdout)
+	}
+
+	if _, _, code := runCLI(t, server.URL, "reprice", "--rule", "match-high"); code != 2 {
+		t.Errorf("unknown rule exit = %d, want 2", code)
+	}
+}
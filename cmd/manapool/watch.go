@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/repricah/manapool"
+)
+
+// runWatch implements "manapool watch".
+func runWatch(ctx context.Context, a *app, args []string) error {
+	if len(args) == 0 {
+		return usagef("expected a subcommand: orders")
+	}
+	switch args[0] {
+	case "orders":
+		return runWatchOrders(ctx, a, args[1:])
+	default:
+		return usagef("unknown watch subcommand %q", args[0])
+	}
+}
+
+// runWatchOrders implements "manapool watch orders". It polls seller orders
+// with an OrderEventSource and, for each new order, runs --notify-cmd or
+// prints a line. A --once run keeps no state between runs, so it requires
+// --since and reports every order created after it; cron jobs should pass the
+// time of the previous run rather than a fixed duration, or expect orders
+// near the boundary to be reported twice.
+func runWatchOrders(ctx context.Context, a *app, args []string) error {
+	fs := a.newFlagSet("watch orders", "[--notify-cmd command] [--interval duration] [--since time|duration] [--once] [--output table|ndjson]")
+	notifyCmd := fs.String("notify-cmd", "", "shell command to run for each new order; the order JSON is written to its stdin")
+	interval := fs.Duration("interval", time.Minute, "how often to poll for new orders")
+	since := fs.String("since", "", "also report orders created after an RFC 3339 time or a duration ago, such as 2h (default: only orders created after the watch starts)")
+	once := fs.Bool("once", false, "poll once and exit, for use from cron; requires --since, and reports every order created after it")
+	formats := []string{formatTable, formatNDJSON}
+	output := outputFlag(fs, formatTable, formats...)
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	if err := checkOutput(*output, formats...); err != nil {
+		return err
+	}
+	if *interval <= 0 {
+		return usagef("interval must be positive")
+	}
+	if *once && *since == "" {
+		return usagef("--once requires --since: a single poll without it only records existing orders")
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	source := &manapool.OrderEventSource{
+		Client: client,
+		Handler: func(ctx context.Context, event manapool.WebhookEvent) error {
+			created, ok := event.(*manapool.OrderCreatedEvent)
+			if !ok {
+				return nil
+			}
+			if *notifyCmd != "" {
+				return a.notifyOrder(ctx, *notifyCmd, created.Order)
+			}
+			return printNewOrder(a, *output, created.Order)
+		},
+	}
+	if *since != "" {
+		t, err := parseSince(*since, time.Now())
+		if err != nil {
+			return err
+		}
+		source.Since = t
+		if lookback := time.Since(t); lookback > manapool.DefaultOrderBackfillLookback {
+			source.Lookback = lookback
+		}
+	}
+
+	if *once {
+		_, err := source.Poll(ctx)
+		return err
+	}
+	err = source.Run(ctx, *interval, func(err error) {
+		_, _ = fmt.Fprintf(a.stderr, "manapool watch orders: %v\n", err)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// printNewOrder writes one line describing order.
+func printNewOrder(a *app, output string, order manapool.OrderDetails) error {
+	if output == formatNDJSON {
+		return json.NewEncoder(a.stdout).Encode(order)
+	}
+	items := 0
+	for _, item := range order.Items {
+		items += item.Quantity
+	}
+	_, err := fmt.Fprintf(a.stdout, "%s  new order %s (%s): %d items, %s\n",
+		order.CreatedAt.UTC().Format(time.RFC3339), order.ID, order.Label, items, formatCents(order.TotalCents))
+	return err
+}
+
+// notifyOrder runs command through the shell with the order JSON on stdin
+// and the order's ID, label, total and creation time in MANAPOOL_ORDER_*
+// environment variables. A failed command is reported and retried on the
+// next poll.
+func (a *app) notifyOrder(ctx context.Context, command string, order manapool.OrderDetails) error {
+	body, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = a.stdout
+	cmd.Stderr = a.stderr
+	cmd.Env = append(os.Environ(),
+		"MANAPOOL_ORDER_ID="+order.ID,
+		"MANAPOOL_ORDER_LABEL="+order.Label,
+		"MANAPOOL_ORDER_TOTAL_CENTS="+strconv.Itoa(order.TotalCents),
+		"MANAPOOL_ORDER_CREATED_AT="+order.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("notify command failed for order %s: %w", order.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWatchOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/seller/orders":
+			if r.URL.Query().Get("offset") != "0" {
+				_, _ = w.Write([]byte(`{"orders":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"orders":[{"id":"old","created_at":"2025-01-01T00:00:00Z"},{"id":"o1","label":"A1","total_cents":1250,"created_at":"2025-01-02T03:04:05Z"}]}`))
+		case "/seller/orders/o1":
+			_, _ = w.Write([]byte(`{"order":{"id":"o1","label":"A1","total_cents":1250,"created_at":"2025-01-02T03:04:05Z","items":[{"quantity":2},{"quantity":1}]}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	stdout, stderr, code := runCLI(t, server.URL, "watch", "orders", "--once", "--since", "2025-01-02T00:00:00Z")
+	if code != 0 {
+		t.Fatalf("exit = %d, stderr = %q", code, stderr)
+	}
+	if want := "2025-01-02T03:04:05Z  new order o1 (A1): 3 items, $12.50\n"; stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+
+	out := filepath.Join(t.TempDir(), "notified")
+	notify := `printf '%s %s ' "$MANAPOOL_ORDER_ID" "$MANAPOOL_ORDER_TOTAL_CENTS" >> ` + out + ` && cat >> ` + out
+	_, stderr, code = runCLI(t, server.URL, "watch", "orders", "--once", "--since", "2025-01-02T00:00:00Z", "--notify-cmd", notify)
+	if code != 0 {
+		t.Fatalf("notify exit = %d, stderr = %q", code, stderr)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read notify output: %v", err)
+	}
+	if !strings.HasPrefix(string(got), `o1 1250 {"id":"o1"`) {
+		t.Errorf("notify output = %q", got)
+	}
+
+	_, stderr, code = runCLI(t, server.URL, "watch", "orders", "--once", "--since", "2025-01-02T00:00:00Z", "--notify-cmd", "exit 3")
+	if code != 1 || !strings.Contains(stderr, "notify command failed for order o1") {
+		t.Errorf("failing notify = %d, %q", code, stderr)
+	}
+}
+
+func TestWatchOrders_UsageErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"watch"},
+		{"watch", "listings"},
+		{"watch", "orders", "--interval", "0s"},
+		{"watch", "orders", "--output", "csv"},
+		{"watch", "orders", "--once"},
+	} {
+		if _, _, code := runCLI(t, "", args...); code != 2 {
+			t.Errorf("%q exit = %d, want 2", args, code)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package manapool
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Collection tracks owned card quantities by card name. Printings are not
+// distinguished: any owned copy of a card satisfies a request for that card.
+type Collection struct {
+	counts map[string]int
+}
+
+// NewCollection creates an empty collection.
+func NewCollection() *Collection {
+	return &Collection{counts: make(map[string]int)}
+}
+
+// Add adds quantity copies of the named card.
+func (c *Collection) Add(name string, quantity int) {
+	key := collectionKey(name)
+	if key == "" || quantity <= 0 {
+		return
+	}
+	c.counts[key] += quantity
+}
+
+// Quantity returns the number of owned copies of the named card.
+func (c *Collection) Quantity(name string) int {
+	return c.counts[collectionKey(name)]
+}
+
+// Len returns the number of distinct cards in the collection.
+func (c *Collection) Len() int {
+	return len(c.counts)
+}
+
+// LoadCollectionCSV reads a collection from CSV with a header row. The
+// quantity column may be named Count, Quantity or Qty and the name column Name
+// or Card Name, which covers Moxfield collection exports and most other
+// collection tools. A leading UTF-8 byte order mark is ignored and rows with
+// a blank name are skipped.
+func LoadCollectionCSV(r io.Reader) (*Collection, error) {
+	reader := newCSVReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, NewValidationError("csv", "collection CSV is empty")
+		}
+		return nil, fmt.Errorf("failed to read collection header: %w", err)
+	}
+
+	nameCol, qtyCol := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "name", "card name", "card":
+			if nameCol < 0 {
+				nameCol = i
+			}
+		case "count", "quantity", "qty":
+			if qtyCol < 0 {
+				qtyCol = i
+			}
+		}
+	}
+	if nameCol < 0 {
+		return nil, NewValidationError("csv", "collection CSV has no name column")
+	}
+	if qtyCol < 0 {
+		return nil, NewValidationError("csv", "collection CSV has no quantity column")
+	}
+
+	collection := NewCollection()
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collection line %d: %w", line, err)
+		}
+		if nameCol >= len(record) || strings.TrimSpace(record[nameCol]) == "" {
+			continue
+		}
+
+		quantity := 1
+		if qtyCol < len(record) && strings.TrimSpace(record[qtyCol]) != "" {
+			quantity, err = strconv.Atoi(strings.TrimSpace(record[qtyCol]))
+			if err != nil {
+				return nil, NewValidationError("csv", fmt.Sprintf("line %d: invalid quantity %q", line, record[qtyCol]))
+			}
+		}
+		collection.Add(record[nameCol], quantity)
+	}
+
+	return collection, nil
+}
+
+// Buylist returns a copy of target containing only the cards and quantities
+// not covered by the collection. Owned copies are consumed in cart order, so a card
+// requested in several printings is only counted once against the collection.
+//
+// Example:
+//
+//	owned, err := manapool.LoadCollectionCSV(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	deck, err := importer.Import(ctx, deckURL, manapool.MoxfieldImportOptions{IgnorePrintings: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cart, err := client.OptimizeCart(ctx, owned.Buylist(*deck))
+func (c *Collection) Buylist(target OptimizerRequest) OptimizerRequest {
+	remaining := make(map[string]int, len(c.counts))
+	for name, qty := range c.counts {
+		remaining[name] = qty
+	}
+
+	buylist := target
+	buylist.Cart = make([]OptimizerCartItem, 0, len(target.Cart))
+	for _, item := range target.Cart {
+		key := collectionKey(item.Name)
+		if owned := remaining[key]; owned > 0 {
+			used := owned
+			if used > item.QuantityRequested {
+				used = item.QuantityRequested
+			}
+			remaining[key] -= used
+			item.QuantityRequested -= used
+		}
+		if item.QuantityRequested > 0 {
+			buylist.Cart = append(buylist.Cart, item)
+		}
+	}
+
+	return buylist
+}
+
+// newCSVReader returns a lenient CSV reader for spreadsheet exports: a leading
+// UTF-8 byte order mark is skipped, rows may have any number of fields and
+// leading spaces are trimmed.
+func newCSVReader(r io.Reader) *csv.Reader {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && string(bom) == "\ufeff" {
+		_, _ = br.Discard(3)
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// collectionKey normalizes a card name for collection lookups. Double-faced
+// cards are keyed by their front face so "Delver of Secrets" and
+// "Delver of Secrets // Insectile Aberration" match.
+func collectionKey(name string) string {
+	if i := strings.Index(name, "//"); i >= 0 {
+		name = name[:i]
+	}
+	return normalizeSearchKey(name)
+}
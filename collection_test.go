@@ -0,0 +1,89 @@
+package manapool
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoadCollectionCSV(t *testing.T) {
+	t.Run("moxfield export", func(t *testing.T) {
+		data := "\ufeff\"Count\",\"Tradelist Count\",\"Name\",\"Edition\",\"Foil\"\n" +
+			"\"2\",\"0\",\"Sol Ring\",\"c21\",\"\"\n" +
+			"\"1\",\"0\",\"Sol Ring\",\"cmr\",\"foil\"\n" +
+			"\"1\",\"0\",\"Delver of Secrets // Insectile Aberration\",\"isd\",\"\"\n" +
+			"\"3\",\"0\",\"\",\"isd\",\"\"\n"
+		collection, err := LoadCollectionCSV(strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("LoadCollectionCSV() error = %v", err)
+		}
+		if got := collection.Quantity("sol ring"); got != 3 {
+			t.Errorf("Sol Ring = %d, want 3", got)
+		}
+		if got := collection.Quantity("Delver of Secrets"); got != 1 {
+			t.Errorf("Delver = %d, want 1", got)
+		}
+		if collection.Len() != 2 {
+			t.Errorf("Len() = %d, want 2", collection.Len())
+		}
+	})
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"no name column", "Count,Set\n1,c21\n"},
+		{"no quantity column", "Name,Set\nSol Ring,c21\n"},
+		{"bad quantity", "Quantity,Card Name\nx,Sol Ring\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadCollectionCSV(strings.NewReader(tt.data))
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCollection_Buylist(t *testing.T) {
+	owned := NewCollection()
+	owned.Add("Sol Ring", 1)
+	owned.Add("Island", 20)
+	owned.Add("Counterspell", 4)
+	owned.Add("Ignored", 0)
+
+	target := OptimizerRequest{
+		Model: "balanced",
+		Cart: []OptimizerCartItem{
+			{Type: "mtg_single", Name: "Sol Ring", SetCode: "C21", QuantityRequested: 2},
+			{Type: "mtg_single", Name: "Island", SetCode: "UNF", QuantityRequested: 15},
+			{Type: "mtg_single", Name: "Island", SetCode: "ONE", QuantityRequested: 10},
+			{Type: "mtg_single", Name: "Counterspell", QuantityRequested: 1},
+			{Type: "mtg_single", Name: "Lightning Bolt", QuantityRequested: 4},
+		},
+	}
+
+	buylist := owned.Buylist(target)
+	if buylist.Model != "balanced" {
+		t.Errorf("model = %q, want balanced", buylist.Model)
+	}
+
+	want := map[string]int{"Sol Ring": 1, "Island": 5, "Lightning Bolt": 4}
+	if len(buylist.Cart) != len(want) {
+		t.Fatalf("cart items = %d, want %d: %+v", len(buylist.Cart), len(want), buylist.Cart)
+	}
+	for _, item := range buylist.Cart {
+		if item.QuantityRequested != want[item.Name] {
+			t.Errorf("%s quantity = %d, want %d", item.Name, item.QuantityRequested, want[item.Name])
+		}
+	}
+	if buylist.Cart[1].SetCode != "ONE" {
+		t.Errorf("expected remaining islands from second printing, got %+v", buylist.Cart[1])
+	}
+	if target.Cart[0].QuantityRequested != 2 || owned.Quantity("Island") != 20 {
+		t.Error("Buylist modified its inputs")
+	}
+}
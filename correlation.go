@@ -0,0 +1,80 @@
+package manapool
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// CorrelationIDHeader is the request header that carries the correlation ID.
+const CorrelationIDHeader = "X-Correlation-Id"
+
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a context whose API calls share the correlation
+// ID id. The ID is sent with every request, prefixed to the client's log
+// lines and recorded in returned APIError and NetworkError values, so a
+// multi-request workflow such as a sync run or checkout can be traced end to
+// end. Calls made without one get a fresh ID per request.
+//
+// Example:
+//
+//	ctx = manapool.WithCorrelationID(ctx, "checkout-"+orderID)
+//	pending, err := client.CreatePendingOrder(ctx, req)
+//	...
+//	_, err = client.PurchasePendingOrder(ctx, pending.ID, purchase)
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID attached to ctx with
+// WithCorrelationID, or "" if there is none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// ensureCorrelationID returns ctx with a correlation ID, generating one if
+// ctx has none, and the ID.
+func ensureCorrelationID(ctx context.Context) (context.Context, string) {
+	if id := CorrelationID(ctx); id != "" {
+		return ctx, id
+	}
+	id := newCorrelationID()
+	return WithCorrelationID(ctx, id), id
+}
+
+// newCorrelationID returns a random 16-character hex ID.
+func newCorrelationID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// correlatedLogger prefixes every message with a correlation ID.
+type correlatedLogger struct {
+	logger Logger
+	id     string
+}
+
+func (l *correlatedLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debugf("[%s] "+format, append([]interface{}{l.id}, args...)...)
+}
+
+func (l *correlatedLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Errorf("[%s] "+format, append([]interface{}{l.id}, args...)...)
+}
+
+// withCorrelation returns logger with messages prefixed by id.
+func withCorrelation(logger Logger, id string) Logger {
+	if _, ok := logger.(*noopLogger); ok || id == "" {
+		return logger
+	}
+	return &correlatedLogger{logger: logger, id: id}
+}
+
+// loggerFor returns the client's logger with messages prefixed by the
+// correlation ID in ctx, if any.
+func (c *Client) loggerFor(ctx context.Context) Logger {
+	return withCorrelation(c.currentLogger(), CorrelationID(ctx))
+}
@@ -0,0 +1,89 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_GeneratesCorrelationID(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(CorrelationIDHeader))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "not found"}`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithLogger(logger))
+
+	_, err := client.GetSellerAccount(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if len(ids) != 1 || !regexp.MustCompile(`^[0-9a-f]{16}$`).MatchString(ids[0]) {
+		t.Fatalf("correlation IDs = %q, want one 16-character hex ID", ids)
+	}
+	id := ids[0]
+	if apiErr.CorrelationID != id || !strings.Contains(apiErr.Error(), "correlation "+id) {
+		t.Errorf("APIError = %v, want correlation %s", apiErr, id)
+	}
+	if len(logger.debug) == 0 {
+		t.Fatal("expected debug messages")
+	}
+	for _, line := range logger.debug {
+		if !strings.HasPrefix(line, "["+id+"] ") {
+			t.Errorf("log line %q is not prefixed with the correlation ID", line)
+		}
+	}
+
+	_, _ = client.GetSellerAccount(context.Background())
+	if len(ids) != 2 || ids[1] == id {
+		t.Errorf("second call reused correlation ID: %q", ids)
+	}
+}
+
+func TestClient_WithCorrelationID(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(CorrelationIDHeader))
+		_, _ = w.Write([]byte(`{"username": "seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := WithCorrelationID(context.Background(), "sync-42")
+	if got := CorrelationID(ctx); got != "sync-42" {
+		t.Errorf("CorrelationID() = %q, want sync-42", got)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSellerAccount(ctx); err != nil {
+			t.Fatalf("GetSellerAccount() error = %v", err)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "sync-42" || ids[1] != "sync-42" {
+		t.Errorf("correlation IDs = %q, want sync-42 twice", ids)
+	}
+}
+
+func TestClient_NetworkErrorCorrelationID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(0, time.Millisecond))
+	_, err := client.GetSellerAccount(WithCorrelationID(context.Background(), "checkout-7"))
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("expected NetworkError, got %v", err)
+	}
+	if netErr.CorrelationID != "checkout-7" || !strings.Contains(err.Error(), "(correlation checkout-7)") {
+		t.Errorf("error = %v, want correlation checkout-7", err)
+	}
+}
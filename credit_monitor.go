@@ -0,0 +1,109 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CreditChange is a change in the buyer's store credit balance.
+type CreditChange struct {
+	PreviousCents int
+	CurrentCents  int
+
+	// DeltaCents is CurrentCents - PreviousCents: positive when credit was
+	// added, negative when it was spent.
+	DeltaCents int
+
+	ObservedAt time.Time
+}
+
+// Added reports whether credit was added.
+func (c CreditChange) Added() bool {
+	return c.DeltaCents > 0
+}
+
+// Spent reports whether credit was spent.
+func (c CreditChange) Spent() bool {
+	return c.DeltaCents < 0
+}
+
+// CreditMonitor tracks the buyer's credit balance between checks. The first
+// check records the starting balance; later checks report any change.
+//
+// Example:
+//
+//	monitor := &manapool.CreditMonitor{Client: client}
+//	err := monitor.Run(ctx, time.Hour, func(c manapool.CreditChange) {
+//	    if c.Added() {
+//	        fmt.Printf("Credit added: $%.2f\n", float64(c.DeltaCents)/100)
+//	    }
+//	}, nil)
+type CreditMonitor struct {
+	// Client is used to fetch the credit balance.
+	Client *Client
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	last    int
+	started bool
+}
+
+// Check fetches the current balance and returns the change since the
+// previous check, or nil if the balance is unchanged or this is the first check.
+func (m *CreditMonitor) Check(ctx context.Context) (*CreditChange, error) {
+	if m.Client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	now := time.Now
+	if m.Now != nil {
+		now = m.Now
+	}
+
+	credit, err := m.Client.GetBuyerCredit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check buyer credit: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previous, started := m.last, m.started
+	m.last, m.started = credit.UserCreditCents, true
+	if !started || previous == credit.UserCreditCents {
+		return nil, nil
+	}
+
+	return &CreditChange{
+		PreviousCents: previous,
+		CurrentCents:  credit.UserCreditCents,
+		DeltaCents:    credit.UserCreditCents - previous,
+		ObservedAt:    now(),
+	}, nil
+}
+
+// Balance returns the last observed balance and whether any check has succeeded.
+func (m *CreditMonitor) Balance() (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last, m.started
+}
+
+// Run calls Check every interval until ctx is cancelled, passing each change
+// to onChange and each failed check's error to onError if it is not nil.
+func (m *CreditMonitor) Run(ctx context.Context, interval time.Duration, onChange func(CreditChange), onError func(error)) error {
+	if onChange == nil {
+		return NewValidationError("onChange", "change handler cannot be nil")
+	}
+
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		change, err := m.Check(ctx)
+		if change != nil {
+			onChange(*change)
+		}
+		return err
+	}, onError)
+}
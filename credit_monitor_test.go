@@ -0,0 +1,105 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreditMonitor_Check(t *testing.T) {
+	var mu sync.Mutex
+	balances := []int{1000, 1000, 2500, 500}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/buyer/credit" {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		balance := balances[0]
+		if len(balances) > 1 {
+			balances = balances[1:]
+		}
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"user_credit_cents":%d}`, balance)
+	}))
+	defer server.Close()
+
+	fixed := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	monitor := &CreditMonitor{Client: client, Now: func() time.Time { return fixed }}
+	ctx := context.Background()
+
+	if _, ok := monitor.Balance(); ok {
+		t.Error("Balance() reported a value before the first check")
+	}
+	for i := 0; i < 2; i++ {
+		change, err := monitor.Check(ctx)
+		if err != nil || change != nil {
+			t.Fatalf("check %d = %+v, %v; want no change", i, change, err)
+		}
+	}
+
+	change, err := monitor.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if change == nil || change.DeltaCents != 1500 || !change.Added() || change.Spent() || !change.ObservedAt.Equal(fixed) {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+
+	change, err = monitor.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if change == nil || change.PreviousCents != 2500 || change.CurrentCents != 500 || !change.Spent() {
+		t.Fatalf("unexpected change: %+v", change)
+	}
+	if balance, ok := monitor.Balance(); !ok || balance != 500 {
+		t.Errorf("Balance() = %d, %v; want 500, true", balance, ok)
+	}
+}
+
+func TestCreditMonitor_Run(t *testing.T) {
+	var mu sync.Mutex
+	balance := 100
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"user_credit_cents":%d}`, balance)
+		balance += 50
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	monitor := &CreditMonitor{Client: client}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var changes []CreditChange
+	err := monitor.Run(ctx, 10*time.Millisecond, func(c CreditChange) {
+		changes = append(changes, c)
+		cancel()
+	}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if len(changes) != 1 || changes[0].DeltaCents != 50 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	var valErr *ValidationError
+	if err := monitor.Run(context.Background(), 0, func(CreditChange) {}, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for interval, got %v", err)
+	}
+	if err := monitor.Run(context.Background(), time.Second, nil, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for handler, got %v", err)
+	}
+	if _, err := (&CreditMonitor{}).Check(context.Background()); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for client, got %v", err)
+	}
+}
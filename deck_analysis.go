@@ -0,0 +1,121 @@
+package manapool
+
+import (
+	"math"
+	"strings"
+)
+
+// DeckCurveMax is the highest mana value with its own DeckAnalysis.Curve
+// bucket; cards costing more are counted in the last bucket.
+const DeckCurveMax = 7
+
+// colorOrder is the conventional WUBRG color order.
+var colorOrder = []string{"W", "U", "B", "R", "G"}
+
+// DeckAnalysis summarizes a deck's colors and mana curve.
+type DeckAnalysis struct {
+	// ColorIdentity is the combined color identity of every card, in WUBRG order.
+	ColorIdentity []string
+
+	// CommanderIdentity is the combined color identity of the commanders, in WUBRG order.
+	CommanderIdentity []string
+
+	// Curve counts copies by mana value: Curve[i] is the number of cards with
+	// mana value i, and Curve[DeckCurveMax] includes everything above it.
+	// Cards without a mana cost, such as lands, are not counted.
+	Curve [DeckCurveMax + 1]int
+
+	// AverageManaValue is the mean mana value of the cards counted in Curve.
+	AverageManaValue float64
+
+	// NoManaCost is the number of copies without a mana cost, mostly lands.
+	NoManaCost int
+
+	// OutsideIdentity lists cards whose color identity is not within the
+	// commanders', as reported by PrecheckDeck.
+	OutsideIdentity []DeckColorIdentityViolation
+
+	// Unknown lists deck cards missing from the card info.
+	Unknown []string
+}
+
+// AnalyzeDeck computes color identity and mana curve statistics for a deck
+// from card info already fetched with GetCardInfo or a CardInfoCache.
+// Quantities count, so four copies of a two-drop add four to Curve[2].
+//
+// Example:
+//
+//	analysis := manapool.AnalyzeDeck(deck, cache.Cards())
+//	fmt.Printf("Colors %s, average mana value %.2f\n",
+//	    strings.Join(analysis.ColorIdentity, ""), analysis.AverageManaValue)
+//	for mv, n := range analysis.Curve {
+//	    fmt.Printf("%d: %s\n", mv, strings.Repeat("#", n))
+//	}
+func AnalyzeDeck(deck DeckCreateRequest, cards []CardInfo) *DeckAnalysis {
+	index := make(map[string]CardInfo, len(cards))
+	for _, card := range cards {
+		index[normalizeSearchKey(card.Name)] = card
+	}
+
+	analysis := &DeckAnalysis{}
+	colors := make(map[string]bool)
+	commanderColors := make(map[string]bool)
+	totalManaValue := 0.0
+	spells := 0
+
+	counts, order := deckCardCounts(deck)
+	for _, name := range order {
+		key := normalizeSearchKey(name)
+		card, ok := index[key]
+		if !ok {
+			analysis.Unknown = append(analysis.Unknown, name)
+			continue
+		}
+		quantity := counts[key]
+		for _, color := range card.ColorIdentity {
+			colors[strings.ToUpper(color)] = true
+		}
+
+		manaValue, hasManaValue := card.ManaValueFloat()
+		if card.ManaCost == nil || *card.ManaCost == "" || !hasManaValue {
+			analysis.NoManaCost += quantity
+			continue
+		}
+		bucket := int(math.Floor(manaValue))
+		if bucket > DeckCurveMax {
+			bucket = DeckCurveMax
+		}
+		if bucket < 0 {
+			bucket = 0
+		}
+		analysis.Curve[bucket] += quantity
+		totalManaValue += manaValue * float64(quantity)
+		spells += quantity
+	}
+	for _, name := range deck.CommanderNames {
+		if card, ok := index[normalizeSearchKey(name)]; ok {
+			for _, color := range card.ColorIdentity {
+				commanderColors[strings.ToUpper(color)] = true
+			}
+		}
+	}
+
+	if spells > 0 {
+		analysis.AverageManaValue = totalManaValue / float64(spells)
+	}
+	analysis.ColorIdentity = orderedColors(colors)
+	analysis.CommanderIdentity = orderedColors(commanderColors)
+	analysis.OutsideIdentity = PrecheckDeck(deck, cards).Details.ColorIdentityViolations
+	return analysis
+}
+
+// orderedColors returns the colors in set in WUBRG order.
+func orderedColors(set map[string]bool) []string {
+	colors := []string{}
+	for _, color := range colorOrder {
+		if set[color] {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
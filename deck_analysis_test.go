@@ -0,0 +1,52 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeDeck(t *testing.T) {
+	str := func(s string) *string { return &s }
+	cards := []CardInfo{
+		{Name: "Niv-Mizzet, Parun", ColorIdentity: []string{"U", "R"}, ManaCost: str("{U}{U}{U}{R}{R}{R}"), ManaValue: str("6"), LegalFormats: []string{"commander"}},
+		{Name: "Sol Ring", ManaCost: str("{1}"), ManaValue: str("1"), LegalFormats: []string{"commander"}},
+		{Name: "Brainstorm", ColorIdentity: []string{"U"}, ManaCost: str("{U}"), ManaValue: str("1"), LegalFormats: []string{"commander"}},
+		{Name: "Swords to Plowshares", ColorIdentity: []string{"W"}, ManaCost: str("{W}"), ManaValue: str("1"), LegalFormats: []string{"commander"}},
+		{Name: "Draco", ManaCost: str("{16}"), ManaValue: str("16"), LegalFormats: []string{"commander"}},
+		{Name: "Island", ManaValue: str("0"), LegalFormats: []string{"commander"}},
+	}
+	deck := DeckCreateRequest{
+		CommanderNames: []string{"Niv-Mizzet, Parun"},
+		OtherCards: []OtherCard{
+			{Name: "Sol Ring", Quantity: 1},
+			{Name: "Brainstorm", Quantity: 1},
+			{Name: "Swords to Plowshares", Quantity: 1},
+			{Name: "Draco", Quantity: 1},
+			{Name: "Island", Quantity: 10},
+			{Name: "Mystery Card", Quantity: 1},
+		},
+	}
+
+	analysis := AnalyzeDeck(deck, cards)
+	if !reflect.DeepEqual(analysis.ColorIdentity, []string{"W", "U", "R"}) {
+		t.Errorf("ColorIdentity = %v", analysis.ColorIdentity)
+	}
+	if !reflect.DeepEqual(analysis.CommanderIdentity, []string{"U", "R"}) {
+		t.Errorf("CommanderIdentity = %v", analysis.CommanderIdentity)
+	}
+	if want := [DeckCurveMax + 1]int{0, 3, 0, 0, 0, 0, 1, 1}; analysis.Curve != want {
+		t.Errorf("Curve = %v, want %v", analysis.Curve, want)
+	}
+	if analysis.AverageManaValue != 5 {
+		t.Errorf("AverageManaValue = %v, want 5", analysis.AverageManaValue)
+	}
+	if analysis.NoManaCost != 10 {
+		t.Errorf("NoManaCost = %d, want 10", analysis.NoManaCost)
+	}
+	if len(analysis.OutsideIdentity) != 1 || analysis.OutsideIdentity[0].Name != "Swords to Plowshares" {
+		t.Errorf("OutsideIdentity = %+v", analysis.OutsideIdentity)
+	}
+	if !reflect.DeepEqual(analysis.Unknown, []string{"Mystery Card"}) {
+		t.Errorf("Unknown = %v", analysis.Unknown)
+	}
+}
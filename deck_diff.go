@@ -0,0 +1,100 @@
+package manapool
+
+import "strings"
+
+// DeckDiff lists the changes between two versions of a deck. Quantities are
+// the change in copies, so raising a card from 1 to 3 copies adds 2.
+type DeckDiff struct {
+	Added   []OtherCard
+	Removed []OtherCard
+
+	CommandersAdded   []string
+	CommandersRemoved []string
+}
+
+// DiffDecks compares two versions of a deck, such as a precon and its
+// upgraded list. Names match case-insensitively and commanders count as one
+// copy each, so a card moved between the command zone and the main deck is
+// reported only as a commander change. Added and Removed follow the order of
+// the new and old decks respectively.
+//
+// Example:
+//
+//	diff := manapool.DiffDecks(precon, upgraded)
+//	cart, err := client.OptimizeCart(ctx, diff.OptimizerRequest(manapool.DeckOrderOptions{}))
+func DiffDecks(oldDeck, newDeck DeckCreateRequest) *DeckDiff {
+	oldCounts, oldOrder := deckCardCounts(oldDeck)
+	newCounts, newOrder := deckCardCounts(newDeck)
+	oldCommanders := deckCommanderSet(oldDeck)
+	newCommanders := deckCommanderSet(newDeck)
+
+	diff := &DeckDiff{}
+	for _, name := range newOrder {
+		key := normalizeSearchKey(name)
+		if delta := newCounts[key] - oldCounts[key]; delta > 0 {
+			diff.Added = append(diff.Added, OtherCard{Name: name, Quantity: delta})
+		}
+	}
+	for _, name := range oldOrder {
+		key := normalizeSearchKey(name)
+		if delta := oldCounts[key] - newCounts[key]; delta > 0 {
+			diff.Removed = append(diff.Removed, OtherCard{Name: name, Quantity: delta})
+		}
+	}
+	for _, name := range newDeck.CommanderNames {
+		if !oldCommanders[normalizeSearchKey(name)] {
+			diff.CommandersAdded = append(diff.CommandersAdded, strings.TrimSpace(name))
+		}
+	}
+	for _, name := range oldDeck.CommanderNames {
+		if !newCommanders[normalizeSearchKey(name)] {
+			diff.CommandersRemoved = append(diff.CommandersRemoved, strings.TrimSpace(name))
+		}
+	}
+	return diff
+}
+
+// Empty reports whether the two decks contain the same cards.
+func (d *DeckDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.CommandersAdded) == 0 && len(d.CommandersRemoved) == 0
+}
+
+// OptimizerRequest returns an optimizer request for the cards to buy: the
+// added cards, including any new commander that was not already in the old
+// deck.
+func (d *DeckDiff) OptimizerRequest(opts DeckOrderOptions) OptimizerRequest {
+	additions := DeckCreateRequest{OtherCards: d.Added}
+	return additions.OptimizerRequest(opts)
+}
+
+// deckCardCounts returns the number of copies of each card in the deck,
+// commanders included, keyed by normalized name, and the names in deck order.
+func deckCardCounts(deck DeckCreateRequest) (map[string]int, []string) {
+	counts := make(map[string]int)
+	var order []string
+	add := func(name string, quantity int) {
+		key := normalizeSearchKey(name)
+		if key == "" || quantity <= 0 {
+			return
+		}
+		if _, ok := counts[key]; !ok {
+			order = append(order, strings.TrimSpace(name))
+		}
+		counts[key] += quantity
+	}
+	for _, name := range deck.CommanderNames {
+		add(name, 1)
+	}
+	for _, card := range deck.OtherCards {
+		add(card.Name, card.Quantity)
+	}
+	return counts, order
+}
+
+func deckCommanderSet(deck DeckCreateRequest) map[string]bool {
+	set := make(map[string]bool, len(deck.CommanderNames))
+	for _, name := range deck.CommanderNames {
+		set[normalizeSearchKey(name)] = true
+	}
+	return set
+}
@@ -0,0 +1,48 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDecks(t *testing.T) {
+	precon := DeckCreateRequest{
+		CommanderNames: []string{"Old Commander"},
+		OtherCards: []OtherCard{
+			{Name: "Sol Ring", Quantity: 1},
+			{Name: "Island", Quantity: 30},
+			{Name: "Weak Card", Quantity: 1},
+			{Name: "New Commander", Quantity: 1},
+		},
+	}
+	upgraded := DeckCreateRequest{
+		CommanderNames: []string{"New Commander"},
+		OtherCards: []OtherCard{
+			{Name: "sol ring", Quantity: 1},
+			{Name: "Island", Quantity: 28},
+			{Name: "Old Commander", Quantity: 1},
+			{Name: "Mana Crypt", Quantity: 1},
+			{Name: "Rhystic Study", Quantity: 1},
+		},
+	}
+
+	diff := DiffDecks(precon, upgraded)
+	if want := []OtherCard{{Name: "Mana Crypt", Quantity: 1}, {Name: "Rhystic Study", Quantity: 1}}; !reflect.DeepEqual(diff.Added, want) {
+		t.Errorf("Added = %+v, want %+v", diff.Added, want)
+	}
+	if want := []OtherCard{{Name: "Island", Quantity: 2}, {Name: "Weak Card", Quantity: 1}}; !reflect.DeepEqual(diff.Removed, want) {
+		t.Errorf("Removed = %+v, want %+v", diff.Removed, want)
+	}
+	if !reflect.DeepEqual(diff.CommandersAdded, []string{"New Commander"}) || !reflect.DeepEqual(diff.CommandersRemoved, []string{"Old Commander"}) {
+		t.Errorf("commander changes = +%v -%v", diff.CommandersAdded, diff.CommandersRemoved)
+	}
+
+	req := diff.OptimizerRequest(DeckOrderOptions{DestinationCountry: "US"})
+	if len(req.Cart) != 2 || req.Cart[0].Name != "Mana Crypt" || req.DestinationCountry != "US" {
+		t.Errorf("OptimizerRequest() = %+v", req)
+	}
+
+	if !DiffDecks(precon, precon).Empty() || diff.Empty() {
+		t.Error("Empty() reported the wrong result")
+	}
+}
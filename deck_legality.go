@@ -0,0 +1,175 @@
+package manapool
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Commander deck rules checked by PrecheckDeck.
+const (
+	DeckFormatCommander = "commander"
+	CommanderDeckSize   = 100
+)
+
+var basicLandNames = map[string]bool{
+	"PLAINS": true, "ISLAND": true, "SWAMP": true, "MOUNTAIN": true, "FOREST": true, "WASTES": true,
+	"SNOW-COVERED PLAINS": true, "SNOW-COVERED ISLAND": true, "SNOW-COVERED SWAMP": true,
+	"SNOW-COVERED MOUNTAIN": true, "SNOW-COVERED FOREST": true, "SNOW-COVERED WASTES": true,
+}
+
+// PrecheckDeck validates a commander deck locally using card metadata from
+// earlier GetCardInfo calls, so deck-building tools can catch obvious
+// problems without calling CreateDeck on every edit. It checks commander
+// legality, singleton quantities (basic lands and cards that allow any number
+// of copies are exempt), color identity, commander count and partner text,
+// and the 100-card deck size.
+//
+// Cards missing from cards are reported in CardsNotFound and make the deck
+// invalid; fetch them first. The result mirrors CreateDeck's response but is
+// a heuristic: CreateDeck remains authoritative, and BuyURL is never set.
+//
+// Example:
+//
+//	info, err := client.GetCardInfo(ctx, manapool.CardInfoRequest{CardNames: names})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if check := manapool.PrecheckDeck(deck, info.Cards); !check.Valid {
+//	    log.Printf("illegal: %v", check.Details.IllegalCards)
+//	}
+func PrecheckDeck(req DeckCreateRequest, cards []CardInfo) *DeckCreateResponse {
+	index := make(map[string]CardInfo, len(cards))
+	for _, card := range cards {
+		index[normalizeSearchKey(card.Name)] = card
+	}
+
+	details := DeckValidationDetails{
+		CommanderCount:          len(req.CommanderNames),
+		IllegalCards:            []string{},
+		CardsNotFound:           []string{},
+		QuantityViolations:      []DeckQuantityViolation{},
+		ColorIdentityViolations: []DeckColorIdentityViolation{},
+		PartnerViolations:       []string{},
+	}
+
+	// Merge repeated names so quantity limits apply to the whole deck.
+	quantities := make(map[string]int)
+	var names []string
+	add := func(name string, quantity int) {
+		key := normalizeSearchKey(name)
+		if _, ok := quantities[key]; !ok {
+			names = append(names, name)
+		}
+		quantities[key] += quantity
+		details.TotalCardCount += quantity
+	}
+	for _, name := range req.CommanderNames {
+		add(name, 1)
+	}
+	for _, card := range req.OtherCards {
+		add(card.Name, card.Quantity)
+	}
+
+	commanderColors := make(map[string]bool)
+	var commanders []CardInfo
+	for _, name := range req.CommanderNames {
+		if card, ok := index[normalizeSearchKey(name)]; ok {
+			commanders = append(commanders, card)
+			for _, color := range card.ColorIdentity {
+				commanderColors[strings.ToUpper(color)] = true
+			}
+		}
+	}
+	sortedCommanderColors := make([]string, 0, len(commanderColors))
+	for color := range commanderColors {
+		sortedCommanderColors = append(sortedCommanderColors, color)
+	}
+	sort.Strings(sortedCommanderColors)
+
+	for _, name := range names {
+		key := normalizeSearchKey(name)
+		card, ok := index[key]
+		if !ok {
+			details.CardsNotFound = append(details.CardsNotFound, name)
+			continue
+		}
+		if !card.IsLegalIn(DeckFormatCommander) {
+			details.IllegalCards = append(details.IllegalCards, name)
+		}
+		if quantity := quantities[key]; quantity > 1 && !allowsAnyNumber(card) {
+			details.QuantityViolations = append(details.QuantityViolations, DeckQuantityViolation{Name: name, Quantity: quantity, MaxAllowed: 1})
+		}
+		if len(commanders) == len(req.CommanderNames) && len(commanders) > 0 {
+			for _, color := range card.ColorIdentity {
+				if !commanderColors[strings.ToUpper(color)] {
+					details.ColorIdentityViolations = append(details.ColorIdentityViolations, DeckColorIdentityViolation{
+						Name:            name,
+						CardColors:      card.ColorIdentity,
+						CommanderColors: sortedCommanderColors,
+					})
+					break
+				}
+			}
+		}
+	}
+
+	switch {
+	case len(req.CommanderNames) == 0:
+		details.PartnerViolations = append(details.PartnerViolations, "deck has no commander")
+	case len(req.CommanderNames) > 2:
+		details.PartnerViolations = append(details.PartnerViolations, fmt.Sprintf("deck has %d commanders; at most 2 are allowed", len(req.CommanderNames)))
+	case len(commanders) == 2 && !cardTextContains(commanders[0], "choose a background") &&
+		!cardTextContains(commanders[1], "choose a background"):
+		for _, card := range commanders {
+			if !canPair(card) {
+				details.PartnerViolations = append(details.PartnerViolations, fmt.Sprintf("%s cannot be paired with another commander", card.Name))
+			}
+		}
+	}
+
+	details.AllCardsLegal = len(details.IllegalCards) == 0
+	details.ValidQuantities = len(details.QuantityViolations) == 0
+	details.ValidColorIdentity = len(details.ColorIdentityViolations) == 0
+	details.ValidPartnership = len(details.PartnerViolations) == 0
+
+	return &DeckCreateResponse{
+		Valid: details.AllCardsLegal && details.ValidQuantities && details.ValidColorIdentity &&
+			details.ValidPartnership && len(details.CardsNotFound) == 0 && details.TotalCardCount == CommanderDeckSize,
+		Details: details,
+	}
+}
+
+// allowsAnyNumber reports whether a deck may contain any number of card.
+func allowsAnyNumber(card CardInfo) bool {
+	if basicLandNames[normalizeSearchKey(card.Name)] {
+		return true
+	}
+	return cardTextContains(card, "any number of cards named")
+}
+
+// canPair reports whether a commander's text allows a second commander.
+// Backgrounds cannot be recognised from their text, so any second commander
+// is accepted when one of them says "choose a background".
+func canPair(card CardInfo) bool {
+	for _, keyword := range []string{"partner", "friends forever", "doctor's companion"} {
+		if cardTextContains(card, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// cardTextContains reports whether card's rules text contains substr, ignoring case.
+func cardTextContains(card CardInfo, substr string) bool {
+	return card.Text != nil && strings.Contains(strings.ToLower(*card.Text), substr)
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func commanderCard(name string, colors []string, text string) CardInfo {
+	card := CardInfo{Name: name, LegalFormats: []string{"commander", "legacy"}, ColorIdentity: colors}
+	if text != "" {
+		card.Text = &text
+	}
+	return card
+}
+
+func TestPrecheckDeck(t *testing.T) {
+	cards := []CardInfo{
+		commanderCard("Urza, Lord High Artificer", []string{"U"}, ""),
+		commanderCard("Island", nil, ""),
+		commanderCard("Sol Ring", nil, ""),
+		commanderCard("Counterspell", []string{"U"}, ""),
+	}
+	deck := DeckCreateRequest{
+		CommanderNames: []string{"Urza, Lord High Artificer"},
+		OtherCards: []OtherCard{
+			{Name: "Sol Ring", Quantity: 1},
+			{Name: "Counterspell", Quantity: 1},
+			{Name: "island", Quantity: 97},
+		},
+	}
+
+	check := PrecheckDeck(deck, cards)
+	if !check.Valid {
+		t.Fatalf("expected a valid deck, got %+v", check.Details)
+	}
+	if check.Details.TotalCardCount != 100 || check.Details.CommanderCount != 1 {
+		t.Errorf("unexpected counts: %+v", check.Details)
+	}
+}
+
+func TestPrecheckDeck_Violations(t *testing.T) {
+	cards := []CardInfo{
+		commanderCard("Urza, Lord High Artificer", []string{"U"}, ""),
+		commanderCard("Lightning Bolt", []string{"R"}, ""),
+		commanderCard("Relentless Rats", []string{"B"}, "A deck can have any number of cards named Relentless Rats."),
+		commanderCard("Sol Ring", nil, ""),
+		{Name: "Black Lotus", LegalFormats: []string{"vintage"}},
+	}
+	deck := DeckCreateRequest{
+		CommanderNames: []string{"Urza, Lord High Artificer"},
+		OtherCards: []OtherCard{
+			{Name: "Sol Ring", Quantity: 1},
+			{Name: "Sol Ring", Quantity: 1},
+			{Name: "Lightning Bolt", Quantity: 1},
+			{Name: "Relentless Rats", Quantity: 20},
+			{Name: "Black Lotus", Quantity: 1},
+			{Name: "Unknown Card", Quantity: 1},
+		},
+	}
+
+	check := PrecheckDeck(deck, cards)
+	if check.Valid {
+		t.Fatal("expected an invalid deck")
+	}
+	d := check.Details
+	if !reflect.DeepEqual(d.IllegalCards, []string{"Black Lotus"}) || d.AllCardsLegal {
+		t.Errorf("IllegalCards = %v", d.IllegalCards)
+	}
+	if !reflect.DeepEqual(d.CardsNotFound, []string{"Unknown Card"}) {
+		t.Errorf("CardsNotFound = %v", d.CardsNotFound)
+	}
+	want := []DeckQuantityViolation{{Name: "Sol Ring", Quantity: 2, MaxAllowed: 1}}
+	if !reflect.DeepEqual(d.QuantityViolations, want) {
+		t.Errorf("QuantityViolations = %+v, want %+v", d.QuantityViolations, want)
+	}
+	if len(d.ColorIdentityViolations) != 2 || d.ColorIdentityViolations[0].Name != "Lightning Bolt" ||
+		!reflect.DeepEqual(d.ColorIdentityViolations[0].CommanderColors, []string{"U"}) {
+		t.Errorf("ColorIdentityViolations = %+v", d.ColorIdentityViolations)
+	}
+	if !d.ValidPartnership {
+		t.Errorf("PartnerViolations = %v", d.PartnerViolations)
+	}
+}
+
+func TestPrecheckDeck_Partners(t *testing.T) {
+	cards := []CardInfo{
+		commanderCard("Thrasios, Triton Hero", []string{"G", "U"}, "Partner"),
+		commanderCard("Tymna the Weaver", []string{"W", "B"}, "Lifelink\nPartner"),
+		commanderCard("Urza, Lord High Artificer", []string{"U"}, ""),
+		commanderCard("Wilson, Refined Grizzly", []string{"G"}, "Choose a Background"),
+		commanderCard("Raised by Giants", []string{"G"}, "Commander creatures you own have base power and toughness 10/10."),
+	}
+
+	tests := []struct {
+		name       string
+		commanders []string
+		valid      bool
+	}{
+		{"partners", []string{"Thrasios, Triton Hero", "Tymna the Weaver"}, true},
+		{"background", []string{"Wilson, Refined Grizzly", "Raised by Giants"}, true},
+		{"no partner", []string{"Thrasios, Triton Hero", "Urza, Lord High Artificer"}, false},
+		{"none", nil, false},
+		{"too many", []string{"Thrasios, Triton Hero", "Tymna the Weaver", "Urza, Lord High Artificer"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := PrecheckDeck(DeckCreateRequest{CommanderNames: tt.commanders}, cards)
+			if check.Details.ValidPartnership != tt.valid {
+				t.Errorf("ValidPartnership = %v, want %v (%v)", check.Details.ValidPartnership, tt.valid, check.Details.PartnerViolations)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeckOrderOptions controls how a deck is turned into a cart and pending order.
+type DeckOrderOptions struct {
+	// SkipBasicLands leaves basic lands out of the cart.
+	SkipBasicLands bool
+
+	// Owned maps card names to the number of copies already owned, which are
+	// subtracted from the quantities bought. Names match case-insensitively.
+	Owned map[string]int
+
+	// Model is passed through to OptimizerRequest.Model.
+	Model string
+
+	// DestinationCountry is passed through to OptimizerRequest.DestinationCountry.
+	DestinationCountry string
+
+	// ShippingAddress, if set, is sent with the pending order as the shipping
+	// and tax address so its totals include tax.
+	ShippingAddress *Address
+
+	// AllowInvalid creates the pending order even if deck validation failed.
+	// Cards the API could not find are always left out.
+	AllowInvalid bool
+}
+
+// DeckOrderResult is the outcome of CreateDeckPendingOrder.
+type DeckOrderResult struct {
+	// Validation is the CreateDeck response the order was based on.
+	Validation *DeckCreateResponse
+
+	// Request is the optimizer request built from the deck.
+	Request OptimizerRequest
+
+	// Cart is the optimized cart.
+	Cart *OptimizedCart
+
+	// PendingOrder is the pending order created from Cart. It is not purchased.
+	PendingOrder *PendingOrder
+}
+
+// OptimizerRequest converts the deck into an optimizer request with one
+// mtg_single item per card name, any printing. Commanders are included and
+// repeated names are combined. Names in skip are left out.
+func (r DeckCreateRequest) OptimizerRequest(opts DeckOrderOptions, skip ...string) OptimizerRequest {
+	excluded := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		excluded[normalizeSearchKey(name)] = true
+	}
+	owned := make(map[string]int, len(opts.Owned))
+	for name, quantity := range opts.Owned {
+		owned[normalizeSearchKey(name)] += quantity
+	}
+
+	req := OptimizerRequest{
+		Cart:               []OptimizerCartItem{},
+		Model:              opts.Model,
+		DestinationCountry: opts.DestinationCountry,
+	}
+	index := make(map[string]int)
+	add := func(name string, quantity int) {
+		key := normalizeSearchKey(name)
+		if key == "" || quantity <= 0 || excluded[key] || (opts.SkipBasicLands && basicLandNames[key]) {
+			return
+		}
+		if i, ok := index[key]; ok {
+			req.Cart[i].QuantityRequested += quantity
+			return
+		}
+		index[key] = len(req.Cart)
+		req.Cart = append(req.Cart, OptimizerCartItem{Type: "mtg_single", Name: strings.TrimSpace(name), QuantityRequested: quantity})
+	}
+	for _, name := range r.CommanderNames {
+		add(name, 1)
+	}
+	for _, card := range r.OtherCards {
+		add(card.Name, card.Quantity)
+	}
+
+	// Subtract owned copies, dropping cards that are fully owned.
+	cart := req.Cart[:0]
+	for _, item := range req.Cart {
+		item.QuantityRequested -= owned[normalizeSearchKey(item.Name)]
+		if item.QuantityRequested > 0 {
+			cart = append(cart, item)
+		}
+	}
+	req.Cart = cart
+	return req
+}
+
+// CreateDeckPendingOrder buys a deck programmatically instead of through the
+// browser buy_url returned by CreateDeck. It converts the deck into an
+// optimizer request, optimizes it and creates a pending order, which can then
+// be bought with PurchasePendingOrder.
+//
+// validation is the deck's CreateDeck response; when nil, CreateDeck is
+// called first. A deck that failed validation is rejected with a
+// ValidationError unless opts.AllowInvalid is set.
+//
+// Example:
+//
+//	deck, err := manapool.ParseDecklist(file, manapool.DecklistOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.CreateDeckPendingOrder(ctx, *deck, nil, manapool.DeckOrderOptions{SkipBasicLands: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("Pending order %s: $%.2f\n", result.PendingOrder.ID, float64(result.PendingOrder.Totals.TotalCents)/100)
+func (c *Client) CreateDeckPendingOrder(ctx context.Context, deck DeckCreateRequest, validation *DeckCreateResponse, opts DeckOrderOptions) (*DeckOrderResult, error) {
+	if validation == nil {
+		var err error
+		validation, err = c.CreateDeck(ctx, deck)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !validation.Valid && !opts.AllowInvalid {
+		return nil, NewValidationError("deck", deckInvalidReason(validation.Details))
+	}
+
+	result := &DeckOrderResult{
+		Validation: validation,
+		Request:    deck.OptimizerRequest(opts, validation.Details.CardsNotFound...),
+	}
+	if len(result.Request.Cart) == 0 {
+		return nil, NewValidationError("deck", "deck has no cards left to buy")
+	}
+
+	cart, err := c.OptimizeCart(ctx, result.Request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to optimize deck: %w", err)
+	}
+	result.Cart = cart
+
+	pendingReq := PendingOrderRequest{LineItems: cart.LineItems()}
+	if opts.ShippingAddress != nil {
+		address := *opts.ShippingAddress
+		pendingReq.ShippingAddress = &address
+		pendingReq.TaxAddress = &address
+	}
+	pending, err := c.CreatePendingOrder(ctx, pendingReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deck pending order: %w", err)
+	}
+	result.PendingOrder = pending
+	return result, nil
+}
+
+// deckInvalidReason summarizes why a deck failed validation.
+func deckInvalidReason(d DeckValidationDetails) string {
+	var reasons []string
+	if len(d.IllegalCards) > 0 {
+		reasons = append(reasons, "illegal cards: "+strings.Join(d.IllegalCards, ", "))
+	}
+	if len(d.CardsNotFound) > 0 {
+		reasons = append(reasons, "cards not found: "+strings.Join(d.CardsNotFound, ", "))
+	}
+	if len(d.QuantityViolations) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d quantity violations", len(d.QuantityViolations)))
+	}
+	if len(d.ColorIdentityViolations) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d color identity violations", len(d.ColorIdentityViolations)))
+	}
+	if len(d.PartnerViolations) > 0 {
+		reasons = append(reasons, "partner violations: "+strings.Join(d.PartnerViolations, ", "))
+	}
+	if len(reasons) == 0 {
+		return "deck failed validation"
+	}
+	return "deck failed validation: " + strings.Join(reasons, "; ")
+}
@@ -0,0 +1,84 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestDeckCreateRequest_OptimizerRequest(t *testing.T) {
+	deck := DeckCreateRequest{
+		CommanderNames: []string{"Urza, Lord High Artificer"},
+		OtherCards: []OtherCard{
+			{Name: "Sol Ring", Quantity: 1},
+			{Name: "Island", Quantity: 30},
+			{Name: "Brainstorm", Quantity: 1},
+			{Name: "sol ring", Quantity: 1},
+			{Name: "Unknown", Quantity: 1},
+		},
+	}
+	req := deck.OptimizerRequest(DeckOrderOptions{
+		SkipBasicLands: true,
+		Owned:          map[string]int{"BRAINSTORM": 1, "Sol Ring": 1},
+		Model:          "lowest_price",
+	}, "unknown")
+
+	want := []OptimizerCartItem{
+		{Type: "mtg_single", Name: "Urza, Lord High Artificer", QuantityRequested: 1},
+		{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 1},
+	}
+	if !reflect.DeepEqual(req.Cart, want) {
+		t.Errorf("cart = %+v, want %+v", req.Cart, want)
+	}
+	if req.Model != "lowest_price" {
+		t.Errorf("model = %q", req.Model)
+	}
+}
+
+func TestClient_CreateDeckPendingOrder(t *testing.T) {
+	var optimized OptimizerRequest
+	var pendingReq PendingOrderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/deck":
+			_, _ = w.Write([]byte(`{"valid":true,"buy_url":"https://manapool.com/add-deck","details":{"cards_not_found":[]}}`))
+		case "/buyer/optimizer":
+			_ = json.NewDecoder(r.Body).Decode(&optimized)
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv1","quantity_selected":1},{"inventory_id":"inv2","quantity_selected":2}],"totals":{"total_cents":500}}`))
+		case "/buyer/orders/pending-orders":
+			_ = json.NewDecoder(r.Body).Decode(&pendingReq)
+			_, _ = w.Write([]byte(`{"id":"po1","totals":{"total_cents":560}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	deck := DeckCreateRequest{CommanderNames: []string{"Urza, Lord High Artificer"}, OtherCards: []OtherCard{{Name: "Sol Ring", Quantity: 2}}}
+	address := Address{Line1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"}
+
+	result, err := client.CreateDeckPendingOrder(context.Background(), deck, nil, DeckOrderOptions{ShippingAddress: &address})
+	if err != nil {
+		t.Fatalf("CreateDeckPendingOrder() error = %v", err)
+	}
+	if result.PendingOrder.ID != "po1" || !result.Validation.Valid {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(optimized.Cart) != 2 || optimized.Cart[1].QuantityRequested != 2 {
+		t.Errorf("optimizer request = %+v", optimized)
+	}
+	if len(pendingReq.LineItems) != 2 || pendingReq.LineItems[1].InventoryID != "inv2" || pendingReq.ShippingAddress == nil {
+		t.Errorf("pending order request = %+v", pendingReq)
+	}
+
+	invalid := &DeckCreateResponse{Details: DeckValidationDetails{IllegalCards: []string{"Black Lotus"}}}
+	var valErr *ValidationError
+	if _, err := client.CreateDeckPendingOrder(context.Background(), deck, invalid, DeckOrderOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for invalid deck, got %v", err)
+	}
+}
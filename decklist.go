@@ -0,0 +1,236 @@
+package manapool
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// decklistQuantityPrefix matches "4 Name", "4x Name" and "4X Name".
+	decklistQuantityPrefix = regexp.MustCompile(`^(\d+)([xX]?)\s+(.+)$`)
+
+	// decklistQuantitySuffix matches "Name x4".
+	decklistQuantitySuffix = regexp.MustCompile(`^(.+?)\s+[xX](\d+)$`)
+
+	// decklistPrinting matches a trailing Arena/MTGO printing such as
+	// "(M10) 146", "[M10]" or "(2XM) 231p".
+	decklistPrinting = regexp.MustCompile(`\s+(\([A-Za-z0-9]{2,6}\)|\[[A-Za-z0-9]{2,6}\])(\s+[A-Za-z0-9-]+)?$`)
+
+	// decklistMarker matches trailing export markers such as "*F*", "*CMDR*" and "#tag".
+	decklistMarker = regexp.MustCompile(`\s+(\*[A-Za-z]+\*|#\S+)$`)
+
+	// decklistSectionCount matches a card count after a section header, as in "Commander (1)".
+	decklistSectionCount = regexp.MustCompile(`\s*\(\d+\)$`)
+
+	// decklistFaceSeparator matches "/" or "//" between card faces.
+	decklistFaceSeparator = regexp.MustCompile(`\s*//?\s*`)
+)
+
+// decklistMaxBareQuantityDigits is the longest number without an "x" read as
+// a quantity. Longer leading numbers belong to the name, as in
+// "1996 World Champion".
+const decklistMaxBareQuantityDigits = 3
+
+// Decklist sections tracked by ParseDecklist.
+const (
+	decklistSectionMain = iota
+	decklistSectionCommander
+	decklistSectionSideboard
+	decklistSectionIgnored
+)
+
+// Decklist section names, lower case and without a trailing colon.
+var (
+	decklistCommanderSections = []string{"commander", "commanders"}
+	decklistSideboardSections = []string{"sideboard", "sb", "companion"}
+	decklistIgnoredSections   = []string{"maybeboard", "maybe", "considering", "tokens", "about"}
+	decklistMainSections      = []string{"deck", "main", "mainboard", "maindeck"}
+)
+
+// DecklistOptions configures ParseDecklist.
+type DecklistOptions struct {
+	// IncludeSideboard adds sideboard cards to OtherCards. By default they
+	// are skipped.
+	IncludeSideboard bool
+}
+
+// ParseDecklist converts decklist text into a DeckCreateRequest. It accepts
+// the common export formats:
+//
+//   - quantities written as "4 Name", "4x Name" or "Name x4"; a bare name is one copy
+//   - names that start with a number, such as "1996 World Champion", when the
+//     number has four or more digits or a quantity comes first
+//   - Arena and MTGO printings such as "1 Lightning Bolt (M10) 146", which are dropped
+//   - section headers such as "Commander", "Deck", "Sideboard" and "Maybeboard",
+//     with or without a trailing colon or card count
+//   - single-line markers "Commander: Name" and "SB: 2 Name"
+//   - Moxfield markers such as "*CMDR*" and "*F*"
+//   - comments starting with "//" or "#"
+//
+// Split, adventure and double-faced names are normalized to "Front // Back".
+// Repeated cards are merged. Maybeboard, token and Arena "About" sections
+// are ignored, and the sideboard and companion are skipped unless
+// IncludeSideboard is set.
+//
+// Example:
+//
+//	deck, err := manapool.ParseDecklist(strings.NewReader(text), manapool.DecklistOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.CreateDeck(ctx, *deck)
+func ParseDecklist(r io.Reader, opts DecklistOptions) (*DeckCreateRequest, error) {
+	deck := &DeckCreateRequest{CommanderNames: []string{}, OtherCards: []OtherCard{}}
+	cardIndex := make(map[string]int)
+	commanderSeen := make(map[string]bool)
+
+	addCommander := func(name string) {
+		key := normalizeSearchKey(name)
+		if !commanderSeen[key] {
+			commanderSeen[key] = true
+			deck.CommanderNames = append(deck.CommanderNames, name)
+		}
+	}
+	addCard := func(name string, quantity int) {
+		key := normalizeSearchKey(name)
+		if i, ok := cardIndex[key]; ok {
+			deck.OtherCards[i].Quantity += quantity
+			return
+		}
+		cardIndex[key] = len(deck.OtherCards)
+		deck.OtherCards = append(deck.OtherCards, OtherCard{Name: name, Quantity: quantity})
+	}
+
+	section := decklistSectionMain
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Section headers, optionally followed by a card on the same line.
+		lineSection := decklistSection(line)
+		if lineSection >= 0 {
+			section = lineSection
+			continue
+		}
+		if head, rest, ok := strings.Cut(line, ":"); ok {
+			if lineSection = decklistSection(head); lineSection >= 0 {
+				line = strings.TrimSpace(rest)
+				if line == "" {
+					section = lineSection
+					continue
+				}
+			}
+		}
+
+		current := section
+		if lineSection >= 0 {
+			current = lineSection
+		}
+
+		name, quantity, isCommander, err := parseDecklistLine(line)
+		if err != nil {
+			return nil, NewValidationError(fmt.Sprintf("line %d", lineNumber), err.Error())
+		}
+		if isCommander {
+			current = decklistSectionCommander
+		}
+
+		switch current {
+		case decklistSectionCommander:
+			addCommander(name)
+		case decklistSectionSideboard:
+			if opts.IncludeSideboard {
+				addCard(name, quantity)
+			}
+		case decklistSectionMain:
+			addCard(name, quantity)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read decklist: %w", err)
+	}
+
+	if len(deck.CommanderNames) == 0 && len(deck.OtherCards) == 0 {
+		return nil, NewValidationError("decklist", "decklist contains no cards")
+	}
+	return deck, nil
+}
+
+// decklistSection returns the section named by a header such as
+// "Sideboard" or "Commander (1)", or -1 if header is not a section name.
+func decklistSection(header string) int {
+	name := strings.ToLower(strings.TrimSpace(decklistSectionCount.ReplaceAllString(header, "")))
+	switch {
+	case containsString(decklistCommanderSections, name):
+		return decklistSectionCommander
+	case containsString(decklistSideboardSections, name):
+		return decklistSectionSideboard
+	case containsString(decklistIgnoredSections, name):
+		return decklistSectionIgnored
+	case containsString(decklistMainSections, name):
+		return decklistSectionMain
+	}
+	return -1
+}
+
+// parseDecklistLine parses one card line into its name and quantity, and
+// reports whether it carried a commander marker.
+func parseDecklistLine(line string) (name string, quantity int, isCommander bool, err error) {
+	for {
+		match := decklistMarker.FindStringSubmatch(line)
+		if match == nil {
+			break
+		}
+		if strings.EqualFold(match[1], "*CMDR*") {
+			isCommander = true
+		}
+		line = strings.TrimSpace(strings.TrimSuffix(line, match[0]))
+	}
+
+	quantity = 1
+	quantityText := ""
+	if match := decklistQuantityPrefix.FindStringSubmatch(line); match != nil && (match[2] != "" || len(match[1]) <= decklistMaxBareQuantityDigits) {
+		quantityText, line = match[1], match[3]
+	}
+	line = decklistPrinting.ReplaceAllString(line, "")
+	if quantityText == "" {
+		if match := decklistQuantitySuffix.FindStringSubmatch(line); match != nil {
+			line, quantityText = match[1], match[2]
+		}
+	}
+	if quantityText != "" {
+		quantity, err = strconv.Atoi(quantityText)
+		if err != nil || quantity <= 0 {
+			return "", 0, false, fmt.Errorf("invalid quantity %q", quantityText)
+		}
+	}
+
+	name = normalizeDecklistName(line)
+	if name == "" {
+		return "", 0, false, fmt.Errorf("missing card name")
+	}
+	return name, quantity, isCommander, nil
+}
+
+// normalizeDecklistName collapses whitespace and writes multi-face names as
+// "Front // Back".
+func normalizeDecklistName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if !strings.Contains(name, "/") {
+		return name
+	}
+	faces := decklistFaceSeparator.Split(name, -1)
+	for i := range faces {
+		faces[i] = strings.TrimSpace(faces[i])
+	}
+	return strings.Join(faces, " // ")
+}
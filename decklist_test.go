@@ -0,0 +1,82 @@
+package manapool
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDecklist(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		opts       DecklistOptions
+		commanders []string
+		cards      []OtherCard
+	}{
+		{
+			name: "arena export",
+			text: "About\nName Urza Artifacts\n\nCommander\n1 Urza, Lord High Artificer (MH1) 75\n\nDeck\n1 Sol Ring (C21) 263\n" +
+				"1 Fire // Ice (MH2) 290\n30 Island (M21) 264\n\nSideboard\n1 Pyroblast (ICE) 212\n",
+			commanders: []string{"Urza, Lord High Artificer"},
+			cards:      []OtherCard{{Name: "Sol Ring", Quantity: 1}, {Name: "Fire // Ice", Quantity: 1}, {Name: "Island", Quantity: 30}},
+		},
+		{
+			name:       "markers and quantity variants",
+			text:       "\ufeffCommander: Thrasios, Triton Hero\n1x Tymna the Weaver *CMDR*\nSol Ring x2\n4X Brainstorm *F*\nFire/Ice\nSB: 2 Pyroblast\n// comment\n# comment\n",
+			opts:       DecklistOptions{IncludeSideboard: true},
+			commanders: []string{"Thrasios, Triton Hero", "Tymna the Weaver"},
+			cards: []OtherCard{
+				{Name: "Sol Ring", Quantity: 2},
+				{Name: "Brainstorm", Quantity: 4},
+				{Name: "Fire // Ice", Quantity: 1},
+				{Name: "Pyroblast", Quantity: 2},
+			},
+		},
+		{
+			name:       "counted sections and repeats",
+			text:       "Commander (1)\nAtraxa, Praetors' Voice\nMainboard (3)\n1 Bonecrusher Giant // Stomp\n1 Circle of Protection: Red\n1 sol ring\n1 Sol Ring\nMaybeboard\n1 Mana Crypt\n",
+			commanders: []string{"Atraxa, Praetors' Voice"},
+			cards: []OtherCard{
+				{Name: "Bonecrusher Giant // Stomp", Quantity: 1},
+				{Name: "Circle of Protection: Red", Quantity: 1},
+				{Name: "sol ring", Quantity: 2},
+			},
+		},
+		{
+			name:       "names starting with a number",
+			text:       "1996 World Champion\n2 1996 World Champion\n1x 1996 World Champion\n100 Relentless Rats\n",
+			commanders: []string{},
+			cards: []OtherCard{
+				{Name: "1996 World Champion", Quantity: 4},
+				{Name: "Relentless Rats", Quantity: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deck, err := ParseDecklist(strings.NewReader(tt.text), tt.opts)
+			if err != nil {
+				t.Fatalf("ParseDecklist() error = %v", err)
+			}
+			if !reflect.DeepEqual(deck.CommanderNames, tt.commanders) {
+				t.Errorf("CommanderNames = %q, want %q", deck.CommanderNames, tt.commanders)
+			}
+			if !reflect.DeepEqual(deck.OtherCards, tt.cards) {
+				t.Errorf("OtherCards = %+v, want %+v", deck.OtherCards, tt.cards)
+			}
+		})
+	}
+}
+
+func TestParseDecklist_Errors(t *testing.T) {
+	var valErr *ValidationError
+	if _, err := ParseDecklist(strings.NewReader("1 Sol Ring\n0 Island\n"), DecklistOptions{}); !errors.As(err, &valErr) || valErr.Field != "line 2" {
+		t.Errorf("expected ValidationError for line 2, got %v", err)
+	}
+	if _, err := ParseDecklist(strings.NewReader("Deck\n\n// nothing\n"), DecklistOptions{}); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for empty decklist, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// recordingLogger records formatted log messages.
+type recordingLogger struct {
+	debug []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.debug = append(l.debug, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {}
+
+// discardLogger is a logger that is not the built-in no-op logger.
+type discardLogger struct{}
+
+func (discardLogger) Debugf(format string, args ...interface{}) {}
+func (discardLogger) Errorf(format string, args ...interface{}) {}
+
+func TestClient_StrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username":"seller","singles_live":true,"payout_schedule":"weekly"}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	strict := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithStrictDecoding())
+	_, err := strict.GetSellerAccount(ctx)
+	var unknown *UnknownFieldError
+	if !errors.As(err, &unknown) || unknown.Field != "payout_schedule" {
+		t.Fatalf("expected UnknownFieldError for payout_schedule, got %v", err)
+	}
+
+	logger := &recordingLogger{}
+	quiet := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithLogger(logger))
+	if _, err := quiet.GetSellerAccount(ctx); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	for _, msg := range logger.debug {
+		if strings.Contains(msg, "unknown field") {
+			t.Errorf("unknown field logged without WithSchemaDriftLogging: %q", msg)
+		}
+	}
+
+	logger = &recordingLogger{}
+	lenient := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithLogger(logger), WithSchemaDriftLogging())
+	account, err := lenient.GetSellerAccount(ctx)
+	if err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if account.Username != "seller" || !account.SinglesLive {
+		t.Errorf("account = %+v", account)
+	}
+	found := false
+	for _, msg := range logger.debug {
+		if strings.Contains(msg, `GET /account: response contains unknown field "payout_schedule"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("unknown field not logged: %q", logger.debug)
+	}
+}
+
+func TestClient_StrictDecodingKnownFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"username":"seller","singles_live":true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithStrictDecoding())
+	if _, err := client.GetSellerAccount(context.Background()); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+}
+
+// truncatedReader returns err after its data is read.
+type truncatedReader struct {
+	data string
+	err  error
+}
+
+func (r *truncatedReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestClient_DecodeResponseStreaming(t *testing.T) {
+	client := NewClient("test-token", "test@example.com")
+
+	t.Run("empty body", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}
+		var account Account
+		if err := client.decodeResponse(resp, &account); err != nil {
+			t.Errorf("decodeResponse() error = %v", err)
+		}
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		body := &truncatedReader{data: `{"username":`, err: errors.New("connection reset")}
+		resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(body)}
+		var account Account
+		err := client.decodeResponse(resp, &account)
+		var netErr *NetworkError
+		if !errors.As(err, &netErr) {
+			t.Errorf("expected NetworkError, got %v", err)
+		}
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"username":`))}
+		var account Account
+		err := client.decodeResponse(resp, &account)
+		var netErr *NetworkError
+		if err == nil || errors.As(err, &netErr) {
+			t.Errorf("expected decode error, got %v", err)
+		}
+	})
+}
+
+func benchmarkDecodeResponse(b *testing.B, client *Client) {
+	items := make([]InventoryItem, 2000)
+	for i := range items {
+		items[i] = InventoryItem{ID: fmt.Sprintf("inv-%d", i), ProductType: ProductTypeSingle, PriceCents: 199, Quantity: 4}
+	}
+	data, err := json.Marshal(InventoryResponse{Inventory: items})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(data))}
+		var inventory InventoryResponse
+		if err := client.decodeResponse(resp, &inventory); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeResponse compares streaming a large response to the decoder
+// with reading it into memory for a debug logger.
+func BenchmarkDecodeResponse(b *testing.B) {
+	b.Run("quiet", func(b *testing.B) {
+		benchmarkDecodeResponse(b, NewClient("test-token", "test@example.com"))
+	})
+	b.Run("debug", func(b *testing.B) {
+		benchmarkDecodeResponse(b, NewClient("test-token", "test@example.com", WithLogger(discardLogger{})))
+	})
+}
@@ -0,0 +1,87 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Discord message limits.
+const (
+	discordContentLimit    = 2000
+	discordEmbedTitleLimit = 256
+	discordEmbedDescLimit  = 4096
+)
+
+// DiscordNotifier is a Notifier that posts to a Discord channel through an
+// incoming webhook. By default each notification is posted as an embed with
+// its title and body; a template in Templates replaces that with a plain
+// message rendered from the notification. Rate-limited posts are retried
+// once after the delay Discord asks for.
+//
+// Example:
+//
+//	discord := &manapool.DiscordNotifier{WebhookURL: os.Getenv("DISCORD_WEBHOOK_URL")}
+//	watcher := &manapool.OrderWatcher{Client: client, Notifier: discord}
+type DiscordNotifier struct {
+	// WebhookURL is the channel's webhook URL from Discord's integration settings.
+	WebhookURL string
+
+	// HTTPClient is used for requests (default: a client with a 30 second timeout).
+	HTTPClient *http.Client
+
+	// Username overrides the webhook's display name, if set.
+	Username string
+
+	// Templates, if set, render the message content per event.
+	Templates NotificationTemplates
+
+	// Events, if set, limits the notifications posted to these events;
+	// others are dropped.
+	Events []string
+}
+
+type discordEmbed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type discordMessage struct {
+	Content  string         `json:"content,omitempty"`
+	Username string         `json:"username,omitempty"`
+	Embeds   []discordEmbed `json:"embeds,omitempty"`
+}
+
+// Notify implements Notifier.
+func (d *DiscordNotifier) Notify(ctx context.Context, n Notification) error {
+	if d.WebhookURL == "" {
+		return NewValidationError("WebhookURL", "webhook URL cannot be empty")
+	}
+	if !notificationWanted(d.Events, n.Event) {
+		return nil
+	}
+
+	msg := discordMessage{Username: d.Username}
+	content, ok, err := d.Templates.Render(n)
+	switch {
+	case err != nil:
+		return err
+	case ok:
+		msg.Content = truncateRunes(content, discordContentLimit)
+	default:
+		msg.Embeds = []discordEmbed{{
+			Title:       truncateRunes(n.Title, discordEmbedTitleLimit),
+			Description: truncateRunes(n.Body, discordEmbedDescLimit),
+		}}
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode Discord message: %w", err)
+	}
+
+	if _, err := postNotification(ctx, d.HTTPClient, d.WebhookURL, nil, body); err != nil {
+		return fmt.Errorf("failed to post to Discord: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+)
+
+func TestDiscordNotifier_Embed(t *testing.T) {
+	var got discordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	discord := &DiscordNotifier{WebhookURL: server.URL, Username: "Manapool"}
+	n := Notification{Event: "new_order", Title: "New order A1: $5.00", Body: strings.Repeat("x", 5000)}
+	if err := discord.Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.Username != "Manapool" || got.Content != "" || len(got.Embeds) != 1 {
+		t.Fatalf("message = %+v", got)
+	}
+	if got.Embeds[0].Title != n.Title || len([]rune(got.Embeds[0].Description)) != discordEmbedDescLimit {
+		t.Errorf("embed = %q, %d runes", got.Embeds[0].Title, len([]rune(got.Embeds[0].Description)))
+	}
+}
+
+func TestDiscordNotifier_Template(t *testing.T) {
+	var got discordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	discord := &DiscordNotifier{
+		WebhookURL: server.URL,
+		Templates: NotificationTemplates{
+			"new_order": template.Must(template.New("").Parse("Sold {{.Data.Order.Label}}!")),
+		},
+	}
+	event := OrderEvent{Type: OrderEventNew, Order: OrderDetails{OrderSummary: OrderSummary{Label: "A1"}}}
+	if err := discord.Notify(context.Background(), event.Notification()); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if got.Content != "Sold A1!" || len(got.Embeds) != 0 {
+		t.Errorf("message = %+v", got)
+	}
+
+	discord.Templates["new_order"] = template.Must(template.New("").Parse("{{.Data.Missing}}"))
+	if err := discord.Notify(context.Background(), event.Notification()); err == nil {
+		t.Error("expected template error")
+	}
+}
+
+func TestDiscordNotifier_Events(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	discord := &DiscordNotifier{WebhookURL: server.URL, Events: []string{"new_order"}}
+	_ = discord.Notify(context.Background(), Notification{Event: "payout"})
+	_ = discord.Notify(context.Background(), Notification{Event: "new_order"})
+	if posts.Load() != 1 {
+		t.Errorf("posted %d messages, want 1", posts.Load())
+	}
+}
+
+func TestDiscordNotifier_RateLimited(t *testing.T) {
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if posts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.01}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	discord := &DiscordNotifier{WebhookURL: server.URL}
+	if err := discord.Notify(context.Background(), Notification{Title: "hi"}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if posts.Load() != 2 {
+		t.Errorf("posted %d times, want 2", posts.Load())
+	}
+}
+
+func TestDiscordNotifier_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Unknown Webhook"}`))
+	}))
+	defer server.Close()
+
+	err := (&DiscordNotifier{WebhookURL: server.URL}).Notify(context.Background(), Notification{Title: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "Unknown Webhook") {
+		t.Errorf("Notify() error = %v", err)
+	}
+
+	var validationErr *ValidationError
+	if err := (&DiscordNotifier{}).Notify(context.Background(), Notification{}); !errors.As(err, &validationErr) {
+		t.Errorf("empty URL error = %v, want ValidationError", err)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("héllo wörld", 6); got != "héllo…" {
+		t.Errorf("truncateRunes() = %q", got)
+	}
+	if got := truncateRunes("short", 10); got != "short" {
+		t.Errorf("truncateRunes() = %q", got)
+	}
+}
@@ -0,0 +1,325 @@
+package manapool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PriceExport identifies one of the price exports.
+type PriceExport string
+
+const (
+	PriceExportSingles  PriceExport = "singles"
+	PriceExportVariants PriceExport = "variants"
+	PriceExportSealed   PriceExport = "sealed"
+)
+
+// ErrChecksumMismatch is returned by DownloadPricesToFile when the
+// downloaded export does not match its expected SHA-256 digest.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// DownloadOptions configures DownloadPricesToFile.
+type DownloadOptions struct {
+	// SHA256 is the expected hex-encoded SHA-256 digest of the export. When
+	// empty, a digest the server sends in a Repr-Digest or Digest header is
+	// used instead; without either, the download is not verified.
+	SHA256 string
+}
+
+// DownloadResult describes a completed download.
+type DownloadResult struct {
+	// Bytes is the size of the downloaded file.
+	Bytes int64
+
+	// SHA256 is the hex-encoded SHA-256 digest of the file.
+	SHA256 string
+
+	// Verified reports whether the digest was checked against an expected one.
+	Verified bool
+
+	// Resumed reports whether bytes from an interrupted earlier attempt were
+	// kept rather than downloaded again.
+	Resumed bool
+}
+
+// DownloadPricesToFile downloads a price export to path without decoding
+// it. The export is written to path+".part" first and renamed into place
+// once complete, so path never holds a partial file.
+//
+// If the connection drops mid-transfer, the download resumes from the last
+// byte written using an HTTP Range request, retrying up to the client's
+// retry count. A ".part" file left by an earlier run is resumed the same way.
+// The server's ETag is sent in If-Range, so a changed export is downloaded
+// again from the start rather than spliced onto stale bytes; servers that
+// ignore Range simply resend the whole export. Without a saved ETag there is
+// nothing to send in If-Range, so the download starts over instead.
+//
+// The finished file is checked against opts.SHA256 or a digest sent by the
+// server. On a mismatch the partial file is removed and the error wraps
+// ErrChecksumMismatch.
+//
+// Example:
+//
+//	result, err := client.DownloadPricesToFile(ctx, manapool.PriceExportVariants, "variants.json", manapool.DownloadOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("downloaded %d bytes (resumed: %v)\n", result.Bytes, result.Resumed)
+func (c *Client) DownloadPricesToFile(ctx context.Context, export PriceExport, path string, opts DownloadOptions) (*DownloadResult, error) {
+	switch export {
+	case PriceExportSingles, PriceExportVariants, PriceExportSealed:
+	default:
+		return nil, NewValidationError("export", fmt.Sprintf("unknown price export %q", export))
+	}
+	if path == "" {
+		return nil, NewValidationError("path", "path cannot be empty")
+	}
+
+	ctx, _ = ensureCorrelationID(ctx)
+	partPath := path + ".part"
+	etagPath := partPath + ".etag"
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s prices: %w", export, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	d := &download{client: c, endpoint: "/prices/" + string(export), file: file}
+	if d.offset, err = file.Seek(0, io.SeekEnd); err != nil {
+		return nil, fmt.Errorf("failed to download %s prices: %w", export, err)
+	}
+	if etag, err := os.ReadFile(etagPath); err == nil {
+		d.etag = string(etag)
+	}
+
+	cfg := c.config()
+	backoff := cfg.initialBackoff
+	for attempt := 0; ; attempt++ {
+		resumable, err := d.fetch(ctx)
+		if d.etag != "" {
+			_ = os.WriteFile(etagPath, []byte(d.etag), 0o644)
+		}
+		if err == nil {
+			break
+		}
+		if !resumable || ctx.Err() != nil || attempt >= cfg.maxRetries {
+			if d.offset == 0 {
+				// Nothing to resume; do not leave an empty file behind.
+				_ = file.Close()
+				_ = os.Remove(partPath)
+				_ = os.Remove(etagPath)
+			}
+			return nil, fmt.Errorf("failed to download %s prices: %w", export, err)
+		}
+		c.loggerFor(ctx).Errorf("Download of %s prices interrupted at byte %d (attempt %d/%d): %v", export, d.offset, attempt+1, cfg.maxRetries+1, err)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to download %s prices: %w", export, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	sum, err := fileSHA256(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s prices: %w", export, err)
+	}
+	result := &DownloadResult{Bytes: d.offset, SHA256: sum, Resumed: d.resumed}
+
+	want := strings.ToLower(opts.SHA256)
+	if want == "" {
+		want = d.digest
+	}
+	if want != "" {
+		if sum != want {
+			_ = file.Close()
+			_ = os.Remove(partPath)
+			_ = os.Remove(etagPath)
+			return nil, fmt.Errorf("failed to download %s prices: %w: got sha256 %s, want %s", export, ErrChecksumMismatch, sum, want)
+		}
+		result.Verified = true
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to download %s prices: %w", export, err)
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return nil, fmt.Errorf("failed to download %s prices: %w", export, err)
+	}
+	_ = os.Remove(etagPath)
+	return result, nil
+}
+
+// download is the state of one DownloadPricesToFile call.
+type download struct {
+	client   *Client
+	endpoint string
+	file     *os.File
+
+	// offset is the number of bytes of the export in file.
+	offset int64
+	etag   string
+
+	// digest is the hex SHA-256 digest the server sent, if any.
+	digest string
+
+	// resumed records that bytes already in file were kept.
+	resumed bool
+}
+
+// fetch requests the export from d.offset and appends it to d.file. It
+// reports whether a failure can be resumed by fetching again: the request
+// itself has already been retried by sendRequest, but a transfer cut off
+// part way keeps what arrived and continues from there.
+func (d *download) fetch(ctx context.Context) (resumable bool, err error) {
+	if d.offset > 0 && d.etag == "" {
+		// Without an ETag for If-Range, the bytes kept may be from an
+		// export that has since changed.
+		if err := d.restart(); err != nil {
+			return false, err
+		}
+	}
+	header := http.Header{}
+	if d.offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", d.offset))
+		header.Set("If-Range", d.etag)
+	}
+	resp, err := d.client.sendRequest(ctx, "GET", d.endpoint, "", nil, "", header)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := d.restart(); err != nil {
+			return false, err
+		}
+	case http.StatusPartialContent:
+		start, _, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != d.offset {
+			// Not the range asked for; start over on the next attempt.
+			if err := d.restart(); err != nil {
+				return false, err
+			}
+			return true, NewNetworkError("unexpected Content-Range "+resp.Header.Get("Content-Range"), nil)
+		}
+		d.resumed = true
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The file already holds the whole export if its size matches.
+		if _, total, ok := parseContentRange(resp.Header.Get("Content-Range")); ok && total == d.offset {
+			d.resumed = true
+			return false, nil
+		}
+		if err := d.restart(); err != nil {
+			return false, err
+		}
+		return true, NewNetworkError("requested range not satisfiable", nil)
+	default:
+		return false, d.client.decodeResponse(resp, nil)
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		d.etag = etag
+	}
+	if digest := responseDigest(resp.Header); digest != "" {
+		d.digest = digest
+	}
+
+	if _, err := d.file.Seek(d.offset, io.SeekStart); err != nil {
+		return false, err
+	}
+	body := &trackingReader{r: resp.Body}
+	n, err := io.Copy(d.file, body)
+	d.offset += n
+	if body.err != nil && !errors.Is(body.err, io.EOF) {
+		netErr := NewNetworkError("failed to read response body", body.err)
+		netErr.CorrelationID = correlationIDOf(resp)
+		return true, netErr
+	}
+	return false, err
+}
+
+// restart discards the bytes downloaded so far.
+func (d *download) restart() error {
+	if err := d.file.Truncate(0); err != nil {
+		return err
+	}
+	d.offset, d.etag, d.digest, d.resumed = 0, "", "", false
+	return nil
+}
+
+// parseContentRange parses a Content-Range header of the form
+// "bytes start-end/total" or "bytes */total". start is -1 in the second
+// form, and total is -1 when it is given as "*".
+func parseContentRange(value string) (start, total int64, ok bool) {
+	spec, found := strings.CutPrefix(value, "bytes ")
+	if !found {
+		return 0, 0, false
+	}
+	rng, size, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+	total = -1
+	if size != "*" {
+		n, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		total = n
+	}
+	if rng == "*" {
+		return -1, total, true
+	}
+	first, _, found := strings.Cut(rng, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return start, total, true
+}
+
+// responseDigest returns the hex SHA-256 digest from a Repr-Digest
+// (RFC 9530) or legacy Digest (RFC 3230) header, or "" if there is none.
+func responseDigest(header http.Header) string {
+	for _, field := range []string{"Repr-Digest", "Digest"} {
+		for _, part := range strings.Split(header.Get(field), ",") {
+			algorithm, value, found := strings.Cut(strings.TrimSpace(part), "=")
+			if !found || !strings.EqualFold(algorithm, "sha-256") {
+				continue
+			}
+			sum, err := base64.StdEncoding.DecodeString(strings.Trim(value, ":"))
+			if err != nil || len(sum) != sha256.Size {
+				continue
+			}
+			return hex.EncodeToString(sum)
+		}
+	}
+	return ""
+}
+
+// fileSHA256 returns the hex SHA-256 digest of file's contents.
+func fileSHA256(file *os.File) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
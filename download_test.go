@@ -0,0 +1,232 @@
+package manapool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var exportBody = []byte(strings.Repeat(`{"name":"Sol Ring","low_price":150},`, 500))
+
+func exportDigest() string {
+	sum := sha256.Sum256(exportBody)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestClient_DownloadPricesToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices/variants" {
+			http.NotFound(w, r)
+			return
+		}
+		sum := sha256.Sum256(exportBody)
+		w.Header().Set("Repr-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":")
+		_, _ = w.Write(exportBody)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	path := filepath.Join(t.TempDir(), "variants.json")
+	result, err := client.DownloadPricesToFile(context.Background(), PriceExportVariants, path, DownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadPricesToFile() error = %v", err)
+	}
+	if result.Bytes != int64(len(exportBody)) || result.SHA256 != exportDigest() || !result.Verified || result.Resumed {
+		t.Errorf("result = %+v", result)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != string(exportBody) {
+		t.Fatalf("file = %d bytes, %v", len(data), err)
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("partial file left behind: %v", err)
+	}
+}
+
+func TestClient_DownloadPricesToFile_Resume(t *testing.T) {
+	var requests atomic.Int32
+	var gotRange, gotIfRange string
+	half := len(exportBody) / 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if requests.Add(1) == 1 {
+			// Send half the export, then drop the connection.
+			w.Header().Set("Content-Length", strconv.Itoa(len(exportBody)))
+			_, _ = w.Write(exportBody[:half])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		gotRange, gotIfRange = r.Header.Get("Range"), r.Header.Get("If-Range")
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", half, len(exportBody)-1, len(exportBody)))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write(exportBody[half:])
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(2, time.Millisecond))
+	path := filepath.Join(t.TempDir(), "variants.json")
+	result, err := client.DownloadPricesToFile(context.Background(), PriceExportVariants, path, DownloadOptions{SHA256: exportDigest()})
+	if err != nil {
+		t.Fatalf("DownloadPricesToFile() error = %v", err)
+	}
+	if want := fmt.Sprintf("bytes=%d-", half); gotRange != want || gotIfRange != `"v1"` {
+		t.Errorf("Range = %q, If-Range = %q; want %q, %q", gotRange, gotIfRange, want, `"v1"`)
+	}
+	if !result.Resumed || !result.Verified || result.Bytes != int64(len(exportBody)) {
+		t.Errorf("result = %+v", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(exportBody) {
+		t.Errorf("file does not match the export")
+	}
+}
+
+func TestClient_DownloadPricesToFile_RangeIgnored(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(exportBody)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "singles.json")
+	if err := os.WriteFile(path+".part", []byte("stale bytes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	result, err := client.DownloadPricesToFile(context.Background(), PriceExportSingles, path, DownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadPricesToFile() error = %v", err)
+	}
+	if result.Resumed || result.Verified {
+		t.Errorf("result = %+v, want a fresh unverified download", result)
+	}
+	if data, _ := os.ReadFile(path); string(data) != string(exportBody) {
+		t.Errorf("stale partial bytes were kept")
+	}
+}
+
+func TestClient_DownloadPricesToFile_NoSavedETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			t.Errorf("Range %q sent without a saved ETag", r.Header.Get("Range"))
+		}
+		w.Header().Set("ETag", `"v2"`)
+		_, _ = w.Write(exportBody)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "singles.json")
+	if err := os.WriteFile(path+".part", exportBody[:10], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	result, err := client.DownloadPricesToFile(context.Background(), PriceExportSingles, path, DownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadPricesToFile() error = %v", err)
+	}
+	if result.Resumed || result.Bytes != int64(len(exportBody)) {
+		t.Errorf("result = %+v, want a fresh download", result)
+	}
+}
+
+func TestClient_DownloadPricesToFile_ChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(exportBody)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	path := filepath.Join(t.TempDir(), "sealed.json")
+	_, err := client.DownloadPricesToFile(context.Background(), PriceExportSealed, path, DownloadOptions{SHA256: strings.Repeat("0", 64)})
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("DownloadPricesToFile() error = %v, want ErrChecksumMismatch", err)
+	}
+	for _, p := range []string{path, path + ".part"} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%s should not exist: %v", p, err)
+		}
+	}
+}
+
+func TestClient_DownloadPricesToFile_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	var validationErr *ValidationError
+	if _, err := client.DownloadPricesToFile(ctx, "graded", filepath.Join(dir, "x.json"), DownloadOptions{}); !errors.As(err, &validationErr) {
+		t.Errorf("unknown export error = %v, want ValidationError", err)
+	}
+	if _, err := client.DownloadPricesToFile(ctx, PriceExportSingles, "", DownloadOptions{}); !errors.As(err, &validationErr) {
+		t.Errorf("empty path error = %v, want ValidationError", err)
+	}
+
+	var apiErr *APIError
+	if _, err := client.DownloadPricesToFile(ctx, PriceExportSingles, filepath.Join(dir, "singles.json"), DownloadOptions{}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("API error = %v, want 403 APIError", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "singles.json.part")); !os.IsNotExist(err) {
+		t.Errorf("empty partial file left behind: %v", err)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		value        string
+		start, total int64
+		ok           bool
+	}{
+		{"bytes 100-199/200", 100, 200, true},
+		{"bytes 0-9/*", 0, -1, true},
+		{"bytes */200", -1, 200, true},
+		{"bytes abc-9/10", 0, 0, false},
+		{"items 0-9/10", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, total, ok := parseContentRange(tt.value)
+		if start != tt.start || total != tt.total || ok != tt.ok {
+			t.Errorf("parseContentRange(%q) = %d, %d, %v", tt.value, start, total, ok)
+		}
+	}
+}
+
+func TestResponseDigest(t *testing.T) {
+	sum := sha256.Sum256([]byte("export"))
+	encoded := base64.StdEncoding.EncodeToString(sum[:])
+	want := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   string
+	}{
+		{"repr-digest", http.Header{"Repr-Digest": {"sha-512=:abc:, sha-256=:" + encoded + ":"}}, want},
+		{"legacy digest", http.Header{"Digest": {"SHA-256=" + encoded}}, want},
+		{"other algorithm", http.Header{"Digest": {"md5=abc"}}, ""},
+		{"none", http.Header{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := responseDigest(tt.header); got != tt.want {
+				t.Errorf("responseDigest() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// smtpTimeout bounds an SMTP conversation when ctx has no deadline.
+const smtpTimeout = time.Minute
+
+// EmailNotifier is a Notifier that sends plain-text email over SMTP, for
+// sellers who want fulfillment events in their inbox rather than in chat.
+// The subject is the notification's title and the body its body, unless a
+// template in Templates renders the body. STARTTLS is used whenever the
+// server offers it; set RequireTLS to refuse to send without it, or
+// ImplicitTLS for servers that expect TLS from the start, usually on port 465.
+//
+// Example:
+//
+//	email := &manapool.EmailNotifier{
+//	    Addr:   "smtp.example.com:587",
+//	    Auth:   smtp.PlainAuth("", "store@example.com", password, "smtp.example.com"),
+//	    From:   "store@example.com",
+//	    To:     []string{"me@example.com"},
+//	    Events: []string{string(manapool.OrderEventShipped), string(manapool.OrderEventDelivered), string(manapool.OrderEventIssueReported)},
+//	}
+//	watcher := &manapool.OrderWatcher{Client: client, Notifier: email}
+type EmailNotifier struct {
+	// Addr is the SMTP server's host and port, e.g. "smtp.example.com:587".
+	Addr string
+
+	// Auth, if set, authenticates with the server, e.g. smtp.PlainAuth.
+	Auth smtp.Auth
+
+	// From is the sender address.
+	From string
+
+	// To lists the recipient addresses.
+	To []string
+
+	// SubjectPrefix is prepended to every subject, e.g. "[Store] ".
+	SubjectPrefix string
+
+	// Templates, if set, render the message body per event.
+	Templates NotificationTemplates
+
+	// Events, if set, limits the notifications sent to these events;
+	// others are dropped.
+	Events []string
+
+	// TLSConfig is used for STARTTLS and ImplicitTLS (default: verify the
+	// certificate against the host in Addr, which is also used when
+	// TLSConfig has no ServerName).
+	TLSConfig *tls.Config
+
+	// RequireTLS fails the send instead of falling back to plaintext when
+	// the server does not offer STARTTLS.
+	RequireTLS bool
+
+	// ImplicitTLS connects with TLS before speaking SMTP, as servers on
+	// port 465 expect, instead of upgrading with STARTTLS.
+	ImplicitTLS bool
+}
+
+// Notify implements Notifier. The SMTP conversation is abandoned if ctx is
+// cancelled, and limited to a minute if ctx has no deadline.
+func (e *EmailNotifier) Notify(ctx context.Context, n Notification) error {
+	if e.Addr == "" {
+		return NewValidationError("Addr", "SMTP server address cannot be empty")
+	}
+	if e.From == "" {
+		return NewValidationError("From", "sender address cannot be empty")
+	}
+	if len(e.To) == 0 {
+		return NewValidationError("To", "at least one recipient is required")
+	}
+	if !notificationWanted(e.Events, n.Event) {
+		return nil
+	}
+
+	body, ok, err := e.Templates.Render(n)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		body = n.Body
+	}
+	msg, err := e.message(e.SubjectPrefix+n.Title, body)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+	if err := e.send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send %s email: %w", n.Event, err)
+	}
+	return nil
+}
+
+// message formats an RFC 5322 message with a quoted-printable UTF-8 body.
+func (e *EmailNotifier) message(subject, body string) ([]byte, error) {
+	// Titles are single-line, but keep stray newlines out of the header.
+	subject = strings.Join(strings.Fields(subject), " ")
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", headerValue(e.From))
+	fmt.Fprintf(&msg, "To: %s\r\n", headerValue(strings.Join(e.To, ", ")))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// headerValue removes CR and LF from s so it cannot end its header line and
+// inject headers of its own.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+// send delivers msg to every recipient in one SMTP conversation.
+func (e *EmailNotifier) send(ctx context.Context, msg []byte) error {
+	host, _, err := net.SplitHostPort(e.Addr)
+	if err != nil {
+		return NewValidationError("Addr", fmt.Sprintf("invalid SMTP server address %q", e.Addr))
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", e.Addr)
+	if err != nil {
+		return NewNetworkError("failed to connect to SMTP server", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	// Closing the connection unblocks the conversation if ctx is cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	config := &tls.Config{ServerName: host}
+	if e.TLSConfig != nil {
+		config = e.TLSConfig
+		if config.ServerName == "" {
+			config = config.Clone()
+			config.ServerName = host
+		}
+	}
+	session := conn
+	if e.ImplicitTLS {
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return NewNetworkError("TLS handshake with SMTP server failed", err)
+		}
+		session = tlsConn
+	}
+
+	client, err := smtp.NewClient(session, host)
+	if err != nil {
+		_ = conn.Close()
+		return NewNetworkError("failed to start SMTP session", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if !e.ImplicitTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(config); err != nil {
+				return fmt.Errorf("STARTTLS failed: %w", err)
+			}
+		} else if e.RequireTLS {
+			return fmt.Errorf("SMTP server %s does not offer STARTTLS", e.Addr)
+		}
+	}
+	if e.Auth != nil {
+		if err := client.Auth(e.Auth); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+	if err := client.Mail(e.From); err != nil {
+		return err
+	}
+	for _, to := range e.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", to, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
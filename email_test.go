@@ -0,0 +1,283 @@
+package manapool
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+)
+
+// fakeSMTP is a minimal SMTP server that records the messages it receives.
+type fakeSMTP struct {
+	listener net.Listener
+	auth     bool   // advertise AUTH PLAIN
+	reject   string // recipient to reject
+
+	mu       sync.Mutex
+	messages []smtpMessage
+}
+
+type smtpMessage struct {
+	from string
+	to   []string
+	auth string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serveFakeSMTP(t, listener)
+}
+
+// newFakeSMTPS starts a fakeSMTP that expects TLS from the start, and
+// returns a client TLS config that trusts it.
+func newFakeSMTPS(t *testing.T) (*fakeSMTP, *tls.Config) {
+	t.Helper()
+	certs := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(certs.Close)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := serveFakeSMTP(t, tls.NewListener(listener, certs.TLS))
+	return server, certs.Client().Transport.(*http.Transport).TLSClientConfig
+}
+
+func serveFakeSMTP(t *testing.T, listener net.Listener) *fakeSMTP {
+	s := &fakeSMTP{listener: listener}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 fake ESMTP")
+	var msg smtpMessage
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if s.auth {
+				_ = tp.PrintfLine("250-fake\r\n250 AUTH PLAIN")
+			} else {
+				_ = tp.PrintfLine("250 fake")
+			}
+		case "AUTH":
+			msg.auth = arg
+			_ = tp.PrintfLine("235 ok")
+		case "MAIL":
+			msg.from = arg
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			if s.reject != "" && strings.Contains(arg, s.reject) {
+				_ = tp.PrintfLine("550 no such user")
+				continue
+			}
+			msg.to = append(msg.to, arg)
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 unsupported")
+		}
+	}
+}
+
+func (s *fakeSMTP) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	server := newFakeSMTP(t)
+	email := &EmailNotifier{
+		Addr:          server.listener.Addr().String(),
+		From:          "store@example.com",
+		To:            []string{"me@example.com", "team@example.com"},
+		SubjectPrefix: "[Store] ",
+	}
+	n := Notification{Event: "order_shipped", Title: "Order A1 shipped — café", Body: "Tracking: USPS 9400\nhttps://example.com/track/9400\n"}
+	if err := email.Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	messages := server.received()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.from != "FROM:<store@example.com>" || len(msg.to) != 2 {
+		t.Errorf("envelope = %q -> %v", msg.from, msg.to)
+	}
+	header, body, _ := strings.Cut(msg.data, "\n\n")
+	if !strings.Contains(header, "Subject: =?utf-8?q?[Store]_Order_A1_shipped_=E2=80=94_caf=C3=A9?=") {
+		t.Errorf("header = %q", header)
+	}
+	if !strings.Contains(header, "To: me@example.com, team@example.com") {
+		t.Errorf("header = %q", header)
+	}
+	decoded, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if err != nil || !strings.Contains(string(decoded), "Tracking: USPS 9400") {
+		t.Errorf("body = %q, %v", decoded, err)
+	}
+}
+
+func TestEmailNotifier_TemplateAndAuth(t *testing.T) {
+	server := newFakeSMTP(t)
+	server.auth = true
+	host, _, _ := net.SplitHostPort(server.listener.Addr().String())
+	email := &EmailNotifier{
+		Addr:      server.listener.Addr().String(),
+		Auth:      smtp.PlainAuth("", "user", "secret", host),
+		From:      "store@example.com",
+		To:        []string{"me@example.com"},
+		Templates: NotificationTemplates{"": template.Must(template.New("").Parse("Event: {{.Event}}"))},
+		Events:    []string{"issue_reported"},
+	}
+	if err := email.Notify(context.Background(), Notification{Event: "new_order", Title: "skipped"}); err != nil {
+		t.Fatalf("Notify(filtered) error = %v", err)
+	}
+	if err := email.Notify(context.Background(), Notification{Event: "issue_reported", Title: "Issue"}); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	messages := server.received()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	if !strings.HasPrefix(messages[0].auth, "PLAIN ") {
+		t.Errorf("AUTH = %q", messages[0].auth)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(messages[0].data), "Event: issue_reported") {
+		t.Errorf("data = %q", messages[0].data)
+	}
+}
+
+func TestEmailNotifier_TLS(t *testing.T) {
+	ctx := context.Background()
+	n := Notification{Event: "order_shipped", Title: "Order A1 shipped"}
+
+	plain := newFakeSMTP(t)
+	email := &EmailNotifier{Addr: plain.listener.Addr().String(), From: "a@example.com", To: []string{"b@example.com"}, RequireTLS: true}
+	if err := email.Notify(ctx, n); err == nil || !strings.Contains(err.Error(), "does not offer STARTTLS") {
+		t.Errorf("RequireTLS error = %v", err)
+	}
+	if got := len(plain.received()); got != 0 {
+		t.Errorf("sent %d messages without TLS, want 0", got)
+	}
+
+	server, config := newFakeSMTPS(t)
+	email = &EmailNotifier{
+		Addr:        server.listener.Addr().String(),
+		From:        "a@example.com",
+		To:          []string{"b@example.com"},
+		TLSConfig:   config,
+		RequireTLS:  true,
+		ImplicitTLS: true,
+	}
+	if err := email.Notify(ctx, n); err != nil {
+		t.Fatalf("Notify(ImplicitTLS) error = %v", err)
+	}
+	if got := len(server.received()); got != 1 {
+		t.Errorf("got %d messages over TLS, want 1", got)
+	}
+}
+
+func TestEmailNotifier_HeaderInjection(t *testing.T) {
+	email := &EmailNotifier{From: "a@example.com\r\nBcc: evil@example.com", To: []string{"b@example.com\nX-Injected: 1"}}
+	msg, err := email.message("Shipped\r\nBcc: evil@example.com", "body")
+	if err != nil {
+		t.Fatalf("message() error = %v", err)
+	}
+	header, _, _ := strings.Cut(string(msg), "\r\n\r\n")
+	for _, line := range strings.Split(header, "\r\n") {
+		if strings.HasPrefix(line, "Bcc:") || strings.HasPrefix(line, "X-Injected:") {
+			t.Errorf("injected header line %q in %q", line, header)
+		}
+	}
+}
+
+func TestEmailNotifier_Errors(t *testing.T) {
+	ctx := context.Background()
+	var validationErr *ValidationError
+	for name, email := range map[string]*EmailNotifier{
+		"no addr":       {From: "a@example.com", To: []string{"b@example.com"}},
+		"no from":       {Addr: "localhost:25", To: []string{"b@example.com"}},
+		"no recipients": {Addr: "localhost:25", From: "a@example.com"},
+		"bad addr":      {Addr: "localhost", From: "a@example.com", To: []string{"b@example.com"}},
+	} {
+		if err := email.Notify(ctx, Notification{}); !errors.As(err, &validationErr) {
+			t.Errorf("%s: error = %v, want ValidationError", name, err)
+		}
+	}
+
+	server := newFakeSMTP(t)
+	server.reject = "nobody@"
+	email := &EmailNotifier{Addr: server.listener.Addr().String(), From: "a@example.com", To: []string{"nobody@example.com"}}
+	if err := email.Notify(ctx, Notification{Title: "hi"}); err == nil || !strings.Contains(err.Error(), "nobody@example.com rejected") {
+		t.Errorf("rejected recipient error = %v", err)
+	}
+
+	// A server that accepts the connection but never answers.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = listener.Close() }()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer func() { _ = conn.Close() }()
+			_, _ = bufio.NewReader(conn).ReadString('\n')
+		}
+	}()
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	email = &EmailNotifier{Addr: listener.Addr().String(), From: "a@example.com", To: []string{"b@example.com"}}
+	if err := email.Notify(ctx, Notification{Title: "hi"}); err == nil {
+		t.Error("expected error from a silent server")
+	}
+}
@@ -1,10 +1,28 @@
 package manapool
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 )
 
+// UnknownFieldError reports a field in an API response that the response
+// type does not declare. It is returned by clients created with
+// WithStrictDecoding, and usually means the API added or renamed a field.
+type UnknownFieldError struct {
+	// Field is the JSON name of the unknown field
+	Field string
+}
+
+// Error implements the error interface.
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("response contains unknown field %q", e.Field)
+}
+
 // APIError represents an error returned by the Manapool API.
 // It contains the HTTP status code, error message, and optional request ID
 // for debugging purposes.
@@ -18,17 +36,32 @@ type APIError struct {
 	// RequestID is the unique identifier for the request (if available)
 	RequestID string
 
+	// CorrelationID is the client-generated ID sent in the X-Correlation-Id
+	// header, also used to prefix the client's log lines for the request
+	CorrelationID string
+
+	// Details is the raw "details" value from a JSON error response (may be nil)
+	Details json.RawMessage
+
+	// Fields lists the field-level problems found in the "details" or
+	// "errors" value of a JSON error response, such as the rows a bulk
+	// upload rejected (may be nil)
+	Fields []FieldError
+
 	// Response is the raw HTTP response (may be nil)
 	Response *http.Response
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	status := fmt.Sprintf("status %d", e.StatusCode)
 	if e.RequestID != "" {
-		return fmt.Sprintf("manapool API error (status %d, request %s): %s",
-			e.StatusCode, e.RequestID, e.Message)
+		status += ", request " + e.RequestID
+	}
+	if e.CorrelationID != "" {
+		status += ", correlation " + e.CorrelationID
 	}
-	return fmt.Sprintf("manapool API error (status %d): %s", e.StatusCode, e.Message)
+	return fmt.Sprintf("manapool API error (%s): %s", status, e.Message)
 }
 
 // IsNotFound returns true if the error is a 404 Not Found error.
@@ -56,6 +89,159 @@ func (e *APIError) IsServerError() bool {
 	return e.StatusCode >= 500 && e.StatusCode < 600
 }
 
+// FieldError is one field-level problem reported in an API error response.
+type FieldError struct {
+	// Field is the path of the rejected field, such as "items[3].price_cents",
+	// or empty when the message does not name one
+	Field string
+
+	// Index is the position of the rejected item in a bulk request, or -1
+	Index int
+
+	// Message describes the problem
+	Message string
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// fieldMessagePattern matches "field: message" detail strings.
+var fieldMessagePattern = regexp.MustCompile(`^(\w[\w.\[\]]*): (.+)$`)
+
+// fieldIndexPattern finds the item index in paths such as "items[3].price_cents"
+// or "3.price_cents".
+var fieldIndexPattern = regexp.MustCompile(`^(?:[A-Za-z_]\w*\[(\d+)\]|(\d+)(?:\.|$))`)
+
+// parseFieldErrors extracts field errors from a "details" or "errors" value.
+// It understands arrays of "field: message" strings, arrays of objects with
+// field and message keys, and objects mapping fields to one or more messages.
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var items []json.RawMessage
+	if json.Unmarshal(raw, &items) == nil {
+		var fields []FieldError
+		for _, item := range items {
+			var text string
+			if json.Unmarshal(item, &text) == nil {
+				fields = append(fields, fieldErrorFromText(text))
+				continue
+			}
+			var obj map[string]json.RawMessage
+			if json.Unmarshal(item, &obj) == nil {
+				if field, ok := fieldErrorFromObject(obj); ok {
+					fields = append(fields, field)
+				}
+			}
+		}
+		return fields
+	}
+
+	var byField map[string]json.RawMessage
+	if json.Unmarshal(raw, &byField) != nil {
+		return nil
+	}
+	names := make([]string, 0, len(byField))
+	for name := range byField {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []FieldError
+	for _, name := range names {
+		for _, message := range jsonMessages(byField[name]) {
+			fields = append(fields, newFieldError(name, message, -1))
+		}
+	}
+	return fields
+}
+
+func fieldErrorFromText(text string) FieldError {
+	if m := fieldMessagePattern.FindStringSubmatch(text); m != nil {
+		return newFieldError(m[1], m[2], -1)
+	}
+	return FieldError{Index: -1, Message: text}
+}
+
+func fieldErrorFromObject(obj map[string]json.RawMessage) (FieldError, bool) {
+	message := firstString(obj, "message", "msg", "error", "detail")
+	if message == "" {
+		return FieldError{}, false
+	}
+
+	field := firstString(obj, "field", "path", "param", "name")
+	if field == "" {
+		// Pydantic-style errors give the path as a list, e.g. ["items", 3, "price_cents"].
+		var loc []interface{}
+		if json.Unmarshal(obj["loc"], &loc) == nil {
+			parts := make([]string, 0, len(loc))
+			for _, part := range loc {
+				parts = append(parts, fmt.Sprint(part))
+			}
+			field = strings.Join(parts, ".")
+		}
+	}
+
+	index := -1
+	for _, key := range []string{"index", "row"} {
+		if err := json.Unmarshal(obj[key], &index); err == nil {
+			break
+		}
+		index = -1
+	}
+	return newFieldError(field, message, index), true
+}
+
+func newFieldError(field, message string, index int) FieldError {
+	if index < 0 {
+		if m := fieldIndexPattern.FindStringSubmatch(field); m != nil {
+			digits := m[1] + m[2]
+			if n, err := strconv.Atoi(digits); err == nil {
+				index = n
+			}
+		}
+	}
+	return FieldError{Field: field, Index: index, Message: message}
+}
+
+// firstString returns the first of keys in obj that holds a non-empty string.
+func firstString(obj map[string]json.RawMessage, keys ...string) string {
+	for _, key := range keys {
+		var s string
+		if json.Unmarshal(obj[key], &s) == nil && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// jsonMessages returns raw as a list of messages: a string, an array of
+// strings, or an object with a message key.
+func jsonMessages(raw json.RawMessage) []string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return []string{s}
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(raw, &obj) == nil {
+		if message := firstString(obj, "message", "msg", "error"); message != "" {
+			return []string{message}
+		}
+	}
+	return nil
+}
+
 // ValidationError represents an error that occurs during input validation.
 type ValidationError struct {
 	Field   string
@@ -71,14 +257,21 @@ func (e *ValidationError) Error() string {
 type NetworkError struct {
 	Message string
 	Err     error
+
+	// CorrelationID is the client-generated ID of the failed request, if any
+	CorrelationID string
 }
 
 // Error implements the error interface.
 func (e *NetworkError) Error() string {
+	message := e.Message
+	if e.CorrelationID != "" {
+		message += " (correlation " + e.CorrelationID + ")"
+	}
 	if e.Err != nil {
-		return fmt.Sprintf("network error: %s: %v", e.Message, e.Err)
+		return fmt.Sprintf("network error: %s: %v", message, e.Err)
 	}
-	return fmt.Sprintf("network error: %s", e.Message)
+	return fmt.Sprintf("network error: %s", message)
 }
 
 // Unwrap returns the underlying error.
@@ -1,8 +1,12 @@
 package manapool
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 )
 
@@ -345,3 +349,89 @@ func TestAPIError_Response(t *testing.T) {
 		t.Errorf("APIError.Response = %v, want %v", err.Response, resp)
 	}
 }
+
+func TestParseFieldErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []FieldError
+	}{
+		{
+			name: "field message strings",
+			raw:  `["items[3].price_cents: must be positive", "something went wrong"]`,
+			want: []FieldError{
+				{Field: "items[3].price_cents", Index: 3, Message: "must be positive"},
+				{Index: -1, Message: "something went wrong"},
+			},
+		},
+		{
+			name: "objects",
+			raw:  `[{"field": "quantity", "message": "too large", "row": 7}, {"path": "2.tcgplayer_sku", "msg": "unknown sku"}]`,
+			want: []FieldError{
+				{Field: "quantity", Index: 7, Message: "too large"},
+				{Field: "2.tcgplayer_sku", Index: 2, Message: "unknown sku"},
+			},
+		},
+		{
+			name: "location lists",
+			raw:  `[{"loc": ["body", 0, "price_cents"], "msg": "field required"}]`,
+			want: []FieldError{{Field: "body.0.price_cents", Index: -1, Message: "field required"}},
+		},
+		{
+			name: "field map",
+			raw:  `{"price_cents": ["must be positive", "must be an integer"], "email": "invalid"}`,
+			want: []FieldError{
+				{Field: "email", Index: -1, Message: "invalid"},
+				{Field: "price_cents", Index: -1, Message: "must be positive"},
+				{Field: "price_cents", Index: -1, Message: "must be an integer"},
+			},
+		},
+		{
+			name: "objects without messages",
+			raw:  `[{"item": {"name": "Sol Ring"}, "total_available": 0}]`,
+		},
+		{name: "empty", raw: ``},
+		{name: "scalar", raw: `42`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseFieldErrors(json.RawMessage(tt.raw))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFieldErrors() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldError_Error(t *testing.T) {
+	if got := (FieldError{Field: "quantity", Message: "too large"}).Error(); got != "quantity: too large" {
+		t.Errorf("Error() = %q", got)
+	}
+	if got := (FieldError{Message: "bad request"}).Error(); got != "bad request" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestAPIError_Fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status": 400, "message": "Invalid request data", "details": ["1.price_cents: must be positive"], "errors": {"quantity": "required"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	_, err := client.CreateInventoryBulkBySKU(context.Background(), []InventoryBulkItemBySKU{{TCGPlayerSKU: 1, PriceCents: 1, Quantity: 1}})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	want := []FieldError{
+		{Field: "1.price_cents", Index: 1, Message: "must be positive"},
+		{Field: "quantity", Index: -1, Message: "required"},
+	}
+	if !reflect.DeepEqual(apiErr.Fields, want) {
+		t.Errorf("Fields = %+v, want %+v", apiErr.Fields, want)
+	}
+}
@@ -0,0 +1,117 @@
+package manapool
+
+import "math"
+
+// FeeSchedule describes the fees a seller pays on a sale. Rates are
+// fractions (0.05 is 5%) and fixed amounts are in cents. Fee rates differ by
+// seller and change over time, so populate the schedule from your account's
+// current terms.
+type FeeSchedule struct {
+	// MarketplaceRate is the marketplace commission rate.
+	MarketplaceRate float64
+
+	// MarketplaceFixedCents is a flat marketplace fee charged per order.
+	MarketplaceFixedCents int
+
+	// MarketplaceFeeOnShipping applies the marketplace rate to shipping as well as the subtotal.
+	MarketplaceFeeOnShipping bool
+
+	// ProcessingRate is the payment processing rate, applied to the order total.
+	ProcessingRate float64
+
+	// ProcessingFixedCents is a flat payment processing fee charged per order.
+	ProcessingFixedCents int
+}
+
+// FeeBreakdown itemizes the fees and net proceeds for an order.
+type FeeBreakdown struct {
+	SubtotalCents int
+	ShippingCents int
+	TotalCents    int
+
+	MarketplaceFeeCents int
+	ProcessingFeeCents  int
+	TotalFeeCents       int
+
+	// ShippingRecoveryCents is the shipping charged to the buyer that is paid out to the seller.
+	ShippingRecoveryCents int
+
+	// NetCents is the total less all fees.
+	NetCents int
+
+	// ReportedFeeCents is the fee reported by the API (zero for projections).
+	ReportedFeeCents int
+
+	// UnexplainedFeeCents is the reported fee not accounted for by the schedule.
+	UnexplainedFeeCents int
+}
+
+// Breakdown itemizes the fees for an existing order payment and compares the
+// result with the fee reported by the API.
+func (s FeeSchedule) Breakdown(p OrderPayment) FeeBreakdown {
+	b := s.compute(p.SubtotalCents, p.ShippingCents, p.TotalCents)
+	b.ReportedFeeCents = p.FeeCents
+	b.UnexplainedFeeCents = p.FeeCents - b.TotalFeeCents
+	return b
+}
+
+// Project estimates the fees and net proceeds for a hypothetical sale.
+//
+// Example:
+//
+//	schedule := manapool.FeeSchedule{MarketplaceRate: 0.079, ProcessingRate: 0.029, ProcessingFixedCents: 30}
+//	projection := schedule.Project(2500, 150)
+//	fmt.Printf("Net on a $25.00 sale: $%.2f\n", float64(projection.NetCents)/100)
+func (s FeeSchedule) Project(priceCents, shippingCents int) FeeBreakdown {
+	return s.compute(priceCents, shippingCents, priceCents+shippingCents)
+}
+
+// PriceForNet returns the lowest sale price in cents whose projected net,
+// excluding shipping recovery, is at least targetNetCents. It returns -1 if
+// no price up to math.MaxInt32 cents reaches the target.
+func (s FeeSchedule) PriceForNet(targetNetCents, shippingCents int) int {
+	if targetNetCents <= 0 {
+		return 0
+	}
+	netOf := func(price int) int {
+		b := s.Project(price, shippingCents)
+		return b.NetCents - b.ShippingRecoveryCents
+	}
+
+	lo, hi := 0, math.MaxInt32
+	if netOf(hi) < targetNetCents {
+		return -1
+	}
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if netOf(mid) >= targetNetCents {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+func (s FeeSchedule) compute(subtotalCents, shippingCents, totalCents int) FeeBreakdown {
+	marketplaceBase := subtotalCents
+	if s.MarketplaceFeeOnShipping {
+		marketplaceBase += shippingCents
+	}
+
+	b := FeeBreakdown{
+		SubtotalCents:         subtotalCents,
+		ShippingCents:         shippingCents,
+		TotalCents:            totalCents,
+		MarketplaceFeeCents:   roundCents(float64(marketplaceBase)*s.MarketplaceRate) + s.MarketplaceFixedCents,
+		ProcessingFeeCents:    roundCents(float64(totalCents)*s.ProcessingRate) + s.ProcessingFixedCents,
+		ShippingRecoveryCents: shippingCents,
+	}
+	b.TotalFeeCents = b.MarketplaceFeeCents + b.ProcessingFeeCents
+	b.NetCents = totalCents - b.TotalFeeCents
+	return b
+}
+
+func roundCents(v float64) int {
+	return int(math.Round(v))
+}
@@ -0,0 +1,59 @@
+package manapool
+
+import "testing"
+
+func TestFeeSchedule_Breakdown(t *testing.T) {
+	schedule := FeeSchedule{MarketplaceRate: 0.05, ProcessingRate: 0.03, ProcessingFixedCents: 30}
+
+	b := schedule.Breakdown(OrderPayment{SubtotalCents: 1000, ShippingCents: 100, TotalCents: 1100, FeeCents: 120, NetCents: 980})
+	if b.MarketplaceFeeCents != 50 {
+		t.Errorf("marketplace fee = %d, want 50", b.MarketplaceFeeCents)
+	}
+	if b.ProcessingFeeCents != 63 {
+		t.Errorf("processing fee = %d, want 63", b.ProcessingFeeCents)
+	}
+	if b.TotalFeeCents != 113 || b.NetCents != 987 {
+		t.Errorf("total fee = %d, net = %d, want 113 and 987", b.TotalFeeCents, b.NetCents)
+	}
+	if b.ShippingRecoveryCents != 100 {
+		t.Errorf("shipping recovery = %d, want 100", b.ShippingRecoveryCents)
+	}
+	if b.ReportedFeeCents != 120 || b.UnexplainedFeeCents != 7 {
+		t.Errorf("reported = %d, unexplained = %d, want 120 and 7", b.ReportedFeeCents, b.UnexplainedFeeCents)
+	}
+
+	schedule.MarketplaceFeeOnShipping = true
+	schedule.MarketplaceFixedCents = 10
+	if got := schedule.Breakdown(OrderPayment{SubtotalCents: 1000, ShippingCents: 100, TotalCents: 1100}).MarketplaceFeeCents; got != 65 {
+		t.Errorf("marketplace fee on shipping = %d, want 65", got)
+	}
+}
+
+func TestFeeSchedule_Project(t *testing.T) {
+	schedule := FeeSchedule{MarketplaceRate: 0.1}
+	p := schedule.Project(2500, 0)
+	if p.TotalCents != 2500 || p.TotalFeeCents != 250 || p.NetCents != 2250 || p.ReportedFeeCents != 0 {
+		t.Fatalf("unexpected projection: %+v", p)
+	}
+}
+
+func TestFeeSchedule_PriceForNet(t *testing.T) {
+	schedule := FeeSchedule{MarketplaceRate: 0.1, ProcessingRate: 0.03, ProcessingFixedCents: 30}
+
+	price := schedule.PriceForNet(1000, 100)
+	p := schedule.Project(price, 100)
+	if p.NetCents-p.ShippingRecoveryCents < 1000 {
+		t.Fatalf("price %d nets %d, want >= 1000", price, p.NetCents-p.ShippingRecoveryCents)
+	}
+	lower := schedule.Project(price-1, 100)
+	if lower.NetCents-lower.ShippingRecoveryCents >= 1000 {
+		t.Fatalf("price %d is not the minimum", price)
+	}
+
+	if got := schedule.PriceForNet(0, 0); got != 0 {
+		t.Errorf("PriceForNet(0) = %d, want 0", got)
+	}
+	if got := (FeeSchedule{MarketplaceRate: 1}).PriceForNet(100, 0); got != -1 {
+		t.Errorf("PriceForNet with 100%% fee = %d, want -1", got)
+	}
+}
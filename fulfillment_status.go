@@ -0,0 +1,118 @@
+package manapool
+
+import "fmt"
+
+// FulfillmentStatus is the status of an order fulfillment.
+type FulfillmentStatus string
+
+// Fulfillment statuses accepted and returned by the API.
+const (
+	FulfillmentStatusError      FulfillmentStatus = "error"
+	FulfillmentStatusProcessing FulfillmentStatus = "processing"
+	FulfillmentStatusShipped    FulfillmentStatus = "shipped"
+	FulfillmentStatusDelivered  FulfillmentStatus = "delivered"
+	FulfillmentStatusRefunded   FulfillmentStatus = "refunded"
+	FulfillmentStatusReplaced   FulfillmentStatus = "replaced"
+)
+
+// fulfillmentTransitions lists the statuses reachable from each status.
+// The empty status represents an order without any fulfillment yet. Orders
+// handed over without tracking may go straight from processing to delivered.
+var fulfillmentTransitions = map[FulfillmentStatus][]FulfillmentStatus{
+	"": {
+		FulfillmentStatusProcessing, FulfillmentStatusShipped,
+		FulfillmentStatusError, FulfillmentStatusRefunded, FulfillmentStatusReplaced,
+	},
+	FulfillmentStatusProcessing: {
+		FulfillmentStatusShipped, FulfillmentStatusDelivered,
+		FulfillmentStatusError, FulfillmentStatusRefunded, FulfillmentStatusReplaced,
+	},
+	FulfillmentStatusShipped: {
+		FulfillmentStatusDelivered, FulfillmentStatusError, FulfillmentStatusRefunded, FulfillmentStatusReplaced,
+	},
+	FulfillmentStatusDelivered: {
+		FulfillmentStatusRefunded, FulfillmentStatusReplaced,
+	},
+	FulfillmentStatusError: {
+		FulfillmentStatusProcessing, FulfillmentStatusShipped, FulfillmentStatusRefunded, FulfillmentStatusReplaced,
+	},
+	FulfillmentStatusRefunded: {},
+	FulfillmentStatusReplaced: {},
+}
+
+// IsValid reports whether s is a status known to the API.
+func (s FulfillmentStatus) IsValid() bool {
+	if s == "" {
+		return false
+	}
+	_, ok := fulfillmentTransitions[s]
+	return ok
+}
+
+// IsTerminal reports whether no further transitions are possible from s.
+func (s FulfillmentStatus) IsTerminal() bool {
+	return s == FulfillmentStatusRefunded || s == FulfillmentStatusReplaced
+}
+
+// String implements fmt.Stringer.
+func (s FulfillmentStatus) String() string {
+	return string(s)
+}
+
+// CanTransitionTo reports whether a fulfillment may move from s to next.
+// Re-sending the current status (for example, to add tracking details) is allowed.
+func (s FulfillmentStatus) CanTransitionTo(next FulfillmentStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range fulfillmentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateFulfillmentTransition returns a ValidationError if a fulfillment
+// cannot move from the current status to next. Pass an empty current status
+// for orders that have no fulfillment yet.
+func ValidateFulfillmentTransition(current, next FulfillmentStatus) error {
+	if current != "" && !current.IsValid() {
+		return NewValidationError("status", fmt.Sprintf("unknown current fulfillment status %q", current))
+	}
+	if !next.IsValid() {
+		return NewValidationError("status", fmt.Sprintf("unknown fulfillment status %q", next))
+	}
+	if !current.CanTransitionTo(next) {
+		return NewValidationError("status", fmt.Sprintf("cannot transition fulfillment from %q to %q", current, next))
+	}
+	return nil
+}
+
+// Validate checks that the fulfillment request is internally consistent.
+// It rejects unknown statuses, timestamps that are out of order, and
+// delivery timestamps on fulfillments that have not been delivered.
+func (r OrderFulfillmentRequest) Validate() error {
+	var status FulfillmentStatus
+	if r.Status != nil {
+		status = FulfillmentStatus(*r.Status)
+		if !status.IsValid() {
+			return NewValidationError("status", fmt.Sprintf("unknown fulfillment status %q", status))
+		}
+	}
+
+	if r.DeliveredAt != nil && (status == FulfillmentStatusProcessing || status == FulfillmentStatusShipped) {
+		return NewValidationError("delivered_at", fmt.Sprintf("delivered_at cannot be set when status is %q", status))
+	}
+	if r.InTransitAt != nil && status == FulfillmentStatusProcessing {
+		return NewValidationError("in_transit_at", "in_transit_at cannot be set when status is \"processing\"")
+	}
+	if r.InTransitAt != nil && r.DeliveredAt != nil && r.DeliveredAt.Before(r.InTransitAt.Time) {
+		return NewValidationError("delivered_at", "delivered_at cannot be before in_transit_at")
+	}
+	if r.InTransitAt != nil && r.EstimatedDeliveryAt != nil && r.EstimatedDeliveryAt.Before(r.InTransitAt.Time) {
+		return NewValidationError("estimated_delivery_at", "estimated_delivery_at cannot be before in_transit_at")
+	}
+
+	return nil
+}
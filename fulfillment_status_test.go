@@ -0,0 +1,178 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFulfillmentStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from FulfillmentStatus
+		to   FulfillmentStatus
+		want bool
+	}{
+		{"", FulfillmentStatusProcessing, true},
+		{FulfillmentStatusProcessing, FulfillmentStatusShipped, true},
+		{FulfillmentStatusShipped, FulfillmentStatusDelivered, true},
+		{FulfillmentStatusShipped, FulfillmentStatusShipped, true},
+		{FulfillmentStatusProcessing, FulfillmentStatusDelivered, true},
+		{"", FulfillmentStatusDelivered, false},
+		{FulfillmentStatusDelivered, FulfillmentStatusShipped, false},
+		{FulfillmentStatusDelivered, FulfillmentStatusRefunded, true},
+		{FulfillmentStatusRefunded, FulfillmentStatusProcessing, false},
+		{FulfillmentStatusError, FulfillmentStatusShipped, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFulfillmentStatus_Helpers(t *testing.T) {
+	if !FulfillmentStatusShipped.IsValid() || FulfillmentStatus("lost").IsValid() || FulfillmentStatus("").IsValid() {
+		t.Error("IsValid mismatch")
+	}
+	if !FulfillmentStatusRefunded.IsTerminal() || FulfillmentStatusShipped.IsTerminal() {
+		t.Error("IsTerminal mismatch")
+	}
+	if FulfillmentStatusDelivered.String() != "delivered" {
+		t.Errorf("String = %q", FulfillmentStatusDelivered.String())
+	}
+}
+
+func TestValidateFulfillmentTransition(t *testing.T) {
+	var valErr *ValidationError
+	if err := ValidateFulfillmentTransition(FulfillmentStatusProcessing, FulfillmentStatusShipped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateFulfillmentTransition(FulfillmentStatusShipped, FulfillmentStatusProcessing); !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if err := ValidateFulfillmentTransition("bogus", FulfillmentStatusShipped); !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError for unknown current, got %v", err)
+	}
+	if err := ValidateFulfillmentTransition("", "bogus"); !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError for unknown next, got %v", err)
+	}
+}
+
+func TestOrderFulfillmentRequest_Validate(t *testing.T) {
+	status := func(s FulfillmentStatus) *string {
+		v := string(s)
+		return &v
+	}
+	at := func(day int) *Timestamp {
+		return &Timestamp{Time: time.Date(2024, 4, day, 0, 0, 0, 0, time.UTC)}
+	}
+
+	tests := []struct {
+		name    string
+		req     OrderFulfillmentRequest
+		wantErr bool
+	}{
+		{"empty", OrderFulfillmentRequest{}, false},
+		{"shipped", OrderFulfillmentRequest{Status: status(FulfillmentStatusShipped), InTransitAt: at(1), EstimatedDeliveryAt: at(3)}, false},
+		{"delivered", OrderFulfillmentRequest{Status: status(FulfillmentStatusDelivered), InTransitAt: at(1), DeliveredAt: at(2)}, false},
+		{"unknown status", OrderFulfillmentRequest{Status: status("lost")}, true},
+		{"delivered before shipped", OrderFulfillmentRequest{Status: status(FulfillmentStatusDelivered), InTransitAt: at(5), DeliveredAt: at(2)}, true},
+		{"delivered_at while shipped", OrderFulfillmentRequest{Status: status(FulfillmentStatusShipped), DeliveredAt: at(2)}, true},
+		{"in_transit_at while processing", OrderFulfillmentRequest{Status: status(FulfillmentStatusProcessing), InTransitAt: at(2)}, true},
+		{"estimate before transit", OrderFulfillmentRequest{Status: status(FulfillmentStatusShipped), InTransitAt: at(5), EstimatedDeliveryAt: at(2)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Fatalf("expected ValidationError, got %T", err)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_UpdateFulfillment_ValidatesBeforeRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	bad := "lost"
+	var valErr *ValidationError
+
+	if _, err := client.UpdateOrderFulfillment(ctx, "abc", OrderFulfillmentRequest{Status: &bad}); !errors.As(err, &valErr) {
+		t.Fatalf("UpdateOrderFulfillment: expected ValidationError, got %v", err)
+	}
+	if _, err := client.UpdateSellerOrderFulfillment(ctx, "abc", OrderFulfillmentRequest{Status: &bad}); !errors.As(err, &valErr) {
+		t.Fatalf("UpdateSellerOrderFulfillment: expected ValidationError, got %v", err)
+	}
+}
+
+func TestClient_UpdateFulfillment_ValidatesTransition(t *testing.T) {
+	var gets, puts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && (r.URL.Path == "/orders/abc" || r.URL.Path == "/seller/orders/abc"):
+			gets++
+			_, _ = w.Write([]byte(`{"order":{"id":"abc","latest_fulfillment_status":"delivered"}}`))
+		case r.Method == http.MethodPut:
+			puts++
+			_, _ = w.Write([]byte(`{"fulfillment":{"status":"refunded"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	shipped := string(FulfillmentStatusShipped)
+	refunded := string(FulfillmentStatusRefunded)
+	var valErr *ValidationError
+
+	if _, err := client.UpdateOrderFulfillment(ctx, "abc", OrderFulfillmentRequest{Status: &shipped}); !errors.As(err, &valErr) {
+		t.Fatalf("UpdateOrderFulfillment: expected ValidationError, got %v", err)
+	}
+	if _, err := client.UpdateSellerOrderFulfillment(ctx, "abc", OrderFulfillmentRequest{Status: &shipped}); !errors.As(err, &valErr) {
+		t.Fatalf("UpdateSellerOrderFulfillment: expected ValidationError, got %v", err)
+	}
+	if puts != 0 {
+		t.Fatalf("sent %d updates for rejected transitions", puts)
+	}
+	if _, err := client.UpdateSellerOrderFulfillment(ctx, "abc", OrderFulfillmentRequest{Status: &refunded}); err != nil {
+		t.Fatalf("UpdateSellerOrderFulfillment error: %v", err)
+	}
+	if gets != 3 {
+		t.Errorf("fetched the order %d times, want 3", gets)
+	}
+
+	// A known current status skips the fetch.
+	if _, err := client.UpdateOrderFulfillmentFrom(ctx, "abc", FulfillmentStatusProcessing, OrderFulfillmentRequest{Status: &shipped}); err != nil {
+		t.Fatalf("UpdateOrderFulfillmentFrom error: %v", err)
+	}
+	if _, err := client.UpdateSellerOrderFulfillmentFrom(ctx, "abc", FulfillmentStatusRefunded, OrderFulfillmentRequest{Status: &shipped}); !errors.As(err, &valErr) {
+		t.Fatalf("UpdateSellerOrderFulfillmentFrom: expected ValidationError, got %v", err)
+	}
+	// A current status this package does not know does not block updates.
+	if _, err := client.UpdateSellerOrderFulfillmentFrom(ctx, "abc", "on_hold", OrderFulfillmentRequest{Status: &shipped}); err != nil {
+		t.Fatalf("UpdateSellerOrderFulfillmentFrom with unknown status error: %v", err)
+	}
+	if gets != 3 || puts != 3 {
+		t.Errorf("gets = %d, puts = %d; want 3 and 3", gets, puts)
+	}
+}
@@ -0,0 +1,159 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGCSURL is the Cloud Storage API base URL used by GCSStore.
+const DefaultGCSURL = "https://storage.googleapis.com/"
+
+// GCSStore is an ObjectStore backed by a Google Cloud Storage bucket, using
+// the JSON API. Like SheetsClient, it leaves authentication to HTTPClient.
+//
+// Example:
+//
+//	// With golang.org/x/oauth2/google:
+//	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/devstorage.read_write")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	store := &manapool.GCSStore{Bucket: "my-store-backups", HTTPClient: oauth2.NewClient(ctx, creds.TokenSource)}
+type GCSStore struct {
+	// Bucket is the bucket name.
+	Bucket string
+
+	// HTTPClient sends requests and must authenticate them with a token
+	// that can read and write the bucket. It is required.
+	HTTPClient *http.Client
+
+	// URL is the API base URL (default: DefaultGCSURL).
+	URL string
+}
+
+// Put implements ObjectStore.
+func (g *GCSStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	query := url.Values{"uploadType": {"media"}, "name": {key}}
+	resp, err := g.do(ctx, "POST", "upload/storage/v1/b/"+url.PathEscape(g.Bucket)+"/o", query, body, size, contentType)
+	if err != nil {
+		return fmt.Errorf("failed to put %s: %w", key, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// Get implements ObjectStore.
+func (g *GCSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := g.do(ctx, "GET", g.objectPath(key), url.Values{"alt": {"media"}}, nil, 0, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+// List implements ObjectStore.
+func (g *GCSStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	query := url.Values{"prefix": {prefix}, "fields": {"items(name,size,updated),nextPageToken"}}
+	for {
+		resp, err := g.do(ctx, "GET", "storage/v1/b/"+url.PathEscape(g.Bucket)+"/o", query, nil, 0, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+		var result struct {
+			Items []struct {
+				Name    string    `json:"name"`
+				Size    string    `json:"size"`
+				Updated time.Time `json:"updated"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode GCS listing: %w", err)
+		}
+		for _, item := range result.Items {
+			size, _ := strconv.ParseInt(item.Size, 10, 64)
+			objects = append(objects, ObjectInfo{Key: item.Name, Size: size, LastModified: item.Updated})
+		}
+		if result.NextPageToken == "" {
+			return objects, nil
+		}
+		query.Set("pageToken", result.NextPageToken)
+	}
+}
+
+// Delete implements ObjectStore.
+func (g *GCSStore) Delete(ctx context.Context, key string) error {
+	resp, err := g.do(ctx, "DELETE", g.objectPath(key), nil, nil, 0, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+func (g *GCSStore) objectPath(key string) string {
+	return "storage/v1/b/" + url.PathEscape(g.Bucket) + "/o/" + url.PathEscape(key)
+}
+
+// do sends a request to path, relative to the API base URL, and returns the
+// response if its status is successful or the request deleted a missing
+// object.
+func (g *GCSStore) do(ctx context.Context, method, path string, query url.Values, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	if g.HTTPClient == nil {
+		return nil, NewValidationError("HTTPClient", "an authenticated HTTP client is required")
+	}
+	if g.Bucket == "" {
+		return nil, NewValidationError("Bucket", "bucket cannot be empty")
+	}
+
+	base := g.URL
+	if base == "" {
+		base = DefaultGCSURL
+	}
+	endpoint := strings.TrimSuffix(base, "/") + "/" + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.ContentLength = size
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := g.HTTPClient.Do(req)
+	if err != nil {
+		return nil, NewNetworkError("failed to call the Cloud Storage API", err)
+	}
+	// Deleting a missing object is not an error.
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 || method == "DELETE" && resp.StatusCode == http.StatusNotFound {
+		return resp, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var apiErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	message := ""
+	if json.Unmarshal(data, &apiErr) == nil {
+		message = apiErr.Error.Message
+	}
+	return nil, objectStoreError("GCS", resp.StatusCode, message, data)
+}
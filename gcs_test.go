@@ -0,0 +1,98 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGCSStore(t *testing.T) {
+	objects := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/upload/storage/v1/b/backups/o":
+			if query.Get("uploadType") != "media" || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("upload query %v, content type %q", query, r.Header.Get("Content-Type"))
+			}
+			data, _ := io.ReadAll(r.Body)
+			objects[query.Get("name")] = string(data)
+			fmt.Fprint(w, `{}`)
+		case r.Method == "GET" && r.URL.Path == "/storage/v1/b/backups/o":
+			if query.Get("pageToken") == "" {
+				fmt.Fprint(w, `{"items":[{"name":"snap/a.json","size":"5","updated":"2024-05-01T00:00:00.000Z"}],"nextPageToken":"next"}`)
+				return
+			}
+			fmt.Fprint(w, `{"items":[{"name":"snap/b.json","size":"7","updated":"2024-05-02T00:00:00.000Z"}]}`)
+		case strings.HasPrefix(r.URL.Path, "/storage/v1/b/backups/o/"):
+			key := strings.TrimPrefix(r.URL.Path, "/storage/v1/b/backups/o/")
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":404,"message":"No such object: backups/`+key+`"}}`)
+				return
+			}
+			if r.Method == "DELETE" {
+				delete(objects, key)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if query.Get("alt") != "media" {
+				t.Errorf("alt = %q, want media", query.Get("alt"))
+			}
+			fmt.Fprint(w, data)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	store := &GCSStore{Bucket: "backups", HTTPClient: server.Client(), URL: server.URL}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "snap/a.json", strings.NewReader("hello"), 5, "application/json"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	body, err := store.Get(ctx, "snap/a.json")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	_ = body.Close()
+	if string(data) != "hello" {
+		t.Errorf("Get() = %q, want hello", data)
+	}
+
+	listed, err := store.List(ctx, "snap/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed) != 2 || listed[0].Size != 5 || listed[1].Key != "snap/b.json" || listed[1].LastModified.Day() != 2 {
+		t.Errorf("List() = %+v", listed)
+	}
+
+	if err := store.Delete(ctx, "snap/a.json"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "snap/a.json"); err != nil {
+		t.Errorf("Delete() of missing object error = %v", err)
+	}
+	_, err = store.Get(ctx, "snap/a.json")
+	if err == nil || !strings.Contains(err.Error(), "GCS status 404: No such object") {
+		t.Errorf("Get() after Delete error = %v", err)
+	}
+}
+
+func TestGCSStore_Validation(t *testing.T) {
+	ctx := context.Background()
+	if _, err := (&GCSStore{Bucket: "b"}).List(ctx, ""); err == nil {
+		t.Error("List() without HTTP client error = nil")
+	}
+	if _, err := (&GCSStore{HTTPClient: http.DefaultClient}).List(ctx, ""); err == nil {
+		t.Error("List() without bucket error = nil")
+	}
+}
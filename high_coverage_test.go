@@ -61,9 +61,9 @@ func TestHighCoverageImprovements(t *testing.T) {
 	// Test orders with all options
 	t.Run("OrdersWithAllOptions", func(t *testing.T) {
 		opts := OrdersOptions{
-			IsUnfulfilled:   &[]bool{true}[0],
-			IsFulfilled:     &[]bool{false}[0],
-			HasFulfillments: &[]bool{true}[0],
+			IsUnfulfilled:   Bool(true),
+			IsFulfilled:     Bool(false),
+			HasFulfillments: Bool(true),
 			Label:           "test",
 			Limit:           10,
 			Offset:          5,
@@ -241,7 +241,8 @@ func TestUpdateRequestPaths(t *testing.T) {
 	})
 
 	t.Run("PurchasePendingOrder", func(t *testing.T) {
-		_, err := client.PurchasePendingOrder(ctx, "test-id", PurchasePendingOrderRequest{})
+		address := Address{Name: "Jane Doe", Line1: "1 Main St", City: "Portland", State: "OR", PostalCode: "97201", Country: "US"}
+		_, err := client.PurchasePendingOrder(ctx, "test-id", PurchasePendingOrderRequest{BillingAddress: address, ShippingAddress: address})
 		if err != nil {
 			t.Fatalf("PurchasePendingOrder error: %v", err)
 		}
@@ -353,7 +354,7 @@ func TestBulkCreationPaths(t *testing.T) {
 	})
 
 	t.Run("CreateInventoryBulkByProduct", func(t *testing.T) {
-		items := []InventoryBulkItemByProduct{{ProductType: "test", ProductID: "test", PriceCents: 100, Quantity: 1}}
+		items := []InventoryBulkItemByProduct{{ProductType: ProductTypeSingle, ProductID: "test", PriceCents: 100, Quantity: 1}}
 		_, err := client.CreateInventoryBulkByProduct(ctx, items)
 		if err != nil {
 			t.Fatalf("CreateInventoryBulkByProduct error: %v", err)
@@ -0,0 +1,477 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CardIDs holds the identifiers of one card printing across catalogs.
+// Zero fields are unknown.
+type CardIDs struct {
+	MTGJSONID   string `json:"mtgjson_id"`
+	ScryfallID  string `json:"scryfall_id,omitempty"`
+	TCGPlayerID int64  `json:"tcgplayer_id,omitempty"`
+
+	// TCGPlayerEtchedID is the TCGplayer product of the etched foil
+	// printing, for cards TCGplayer lists separately.
+	TCGPlayerEtchedID int64 `json:"tcgplayer_etched_id,omitempty"`
+
+	// CardmarketID is the Cardmarket (MCM) product ID.
+	CardmarketID int64 `json:"cardmarket_id,omitempty"`
+}
+
+// TCGPlayerSKU is a TCGplayer SKU: one condition, finish and language of a
+// TCGplayer product. Its IDs use ManaPool's codes, e.g. "NM", "FO", "EN".
+type TCGPlayerSKU struct {
+	SKU         int64  `json:"sku"`
+	TCGPlayerID int64  `json:"tcgplayer_id"`
+	MTGJSONID   string `json:"mtgjson_id"`
+	ConditionID string `json:"condition_id"`
+	FinishID    string `json:"finish_id"`
+	LanguageID  string `json:"language_id"`
+}
+
+// IDMap converts between the Scryfall, MTGJSON and TCGplayer identifiers of
+// cards, which import and export formats each key cards by differently. Load
+// the cards from MTGJSON's AllIdentifiers.json with LoadIDMap and, to map
+// SKUs, add TcgplayerSkus.json with LoadTCGPlayerSKUs. Both files are large,
+// so save the result with WriteCache and load it next time with
+// ReadIDMapCache.
+//
+// Double-faced cards have an MTGJSON ID per face; lookups by the other IDs
+// return the front face.
+//
+// An IDMap is safe for concurrent lookups once loaded.
+//
+// Example:
+//
+//	ids, err := manapool.LoadIDMap(identifiersFile)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := ids.LoadTCGPlayerSKUs(skusFile); err != nil {
+//	    log.Fatal(err)
+//	}
+//	sku, ok := ids.SKU(scryfallID, "NM", "FO", "EN")
+type IDMap struct {
+	byMTGJSON    map[string]CardIDs
+	byScryfall   map[string]string // Scryfall ID -> MTGJSON ID
+	byTCGPlayer  map[int64]string  // TCGplayer product ID -> MTGJSON ID
+	byCardmarket map[int64]string  // Cardmarket product ID -> MTGJSON ID
+	bySKU        map[int64]TCGPlayerSKU
+	skus         map[int64][]int64 // TCGplayer product ID -> SKUs
+}
+
+// NewIDMap creates an empty ID map.
+func NewIDMap() *IDMap {
+	return &IDMap{
+		byMTGJSON:    make(map[string]CardIDs),
+		byScryfall:   make(map[string]string),
+		byTCGPlayer:  make(map[int64]string),
+		byCardmarket: make(map[int64]string),
+		bySKU:        make(map[int64]TCGPlayerSKU),
+		skus:         make(map[int64][]int64),
+	}
+}
+
+// LoadIDMap reads MTGJSON's AllIdentifiers.json, or any MTGJSON file whose
+// "data" maps card UUIDs to cards. The file is decoded one card at a time,
+// so only the identifiers are held in memory.
+func LoadIDMap(r io.Reader) (*IDMap, error) {
+	m := NewIDMap()
+	err := decodeMTGJSONData(r, func(uuid string, dec *json.Decoder) error {
+		var card struct {
+			Side        string `json:"side"`
+			Identifiers struct {
+				ScryfallID               string `json:"scryfallId"`
+				TCGPlayerProductID       string `json:"tcgplayerProductId"`
+				TCGPlayerEtchedProductID string `json:"tcgplayerEtchedProductId"`
+				McmID                    string `json:"mcmId"`
+			} `json:"identifiers"`
+		}
+		if err := dec.Decode(&card); err != nil {
+			return err
+		}
+		ids := card.Identifiers
+		// Only the front face of a double-faced card claims the shared IDs.
+		m.add(CardIDs{
+			MTGJSONID:         uuid,
+			ScryfallID:        ids.ScryfallID,
+			TCGPlayerID:       parseMTGJSONID(ids.TCGPlayerProductID),
+			TCGPlayerEtchedID: parseMTGJSONID(ids.TCGPlayerEtchedProductID),
+			CardmarketID:      parseMTGJSONID(ids.McmID),
+		}, card.Side == "" || card.Side == "a")
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode MTGJSON identifiers: %w", err)
+	}
+	return m, nil
+}
+
+// LoadTCGPlayerSKUs adds the SKUs in MTGJSON's TcgplayerSkus.json. SKUs in
+// conditions, languages or printings ManaPool does not list are skipped.
+func (m *IDMap) LoadTCGPlayerSKUs(r io.Reader) error {
+	err := decodeMTGJSONData(r, func(uuid string, dec *json.Decoder) error {
+		var skus []struct {
+			Condition string   `json:"condition"`
+			Finishes  []string `json:"finishes"`
+			Language  string   `json:"language"`
+			Printing  string   `json:"printing"`
+			ProductID int64    `json:"productId"`
+			SkuID     int64    `json:"skuId"`
+		}
+		if err := dec.Decode(&skus); err != nil {
+			return err
+		}
+		for _, s := range skus {
+			condition, ok := tcgPlayerConditions[s.Condition]
+			if !ok {
+				continue
+			}
+			language, ok := tcgPlayerLanguages[s.Language]
+			if !ok {
+				continue
+			}
+			finish := "NF"
+			if s.Printing == "FOIL" {
+				finish = "FO"
+			}
+			for _, f := range s.Finishes {
+				if f == "ETCHED" {
+					finish = "EF"
+				}
+			}
+			m.AddSKU(TCGPlayerSKU{
+				SKU:         s.SkuID,
+				TCGPlayerID: s.ProductID,
+				MTGJSONID:   uuid,
+				ConditionID: condition,
+				FinishID:    finish,
+				LanguageID:  language,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decode MTGJSON TCGplayer SKUs: %w", err)
+	}
+	return nil
+}
+
+// idMapCache is the file format of WriteCache.
+type idMapCache struct {
+	Cards []CardIDs      `json:"cards"`
+	SKUs  []TCGPlayerSKU `json:"skus"`
+}
+
+// ReadIDMapCache reads an ID map saved by WriteCache.
+func ReadIDMapCache(r io.Reader) (*IDMap, error) {
+	var cache idMapCache
+	if err := json.NewDecoder(r).Decode(&cache); err != nil {
+		return nil, fmt.Errorf("failed to decode ID map cache: %w", err)
+	}
+	m := NewIDMap()
+	for _, ids := range cache.Cards {
+		m.Add(ids)
+	}
+	for _, sku := range cache.SKUs {
+		m.AddSKU(sku)
+	}
+	return m, nil
+}
+
+// WriteCache saves the map in a compact form for ReadIDMapCache, a small
+// fraction of the size of the MTGJSON files it was loaded from.
+func (m *IDMap) WriteCache(w io.Writer) error {
+	cache := idMapCache{Cards: make([]CardIDs, 0, len(m.byMTGJSON)), SKUs: make([]TCGPlayerSKU, 0, len(m.bySKU))}
+	primary := make(map[string]bool, len(m.byMTGJSON))
+	for _, uuid := range m.byScryfall {
+		primary[uuid] = true
+	}
+	for _, index := range []map[int64]string{m.byTCGPlayer, m.byCardmarket} {
+		for _, uuid := range index {
+			primary[uuid] = true
+		}
+	}
+	for _, ids := range m.byMTGJSON {
+		cache.Cards = append(cache.Cards, ids)
+	}
+	// Cards that own shared IDs go last so they win them again when read
+	// back; otherwise the order is fixed so caches can be diffed.
+	sort.Slice(cache.Cards, func(i, j int) bool {
+		a, b := cache.Cards[i], cache.Cards[j]
+		if primary[a.MTGJSONID] != primary[b.MTGJSONID] {
+			return !primary[a.MTGJSONID]
+		}
+		return a.MTGJSONID < b.MTGJSONID
+	})
+	for _, sku := range m.bySKU {
+		cache.SKUs = append(cache.SKUs, sku)
+	}
+	sort.Slice(cache.SKUs, func(i, j int) bool {
+		a, b := cache.SKUs[i], cache.SKUs[j]
+		if a.TCGPlayerID != b.TCGPlayerID {
+			return a.TCGPlayerID < b.TCGPlayerID
+		}
+		return a.SKU < b.SKU
+	})
+	if err := json.NewEncoder(w).Encode(cache); err != nil {
+		return fmt.Errorf("failed to write ID map cache: %w", err)
+	}
+	return nil
+}
+
+// Add records a card's identifiers. Its Scryfall and TCGplayer IDs map to
+// it from now on, replacing any card added before with the same IDs.
+func (m *IDMap) Add(ids CardIDs) {
+	m.add(ids, true)
+}
+
+func (m *IDMap) add(ids CardIDs, primary bool) {
+	if ids.MTGJSONID == "" {
+		return
+	}
+	ids.MTGJSONID = strings.ToLower(ids.MTGJSONID)
+	ids.ScryfallID = strings.ToLower(ids.ScryfallID)
+	m.byMTGJSON[ids.MTGJSONID] = ids
+	claim := func(present bool) bool { return primary || !present }
+	if ids.ScryfallID != "" {
+		if _, ok := m.byScryfall[ids.ScryfallID]; claim(ok) {
+			m.byScryfall[ids.ScryfallID] = ids.MTGJSONID
+		}
+	}
+	for _, product := range []int64{ids.TCGPlayerID, ids.TCGPlayerEtchedID} {
+		if product > 0 {
+			if _, ok := m.byTCGPlayer[product]; claim(ok) {
+				m.byTCGPlayer[product] = ids.MTGJSONID
+			}
+		}
+	}
+	if ids.CardmarketID > 0 {
+		if _, ok := m.byCardmarket[ids.CardmarketID]; claim(ok) {
+			m.byCardmarket[ids.CardmarketID] = ids.MTGJSONID
+		}
+	}
+}
+
+// AddSKU records a TCGplayer SKU. Adding a SKU again replaces it.
+func (m *IDMap) AddSKU(sku TCGPlayerSKU) {
+	if sku.SKU <= 0 || sku.TCGPlayerID <= 0 {
+		return
+	}
+	sku.MTGJSONID = strings.ToLower(sku.MTGJSONID)
+	if _, ok := m.bySKU[sku.SKU]; !ok {
+		m.skus[sku.TCGPlayerID] = append(m.skus[sku.TCGPlayerID], sku.SKU)
+	}
+	m.bySKU[sku.SKU] = sku
+}
+
+// Len returns the number of cards in the map.
+func (m *IDMap) Len() int {
+	return len(m.byMTGJSON)
+}
+
+// ByMTGJSONID returns the identifiers of the card with an MTGJSON UUID.
+func (m *IDMap) ByMTGJSONID(mtgjsonID string) (CardIDs, bool) {
+	ids, ok := m.byMTGJSON[strings.ToLower(mtgjsonID)]
+	return ids, ok
+}
+
+// ByScryfallID returns the identifiers of the card with a Scryfall ID.
+func (m *IDMap) ByScryfallID(scryfallID string) (CardIDs, bool) {
+	return m.ByMTGJSONID(m.byScryfall[strings.ToLower(scryfallID)])
+}
+
+// ByTCGPlayerID returns the identifiers of the card with a TCGplayer
+// product ID, which may be its etched foil product.
+func (m *IDMap) ByTCGPlayerID(tcgplayerID int64) (CardIDs, bool) {
+	return m.ByMTGJSONID(m.byTCGPlayer[tcgplayerID])
+}
+
+// BySKU returns a TCGplayer SKU and the identifiers of its card.
+func (m *IDMap) BySKU(sku int64) (TCGPlayerSKU, CardIDs, bool) {
+	s, ok := m.bySKU[sku]
+	if !ok {
+		return TCGPlayerSKU{}, CardIDs{}, false
+	}
+	ids, ok := m.ByTCGPlayerID(s.TCGPlayerID)
+	if !ok {
+		ids, ok = m.ByMTGJSONID(s.MTGJSONID)
+	}
+	return s, ids, ok
+}
+
+// SKUs returns the SKUs of a TCGplayer product in the order they were added.
+func (m *IDMap) SKUs(tcgplayerID int64) []TCGPlayerSKU {
+	skus := make([]TCGPlayerSKU, 0, len(m.skus[tcgplayerID]))
+	for _, sku := range m.skus[tcgplayerID] {
+		skus = append(skus, m.bySKU[sku])
+	}
+	return skus
+}
+
+// SKU returns the TCGplayer SKU of a card by Scryfall ID in a ManaPool
+// condition, finish and language, such as "NM", "FO" and "EN". An empty
+// language is English.
+func (m *IDMap) SKU(scryfallID, conditionID, finishID, languageID string) (int64, bool) {
+	ids, ok := m.ByScryfallID(scryfallID)
+	if !ok {
+		return 0, false
+	}
+	if languageID == "" {
+		languageID = "EN"
+	}
+	products := []int64{ids.TCGPlayerID}
+	if strings.EqualFold(finishID, "EF") && ids.TCGPlayerEtchedID > 0 {
+		products = []int64{ids.TCGPlayerEtchedID, ids.TCGPlayerID}
+	}
+	for _, product := range products {
+		for _, sku := range m.SKUs(product) {
+			if strings.EqualFold(sku.ConditionID, conditionID) && strings.EqualFold(sku.FinishID, finishID) &&
+				strings.EqualFold(sku.LanguageID, languageID) {
+				return sku.SKU, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// FillSingle sets whichever of single's Scryfall, MTGJSON and TCGplayer IDs
+// are missing from the one it has, and reports whether the card was found.
+func (m *IDMap) FillSingle(single *Single) bool {
+	ids, ok := m.ByScryfallID(single.ScryfallID)
+	if !ok {
+		ids, ok = m.ByMTGJSONID(single.MTGJsonID)
+	}
+	if !ok && single.TCGPlayerID != nil {
+		ids, ok = m.ByTCGPlayerID(*single.TCGPlayerID)
+	}
+	if !ok {
+		return false
+	}
+	if single.ScryfallID == "" {
+		single.ScryfallID = ids.ScryfallID
+	}
+	if single.MTGJsonID == "" {
+		single.MTGJsonID = ids.MTGJSONID
+	}
+	if single.TCGPlayerID == nil && ids.TCGPlayerID > 0 {
+		single.TCGPlayerID = Int64(ids.TCGPlayerID)
+	}
+	return true
+}
+
+// Cardmarket returns a CardmarketProductResolver backed by the map's
+// Cardmarket IDs.
+//
+// Example:
+//
+//	articles, skipped, err := manapool.ToCardmarketArticles(ctx, items, ids.Cardmarket(), 1.08)
+func (m *IDMap) Cardmarket() CardmarketProductResolver {
+	return idMapCardmarket{m}
+}
+
+type idMapCardmarket struct{ m *IDMap }
+
+func (c idMapCardmarket) ProductID(_ context.Context, scryfallID string) (int64, bool, error) {
+	ids, ok := c.m.ByScryfallID(scryfallID)
+	return ids.CardmarketID, ok && ids.CardmarketID > 0, nil
+}
+
+func (c idMapCardmarket) ScryfallID(_ context.Context, productID int64) (string, bool, error) {
+	ids, ok := c.m.ByMTGJSONID(c.m.byCardmarket[productID])
+	return ids.ScryfallID, ok && ids.ScryfallID != "", nil
+}
+
+// tcgPlayerConditions maps MTGJSON's TCGplayer SKU conditions to ManaPool
+// condition IDs.
+var tcgPlayerConditions = map[string]string{
+	"NEAR MINT":         "NM",
+	"LIGHTLY PLAYED":    "LP",
+	"MODERATELY PLAYED": "MP",
+	"HEAVILY PLAYED":    "HP",
+	"DAMAGED":           "DMG",
+}
+
+// tcgPlayerLanguages maps MTGJSON's TCGplayer SKU languages to ManaPool
+// language IDs.
+var tcgPlayerLanguages = map[string]string{
+	"ENGLISH":             "EN",
+	"CHINESE SIMPLIFIED":  "ZHS",
+	"CHINESE TRADITIONAL": "ZHT",
+	"FRENCH":              "FR",
+	"GERMAN":              "DE",
+	"ITALIAN":             "IT",
+	"JAPANESE":            "JA",
+	"KOREAN":              "KO",
+	"PORTUGUESE":          "PT",
+	"RUSSIAN":             "RU",
+	"SPANISH":             "ES",
+}
+
+// parseMTGJSONID parses a numeric identifier, which MTGJSON stores as a
+// string, returning 0 if it is missing or invalid.
+func parseMTGJSONID(s string) int64 {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
+
+// decodeMTGJSONData streams the "data" object of an MTGJSON file, calling
+// each with every key and a decoder positioned at its value, which each
+// must decode. Other top-level fields are skipped.
+func decodeMTGJSONData(r io.Reader, each func(key string, dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if token != "data" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := expectJSONDelim(dec, '{'); err != nil {
+			return err
+		}
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			if err := each(key, dec); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		if err := expectJSONDelim(dec, '}'); err != nil {
+			return err
+		}
+	}
+	return expectJSONDelim(dec, '}')
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	idmapIdentifiersJSON = `{
+	"meta": {"date": "2024-05-01", "version": "5.2.2"},
+	"data": {
+		"uuid-sol": {"name": "Sol Ring", "identifiers": {"scryfallId": "` + solRingScryfallID + `", "tcgplayerProductId": "1001", "tcgplayerEtchedProductId": "1002", "mcmId": "5001"}},
+		"uuid-back": {"name": "Insectile Aberration", "side": "b", "identifiers": {"scryfallId": "dfc-scryfall", "tcgplayerProductId": "2001"}},
+		"UUID-FRONT": {"name": "Delver of Secrets", "side": "a", "identifiers": {"scryfallId": "DFC-SCRYFALL", "tcgplayerProductId": "2001"}},
+		"uuid-bare": {"name": "Token"}
+	}
+}`
+	idmapSKUsJSON = `{
+	"meta": {},
+	"data": {
+		"uuid-sol": [
+			{"condition": "NEAR MINT", "language": "ENGLISH", "printing": "NON FOIL", "productId": 1001, "skuId": 9001},
+			{"condition": "NEAR MINT", "language": "ENGLISH", "printing": "FOIL", "productId": 1001, "skuId": 9002},
+			{"condition": "LIGHTLY PLAYED", "language": "JAPANESE", "printing": "FOIL", "productId": 1001, "skuId": 9003},
+			{"condition": "NEAR MINT", "language": "ENGLISH", "printing": "FOIL", "finishes": ["ETCHED"], "productId": 1002, "skuId": 9004},
+			{"condition": "UNOPENED", "language": "ENGLISH", "printing": "NON FOIL", "productId": 1001, "skuId": 9005}
+		]
+	}
+}`
+)
+
+func loadTestIDMap(t *testing.T) *IDMap {
+	t.Helper()
+	ids, err := LoadIDMap(strings.NewReader(idmapIdentifiersJSON))
+	if err != nil {
+		t.Fatalf("LoadIDMap() error = %v", err)
+	}
+	if err := ids.LoadTCGPlayerSKUs(strings.NewReader(idmapSKUsJSON)); err != nil {
+		t.Fatalf("LoadTCGPlayerSKUs() error = %v", err)
+	}
+	return ids
+}
+
+func TestIDMap_Lookups(t *testing.T) {
+	ids := loadTestIDMap(t)
+	if ids.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", ids.Len())
+	}
+
+	sol := CardIDs{MTGJSONID: "uuid-sol", ScryfallID: solRingScryfallID, TCGPlayerID: 1001, TCGPlayerEtchedID: 1002, CardmarketID: 5001}
+	if got, ok := ids.ByScryfallID(strings.ToUpper(solRingScryfallID)); !ok || got != sol {
+		t.Errorf("ByScryfallID() = %+v, %v", got, ok)
+	}
+	if got, ok := ids.ByTCGPlayerID(1002); !ok || got != sol {
+		t.Errorf("ByTCGPlayerID(etched) = %+v, %v", got, ok)
+	}
+	if got, ok := ids.ByMTGJSONID("UUID-SOL"); !ok || got != sol {
+		t.Errorf("ByMTGJSONID() = %+v, %v", got, ok)
+	}
+
+	// The front face wins shared IDs even when the back face comes first.
+	if got, ok := ids.ByScryfallID("dfc-scryfall"); !ok || got.MTGJSONID != "uuid-front" {
+		t.Errorf("ByScryfallID(dfc) = %+v, %v", got, ok)
+	}
+	if got, ok := ids.ByTCGPlayerID(2001); !ok || got.MTGJSONID != "uuid-front" {
+		t.Errorf("ByTCGPlayerID(dfc) = %+v, %v", got, ok)
+	}
+	if _, ok := ids.ByScryfallID("unknown"); ok {
+		t.Error("ByScryfallID(unknown) ok = true")
+	}
+
+	sku, card, ok := ids.BySKU(9003)
+	if !ok || card != sol || sku != (TCGPlayerSKU{SKU: 9003, TCGPlayerID: 1001, MTGJSONID: "uuid-sol", ConditionID: "LP", FinishID: "FO", LanguageID: "JA"}) {
+		t.Errorf("BySKU() = %+v, %+v, %v", sku, card, ok)
+	}
+	if _, _, ok := ids.BySKU(9005); ok {
+		t.Error("BySKU() found SKU in an unlisted condition")
+	}
+	if got := len(ids.SKUs(1001)); got != 3 {
+		t.Errorf("SKUs(1001) returned %d, want 3", got)
+	}
+}
+
+func TestIDMap_SKU(t *testing.T) {
+	ids := loadTestIDMap(t)
+	tests := []struct {
+		condition, finish, language string
+		want                        int64
+	}{
+		{"NM", "NF", "EN", 9001},
+		{"nm", "FO", "", 9002},
+		{"LP", "FO", "JA", 9003},
+		{"NM", "EF", "EN", 9004},
+		{"MP", "NF", "EN", 0},
+	}
+	for _, tt := range tests {
+		got, ok := ids.SKU(solRingScryfallID, tt.condition, tt.finish, tt.language)
+		if got != tt.want || ok != (tt.want != 0) {
+			t.Errorf("SKU(%s, %s, %s) = %d, %v; want %d", tt.condition, tt.finish, tt.language, got, ok, tt.want)
+		}
+	}
+}
+
+func TestIDMap_FillSingle(t *testing.T) {
+	ids := loadTestIDMap(t)
+
+	single := &Single{TCGPlayerID: Int64(1001)}
+	if !ids.FillSingle(single) {
+		t.Fatal("FillSingle() = false")
+	}
+	if single.ScryfallID != solRingScryfallID || single.MTGJsonID != "uuid-sol" {
+		t.Errorf("FillSingle() = %+v", single)
+	}
+
+	single = &Single{ScryfallID: solRingScryfallID}
+	ids.FillSingle(single)
+	if single.TCGPlayerID == nil || *single.TCGPlayerID != 1001 {
+		t.Errorf("FillSingle() TCGPlayerID = %v", single.TCGPlayerID)
+	}
+
+	if ids.FillSingle(&Single{ScryfallID: "unknown"}) {
+		t.Error("FillSingle(unknown) = true")
+	}
+}
+
+func TestIDMap_Cardmarket(t *testing.T) {
+	resolver := loadTestIDMap(t).Cardmarket()
+	ctx := context.Background()
+
+	if id, ok, err := resolver.ProductID(ctx, solRingScryfallID); err != nil || !ok || id != 5001 {
+		t.Errorf("ProductID() = %d, %v, %v", id, ok, err)
+	}
+	if id, ok, _ := resolver.ScryfallID(ctx, 5001); !ok || id != solRingScryfallID {
+		t.Errorf("ScryfallID() = %q, %v", id, ok)
+	}
+	if _, ok, _ := resolver.ProductID(ctx, "dfc-scryfall"); ok {
+		t.Error("ProductID() found a card without a Cardmarket ID")
+	}
+}
+
+func TestIDMap_Cache(t *testing.T) {
+	ids := loadTestIDMap(t)
+
+	var buf bytes.Buffer
+	if err := ids.WriteCache(&buf); err != nil {
+		t.Fatalf("WriteCache() error = %v", err)
+	}
+	if buf.Len() >= len(idmapIdentifiersJSON)+len(idmapSKUsJSON) {
+		t.Errorf("cache is %d bytes, not smaller than its sources", buf.Len())
+	}
+	cached, err := ReadIDMapCache(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadIDMapCache() error = %v", err)
+	}
+	if !reflect.DeepEqual(cached, ids) {
+		t.Errorf("cached map differs:\n%+v\nwant\n%+v", cached, ids)
+	}
+
+	var again bytes.Buffer
+	_ = cached.WriteCache(&again)
+	if again.String() != buf.String() {
+		t.Error("WriteCache() output is not deterministic")
+	}
+}
+
+func TestLoadIDMap_Invalid(t *testing.T) {
+	for _, input := range []string{`[]`, `{"data": {"uuid": [1]}}`, `{"data": {`} {
+		if _, err := LoadIDMap(strings.NewReader(input)); err == nil {
+			t.Errorf("LoadIDMap(%s) error = nil", input)
+		}
+	}
+}
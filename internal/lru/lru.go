@@ -0,0 +1,89 @@
+// Package lru provides a least-recently-used map. It is not safe for
+// concurrent use; callers guard it with their own lock.
+package lru
+
+import "container/list"
+
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// Cache maps keys to values and remembers the order they were last used in.
+// The zero value is an empty cache ready to use.
+type Cache[K comparable, V any] struct {
+	items map[K]*list.Element
+	order list.List // front is most recently used
+}
+
+// Get returns the value for key and marks it most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*entry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Peek returns the value for key without changing its position.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	if el, ok := c.items[key]; ok {
+		return el.Value.(*entry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Add sets the value for key and marks it most recently used.
+func (c *Cache[K, V]) Add(key K, value V) {
+	if el, ok := c.items[key]; ok {
+		el.Value.(*entry[K, V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	if c.items == nil {
+		c.items = make(map[K]*list.Element)
+	}
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+}
+
+// Remove deletes key and reports whether it was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	el, ok := c.items[key]
+	if ok {
+		c.order.Remove(el)
+		delete(c.items, key)
+	}
+	return ok
+}
+
+// Trim removes least recently used entries until at most max remain and
+// returns how many it removed. A max of zero or less removes nothing.
+func (c *Cache[K, V]) Trim(max int) int {
+	if max <= 0 {
+		return 0
+	}
+	removed := 0
+	for len(c.items) > max {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.items, el.Value.(*entry[K, V]).key)
+		removed++
+	}
+	return removed
+}
+
+// Len returns the number of entries.
+func (c *Cache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Each calls fn for every entry from most to least recently used, without
+// changing their order. fn must not modify the cache.
+func (c *Cache[K, V]) Each(fn func(key K, value V)) {
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		e := el.Value.(*entry[K, V])
+		fn(e.key, e.value)
+	}
+}
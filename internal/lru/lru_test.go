@@ -0,0 +1,54 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func keys(c *Cache[string, int]) []string {
+	var got []string
+	c.Each(func(key string, _ int) { got = append(got, key) })
+	return got
+}
+
+func TestCache(t *testing.T) {
+	var c Cache[string, int]
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get() on an empty cache should miss")
+	}
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Add("c", 3)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v", v, ok)
+	}
+	if v, ok := c.Peek("b"); !ok || v != 2 {
+		t.Errorf("Peek(b) = %d, %v", v, ok)
+	}
+	if got, want := keys(&c), []string{"a", "c", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	c.Add("b", 20)
+	if got, want := keys(&c), []string{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order after update = %v, want %v", got, want)
+	}
+
+	if n := c.Trim(2); n != 1 || c.Len() != 2 {
+		t.Errorf("Trim(2) removed %d, Len() = %d", n, c.Len())
+	}
+	if _, ok := c.Peek("c"); ok {
+		t.Error("Trim should remove the least recently used key")
+	}
+	if n := c.Trim(0); n != 0 || c.Len() != 2 {
+		t.Errorf("Trim(0) removed %d", n)
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Error("Remove should report whether the key was present")
+	}
+	if got, want := keys(&c), []string{"b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keys = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,183 @@
+// Package parallel runs independent operations with a concurrency cap and
+// collects their errors.
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Limiter paces operations. *rate.Limiter satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Options controls Run.
+type Options struct {
+	// Concurrency is the maximum number of operations in flight (default: 1).
+	Concurrency int
+
+	// Limiter, if set, is waited on before each operation starts. Leave it
+	// nil when the operations already wait on a limiter themselves, as API
+	// client calls do, so tokens are not taken twice.
+	Limiter Limiter
+
+	// StopOnError stops starting new operations after the first failure.
+	// Operations already in flight run to completion.
+	StopOnError bool
+}
+
+// Run calls fn for each index in [0, n) with at most opts.Concurrency calls
+// in flight. It returns nil if every call succeeded, or an *Errors holding
+// each failure by index. Indexes not started because the caller cancelled
+// ctx are reported with the context's error; those skipped because of
+// StopOnError are not reported.
+func Run(ctx context.Context, n int, opts Options, fn func(ctx context.Context, i int) error) error {
+	if n <= 0 {
+		return nil
+	}
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		failures []IndexError
+		stopped  bool
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures = append(failures, IndexError{Index: i, Err: err})
+		if opts.StopOnError && !stopped {
+			stopped = true
+			cancel()
+		}
+	}
+	notStarted := func(i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !stopped {
+			failures = append(failures, IndexError{Index: i, Err: ctx.Err()})
+		}
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if runCtx.Err() != nil {
+					notStarted(i)
+					continue
+				}
+				if opts.Limiter != nil {
+					if err := opts.Limiter.Wait(runCtx); err != nil {
+						if runCtx.Err() != nil {
+							notStarted(i)
+						} else {
+							fail(i, err)
+						}
+						continue
+					}
+				}
+				if err := fn(runCtx, i); err != nil {
+					fail(i, err)
+				}
+			}
+		}()
+	}
+
+	next := 0
+send:
+	for ; next < n; next++ {
+		select {
+		case indexes <- next:
+		case <-runCtx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := next; i < n; i++ {
+		notStarted(i)
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	sort.Slice(failures, func(a, b int) bool { return failures[a].Index < failures[b].Index })
+	return &Errors{Total: n, Failures: failures}
+}
+
+// IndexError is the failure of the operation at Index.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e IndexError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e IndexError) Unwrap() error {
+	return e.Err
+}
+
+// Errors aggregates the failures of a Run, sorted by index.
+type Errors struct {
+	// Total is the number of operations requested.
+	Total int
+
+	// Failures lists the failed operations.
+	Failures []IndexError
+}
+
+// Error implements the error interface.
+func (e *Errors) Error() string {
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("1 of %d operations failed: %v", e.Total, e.Failures[0])
+	}
+	return fmt.Sprintf("%d of %d operations failed; first: %v", len(e.Failures), e.Total, e.Failures[0])
+}
+
+// Unwrap returns the individual failures, so errors.Is and errors.As see
+// each of them.
+func (e *Errors) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
+}
+
+// Failed reports whether the operation at index failed.
+func (e *Errors) Failed(index int) bool {
+	for _, failure := range e.Failures {
+		if failure.Index == index {
+			return true
+		}
+	}
+	return false
+}
+
+// As returns the *Errors in err's chain, if any.
+func As(err error) (*Errors, bool) {
+	var errs *Errors
+	ok := errors.As(err, &errs)
+	return errs, ok
+}
@@ -0,0 +1,135 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	seen := make([]bool, 20)
+	err := Run(context.Background(), len(seen), Options{Concurrency: 3}, func(ctx context.Context, i int) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		seen[i] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak.Load())
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("index %d was not run", i)
+		}
+	}
+}
+
+func TestRun_AggregatesErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	err := Run(context.Background(), 6, Options{Concurrency: 2}, func(ctx context.Context, i int) error {
+		if i%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+
+	errs, ok := As(err)
+	if !ok {
+		t.Fatalf("expected *Errors, got %v", err)
+	}
+	if errs.Total != 6 || len(errs.Failures) != 3 {
+		t.Fatalf("Errors = %+v", errs)
+	}
+	for i, failure := range errs.Failures {
+		if failure.Index != 2*i+1 {
+			t.Errorf("Failures[%d].Index = %d, want %d", i, failure.Index, 2*i+1)
+		}
+	}
+	if !errs.Failed(3) || errs.Failed(2) {
+		t.Error("Failed() reported the wrong indexes")
+	}
+	if !errors.Is(err, errOdd) {
+		t.Error("errors.Is should find the item error")
+	}
+	if got := err.Error(); got != "3 of 6 operations failed; first: item 1: odd" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestRun_StopOnError(t *testing.T) {
+	var started atomic.Int32
+	err := Run(context.Background(), 100, Options{Concurrency: 1, StopOnError: true}, func(ctx context.Context, i int) error {
+		started.Add(1)
+		if i == 2 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	errs, ok := As(err)
+	if !ok || len(errs.Failures) != 1 || errs.Failures[0].Index != 2 {
+		t.Fatalf("Run() error = %v, want only item 2", err)
+	}
+	if n := started.Load(); n > 4 {
+		t.Errorf("started %d operations after StopOnError, want at most 4", n)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var once sync.Once
+	err := Run(ctx, 10, Options{Concurrency: 1}, func(ctx context.Context, i int) error {
+		once.Do(cancel)
+		return nil
+	})
+	errs, ok := As(err)
+	if !ok || len(errs.Failures) == 0 {
+		t.Fatalf("Run() error = %v, want not-started operations", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("errors.Is(err, context.Canceled) = false for %v", err)
+	}
+}
+
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return ctx.Err()
+}
+
+func TestRun_Limiter(t *testing.T) {
+	limiter := &countingLimiter{}
+	if err := Run(context.Background(), 5, Options{Concurrency: 2, Limiter: limiter}, func(ctx context.Context, i int) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := limiter.waits.Load(); got != 5 {
+		t.Errorf("limiter waits = %d, want 5", got)
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	if err := Run(context.Background(), 0, Options{}, func(ctx context.Context, i int) error {
+		t.Error("fn called for an empty run")
+		return nil
+	}); err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}
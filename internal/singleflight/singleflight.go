@@ -0,0 +1,84 @@
+// Package singleflight lets concurrent callers asking for the same key share
+// one call.
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errAborted is returned to waiting callers when the shared call panicked.
+var errAborted = errors.New("singleflight: shared call did not return")
+
+type call[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+
+	// cancelled records that the caller running fn had its context end, so
+	// waiters whose own context is still live run the call again.
+	cancelled bool
+	dups      int
+}
+
+// Group deduplicates calls by key. The zero value is ready to use.
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// Do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call and returns its result. shared reports
+// whether the result went to more than one caller; shared values must be
+// treated as read-only.
+//
+// fn runs with the context of the caller that started it. A waiter whose
+// context ends stops waiting and returns its context's error. If the
+// starting caller's context ends instead, waiters with live contexts start
+// the call again rather than receiving an error they did not cause.
+func (g *Group[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (v T, shared bool, err error) {
+	for {
+		g.mu.Lock()
+		if g.calls == nil {
+			g.calls = make(map[string]*call[T])
+		}
+		if c, ok := g.calls[key]; ok {
+			c.dups++
+			g.mu.Unlock()
+
+			select {
+			case <-c.done:
+			case <-ctx.Done():
+				var zero T
+				return zero, true, ctx.Err()
+			}
+			if c.err != nil && c.cancelled && ctx.Err() == nil {
+				continue
+			}
+			return c.val, true, c.err
+		}
+		c := &call[T]{done: make(chan struct{}), err: errAborted}
+		g.calls[key] = c
+		g.mu.Unlock()
+
+		shared = g.run(ctx, key, c, fn)
+		return c.val, shared, c.err
+	}
+}
+
+// run calls fn for c and reports whether any other caller joined it. The
+// call is removed and its waiters released even if fn panics.
+func (g *Group[T]) run(ctx context.Context, key string, c *call[T], fn func(ctx context.Context) (T, error)) (shared bool) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		shared = c.dups > 0
+		g.mu.Unlock()
+		close(c.done)
+	}()
+
+	c.val, c.err = fn(ctx)
+	c.cancelled = ctx.Err() != nil
+	return false
+}
@@ -0,0 +1,161 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForDups blocks until n callers have joined the call for key.
+func waitForDups[T any](t *testing.T, g *Group[T], key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		c, ok := g.calls[key]
+		joined := ok && c.dups >= n
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d callers did not join %q", n, key)
+}
+
+func TestGroup_Do(t *testing.T) {
+	var g Group[int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	shared := make([]bool, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, s, err := g.Do(context.Background(), "key", fn)
+			if err != nil {
+				t.Errorf("Do() error = %v", err)
+			}
+			results[i], shared[i] = v, s
+		}(i)
+	}
+	waitForDups(t, &g, "key", callers-1)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+	for i := range results {
+		if results[i] != 42 || !shared[i] {
+			t.Errorf("caller %d got %d, shared=%v", i, results[i], shared[i])
+		}
+	}
+
+	// Once the call is done, the next call for the key runs again.
+	v, s, err := g.Do(context.Background(), "key", func(ctx context.Context) (int, error) { return 7, nil })
+	if v != 7 || s || err != nil {
+		t.Errorf("Do() = %d, %v, %v; want 7, false, nil", v, s, err)
+	}
+}
+
+func TestGroup_DoError(t *testing.T) {
+	var g Group[string]
+	errBoom := errors.New("boom")
+	_, _, err := g.Do(context.Background(), "key", func(ctx context.Context) (string, error) {
+		return "", errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Do() error = %v, want %v", err, errBoom)
+	}
+}
+
+func TestGroup_WaiterCancelled(t *testing.T) {
+	var g Group[int]
+	release := make(chan struct{})
+	defer close(release)
+	go func() {
+		_, _, _ = g.Do(context.Background(), "key", func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		})
+	}()
+	waitForDups(t, &g, "key", 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		waitForDups(t, &g, "key", 1)
+		cancel()
+	}()
+	if _, _, err := g.Do(ctx, "key", func(ctx context.Context) (int, error) { return 2, nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestGroup_LeaderCancelled(t *testing.T) {
+	var g Group[int]
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	fn := func(ctx context.Context) (int, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return 9, nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := g.Do(leaderCtx, "key", fn)
+		done <- err
+	}()
+	waitForDups(t, &g, "key", 0)
+
+	go func() {
+		waitForDups(t, &g, "key", 1)
+		cancelLeader()
+	}()
+	v, _, err := g.Do(context.Background(), "key", fn)
+	if err != nil || v != 9 {
+		t.Errorf("waiter Do() = %d, %v; want a fresh call's result", v, err)
+	}
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader Do() error = %v, want context.Canceled", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("calls = %d, want 2", got)
+	}
+}
+
+func TestGroup_Panic(t *testing.T) {
+	var g Group[int]
+	release := make(chan struct{})
+	go func() {
+		defer func() { _ = recover() }()
+		_, _, _ = g.Do(context.Background(), "key", func(ctx context.Context) (int, error) {
+			<-release
+			panic("boom")
+		})
+	}()
+	waitForDups(t, &g, "key", 0)
+
+	go func() {
+		waitForDups(t, &g, "key", 1)
+		close(release)
+	}()
+	if _, _, err := g.Do(context.Background(), "key", func(ctx context.Context) (int, error) { return 1, nil }); !errors.Is(err, errAborted) {
+		t.Errorf("Do() error = %v, want errAborted", err)
+	}
+}
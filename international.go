@@ -0,0 +1,84 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OptimizerCountries lists the countries accepted for
+// OptimizerRequest.DestinationCountry and ShipFromCountries.
+var OptimizerCountries = []string{CountryUS, CountryCA}
+
+// Validate checks the request's destination and ship-from countries against
+// OptimizerCountries, normalizing names and codes to upper-case alpha-2 codes.
+// When ShipFromCountries is empty the API only ships from the US, so a
+// Canadian destination is only served by US sellers unless CA is listed.
+func (r *OptimizerRequest) Validate() error {
+	if r.DestinationCountry != "" {
+		country := NormalizeCountryCode(r.DestinationCountry)
+		if !containsString(OptimizerCountries, country) {
+			return NewValidationError("destination_country", fmt.Sprintf("unsupported country %q (supported: %s)",
+				r.DestinationCountry, strings.Join(OptimizerCountries, ", ")))
+		}
+		r.DestinationCountry = country
+	}
+
+	if len(r.ShipFromCountries) > 0 {
+		countries := make([]string, 0, len(r.ShipFromCountries))
+		for _, c := range r.ShipFromCountries {
+			country := NormalizeCountryCode(c)
+			if !containsString(OptimizerCountries, country) {
+				return NewValidationError("ship_from_countries", fmt.Sprintf("unsupported country %q (supported: %s)",
+					c, strings.Join(OptimizerCountries, ", ")))
+			}
+			if containsString(countries, country) {
+				return NewValidationError("ship_from_countries", fmt.Sprintf("duplicate country %q", country))
+			}
+			countries = append(countries, country)
+		}
+		r.ShipFromCountries = countries
+	}
+
+	return nil
+}
+
+// CheckDestinationAvailability reports which cart items cannot be fully
+// filled when shipping to destination, before anything is purchased. The
+// request's ShipFromCountries are kept; when empty, sellers in every
+// supported country are allowed.
+//
+// Example:
+//
+//	report, err := client.CheckDestinationAvailability(ctx, req, manapool.CountryCA)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range report.Items {
+//	    fmt.Printf("%s: only %d of %d ship to Canada\n", s.Item.Name, s.Filled, s.Requested)
+//	}
+func (c *Client) CheckDestinationAvailability(ctx context.Context, req OptimizerRequest, destination string) (*ShortfallReport, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	if destination == "" {
+		return nil, NewValidationError("destination_country", "destination country cannot be empty")
+	}
+
+	req.DestinationCountry = destination
+	if len(req.ShipFromCountries) == 0 {
+		req.ShipFromCountries = OptimizerCountries
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	cart, err := c.OptimizeCart(ctx, req)
+	if err != nil {
+		if report, ok := ShortfallReportFromError(req, err); ok {
+			return report, nil
+		}
+		return nil, err
+	}
+	return c.ShortfallReport(ctx, req, cart)
+}
@@ -0,0 +1,95 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestOptimizerRequest_Validate(t *testing.T) {
+	req := OptimizerRequest{DestinationCountry: "canada", ShipFromCountries: []string{"usa", "CA"}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if req.DestinationCountry != CountryCA || !reflect.DeepEqual(req.ShipFromCountries, []string{CountryUS, CountryCA}) {
+		t.Errorf("countries not normalized: %q %v", req.DestinationCountry, req.ShipFromCountries)
+	}
+
+	tests := []struct {
+		name  string
+		req   OptimizerRequest
+		field string
+	}{
+		{"unsupported destination", OptimizerRequest{DestinationCountry: "DE"}, "destination_country"},
+		{"unsupported origin", OptimizerRequest{ShipFromCountries: []string{"US", "GB"}}, "ship_from_countries"},
+		{"duplicate origin", OptimizerRequest{ShipFromCountries: []string{"US", "United States"}}, "ship_from_countries"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := tt.req.Validate(); !errors.As(err, &valErr) || valErr.Field != tt.field {
+				t.Errorf("Validate() error = %v, want ValidationError on %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestClient_CheckDestinationAvailability(t *testing.T) {
+	conflict := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode optimizer request: %v", err)
+			}
+			if req.DestinationCountry != CountryCA || len(req.ShipFromCountries) != 2 {
+				t.Errorf("unexpected countries: %q %v", req.DestinationCountry, req.ShipFromCountries)
+			}
+			if conflict {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":"unable to fill cart","details":[{"item":{"type":"mtg_single","name":"Sol Ring","quantity_requested":2,"index":0},"total_available":0}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv-sol","quantity_selected":2}],"totals":{"subtotal_cents":300,"shipping_cents":100,"total_cents":400,"seller_count":1}}`))
+		case "/inventory/listings":
+			_, _ = w.Write([]byte(`{"inventory_items":[{"id":"inv-sol","product_id":"p1","product":{"type":"mtg_single","id":"p1","single":{"name":"Sol Ring"}},"price_cents":150,"quantity":5}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := OptimizerRequest{Cart: []OptimizerCartItem{{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 2}}}
+
+	report, err := client.CheckDestinationAvailability(ctx, req, "Canada")
+	if err != nil {
+		t.Fatalf("CheckDestinationAvailability() error = %v", err)
+	}
+	if !report.Complete() {
+		t.Errorf("expected complete report, got %+v", report.Items)
+	}
+
+	conflict = true
+	report, err = client.CheckDestinationAvailability(ctx, req, CountryCA)
+	if err != nil {
+		t.Fatalf("CheckDestinationAvailability() error = %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Reason != ShortfallFiltersTooStrict || report.Items[0].Filled != 0 {
+		t.Errorf("unexpected report: %+v", report.Items)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.CheckDestinationAvailability(ctx, req, "DE"); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for unsupported destination, got %v", err)
+	}
+	if _, err := client.OptimizeCart(ctx, OptimizerRequest{DestinationCountry: "DE"}); !errors.As(err, &valErr) {
+		t.Errorf("expected OptimizeCart to reject unsupported destination, got %v", err)
+	}
+}
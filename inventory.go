@@ -3,8 +3,6 @@ package manapool
 import (
 	"context"
 	"fmt"
-	"net/url"
-	"strconv"
 )
 
 // GetSellerInventory retrieves the seller's inventory with pagination support.
@@ -42,24 +40,25 @@ func (c *Client) GetSellerInventory(ctx context.Context, opts InventoryOptions)
 		return nil, err
 	}
 
-	c.logger.Debugf("Getting seller inventory: limit=%d, offset=%d", opts.Limit, opts.Offset)
+	ctx, _ = ensureCorrelationID(ctx)
+	c.loggerFor(ctx).Debugf("Getting seller inventory: limit=%d, offset=%d", opts.Limit, opts.Offset)
 
 	// Build query parameters
-	params := url.Values{}
-	params.Add("limit", strconv.Itoa(opts.Limit))
-	params.Add("offset", strconv.Itoa(opts.Offset))
+	params := newQuery()
+	params.addInt("limit", opts.Limit)
+	params.addInt("offset", opts.Offset)
 
-	resp, err := c.doRequest(ctx, "GET", "/seller/inventory", params)
+	resp, err := c.doQueryRequest(ctx, "GET", "/seller/inventory", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seller inventory: %w", err)
 	}
 
-	var inventoryResp InventoryResponse
+	inventoryResp := InventoryResponse{Inventory: presize[InventoryItem](opts.Limit)}
 	if err := c.decodeResponse(resp, &inventoryResp); err != nil {
 		return nil, fmt.Errorf("failed to decode seller inventory: %w", err)
 	}
 
-	c.logger.Debugf("Retrieved %d inventory items (total: %d)",
+	c.loggerFor(ctx).Debugf("Retrieved %d inventory items (total: %d)",
 		inventoryResp.Pagination.Returned, inventoryResp.Pagination.Total)
 
 	return &inventoryResp, nil
@@ -72,7 +71,7 @@ func (c *Client) GetSellerInventory(ctx context.Context, opts InventoryOptions)
 //
 // Example:
 //
-//	item, err := client.GetInventoryByTCGPlayerID(ctx, "4549403")
+//	item, err := client.GetInventoryByTCGPlayerID(ctx, 4549403)
 //	if err != nil {
 //	    var apiErr *manapool.APIError
 //	    if errors.As(err, &apiErr) && apiErr.IsNotFound() {
@@ -91,14 +90,15 @@ func (c *Client) GetSellerInventory(ctx context.Context, opts InventoryOptions)
 // Returns:
 //   - *InventoryItem: The inventory item
 //   - error: Any error that occurred during the request (404 if not found)
-func (c *Client) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID string) (*InventoryItem, error) {
-	if tcgplayerID == "" {
-		return nil, NewValidationError("tcgplayerID", "tcgplayerID cannot be empty")
+func (c *Client) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int64) (*InventoryItem, error) {
+	if tcgplayerID <= 0 {
+		return nil, NewValidationError("tcgplayerID", "tcgplayerID must be positive")
 	}
 
-	c.logger.Debugf("Getting inventory by TCGPlayer ID: %s", tcgplayerID)
+	ctx, _ = ensureCorrelationID(ctx)
+	c.loggerFor(ctx).Debugf("Getting inventory by TCGPlayer ID: %d", tcgplayerID)
 
-	endpoint := fmt.Sprintf("/seller/inventory/tcgsku/%s", tcgplayerID)
+	endpoint := fmt.Sprintf("/seller/inventory/tcgsku/%d", tcgplayerID)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get inventory by TCGPlayer ID: %w", err)
@@ -114,16 +114,19 @@ func (c *Client) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID stri
 		itemName = item.Product.Single.Name
 	}
 
-	tcgSKU := 0
+	var tcgSKU int64
 	if item.Product.TCGPlayerSKU != nil {
 		tcgSKU = *item.Product.TCGPlayerSKU
 	}
 
-	c.logger.Debugf("Retrieved inventory item: %s (TCG SKU: %d)", itemName, tcgSKU)
+	c.loggerFor(ctx).Debugf("Retrieved inventory item: %s (TCG SKU: %d)", itemName, tcgSKU)
 
 	return &item, nil
 }
 
+// inventoryPageSize is the page size used to walk the whole inventory.
+const inventoryPageSize = 500
+
 // IterateInventory is a helper function that automatically handles pagination
 // and calls the provided callback for each inventory item.
 //
@@ -151,11 +154,10 @@ func (c *Client) GetInventoryByTCGPlayerID(ctx context.Context, tcgplayerID stri
 //   - error: Any error that occurred during iteration
 func IterateInventory(ctx context.Context, client APIClient, callback func(*InventoryItem) error) error {
 	offset := 0
-	limit := 500
 
 	for {
 		opts := InventoryOptions{
-			Limit:  limit,
+			Limit:  inventoryPageSize,
 			Offset: offset,
 		}
 
@@ -181,3 +183,26 @@ func IterateInventory(ctx context.Context, client APIClient, callback func(*Inve
 
 	return nil
 }
+
+// collectInventory returns the seller's whole inventory. The result is sized
+// from the first page's pagination total, so a large inventory is copied
+// once rather than regrown page by page.
+func (c *Client) collectInventory(ctx context.Context) ([]InventoryItem, error) {
+	var inventory []InventoryItem
+	offset := 0
+	for {
+		resp, err := c.GetSellerInventory(ctx, InventoryOptions{Limit: inventoryPageSize, Offset: offset})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inventory at offset %d: %w", offset, err)
+		}
+		if inventory == nil {
+			inventory = presize[InventoryItem](resp.Pagination.Total)
+		}
+		inventory = append(inventory, resp.Inventory...)
+
+		if resp.Pagination.Returned == 0 || offset+resp.Pagination.Returned >= resp.Pagination.Total {
+			return inventory, nil
+		}
+		offset += resp.Pagination.Returned
+	}
+}
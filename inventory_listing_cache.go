@@ -0,0 +1,137 @@
+package manapool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/repricah/manapool/internal/lru"
+)
+
+// DefaultInventoryListingCacheTTL is how long InventoryListingCache keeps a
+// listing by default. Listings change price and quantity far more often than
+// card metadata, so it is much shorter than DefaultCardInfoCacheTTL.
+const DefaultInventoryListingCacheTTL = 5 * time.Minute
+
+// DefaultInventoryListingCacheSize is the default number of listings an
+// InventoryListingCache holds.
+const DefaultInventoryListingCacheSize = 10000
+
+type inventoryListingCacheEntry struct {
+	listing   *InventoryItemResponse
+	fetchedAt time.Time
+}
+
+// InventoryListingCache caches GetInventoryListing results by listing ID,
+// evicting the least recently used listings beyond MaxEntries. It suits
+// enrichment passes and webhook handlers that look up the same listings
+// repeatedly and can tolerate data up to TTL old. InventoryListingCache is
+// safe for concurrent use.
+//
+// Example:
+//
+//	cache := manapool.NewInventoryListingCache(client, time.Minute)
+//	listing, err := cache.GetInventoryListing(ctx, event.ListingID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+type InventoryListingCache struct {
+	client *Client
+
+	// TTL is how long entries are used (default: DefaultInventoryListingCacheTTL).
+	TTL time.Duration
+
+	// MaxEntries bounds the number of cached listings
+	// (default: DefaultInventoryListingCacheSize).
+	MaxEntries int
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries lru.Cache[string, inventoryListingCacheEntry]
+	stats   CacheStats
+}
+
+// NewInventoryListingCache creates an empty cache in front of client. A ttl
+// of zero uses DefaultInventoryListingCacheTTL.
+func NewInventoryListingCache(client *Client, ttl time.Duration) *InventoryListingCache {
+	return &InventoryListingCache{
+		client:     client,
+		TTL:        ttl,
+		MaxEntries: DefaultInventoryListingCacheSize,
+	}
+}
+
+// GetInventoryListing returns the listing like Client.GetInventoryListing,
+// calling the API only if id is not cached or has expired. The returned
+// listing is shared with other callers and must not be modified.
+func (lc *InventoryListingCache) GetInventoryListing(ctx context.Context, id string) (*InventoryItemResponse, error) {
+	now := lc.now()
+	lc.mu.Lock()
+	entry, ok := lc.entries.Get(id)
+	if ok && now.Sub(entry.fetchedAt) < lc.ttl() {
+		lc.stats.Hits++
+		lc.mu.Unlock()
+		return entry.listing, nil
+	}
+	lc.stats.Misses++
+	lc.mu.Unlock()
+
+	if lc.client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	listing, err := lc.client.GetInventoryListing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.entries.Add(id, inventoryListingCacheEntry{listing: listing, fetchedAt: now})
+	lc.stats.Evictions += int64(lc.entries.Trim(lc.maxEntries()))
+	return listing, nil
+}
+
+// Invalidate removes id from the cache, for example after a webhook reports
+// that the listing changed.
+func (lc *InventoryListingCache) Invalidate(id string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.entries.Remove(id)
+}
+
+// Len returns the number of cached listings, including expired ones.
+func (lc *InventoryListingCache) Len() int {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.entries.Len()
+}
+
+// Stats returns the cache's hit, miss and eviction counts so far.
+func (lc *InventoryListingCache) Stats() CacheStats {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.stats
+}
+
+func (lc *InventoryListingCache) ttl() time.Duration {
+	if lc.TTL <= 0 {
+		return DefaultInventoryListingCacheTTL
+	}
+	return lc.TTL
+}
+
+func (lc *InventoryListingCache) maxEntries() int {
+	if lc.MaxEntries <= 0 {
+		return DefaultInventoryListingCacheSize
+	}
+	return lc.MaxEntries
+}
+
+func (lc *InventoryListingCache) now() time.Time {
+	if lc.Now != nil {
+		return lc.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,80 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInventoryListingCache(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/inventory/listings/")
+		requested = append(requested, id)
+		_, _ = w.Write([]byte(`{"inventory_item":{"id":"` + id + `"}}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	cache := NewInventoryListingCache(client, time.Minute)
+	cache.MaxEntries = 2
+	cache.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	get := func(id string) {
+		t.Helper()
+		listing, err := cache.GetInventoryListing(ctx, id)
+		if err != nil {
+			t.Fatalf("GetInventoryListing(%q) error = %v", id, err)
+		}
+		if listing.InventoryItem.ID != id {
+			t.Fatalf("GetInventoryListing(%q) = %+v", id, listing)
+		}
+	}
+
+	get("a")
+	get("b")
+	get("a") // hit; b is now least recently used
+	get("c") // evicts b
+	get("b") // miss; evicts a
+	if want := "a,b,c,b"; strings.Join(requested, ",") != want {
+		t.Errorf("requested = %v, want %s", requested, want)
+	}
+	want := CacheStats{Hits: 1, Misses: 4, Evictions: 2}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	// Expired and invalidated listings are fetched again.
+	now = now.Add(time.Minute)
+	get("c")
+	cache.Invalidate("c")
+	get("c")
+	if len(requested) != 6 {
+		t.Errorf("requested = %v, want 6 requests", requested)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func TestInventoryListingCache_Errors(t *testing.T) {
+	cache := NewInventoryListingCache(nil, 0)
+	var validationErr *ValidationError
+	if _, err := cache.GetInventoryListing(context.Background(), "a"); !errors.As(err, &validationErr) {
+		t.Errorf("GetInventoryListing() error = %v, want ValidationError", err)
+	}
+
+	cache = NewInventoryListingCache(NewClient("test-token", "test@example.com"), 0)
+	if _, err := cache.GetInventoryListing(context.Background(), ""); !errors.As(err, &validationErr) {
+		t.Errorf("GetInventoryListing(\"\") error = %v, want ValidationError", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", cache.Len())
+	}
+}
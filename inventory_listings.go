@@ -3,7 +3,6 @@ package manapool
 import (
 	"context"
 	"fmt"
-	"net/url"
 )
 
 // GetInventoryListings retrieves inventory listings by ID.
@@ -12,14 +11,14 @@ func (c *Client) GetInventoryListings(ctx context.Context, ids []string) (*Inven
 		return &InventoryListingsResponse{}, nil
 	}
 
-	params := url.Values{}
+	params := newQuery()
 	for _, id := range ids {
 		if id != "" {
-			params.Add("id", id)
+			params.add("id", id)
 		}
 	}
 
-	resp, err := c.doRequest(ctx, "GET", "/inventory/listings", params)
+	resp, err := c.doQueryRequest(ctx, "GET", "/inventory/listings", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get inventory listings: %w", err)
 	}
@@ -32,28 +31,33 @@ func (c *Client) GetInventoryListings(ctx context.Context, ids []string) (*Inven
 	return &listings, nil
 }
 
-// GetInventoryListing retrieves a single inventory listing by ID.
+// GetInventoryListing retrieves a single inventory listing by ID. Concurrent
+// calls for the same ID, as from a webhook handler fanning out, share one
+// API call and receive the same listing, which must not be modified.
 func (c *Client) GetInventoryListing(ctx context.Context, id string) (*InventoryItemResponse, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/inventory/listings/%s", id)
-	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get inventory listing: %w", err)
-	}
+	listing, _, err := c.listingCalls.Do(ctx, id, func(ctx context.Context) (*InventoryItemResponse, error) {
+		endpoint := endpointPath("/inventory/listings/%s", id)
+		resp, err := c.doRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inventory listing: %w", err)
+		}
 
-	var listing InventoryItemResponse
-	if err := c.decodeResponse(resp, &listing); err != nil {
-		return nil, fmt.Errorf("failed to decode inventory listing: %w", err)
-	}
+		var listing InventoryItemResponse
+		if err := c.decodeResponse(resp, &listing); err != nil {
+			return nil, fmt.Errorf("failed to decode inventory listing: %w", err)
+		}
 
-	return &listing, nil
+		return &listing, nil
+	})
+	return listing, err
 }
 
 // GetInventoryBySKU retrieves an inventory item by TCGPlayer SKU.
-func (c *Client) GetInventoryBySKU(ctx context.Context, sku int) (*InventoryListingResponse, error) {
+func (c *Client) GetInventoryBySKU(ctx context.Context, sku int64) (*InventoryListingResponse, error) {
 	if sku <= 0 {
 		return nil, NewValidationError("sku", "sku must be positive")
 	}
@@ -73,7 +77,7 @@ func (c *Client) GetInventoryBySKU(ctx context.Context, sku int) (*InventoryList
 }
 
 // UpdateInventoryBySKU updates an inventory item by TCGPlayer SKU.
-func (c *Client) UpdateInventoryBySKU(ctx context.Context, sku int, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
+func (c *Client) UpdateInventoryBySKU(ctx context.Context, sku int64, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
 	if sku <= 0 {
 		return nil, NewValidationError("sku", "sku must be positive")
 	}
@@ -93,7 +97,7 @@ func (c *Client) UpdateInventoryBySKU(ctx context.Context, sku int, update Inven
 }
 
 // DeleteInventoryBySKU deletes an inventory item by TCGPlayer SKU.
-func (c *Client) DeleteInventoryBySKU(ctx context.Context, sku int) (*InventoryListingResponse, error) {
+func (c *Client) DeleteInventoryBySKU(ctx context.Context, sku int64) (*InventoryListingResponse, error) {
 	if sku <= 0 {
 		return nil, NewValidationError("sku", "sku must be positive")
 	}
@@ -151,7 +155,7 @@ func (c *Client) CreateInventoryBulkBySKU(ctx context.Context, items []Inventory
 }
 
 // GetSellerInventoryBySKU retrieves a seller inventory item by SKU.
-func (c *Client) GetSellerInventoryBySKU(ctx context.Context, sku int) (*InventoryListingResponse, error) {
+func (c *Client) GetSellerInventoryBySKU(ctx context.Context, sku int64) (*InventoryListingResponse, error) {
 	if sku <= 0 {
 		return nil, NewValidationError("sku", "sku must be positive")
 	}
@@ -171,7 +175,7 @@ func (c *Client) GetSellerInventoryBySKU(ctx context.Context, sku int) (*Invento
 }
 
 // UpdateSellerInventoryBySKU updates a seller inventory item by SKU.
-func (c *Client) UpdateSellerInventoryBySKU(ctx context.Context, sku int, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
+func (c *Client) UpdateSellerInventoryBySKU(ctx context.Context, sku int64, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
 	if sku <= 0 {
 		return nil, NewValidationError("sku", "sku must be positive")
 	}
@@ -191,7 +195,7 @@ func (c *Client) UpdateSellerInventoryBySKU(ctx context.Context, sku int, update
 }
 
 // DeleteSellerInventoryBySKU deletes a seller inventory item by SKU.
-func (c *Client) DeleteSellerInventoryBySKU(ctx context.Context, sku int) (*InventoryListingResponse, error) {
+func (c *Client) DeleteSellerInventoryBySKU(ctx context.Context, sku int64) (*InventoryListingResponse, error) {
 	if sku <= 0 {
 		return nil, NewValidationError("sku", "sku must be positive")
 	}
@@ -211,10 +215,16 @@ func (c *Client) DeleteSellerInventoryBySKU(ctx context.Context, sku int) (*Inve
 }
 
 // CreateInventoryBulkByProduct creates or updates (upserts) inventory in bulk by product.
+// Every item must have a valid ProductType.
 func (c *Client) CreateInventoryBulkByProduct(ctx context.Context, items []InventoryBulkItemByProduct) (*InventoryItemsResponse, error) {
 	if len(items) == 0 {
 		return nil, NewValidationError("items", "items cannot be empty")
 	}
+	for i, item := range items {
+		if !item.ProductType.IsValid() {
+			return nil, NewValidationError(fmt.Sprintf("items[%d].product_type", i), fmt.Sprintf("unknown product type %q", item.ProductType))
+		}
+	}
 
 	resp, err := c.doJSONRequest(ctx, "POST", "/seller/inventory/product", nil, items)
 	if err != nil {
@@ -230,12 +240,15 @@ func (c *Client) CreateInventoryBulkByProduct(ctx context.Context, items []Inven
 }
 
 // GetSellerInventoryByProduct retrieves inventory by product ID.
-func (c *Client) GetSellerInventoryByProduct(ctx context.Context, productType, productID string) (*InventoryListingResponse, error) {
+func (c *Client) GetSellerInventoryByProduct(ctx context.Context, productType ProductType, productID string) (*InventoryListingResponse, error) {
 	if productType == "" || productID == "" {
 		return nil, NewValidationError("product", "productType and productID are required")
 	}
+	if !productType.IsValid() {
+		return nil, NewValidationError("product_type", fmt.Sprintf("unknown product type %q", productType))
+	}
 
-	endpoint := fmt.Sprintf("/seller/inventory/product/%s/%s", productType, productID)
+	endpoint := endpointPath("/seller/inventory/product/%s/%s", productType.String(), productID)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seller inventory by product: %w", err)
@@ -250,12 +263,15 @@ func (c *Client) GetSellerInventoryByProduct(ctx context.Context, productType, p
 }
 
 // UpdateSellerInventoryByProduct updates inventory by product ID.
-func (c *Client) UpdateSellerInventoryByProduct(ctx context.Context, productType, productID string, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
+func (c *Client) UpdateSellerInventoryByProduct(ctx context.Context, productType ProductType, productID string, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
 	if productType == "" || productID == "" {
 		return nil, NewValidationError("product", "productType and productID are required")
 	}
+	if !productType.IsValid() {
+		return nil, NewValidationError("product_type", fmt.Sprintf("unknown product type %q", productType))
+	}
 
-	endpoint := fmt.Sprintf("/seller/inventory/product/%s/%s", productType, productID)
+	endpoint := endpointPath("/seller/inventory/product/%s/%s", productType.String(), productID)
 	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, nil, update)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update seller inventory by product: %w", err)
@@ -270,12 +286,15 @@ func (c *Client) UpdateSellerInventoryByProduct(ctx context.Context, productType
 }
 
 // DeleteSellerInventoryByProduct deletes inventory by product ID.
-func (c *Client) DeleteSellerInventoryByProduct(ctx context.Context, productType, productID string) (*InventoryListingResponse, error) {
+func (c *Client) DeleteSellerInventoryByProduct(ctx context.Context, productType ProductType, productID string) (*InventoryListingResponse, error) {
 	if productType == "" || productID == "" {
 		return nil, NewValidationError("product", "productType and productID are required")
 	}
+	if !productType.IsValid() {
+		return nil, NewValidationError("product_type", fmt.Sprintf("unknown product type %q", productType))
+	}
 
-	endpoint := fmt.Sprintf("/seller/inventory/product/%s/%s", productType, productID)
+	endpoint := endpointPath("/seller/inventory/product/%s/%s", productType.String(), productID)
 	resp, err := c.doRequest(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete seller inventory by product: %w", err)
@@ -316,7 +335,7 @@ func (c *Client) GetSellerInventoryByScryfall(ctx context.Context, scryfallID st
 
 	params := opts.toParams()
 
-	endpoint := fmt.Sprintf("/seller/inventory/scryfall_id/%s", scryfallID)
+	endpoint := endpointPath("/seller/inventory/scryfall_id/%s", scryfallID)
 	resp, err := c.doRequest(ctx, "GET", endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seller inventory by scryfall: %w", err)
@@ -338,7 +357,7 @@ func (c *Client) UpdateSellerInventoryByScryfall(ctx context.Context, scryfallID
 
 	params := opts.toParams()
 
-	endpoint := fmt.Sprintf("/seller/inventory/scryfall_id/%s", scryfallID)
+	endpoint := endpointPath("/seller/inventory/scryfall_id/%s", scryfallID)
 	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, params, update)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update seller inventory by scryfall: %w", err)
@@ -360,7 +379,7 @@ func (c *Client) DeleteSellerInventoryByScryfall(ctx context.Context, scryfallID
 
 	params := opts.toParams()
 
-	endpoint := fmt.Sprintf("/seller/inventory/scryfall_id/%s", scryfallID)
+	endpoint := endpointPath("/seller/inventory/scryfall_id/%s", scryfallID)
 	resp, err := c.doRequest(ctx, "DELETE", endpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete seller inventory by scryfall: %w", err)
@@ -394,7 +413,7 @@ func (c *Client) CreateInventoryBulkByTCGPlayerID(ctx context.Context, items []I
 }
 
 // GetSellerInventoryByTCGPlayerID retrieves inventory by TCGPlayer ID.
-func (c *Client) GetSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int, opts InventoryByTCGPlayerOptions) (*InventoryListingResponse, error) {
+func (c *Client) GetSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int64, opts InventoryByTCGPlayerOptions) (*InventoryListingResponse, error) {
 	if tcgplayerID <= 0 {
 		return nil, NewValidationError("tcgplayer_id", "tcgplayerID must be positive")
 	}
@@ -416,7 +435,7 @@ func (c *Client) GetSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerI
 }
 
 // UpdateSellerInventoryByTCGPlayerID updates inventory by TCGPlayer ID.
-func (c *Client) UpdateSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int, opts InventoryByTCGPlayerOptions, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
+func (c *Client) UpdateSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int64, opts InventoryByTCGPlayerOptions, update InventoryUpdateRequest) (*InventoryListingResponse, error) {
 	if tcgplayerID <= 0 {
 		return nil, NewValidationError("tcgplayer_id", "tcgplayerID must be positive")
 	}
@@ -438,7 +457,7 @@ func (c *Client) UpdateSellerInventoryByTCGPlayerID(ctx context.Context, tcgplay
 }
 
 // DeleteSellerInventoryByTCGPlayerID deletes inventory by TCGPlayer ID.
-func (c *Client) DeleteSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int, opts InventoryByTCGPlayerOptions) (*InventoryListingResponse, error) {
+func (c *Client) DeleteSellerInventoryByTCGPlayerID(ctx context.Context, tcgplayerID int64, opts InventoryByTCGPlayerOptions) (*InventoryListingResponse, error) {
 	if tcgplayerID <= 0 {
 		return nil, NewValidationError("tcgplayer_id", "tcgplayerID must be positive")
 	}
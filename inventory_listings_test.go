@@ -7,7 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestClient_InventoryListingEndpoints(t *testing.T) {
@@ -356,3 +359,54 @@ func TestClient_InventoryListingEndpoints(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_LargeSKUs(t *testing.T) {
+	const sku int64 = 1 << 40
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte(`{"inventory":{"product":{"tcgplayer_sku":1099511627776}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	listing, err := client.GetSellerInventoryBySKU(context.Background(), sku)
+	if err != nil {
+		t.Fatalf("GetSellerInventoryBySKU() error = %v", err)
+	}
+	if gotPath != "/seller/inventory/tcgsku/1099511627776" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if got := Int64Value(listing.Inventory.Product.TCGPlayerSKU); got != sku {
+		t.Errorf("TCGPlayerSKU = %d, want %d", got, sku)
+	}
+}
+
+func TestClient_GetInventoryListing_SharesConcurrentCalls(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// Hold the first call open while the others arrive.
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"inventory_item":{"id":"inv123"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			listing, err := client.GetInventoryListing(context.Background(), "inv123")
+			if err != nil || listing.InventoryItem.ID != "inv123" {
+				t.Errorf("GetInventoryListing() = %+v, %v", listing, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := hits.Load(); got != 1 {
+		t.Errorf("API calls = %d, want 1", got)
+	}
+}
@@ -251,7 +251,7 @@ func TestClient_GetInventoryByTCGPlayerID_Success(t *testing.T) {
 	)
 
 	ctx := context.Background()
-	item, err := client.GetInventoryByTCGPlayerID(ctx, "4549403")
+	item, err := client.GetInventoryByTCGPlayerID(ctx, 4549403)
 	if err != nil {
 		t.Fatalf("GetInventoryByTCGPlayerID() error = %v", err)
 	}
@@ -267,7 +267,7 @@ func TestClient_GetInventoryByTCGPlayerID_Success(t *testing.T) {
 		t.Errorf("Quantity = %d, want 3", item.Quantity)
 	}
 	if item.Product.TCGPlayerSKU == nil || *item.Product.TCGPlayerSKU != 4549403 {
-		value := 0
+		var value int64
 		if item.Product.TCGPlayerSKU != nil {
 			value = *item.Product.TCGPlayerSKU
 		}
@@ -294,7 +294,7 @@ func TestClient_GetInventoryByTCGPlayerID_NotFound(t *testing.T) {
 	)
 
 	ctx := context.Background()
-	_, err := client.GetInventoryByTCGPlayerID(ctx, "999999")
+	_, err := client.GetInventoryByTCGPlayerID(ctx, 999999)
 	if err == nil {
 		t.Fatal("GetInventoryByTCGPlayerID() expected error, got nil")
 	}
@@ -308,13 +308,13 @@ func TestClient_GetInventoryByTCGPlayerID_NotFound(t *testing.T) {
 	}
 }
 
-func TestClient_GetInventoryByTCGPlayerID_EmptyID(t *testing.T) {
+func TestClient_GetInventoryByTCGPlayerID_InvalidID(t *testing.T) {
 	client := NewClient("test-token", "test@example.com")
 
 	ctx := context.Background()
-	_, err := client.GetInventoryByTCGPlayerID(ctx, "")
+	_, err := client.GetInventoryByTCGPlayerID(ctx, 0)
 	if err == nil {
-		t.Fatal("GetInventoryByTCGPlayerID() expected error for empty ID, got nil")
+		t.Fatal("GetInventoryByTCGPlayerID() expected error for zero ID, got nil")
 	}
 
 	var valErr *ValidationError
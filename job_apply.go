@@ -4,23 +4,93 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"mime/multipart"
 )
 
 const defaultApplicationFilename = "application.zip"
 
-// SubmitJobApplication submits a job application.
+// MaxJobApplicationSize is the largest application file the API accepts.
+const MaxJobApplicationSize = 25 << 20
+
+// SubmitJobApplication submits a job application. The multipart body is
+// streamed to the API, so an application given as ApplicationReader is read
+// once and never held in memory.
+//
+// Example:
+//
+//	f, err := os.Open("application.zip")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//	info, err := f.Stat()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := client.SubmitJobApplication(ctx, manapool.JobApplicationRequest{
+//	    FirstName:         "Jane",
+//	    LastName:          "Doe",
+//	    Email:             "jane@example.com",
+//	    ApplicationReader: f,
+//	    ApplicationSize:   info.Size(),
+//	})
 func (c *Client) SubmitJobApplication(ctx context.Context, req JobApplicationRequest) (*JobApplicationResponse, error) {
-	if req.FirstName == "" || req.LastName == "" || req.Email == "" || len(req.Application) == 0 {
+	file, size := req.Application, int64(len(req.Application))
+	var reader io.Reader = bytes.NewReader(file)
+	if size == 0 && req.ApplicationReader != nil {
+		reader, size = req.ApplicationReader, req.ApplicationSize
+	}
+	if req.FirstName == "" || req.LastName == "" || req.Email == "" || size <= 0 {
 		return nil, NewValidationError("application", "first name, last name, email, and application are required")
 	}
+	if size > MaxJobApplicationSize {
+		return nil, NewValidationError("application", fmt.Sprintf("application is %d bytes, the limit is %d", size, MaxJobApplicationSize))
+	}
 	filename := req.ApplicationFilename
 	if filename == "" {
 		filename = defaultApplicationFilename
 	}
 
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+	// Write the form once without the file to learn the boundary and the
+	// size of everything around the file, so the request has a Content-Length.
+	var overhead countingWriter
+	writer, err := writeJobApplication(&overhead, "", req, filename, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	boundary := writer.Boundary()
+
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := writeJobApplication(pw, boundary, req, filename, reader, size)
+		_ = pw.CloseWithError(err)
+	}()
+
+	body := &sizedBody{ReadCloser: pr, size: overhead.n + size}
+	resp, err := c.doRequestWithBody(ctx, "POST", "/job-apply", nil, body, writer.FormDataContentType())
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit job application: %w", err)
+	}
+
+	var response JobApplicationResponse
+	if err := c.decodeResponse(resp, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode job application response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// writeJobApplication writes req to w as a multipart form, using boundary
+// if it is not empty. It copies size bytes of file into the application
+// part; a nil file leaves the part empty.
+func writeJobApplication(w io.Writer, boundary string, req JobApplicationRequest, filename string, file io.Reader, size int64) (*multipart.Writer, error) {
+	writer := multipart.NewWriter(w)
+	if boundary != "" {
+		if err := writer.SetBoundary(boundary); err != nil {
+			return nil, err
+		}
+	}
 	if err := writer.WriteField("first_name", req.FirstName); err != nil {
 		return nil, NewValidationError("first_name", "failed to encode first_name field: "+err.Error())
 	}
@@ -44,22 +114,38 @@ func (c *Client) SubmitJobApplication(ctx context.Context, req JobApplicationReq
 	if err != nil {
 		return nil, NewValidationError("application", "failed to create application form file: "+err.Error())
 	}
-	if _, err := fileWriter.Write(req.Application); err != nil {
-		return nil, NewValidationError("application", "failed to encode application file data: "+err.Error())
+	if file != nil {
+		n, err := io.Copy(fileWriter, io.LimitReader(file, size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read application: %w", err)
+		}
+		if n != size {
+			return nil, NewValidationError("application", fmt.Sprintf("application has %d bytes, ApplicationSize is %d", n, size))
+		}
 	}
 	if err := writer.Close(); err != nil {
 		return nil, NewValidationError("application", "failed to finalize multipart body: "+err.Error())
 	}
+	return writer, nil
+}
 
-	resp, err := c.doRequestWithBody(ctx, "POST", "/job-apply", nil, &body, writer.FormDataContentType())
-	if err != nil {
-		return nil, fmt.Errorf("failed to submit job application: %w", err)
-	}
+// countingWriter discards what is written and counts the bytes.
+type countingWriter struct {
+	n int64
+}
 
-	var response JobApplicationResponse
-	if err := c.decodeResponse(resp, &response); err != nil {
-		return nil, fmt.Errorf("failed to decode job application response: %w", err)
-	}
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
 
-	return &response, nil
+// sizedBody is a streamed request body of known length.
+type sizedBody struct {
+	io.ReadCloser
+	size int64
+}
+
+// Len returns the size of the body, so requests can send a Content-Length.
+func (b *sizedBody) Len() int {
+	return int(b.size)
 }
@@ -0,0 +1,92 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_SubmitJobApplication_Streaming(t *testing.T) {
+	application := strings.Repeat("PK", 512<<10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading body: %v", err)
+		}
+		if r.ContentLength != int64(len(data)) {
+			t.Errorf("ContentLength = %d, body length %d", r.ContentLength, len(data))
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		if got := r.FormValue("github_url"); got != "https://github.com/janedoe" {
+			t.Errorf("github_url = %q", got)
+		}
+		file, header, err := r.FormFile("application")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		defer file.Close()
+		got, _ := io.ReadAll(file)
+		if header.Filename != "resume.zip" || string(got) != application {
+			t.Errorf("application = %s (%d bytes), want resume.zip (%d bytes)", header.Filename, len(got), len(application))
+		}
+		_, _ = w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	resp, err := client.SubmitJobApplication(context.Background(), JobApplicationRequest{
+		FirstName:           "Jane",
+		LastName:            "Doe",
+		Email:               "jane@example.com",
+		GitHubURL:           "https://github.com/janedoe",
+		ApplicationReader:   io.MultiReader(strings.NewReader(application[:10]), strings.NewReader(application[10:])),
+		ApplicationSize:     int64(len(application)),
+		ApplicationFilename: "resume.zip",
+	})
+	if err != nil {
+		t.Fatalf("SubmitJobApplication() error = %v", err)
+	}
+	if !resp.Success {
+		t.Error("Success = false, want true")
+	}
+}
+
+func TestClient_SubmitJobApplication_Size(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	req := JobApplicationRequest{FirstName: "Jane", LastName: "Doe", Email: "jane@example.com"}
+
+	tooLarge := req
+	tooLarge.ApplicationReader = strings.NewReader("zip")
+	tooLarge.ApplicationSize = MaxJobApplicationSize + 1
+	_, err := client.SubmitJobApplication(context.Background(), tooLarge)
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for an oversized application, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("calls = %d, want 0", calls)
+	}
+
+	short := req
+	short.ApplicationReader = strings.NewReader("zip")
+	short.ApplicationSize = 10
+	if _, err := client.SubmitJobApplication(context.Background(), short); err == nil {
+		t.Error("expected an error when the reader is shorter than ApplicationSize")
+	}
+}
@@ -0,0 +1,184 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIStats counts the API requests a Client has made.
+type APIStats struct {
+	// Requests is the number of API requests, counting retries of one
+	// request once.
+	Requests int64
+
+	// Errors is the number of those requests that failed with a network
+	// error or an HTTP error status.
+	Errors int64
+}
+
+// ErrorRate returns the fraction of requests that failed, or zero if none
+// were made.
+func (s APIStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// APIStats returns the number of API requests made with c so far and how
+// many of them failed.
+func (c *Client) APIStats() APIStats {
+	return APIStats{Requests: c.requests.Load(), Errors: c.requestErrors.Load()}
+}
+
+// BusinessMetrics is a snapshot of seller metrics computed by
+// MetricsExporter.
+type BusinessMetrics struct {
+	// CollectedAt is when the snapshot was taken.
+	CollectedAt time.Time
+
+	// OpenUnfulfilledOrders is the number of orders not yet fulfilled.
+	OpenUnfulfilledOrders int
+
+	// ListingCount, ListedQuantity and ListedValueCents describe the
+	// listings with at least one copy in stock.
+	ListingCount     int
+	ListedQuantity   int
+	ListedValueCents int
+
+	// DailyOrders and DailySalesCents count the orders placed since
+	// midnight and their totals.
+	DailyOrders     int
+	DailySalesCents int
+}
+
+// MetricsExporter periodically computes seller business metrics and serves
+// them, with the client's API request counters, in the Prometheus text
+// format. Register it as the handler for /metrics and start Run:
+//
+//	exporter := &manapool.MetricsExporter{Client: client}
+//	go exporter.Run(ctx, 5*time.Minute, func(err error) { log.Print(err) })
+//	http.Handle("/metrics", exporter)
+//
+// The API error rate can be graphed in Prometheus as
+// rate(manapool_api_errors_total[5m]) / rate(manapool_api_requests_total[5m]).
+// Each collection lists the whole inventory and the day's orders, so keep
+// the interval at minutes rather than seconds.
+type MetricsExporter struct {
+	// Client is used to compute the metrics.
+	Client *Client
+
+	// Location is the time zone whose midnight starts the daily sales
+	// window (default: UTC).
+	Location *time.Location
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu            sync.Mutex
+	metrics       *BusinessMetrics
+	collectErrors int64
+}
+
+// Collect computes the business metrics and stores them for ServeHTTP. On
+// failure the previous snapshot is kept and the failure is counted.
+func (m *MetricsExporter) Collect(ctx context.Context) (*BusinessMetrics, error) {
+	metrics, err := m.collect(ctx)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.collectErrors++
+		return nil, fmt.Errorf("failed to collect metrics: %w", err)
+	}
+	m.metrics = metrics
+	return metrics, nil
+}
+
+func (m *MetricsExporter) collect(ctx context.Context) (*BusinessMetrics, error) {
+	if m.Client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	now := time.Now()
+	if m.Now != nil {
+		now = m.Now()
+	}
+	location := m.Location
+	if location == nil {
+		location = time.UTC
+	}
+	metrics := &BusinessMetrics{CollectedAt: now}
+
+	open, err := m.Client.GetSellerOrdersCount(ctx, OrdersOptions{IsFulfilled: Bool(false)})
+	if err != nil {
+		return nil, err
+	}
+	metrics.OpenUnfulfilledOrders = open
+
+	inventory, err := m.Client.collectInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range inventory {
+		if item.Quantity > 0 {
+			metrics.ListingCount++
+			metrics.ListedQuantity += item.Quantity
+			metrics.ListedValueCents += item.PriceCents * item.Quantity
+		}
+	}
+
+	local := now.In(location)
+	midnight := Timestamp{Time: time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)}
+	err = m.Client.IterateSellerOrders(ctx, OrdersOptions{Since: &midnight}, func(order *OrderSummary) error {
+		metrics.DailyOrders++
+		metrics.DailySalesCents += order.TotalCents
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// Run calls Collect every interval until ctx is cancelled, passing each
+// failure to onError if it is not nil.
+func (m *MetricsExporter) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		_, err := m.Collect(ctx)
+		return err
+	}, onError)
+}
+
+// ServeHTTP writes the latest metrics in the Prometheus text exposition
+// format. Business metrics are left out until the first successful Collect.
+func (m *MetricsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	metrics, collectErrors := m.metrics, m.collectErrors
+	m.mu.Unlock()
+
+	var b strings.Builder
+	metric := func(name, kind, help string, value any) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, kind, name, value)
+	}
+	if metrics != nil {
+		metric("manapool_open_unfulfilled_orders", "gauge", "Seller orders not yet fulfilled.", metrics.OpenUnfulfilledOrders)
+		metric("manapool_listings", "gauge", "Listings with at least one copy in stock.", metrics.ListingCount)
+		metric("manapool_listed_quantity", "gauge", "Copies in stock across all listings.", metrics.ListedQuantity)
+		metric("manapool_listed_value_cents", "gauge", "Sum of price times quantity over all listings, in cents.", metrics.ListedValueCents)
+		metric("manapool_daily_orders", "gauge", "Orders placed since midnight.", metrics.DailyOrders)
+		metric("manapool_daily_sales_cents", "gauge", "Total of orders placed since midnight, in cents.", metrics.DailySalesCents)
+		metric("manapool_metrics_collected_timestamp_seconds", "gauge", "Unix time of the last successful collection.", metrics.CollectedAt.Unix())
+	}
+	metric("manapool_metrics_collect_errors_total", "counter", "Failed metric collections.", collectErrors)
+	if m.Client != nil {
+		stats := m.Client.APIStats()
+		metric("manapool_api_requests_total", "counter", "API requests made by the client.", stats.Requests)
+		metric("manapool_api_errors_total", "counter", "API requests that failed with a network error or error status.", stats.Errors)
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
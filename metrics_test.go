@@ -0,0 +1,162 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetricsExporter(t *testing.T) {
+	var fail atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/seller/inventory":
+			fmt.Fprint(w, `{"inventory":[{"price_cents":250,"quantity":3},{"price_cents":1000,"quantity":1},{"price_cents":500,"quantity":0}],`+
+				`"pagination":{"total":3,"returned":3,"offset":0,"limit":500}}`)
+		case r.URL.Path == "/seller/orders" && query.Get("is_fulfilled") == "false":
+			fmt.Fprint(w, `{"orders":[{"id":"o1"}],"pagination":{"total":4,"returned":1,"offset":0,"limit":1}}`)
+		case r.URL.Path == "/seller/orders":
+			if got, want := query.Get("since"), "2024-05-02T00:00:00-04:00"; got != want {
+				t.Errorf("since = %q, want %q", got, want)
+			}
+			fmt.Fprint(w, `{"orders":[{"id":"o1","total_cents":1200},{"id":"o2","total_cents":300}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	exporter := &MetricsExporter{
+		Client:   client,
+		Location: newYork,
+		Now:      func() time.Time { return time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC) },
+	}
+
+	metrics, err := exporter.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	want := BusinessMetrics{
+		CollectedAt:           exporter.Now(),
+		OpenUnfulfilledOrders: 4,
+		ListingCount:          2,
+		ListedQuantity:        4,
+		ListedValueCents:      1750,
+		DailyOrders:           2,
+		DailySalesCents:       1500,
+	}
+	if *metrics != want {
+		t.Errorf("Collect() = %+v, want %+v", *metrics, want)
+	}
+
+	fail.Store(true)
+	if _, err := exporter.Collect(context.Background()); err == nil {
+		t.Fatal("Collect() error = nil, want error")
+	}
+
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", got)
+	}
+	body := rec.Body.String()
+	for _, line := range []string{
+		"# TYPE manapool_open_unfulfilled_orders gauge",
+		"manapool_open_unfulfilled_orders 4",
+		"manapool_listed_value_cents 1750",
+		"manapool_daily_sales_cents 1500",
+		"manapool_metrics_collected_timestamp_seconds 1714662000",
+		"manapool_metrics_collect_errors_total 1",
+		"# TYPE manapool_api_requests_total counter",
+		"manapool_api_requests_total 4",
+		"manapool_api_errors_total 1",
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("metrics missing %q:\n%s", line, body)
+		}
+	}
+}
+
+func TestMetricsExporter_BeforeCollect(t *testing.T) {
+	exporter := &MetricsExporter{Client: NewClient("test-token", "test@example.com")}
+
+	server := httptest.NewServer(exporter)
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET error = %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if strings.Contains(string(body), "manapool_listings") {
+		t.Errorf("business metrics served before Collect:\n%s", body)
+	}
+	if !strings.Contains(string(body), "manapool_api_requests_total 0\n") {
+		t.Errorf("missing API request counter:\n%s", body)
+	}
+}
+
+func TestMetricsExporter_Run(t *testing.T) {
+	exporter := &MetricsExporter{}
+	if err := exporter.Run(context.Background(), 0, nil); err == nil {
+		t.Error("Run() with zero interval error = nil, want error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var got error
+	err := exporter.Run(ctx, time.Hour, func(err error) {
+		got = err
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	var validationErr *ValidationError
+	if !errors.As(got, &validationErr) {
+		t.Errorf("onError got %v, want *ValidationError", got)
+	}
+}
+
+func TestClient_APIStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/account" {
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+
+	_, _ = client.GetSellerAccount(context.Background())
+	_, _ = client.GetSellerOrder(context.Background(), "missing")
+
+	stats := client.APIStats()
+	if stats.Requests != 2 || stats.Errors != 1 {
+		t.Errorf("APIStats() = %+v, want 2 requests, 1 error", stats)
+	}
+	if got := stats.ErrorRate(); got != 0.5 {
+		t.Errorf("ErrorRate() = %v, want 0.5", got)
+	}
+	if got := (APIStats{}).ErrorRate(); got != 0 {
+		t.Errorf("ErrorRate() with no requests = %v, want 0", got)
+	}
+}
@@ -0,0 +1,266 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMoxfieldBaseURL is the public Moxfield deck endpoint used by MoxfieldImporter.
+const DefaultMoxfieldBaseURL = "https://api2.moxfield.com/v2/decks/all/"
+
+// Moxfield board names.
+const (
+	MoxfieldMainboard  = "mainboard"
+	MoxfieldSideboard  = "sideboard"
+	MoxfieldCommanders = "commanders"
+)
+
+var moxfieldDeckIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// MoxfieldDeck is the subset of a public Moxfield deck used for importing.
+type MoxfieldDeck struct {
+	PublicID   string                  `json:"publicId"`
+	Name       string                  `json:"name"`
+	Format     string                  `json:"format"`
+	Mainboard  map[string]MoxfieldCard `json:"mainboard"`
+	Sideboard  map[string]MoxfieldCard `json:"sideboard"`
+	Commanders map[string]MoxfieldCard `json:"commanders"`
+}
+
+// MoxfieldCard is a single board entry in a Moxfield deck.
+type MoxfieldCard struct {
+	Quantity int    `json:"quantity"`
+	Finish   string `json:"finish"`
+	IsFoil   bool   `json:"isFoil"`
+	Card     struct {
+		Name string `json:"name"`
+		Set  string `json:"set"`
+		CN   string `json:"cn"`
+	} `json:"card"`
+}
+
+// MoxfieldImportOptions controls how a Moxfield deck is converted to an optimizer request.
+type MoxfieldImportOptions struct {
+	// Boards lists the boards to include (default: mainboard, sideboard and commanders).
+	Boards []string
+
+	// IgnorePrintings drops the set, collector number and finish chosen in
+	// Moxfield so the optimizer may pick any printing.
+	IgnorePrintings bool
+
+	// Model is passed through to OptimizerRequest.Model.
+	Model string
+
+	// DestinationCountry is passed through to OptimizerRequest.DestinationCountry.
+	DestinationCountry string
+}
+
+// MoxfieldImporter fetches public Moxfield decks.
+//
+// Example:
+//
+//	importer := &manapool.MoxfieldImporter{}
+//	req, err := importer.Import(ctx, "https://www.moxfield.com/decks/abc123", manapool.MoxfieldImportOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cart, err := client.OptimizeCart(ctx, *req)
+type MoxfieldImporter struct {
+	// HTTPClient is used for requests (default: a client with a 30 second timeout).
+	HTTPClient *http.Client
+
+	// BaseURL is the deck endpoint prefix (default: DefaultMoxfieldBaseURL).
+	BaseURL string
+}
+
+// ParseMoxfieldDeckID extracts the public deck ID from a Moxfield deck URL,
+// or returns the input unchanged if it is already a bare ID.
+func ParseMoxfieldDeckID(urlOrID string) (string, error) {
+	s := strings.TrimSpace(urlOrID)
+	if s == "" {
+		return "", NewValidationError("deck", "deck URL or ID cannot be empty")
+	}
+
+	if strings.Contains(s, "/") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", NewValidationError("deck", fmt.Sprintf("invalid deck URL: %v", err))
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		s = ""
+		for i := 0; i < len(parts)-1; i++ {
+			if parts[i] == "decks" {
+				s = parts[i+1]
+				break
+			}
+		}
+		if s == "" {
+			return "", NewValidationError("deck", "URL is not a Moxfield deck URL")
+		}
+	}
+
+	if !moxfieldDeckIDPattern.MatchString(s) {
+		return "", NewValidationError("deck", "invalid Moxfield deck ID")
+	}
+	return s, nil
+}
+
+// FetchDeck downloads a public Moxfield deck by URL or ID.
+func (m *MoxfieldImporter) FetchDeck(ctx context.Context, urlOrID string) (*MoxfieldDeck, error) {
+	id, err := ParseMoxfieldDeckID(urlOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := m.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultMoxfieldBaseURL
+	}
+	httpClient := m.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", strings.TrimSuffix(baseURL, "/")+"/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, NewNetworkError("failed to fetch Moxfield deck", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to fetch Moxfield deck %s: status %d: %s", id, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var deck MoxfieldDeck
+	if err := json.NewDecoder(resp.Body).Decode(&deck); err != nil {
+		return nil, fmt.Errorf("failed to decode Moxfield deck: %w", err)
+	}
+
+	return &deck, nil
+}
+
+// Import fetches a public Moxfield deck and converts it to an optimizer request.
+func (m *MoxfieldImporter) Import(ctx context.Context, urlOrID string, opts MoxfieldImportOptions) (*OptimizerRequest, error) {
+	deck, err := m.FetchDeck(ctx, urlOrID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := deck.OptimizerRequest(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// OptimizerRequest converts the deck into an optimizer request. Entries for
+// the same printing on different boards are combined into one cart item.
+func (d *MoxfieldDeck) OptimizerRequest(opts MoxfieldImportOptions) (OptimizerRequest, error) {
+	boards := opts.Boards
+	if len(boards) == 0 {
+		boards = []string{MoxfieldMainboard, MoxfieldSideboard, MoxfieldCommanders}
+	}
+
+	type printing struct {
+		name, set, number, finish string
+	}
+	quantities := make(map[printing]int)
+	for _, board := range boards {
+		var entries map[string]MoxfieldCard
+		switch board {
+		case MoxfieldMainboard:
+			entries = d.Mainboard
+		case MoxfieldSideboard:
+			entries = d.Sideboard
+		case MoxfieldCommanders:
+			entries = d.Commanders
+		default:
+			return OptimizerRequest{}, NewValidationError("boards", fmt.Sprintf("unknown Moxfield board %q", board))
+		}
+
+		for key, entry := range entries {
+			if entry.Quantity <= 0 {
+				continue
+			}
+			p := printing{name: entry.Card.Name}
+			if p.name == "" {
+				p.name = key
+			}
+			if !opts.IgnorePrintings {
+				p.set = strings.ToUpper(entry.Card.Set)
+				p.number = entry.Card.CN
+				p.finish = moxfieldFinishID(entry)
+			}
+			quantities[p] += entry.Quantity
+		}
+	}
+
+	keys := make([]printing, 0, len(quantities))
+	for p := range quantities {
+		keys = append(keys, p)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.set != b.set {
+			return a.set < b.set
+		}
+		if a.number != b.number {
+			return a.number < b.number
+		}
+		return a.finish < b.finish
+	})
+
+	req := OptimizerRequest{
+		Cart:               make([]OptimizerCartItem, 0, len(keys)),
+		Model:              opts.Model,
+		DestinationCountry: opts.DestinationCountry,
+	}
+	for _, p := range keys {
+		item := OptimizerCartItem{
+			Type:              "mtg_single",
+			Name:              p.name,
+			SetCode:           p.set,
+			CollectorNumber:   p.number,
+			QuantityRequested: quantities[p],
+		}
+		if p.finish != "" {
+			item.FinishIDs = []string{p.finish}
+		}
+		req.Cart = append(req.Cart, item)
+	}
+
+	return req, nil
+}
+
+// moxfieldFinishID maps a Moxfield finish to a Mana Pool finish ID.
+func moxfieldFinishID(entry MoxfieldCard) string {
+	switch strings.ToLower(entry.Finish) {
+	case "nonfoil":
+		return "NF"
+	case "foil":
+		return "FO"
+	case "etched":
+		return "EF"
+	}
+	if entry.IsFoil {
+		return "FO"
+	}
+	return ""
+}
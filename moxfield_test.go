@@ -0,0 +1,127 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testMoxfieldDeck = `{
+	"publicId": "abc123",
+	"name": "Test Deck",
+	"format": "commander",
+	"mainboard": {
+		"Sol Ring": {"quantity": 1, "finish": "nonFoil", "card": {"name": "Sol Ring", "set": "c21", "cn": "263"}},
+		"Island": {"quantity": 30, "finish": "foil", "card": {"name": "Island", "set": "unf", "cn": "236"}}
+	},
+	"sideboard": {
+		"Sol Ring": {"quantity": 1, "finish": "nonFoil", "card": {"name": "Sol Ring", "set": "c21", "cn": "263"}},
+		"Counterspell": {"quantity": 0, "card": {"name": "Counterspell", "set": "mh2", "cn": "267"}}
+	},
+	"commanders": {
+		"Urza, Lord High Artificer": {"quantity": 1, "finish": "etched", "card": {"name": "Urza, Lord High Artificer", "set": "mh1", "cn": "75"}}
+	}
+}`
+
+func TestParseMoxfieldDeckID(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"abc123", "abc123", false},
+		{"https://www.moxfield.com/decks/abc-12_3", "abc-12_3", false},
+		{"https://moxfield.com/decks/abc123/primer", "abc123", false},
+		{"", "", true},
+		{"https://www.moxfield.com/users/someone", "", true},
+		{"bad id!", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMoxfieldDeckID(tt.input)
+			if tt.wantErr {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseMoxfieldDeckID() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoxfieldImporter_Import(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/decks/all/abc123":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testMoxfieldDeck))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	importer := &MoxfieldImporter{BaseURL: server.URL + "/decks/all/"}
+	ctx := context.Background()
+
+	t.Run("with printings", func(t *testing.T) {
+		req, err := importer.Import(ctx, "https://www.moxfield.com/decks/abc123", MoxfieldImportOptions{Model: "lowest_price"})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+		if req.Model != "lowest_price" {
+			t.Errorf("model = %q", req.Model)
+		}
+		if len(req.Cart) != 3 {
+			t.Fatalf("cart items = %d, want 3: %+v", len(req.Cart), req.Cart)
+		}
+
+		island, sol, urza := req.Cart[0], req.Cart[1], req.Cart[2]
+		if island.Name != "Island" || island.SetCode != "UNF" || island.QuantityRequested != 30 || island.FinishIDs[0] != "FO" {
+			t.Errorf("unexpected island item: %+v", island)
+		}
+		if sol.Name != "Sol Ring" || sol.QuantityRequested != 2 || sol.CollectorNumber != "263" || sol.FinishIDs[0] != "NF" {
+			t.Errorf("unexpected sol ring item: %+v", sol)
+		}
+		if urza.FinishIDs[0] != "EF" || urza.Type != "mtg_single" {
+			t.Errorf("unexpected commander item: %+v", urza)
+		}
+	})
+
+	t.Run("ignore printings and boards", func(t *testing.T) {
+		req, err := importer.Import(ctx, "abc123", MoxfieldImportOptions{
+			Boards:          []string{MoxfieldMainboard},
+			IgnorePrintings: true,
+		})
+		if err != nil {
+			t.Fatalf("Import() error = %v", err)
+		}
+		if len(req.Cart) != 2 {
+			t.Fatalf("cart items = %d, want 2", len(req.Cart))
+		}
+		if req.Cart[1].SetCode != "" || req.Cart[1].FinishIDs != nil || req.Cart[1].QuantityRequested != 1 {
+			t.Errorf("printing not ignored: %+v", req.Cart[1])
+		}
+	})
+
+	t.Run("unknown board", func(t *testing.T) {
+		_, err := importer.Import(ctx, "abc123", MoxfieldImportOptions{Boards: []string{"maybeboard"}})
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.Field != "boards" {
+			t.Fatalf("expected boards ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := importer.FetchDeck(ctx, "missing"); err == nil {
+			t.Fatal("expected error for missing deck")
+		}
+	})
+}
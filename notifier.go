@@ -0,0 +1,152 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// maxNotifyRetryAfter caps how long a rate-limited notification waits
+// before its single retry.
+const maxNotifyRetryAfter = time.Minute
+
+// Notification is a message sent to a Notifier by monitors and watchers.
+type Notification struct {
+	// Event identifies what happened, for example "sla_breach" or one of the
+	// OrderEventType values.
+	Event string
+
+	// Title is a short, single-line summary.
+	Title string
+
+	// Body is the full human-readable message.
+	Body string
+
+	// Data is the value the notification describes, such as an OrderEvent
+	// or *SLAReport, for templates that need more than Title and Body.
+	Data any
+}
+
+// Notifier delivers notifications to an external channel such as chat or email.
+// Implementations must be safe for concurrent use.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, n Notification) error
+
+// Notify implements Notifier.
+func (f NotifierFunc) Notify(ctx context.Context, n Notification) error {
+	return f(ctx, n)
+}
+
+// NotificationTemplates customizes notification messages per event. Each
+// template is executed with the Notification as its data, so it can use
+// {{.Title}}, {{.Body}} and fields of {{.Data}}. The "" key applies to
+// events without a template of their own.
+//
+// Example:
+//
+//	templates := manapool.NotificationTemplates{
+//	    string(manapool.OrderEventNew): template.Must(template.New("").Parse(
+//	        "Sold! {{.Data.Order.Label}}: {{len .Data.Order.Items}} item(s)")),
+//	}
+type NotificationTemplates map[string]*template.Template
+
+// Render executes the template for n.Event, or the "" template if the event
+// has none. ok is false when neither exists.
+func (t NotificationTemplates) Render(n Notification) (text string, ok bool, err error) {
+	tmpl := t[n.Event]
+	if tmpl == nil {
+		tmpl = t[""]
+	}
+	if tmpl == nil {
+		return "", false, nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, n); err != nil {
+		return "", true, fmt.Errorf("failed to render %s notification: %w", n.Event, err)
+	}
+	return b.String(), true, nil
+}
+
+// formatDollars formats an amount in cents as dollars, e.g. "$12.34".
+func formatDollars(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
+
+// postNotification posts a JSON body to a chat webhook or API endpoint and
+// returns the response body. A rate-limited (429) post is retried once after
+// its Retry-After delay, if that is at most maxNotifyRetryAfter.
+func postNotification(ctx context.Context, httpClient *http.Client, endpoint string, header http.Header, body []byte) ([]byte, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", fmt.Sprintf("manapool-go/%s", Version))
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, NewNetworkError("failed to send notification", err)
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxDrainBytes))
+		_ = resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return data, nil
+		}
+
+		err = fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+		seconds, _ := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+		delay := time.Duration(seconds * float64(time.Second))
+		if resp.StatusCode != http.StatusTooManyRequests || attempt > 0 || delay <= 0 || delay > maxNotifyRetryAfter {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// notificationWanted reports whether event is in events, or events is empty.
+func notificationWanted(events []string, event string) bool {
+	if len(events) == 0 {
+		return true
+	}
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateRunes shortens s to at most limit runes, ending in an ellipsis
+// when it was cut.
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
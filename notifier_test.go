@@ -0,0 +1,35 @@
+package manapool
+
+import (
+	"testing"
+	"text/template"
+)
+
+func TestNotificationTemplates_Render(t *testing.T) {
+	templates := NotificationTemplates{
+		"payout": template.Must(template.New("").Parse("paid: {{.Title}}")),
+		"":       template.Must(template.New("").Parse("{{.Event}}: {{.Body}}")),
+	}
+
+	if text, ok, err := templates.Render(Notification{Event: "payout", Title: "p1"}); err != nil || !ok || text != "paid: p1" {
+		t.Errorf("Render(payout) = %q, %v, %v", text, ok, err)
+	}
+	if text, ok, err := templates.Render(Notification{Event: "sla_breach", Body: "late"}); err != nil || !ok || text != "sla_breach: late" {
+		t.Errorf("Render(default) = %q, %v, %v", text, ok, err)
+	}
+	if _, ok, err := (NotificationTemplates{}).Render(Notification{Event: "payout"}); ok || err != nil {
+		t.Errorf("Render() without templates = %v, %v", ok, err)
+	}
+	var nilTemplates NotificationTemplates
+	if _, ok, _ := nilTemplates.Render(Notification{}); ok {
+		t.Error("nil templates should not render")
+	}
+}
+
+func TestFormatDollars(t *testing.T) {
+	for cents, want := range map[int]string{0: "$0.00", 1234: "$12.34", -5: "-$0.05"} {
+		if got := formatDollars(cents); got != want {
+			t.Errorf("formatDollars(%d) = %q, want %q", cents, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ObjectStore is durable storage for price snapshots and inventory backups,
+// such as an S3 or GCS bucket. Implementations must be safe for concurrent
+// use.
+type ObjectStore interface {
+	// Put stores size bytes read from body under key, replacing any object
+	// already there.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get opens the object stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// List returns the objects whose keys start with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+
+	// Delete removes the object under key. Deleting a missing object is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// RetentionPolicy limits how many objects under a prefix are kept. An
+// object is deleted when it is older than MaxAge or not among the MaxCount
+// most recent; a zero field does not limit.
+type RetentionPolicy struct {
+	MaxAge   time.Duration
+	MaxCount int
+}
+
+// Prune applies the policy to the objects under prefix, as of now, and
+// returns the keys deleted. Objects are ordered by LastModified, then key.
+//
+// Example:
+//
+//	policy := manapool.RetentionPolicy{MaxAge: 30 * 24 * time.Hour, MaxCount: 60}
+//	deleted, err := policy.Prune(ctx, store, "snapshots/prices/singles/", time.Now())
+func (p RetentionPolicy) Prune(ctx context.Context, store ObjectStore, prefix string, now time.Time) ([]string, error) {
+	if p.MaxAge <= 0 && p.MaxCount <= 0 {
+		return nil, nil
+	}
+	objects, err := store.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s for retention: %w", prefix, err)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if !objects[i].LastModified.Equal(objects[j].LastModified) {
+			return objects[i].LastModified.After(objects[j].LastModified)
+		}
+		return objects[i].Key > objects[j].Key
+	})
+
+	var deleted []string
+	for i, object := range objects {
+		expired := p.MaxAge > 0 && now.Sub(object.LastModified) > p.MaxAge
+		excess := p.MaxCount > 0 && i >= p.MaxCount
+		if !expired && !excess {
+			continue
+		}
+		if err := store.Delete(ctx, object.Key); err != nil {
+			return deleted, fmt.Errorf("failed to delete %s: %w", object.Key, err)
+		}
+		deleted = append(deleted, object.Key)
+	}
+	return deleted, nil
+}
+
+// objectStoreError formats an unsuccessful response from a storage API,
+// preferring message, the error the API reported, over the raw body.
+func objectStoreError(service string, status int, message string, body []byte) error {
+	if message == "" {
+		message = strings.TrimSpace(string(body))
+	}
+	return fmt.Errorf("%s status %d: %s", service, status, message)
+}
@@ -0,0 +1,125 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore is an in-memory ObjectStore.
+type memoryStore struct {
+	mu      sync.Mutex
+	now     time.Time
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data        []byte
+	contentType string
+	modified    time.Time
+}
+
+func (m *memoryStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return errors.New("size mismatch")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.objects == nil {
+		m.objects = make(map[string]memoryObject)
+	}
+	m.objects[key] = memoryObject{data: data, contentType: contentType, modified: m.now}
+	return nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	object, ok := m.objects[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(object.data)), nil
+}
+
+func (m *memoryStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var objects []ObjectInfo
+	for key, object := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, ObjectInfo{Key: key, Size: int64(len(object.data)), LastModified: object.modified})
+		}
+	}
+	return objects, nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *memoryStore) keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []string
+	for key := range m.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestRetentionPolicy_Prune(t *testing.T) {
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	newStore := func() *memoryStore {
+		store := &memoryStore{}
+		for day := 1; day <= 9; day += 2 {
+			store.now = time.Date(2024, 5, day, 0, 0, 0, 0, time.UTC)
+			key := "snap/" + store.now.Format("0102")
+			_ = store.Put(context.Background(), key, strings.NewReader(""), 0, "")
+		}
+		store.now = now
+		_ = store.Put(context.Background(), "other/0510", strings.NewReader(""), 0, "")
+		return store
+	}
+
+	tests := []struct {
+		name        string
+		policy      RetentionPolicy
+		wantDeleted []string
+	}{
+		{"unlimited", RetentionPolicy{}, nil},
+		{"max age", RetentionPolicy{MaxAge: 4 * 24 * time.Hour}, []string{"snap/0505", "snap/0503", "snap/0501"}},
+		{"max count", RetentionPolicy{MaxCount: 2}, []string{"snap/0505", "snap/0503", "snap/0501"}},
+		{"both", RetentionPolicy{MaxAge: 8 * 24 * time.Hour, MaxCount: 4}, []string{"snap/0501"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newStore()
+			deleted, err := tt.policy.Prune(context.Background(), store, "snap/", now)
+			if err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if !reflect.DeepEqual(deleted, tt.wantDeleted) {
+				t.Errorf("Prune() deleted %v, want %v", deleted, tt.wantDeleted)
+			}
+			if keys := store.keys(); len(keys) != 6-len(tt.wantDeleted) {
+				t.Errorf("remaining keys = %v", keys)
+			}
+		})
+	}
+}
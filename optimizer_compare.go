@@ -0,0 +1,101 @@
+package manapool
+
+import (
+	"context"
+
+	"github.com/repricah/manapool/internal/parallel"
+)
+
+// Optimizer models accepted by OptimizerRequest.Model.
+const (
+	OptimizerModelLowestPrice    = "lowest_price"
+	OptimizerModelBalanced       = "balanced"
+	OptimizerModelFewestPackages = "fewest_packages"
+)
+
+// OptimizerModels lists every optimizer model, in the order used by
+// CompareOptimizerModels when no models are given.
+var OptimizerModels = []string{OptimizerModelLowestPrice, OptimizerModelBalanced, OptimizerModelFewestPackages}
+
+// OptimizerComparison is one model's result in a CompareOptimizerModels run.
+type OptimizerComparison struct {
+	Model string
+
+	// Cart is the optimized cart, or nil if Err is set.
+	Cart *OptimizedCart
+	Err  error
+
+	TotalCents     int
+	ShippingCents  int
+	SellerCount    int
+	ItemsRequested int
+	ItemsSelected  int
+
+	// FillRate is ItemsSelected divided by ItemsRequested.
+	FillRate float64
+}
+
+// CompareOptimizerModels optimizes the same cart with each model concurrently
+// and returns the results in the order the models were given. If no models
+// are given, every model in OptimizerModels is compared. A failure for one
+// model is reported in its Err field and does not affect the others.
+//
+// Example:
+//
+//	results, err := client.CompareOptimizerModels(ctx, req)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, r := range results {
+//	    fmt.Printf("%-16s $%.2f from %d sellers (%.0f%% filled)\n",
+//	        r.Model, float64(r.TotalCents)/100, r.SellerCount, r.FillRate*100)
+//	}
+func (c *Client) CompareOptimizerModels(ctx context.Context, req OptimizerRequest, models ...string) ([]OptimizerComparison, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	if len(models) == 0 {
+		models = OptimizerModels
+	}
+	for _, model := range models {
+		if model == "" {
+			return nil, NewValidationError("models", "model cannot be empty")
+		}
+	}
+
+	requested := 0
+	for _, item := range req.Cart {
+		requested += item.QuantityRequested
+	}
+
+	results := make([]OptimizerComparison, len(models))
+	// Each model's error is reported in its result, so Run never fails.
+	_ = parallel.Run(ctx, len(models), parallel.Options{Concurrency: len(models)}, func(ctx context.Context, i int) error {
+		model := models[i]
+		modelReq := req
+		modelReq.Model = model
+		result := OptimizerComparison{Model: model, ItemsRequested: requested}
+		result.Cart, result.Err = c.OptimizeCart(ctx, modelReq)
+		if result.Err == nil {
+			result.TotalCents = result.Cart.Totals.TotalCents
+			result.ShippingCents = result.Cart.Totals.ShippingCents
+			result.SellerCount = result.Cart.Totals.SellerCount
+			for _, item := range result.Cart.Cart {
+				result.ItemsSelected += item.QuantitySelected
+			}
+			if requested > 0 {
+				result.FillRate = float64(result.ItemsSelected) / float64(requested)
+			}
+		}
+		results[i] = result
+		return nil
+	})
+	for i, result := range results {
+		if result.Model == "" {
+			// Not started because ctx was cancelled.
+			results[i] = OptimizerComparison{Model: models[i], ItemsRequested: requested, Err: ctx.Err()}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_CompareOptimizerModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OptimizerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode optimizer request: %v", err)
+		}
+		switch req.Model {
+		case OptimizerModelLowestPrice:
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"a","quantity_selected":2},{"inventory_id":"b","quantity_selected":1}],"totals":{"subtotal_cents":1000,"shipping_cents":900,"total_cents":1900,"seller_count":3}}`))
+		case OptimizerModelFewestPackages:
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"c","quantity_selected":3}],"totals":{"subtotal_cents":1800,"shipping_cents":300,"total_cents":2100,"seller_count":1}}`))
+		case OptimizerModelBalanced:
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"d","quantity_selected":2}],"totals":{"subtotal_cents":1200,"shipping_cents":500,"total_cents":1700,"seller_count":2}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid model"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := OptimizerRequest{Cart: []OptimizerCartItem{{Type: "mtg_single", Name: "Card", QuantityRequested: 3}}}
+
+	t.Run("all models", func(t *testing.T) {
+		results, err := client.CompareOptimizerModels(ctx, req)
+		if err != nil {
+			t.Fatalf("CompareOptimizerModels() error = %v", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("results = %d, want 3", len(results))
+		}
+		for i, model := range OptimizerModels {
+			if results[i].Model != model || results[i].Err != nil {
+				t.Errorf("result %d = %+v", i, results[i])
+			}
+		}
+		if r := results[0]; r.TotalCents != 1900 || r.SellerCount != 3 || r.ItemsSelected != 3 || r.FillRate != 1 {
+			t.Errorf("unexpected lowest_price result: %+v", r)
+		}
+		if r := results[1]; r.ItemsSelected != 2 || r.ItemsRequested != 3 || r.FillRate < 0.66 || r.FillRate > 0.67 {
+			t.Errorf("unexpected balanced fill: %+v", r)
+		}
+		if req.Model != "" {
+			t.Error("request model was modified")
+		}
+	})
+
+	t.Run("per-model error", func(t *testing.T) {
+		results, err := client.CompareOptimizerModels(ctx, req, OptimizerModelFewestPackages, "unknown")
+		if err != nil {
+			t.Fatalf("CompareOptimizerModels() error = %v", err)
+		}
+		if results[0].Err != nil || results[0].ShippingCents != 300 {
+			t.Errorf("unexpected fewest_packages result: %+v", results[0])
+		}
+		var apiErr *APIError
+		if !errors.As(results[1].Err, &apiErr) || results[1].Cart != nil {
+			t.Errorf("expected APIError for unknown model, got %+v", results[1])
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.CompareOptimizerModels(ctx, OptimizerRequest{}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty cart, got %v", err)
+		}
+		if _, err := client.CompareOptimizerModels(ctx, req, ""); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty model, got %v", err)
+		}
+	})
+}
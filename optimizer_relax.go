@@ -0,0 +1,217 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Constraint names an optimizer cart item restriction that can be relaxed.
+type Constraint string
+
+// Optimizer cart item constraints, in the default relaxation order.
+const (
+	ConstraintFinish    Constraint = "finish"
+	ConstraintCondition Constraint = "condition"
+	ConstraintLanguage  Constraint = "language"
+)
+
+// DefaultRelaxationOrder is the order in which constraints are relaxed when
+// RelaxationOptions.Order is empty.
+var DefaultRelaxationOrder = []Constraint{ConstraintFinish, ConstraintCondition, ConstraintLanguage}
+
+// OptimizerShortage is a cart item the optimizer could not fill, as reported
+// in a 409 Conflict response.
+type OptimizerShortage struct {
+	Item           OptimizerCartItem `json:"item"`
+	TotalAvailable int               `json:"total_available"`
+}
+
+// OptimizerShortages extracts the unfillable items from an OptimizeCart
+// error. It returns false if err is not an optimizer conflict.
+func OptimizerShortages(err error) ([]OptimizerShortage, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || len(apiErr.Details) == 0 {
+		return nil, false
+	}
+	var shortages []OptimizerShortage
+	if json.Unmarshal(apiErr.Details, &shortages) != nil {
+		return nil, false
+	}
+	return shortages, true
+}
+
+// RelaxationOptions controls OptimizeCartWithRelaxation.
+type RelaxationOptions struct {
+	// Order lists the constraints to relax, one per retry (default: DefaultRelaxationOrder).
+	Order []Constraint
+}
+
+// RelaxedItem records the constraints dropped for one cart item.
+type RelaxedItem struct {
+	// Index is the item's position in the original request cart.
+	Index   int
+	Name    string
+	Relaxed []Constraint
+}
+
+// RelaxedOptimization is the result of OptimizeCartWithRelaxation.
+type RelaxedOptimization struct {
+	// Cart is the optimized cart from the final, successful attempt.
+	Cart *OptimizedCart
+
+	// Request is the request that produced Cart.
+	Request OptimizerRequest
+
+	// Relaxed lists the items whose constraints were dropped, in cart order.
+	Relaxed []RelaxedItem
+
+	// Attempts is the number of optimizer calls made.
+	Attempts int
+}
+
+// OptimizeCartWithRelaxation runs OptimizeCart and, if the optimizer cannot
+// fill the cart, retries with progressively fewer finish, condition and
+// language restrictions. When the optimizer reports which items are short,
+// only those items are relaxed; for other client errors every item that still
+// has a restriction is relaxed. Authentication, rate limit, server and network
+// errors are returned without retrying.
+//
+// If every constraint has been relaxed and the cart still cannot be filled,
+// the last error is returned along with the partial result so callers can
+// see what was attempted.
+//
+// Example:
+//
+//	result, err := client.OptimizeCartWithRelaxation(ctx, req, manapool.RelaxationOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, item := range result.Relaxed {
+//	    fmt.Printf("%s: relaxed %v\n", item.Name, item.Relaxed)
+//	}
+func (c *Client) OptimizeCartWithRelaxation(ctx context.Context, req OptimizerRequest, opts RelaxationOptions) (*RelaxedOptimization, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	order := opts.Order
+	if len(order) == 0 {
+		order = DefaultRelaxationOrder
+	}
+	for _, constraint := range order {
+		switch constraint {
+		case ConstraintFinish, ConstraintCondition, ConstraintLanguage:
+		default:
+			return nil, NewValidationError("order", fmt.Sprintf("unknown constraint %q", constraint))
+		}
+	}
+
+	// Work on a copy with explicit indexes so conflict details can be matched
+	// back to the original cart positions.
+	current := req
+	current.Cart = make([]OptimizerCartItem, len(req.Cart))
+	for i, item := range req.Cart {
+		index := i
+		item.Index = &index
+		current.Cart[i] = item.Clone()
+	}
+
+	stage := make([]int, len(current.Cart))
+	relaxed := make([][]Constraint, len(current.Cart))
+	result := &RelaxedOptimization{}
+
+	for {
+		result.Attempts++
+		cart, err := c.OptimizeCart(ctx, current)
+		if err == nil {
+			result.Cart = cart
+			result.Request = current
+			result.Relaxed = relaxedItems(current.Cart, relaxed)
+			return result, nil
+		}
+		if !isRelaxableOptimizerError(err) {
+			return nil, err
+		}
+
+		targets := make([]int, 0, len(current.Cart))
+		if shortages, ok := OptimizerShortages(err); ok && len(shortages) > 0 {
+			for _, s := range shortages {
+				if s.Item.Index != nil && *s.Item.Index >= 0 && *s.Item.Index < len(current.Cart) {
+					targets = append(targets, *s.Item.Index)
+				}
+			}
+		} else {
+			for i := range current.Cart {
+				targets = append(targets, i)
+			}
+		}
+
+		changed := false
+		for _, i := range targets {
+			for stage[i] < len(order) {
+				constraint := order[stage[i]]
+				stage[i]++
+				if relaxConstraint(&current.Cart[i], constraint) {
+					relaxed[i] = append(relaxed[i], constraint)
+					changed = true
+					break
+				}
+			}
+		}
+
+		if !changed {
+			result.Request = current
+			result.Relaxed = relaxedItems(current.Cart, relaxed)
+			return result, fmt.Errorf("failed to optimize cart after relaxing constraints: %w", err)
+		}
+	}
+}
+
+// isRelaxableOptimizerError reports whether a failed optimizer call might
+// succeed with fewer item restrictions.
+func isRelaxableOptimizerError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.IsUnauthorized() || apiErr.IsForbidden() || apiErr.IsRateLimited() || apiErr.IsServerError() {
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+// relaxConstraint drops a constraint from item, reporting whether anything changed.
+func relaxConstraint(item *OptimizerCartItem, constraint Constraint) bool {
+	switch constraint {
+	case ConstraintFinish:
+		if len(item.FinishIDs) == 0 {
+			return false
+		}
+		item.FinishIDs = nil
+	case ConstraintCondition:
+		if len(item.ConditionIDs) == 0 {
+			return false
+		}
+		item.ConditionIDs = nil
+	case ConstraintLanguage:
+		if len(item.LanguageIDs) == 0 {
+			return false
+		}
+		item.LanguageIDs = nil
+	default:
+		return false
+	}
+	return true
+}
+
+func relaxedItems(cart []OptimizerCartItem, relaxed [][]Constraint) []RelaxedItem {
+	var items []RelaxedItem
+	for i, constraints := range relaxed {
+		if len(constraints) > 0 {
+			items = append(items, RelaxedItem{Index: i, Name: cart[i].Name, Relaxed: constraints})
+		}
+	}
+	return items
+}
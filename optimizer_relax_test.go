@@ -0,0 +1,133 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_OptimizeCartWithRelaxation(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/buyer/optimizer" {
+			http.NotFound(w, r)
+			return
+		}
+		calls++
+
+		var req OptimizerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode optimizer request: %v", err)
+		}
+
+		// "Scarce" can only be filled once both its finish and condition
+		// restrictions are gone; "Unfillable" can never be filled.
+		var short []string
+		for _, item := range req.Cart {
+			if item.Index == nil {
+				t.Fatalf("item %q has no index", item.Name)
+			}
+			switch {
+			case item.Name == "Scarce" && (len(item.FinishIDs) > 0 || len(item.ConditionIDs) > 0):
+				short = append(short, fmt.Sprintf(`{"item":{"type":"mtg_single","name":"Scarce","index":%d,"quantity_requested":1},"total_available":0}`, *item.Index))
+			case item.Name == "Unfillable":
+				short = append(short, fmt.Sprintf(`{"item":{"type":"mtg_single","name":"Unfillable","index":%d,"quantity_requested":1},"total_available":0}`, *item.Index))
+			}
+		}
+		if len(short) > 0 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = fmt.Fprintf(w, `{"status":409,"message":"Conflict","details":[%s]}`, strings.Join(short, ","))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv","quantity_selected":2}],"totals":{"subtotal_cents":1000,"shipping_cents":500,"total_cents":1500,"seller_count":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	t.Run("relaxes only short items", func(t *testing.T) {
+		calls = 0
+		req := OptimizerRequest{Cart: []OptimizerCartItem{
+			{Type: "mtg_single", Name: "Plenty", FinishIDs: []string{"FO"}, QuantityRequested: 1},
+			{Type: "mtg_single", Name: "Scarce", FinishIDs: []string{"EF"}, LanguageIDs: []string{"JA"}, ConditionIDs: []string{"NM"}, QuantityRequested: 1},
+		}}
+
+		result, err := client.OptimizeCartWithRelaxation(ctx, req, RelaxationOptions{})
+		if err != nil {
+			t.Fatalf("OptimizeCartWithRelaxation() error = %v", err)
+		}
+		if result.Attempts != 3 || calls != 3 {
+			t.Errorf("attempts = %d, calls = %d, want 3", result.Attempts, calls)
+		}
+		if result.Cart == nil || result.Cart.Totals.TotalCents != 1500 {
+			t.Fatalf("unexpected cart: %+v", result.Cart)
+		}
+		if len(result.Relaxed) != 1 || result.Relaxed[0].Index != 1 || result.Relaxed[0].Name != "Scarce" {
+			t.Fatalf("unexpected relaxed items: %+v", result.Relaxed)
+		}
+		if got := result.Relaxed[0].Relaxed; len(got) != 2 || got[0] != ConstraintFinish || got[1] != ConstraintCondition {
+			t.Errorf("relaxed constraints = %v, want [finish condition]", got)
+		}
+		if len(result.Request.Cart[0].FinishIDs) != 1 || len(result.Request.Cart[1].LanguageIDs) != 1 {
+			t.Errorf("unexpected constraints dropped: %+v", result.Request.Cart)
+		}
+		if len(req.Cart[1].FinishIDs) != 1 || req.Cart[1].Index != nil {
+			t.Error("original request was modified")
+		}
+	})
+
+	t.Run("gives up when nothing left to relax", func(t *testing.T) {
+		req := OptimizerRequest{Cart: []OptimizerCartItem{
+			{Type: "mtg_single", Name: "Unfillable", ConditionIDs: []string{"NM"}, QuantityRequested: 1},
+		}}
+
+		result, err := client.OptimizeCartWithRelaxation(ctx, req, RelaxationOptions{Order: []Constraint{ConstraintCondition}})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if shortages, ok := OptimizerShortages(err); !ok || len(shortages) != 1 || shortages[0].Item.Name != "Unfillable" {
+			t.Errorf("OptimizerShortages() = %+v, %v", shortages, ok)
+		}
+		if result == nil || result.Attempts != 2 || len(result.Relaxed) != 1 {
+			t.Fatalf("unexpected partial result: %+v", result)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.OptimizeCartWithRelaxation(ctx, OptimizerRequest{}, RelaxationOptions{}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty cart, got %v", err)
+		}
+		req := OptimizerRequest{Cart: []OptimizerCartItem{{Name: "Plenty", QuantityRequested: 1}}}
+		if _, err := client.OptimizeCartWithRelaxation(ctx, req, RelaxationOptions{Order: []Constraint{"price"}}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for unknown constraint, got %v", err)
+		}
+	})
+}
+
+func TestClient_OptimizeCartWithRelaxation_Unauthorized(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	req := OptimizerRequest{Cart: []OptimizerCartItem{{Name: "Card", FinishIDs: []string{"FO"}, QuantityRequested: 1}}}
+	if _, err := client.OptimizeCartWithRelaxation(context.Background(), req, RelaxationOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,176 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultOptimizerChunkSize is the number of cart items sent per optimizer
+// call by OptimizeLargeCart. The API does not document a cart size limit;
+// this keeps individual requests well within typical request timeouts.
+const DefaultOptimizerChunkSize = 100
+
+// LargeCartOptions controls OptimizeLargeCart.
+type LargeCartOptions struct {
+	// ChunkSize is the maximum number of cart items per optimizer call
+	// (default: DefaultOptimizerChunkSize).
+	ChunkSize int
+
+	// PriceWithPendingOrder creates a pending order from the merged cart to
+	// get combined shipping, tax and totals. Shipping is charged per seller,
+	// and the API does not say which seller a listing belongs to, so chunk
+	// shipping cannot be combined without it.
+	//
+	// The pending order is not purchased, and the API has no endpoint to
+	// delete it; it is returned in LargeCartResult.PendingOrder so it can be
+	// updated or purchased instead of creating another.
+	PriceWithPendingOrder bool
+}
+
+// LargeCartResult is the merged result of OptimizeLargeCart.
+type LargeCartResult struct {
+	// Cart is the merged cart. Listings selected by several chunks are
+	// combined into one line, capped at the listing's stock. SubtotalCents is the sum of the chunk
+	// subtotals. ShippingCents is only known when PendingOrder is set, in
+	// which case subtotal, shipping and total come from the pending order
+	// and the total includes tax; otherwise shipping is zero and the total
+	// is the subtotal. SellerCount is the largest chunk seller count, a
+	// lower bound, since the API does not identify the seller of each
+	// listing. Chunks holds each chunk's own shipping and seller count.
+	Cart *OptimizedCart
+
+	// Chunks holds each chunk's optimizer result, in cart order.
+	Chunks []*OptimizedCart
+
+	// DuplicateListings is the number of listings selected by more than one chunk.
+	DuplicateListings int
+
+	// CappedQuantity is the number of copies dropped from duplicate listings
+	// whose merged quantity exceeded the listing's stock. The cart is short
+	// by that many copies of the cards those listings were selected for.
+	CappedQuantity int
+
+	// PendingOrder is the pending order used for pricing, if requested.
+	PendingOrder *PendingOrder
+}
+
+// OptimizeLargeCart optimizes a cart too large for a single optimizer call by
+// splitting it into chunks of opts.ChunkSize items, optimizing each chunk
+// with the same request settings, and merging the results. Listings selected
+// by several chunks are looked up and capped at their current stock.
+//
+// Example:
+//
+//	result, err := client.OptimizeLargeCart(ctx, req, manapool.LargeCartOptions{PriceWithPendingOrder: true})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d listings across %d optimizer calls, total $%.2f\n",
+//	    len(result.Cart.Cart), len(result.Chunks), float64(result.Cart.Totals.TotalCents)/100)
+func (c *Client) OptimizeLargeCart(ctx context.Context, req OptimizerRequest, opts LargeCartOptions) (*LargeCartResult, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize < 0 {
+		return nil, NewValidationError("chunk_size", "chunk size cannot be negative")
+	}
+	if chunkSize == 0 {
+		chunkSize = DefaultOptimizerChunkSize
+	}
+
+	result := &LargeCartResult{Cart: &OptimizedCart{}}
+	positions := make(map[string]int)
+	chunksSeen := make(map[string]int)
+
+	for start := 0; start < len(req.Cart); start += chunkSize {
+		end := start + chunkSize
+		if end > len(req.Cart) {
+			end = len(req.Cart)
+		}
+		chunkReq := req
+		chunkReq.Cart = req.Cart[start:end]
+
+		cart, err := c.OptimizeCart(ctx, chunkReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to optimize cart items %d-%d: %w", start, end-1, err)
+		}
+		result.Chunks = append(result.Chunks, cart)
+
+		inChunk := make(map[string]bool, len(cart.Cart))
+		for _, item := range cart.Cart {
+			if !inChunk[item.InventoryID] {
+				inChunk[item.InventoryID] = true
+				chunksSeen[item.InventoryID]++
+				if chunksSeen[item.InventoryID] == 2 {
+					result.DuplicateListings++
+				}
+			}
+			if i, ok := positions[item.InventoryID]; ok {
+				result.Cart.Cart[i].QuantitySelected += item.QuantitySelected
+				continue
+			}
+			positions[item.InventoryID] = len(result.Cart.Cart)
+			result.Cart.Cart = append(result.Cart.Cart, item)
+		}
+
+		result.Cart.Totals.SubtotalCents += cart.Totals.SubtotalCents
+		if cart.Totals.SellerCount > result.Cart.Totals.SellerCount {
+			result.Cart.Totals.SellerCount = cart.Totals.SellerCount
+		}
+	}
+	if result.DuplicateListings > 0 {
+		if err := c.capMergedQuantities(ctx, result, chunksSeen); err != nil {
+			return nil, err
+		}
+	}
+	result.Cart.Totals.TotalCents = result.Cart.Totals.SubtotalCents
+
+	if opts.PriceWithPendingOrder && len(result.Cart.Cart) > 0 {
+		pending, err := c.CreatePendingOrder(ctx, PendingOrderRequest{LineItems: result.Cart.LineItems()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to price merged cart: %w", err)
+		}
+		result.PendingOrder = pending
+		result.Cart.Totals.SubtotalCents = pending.Totals.SubtotalCents
+		result.Cart.Totals.ShippingCents = pending.Totals.ShippingCents
+		result.Cart.Totals.TotalCents = pending.Totals.TotalCents
+	}
+
+	return result, nil
+}
+
+// capMergedQuantities limits each listing selected by more than one chunk to
+// the listing's stock, removing the excess from the merged cart and its
+// subtotal. Listings the API no longer returns are left as merged.
+func (c *Client) capMergedQuantities(ctx context.Context, result *LargeCartResult, chunksSeen map[string]int) error {
+	var ids []string
+	for _, item := range result.Cart.Cart {
+		if chunksSeen[item.InventoryID] > 1 {
+			ids = append(ids, item.InventoryID)
+		}
+	}
+	listings, err := c.GetInventoryListings(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to check stock of duplicate listings: %w", err)
+	}
+	stock := make(map[string]InventoryItem, len(listings.InventoryItems))
+	for _, listing := range listings.InventoryItems {
+		stock[listing.ID] = listing
+	}
+
+	merged := result.Cart.Cart[:0]
+	for _, item := range result.Cart.Cart {
+		if listing, ok := stock[item.InventoryID]; ok && item.QuantitySelected > listing.Quantity {
+			excess := item.QuantitySelected - listing.Quantity
+			result.CappedQuantity += excess
+			result.Cart.Totals.SubtotalCents -= excess * listing.PriceCents
+			item.QuantitySelected = listing.Quantity
+		}
+		if item.QuantitySelected > 0 {
+			merged = append(merged, item)
+		}
+	}
+	result.Cart.Cart = merged
+	return nil
+}
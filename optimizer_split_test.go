@@ -0,0 +1,113 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_OptimizeLargeCart(t *testing.T) {
+	var chunkSizes []int
+	var pendingLines []PendingOrderLineItem
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode optimizer request: %v", err)
+			}
+			if req.Model != OptimizerModelBalanced {
+				t.Errorf("model = %q, want balanced", req.Model)
+			}
+			chunkSizes = append(chunkSizes, len(req.Cart))
+			// Every chunk selects the shared listing plus one of its own.
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"shared","quantity_selected":1},{"inventory_id":"` + req.Cart[0].Name + `","quantity_selected":2}],"totals":{"subtotal_cents":1000,"shipping_cents":300,"total_cents":1300,"seller_count":2}}`))
+		case "/inventory/listings":
+			if ids := r.URL.Query()["id"]; len(ids) != 1 || ids[0] != "shared" {
+				t.Errorf("listing ids = %v, want [shared]", ids)
+			}
+			_, _ = w.Write([]byte(`{"inventory_items":[{"id":"shared","price_cents":100,"quantity":2}]}`))
+		case "/buyer/orders/pending-orders":
+			var req PendingOrderRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode pending order: %v", err)
+			}
+			pendingLines = req.LineItems
+			_, _ = w.Write([]byte(`{"id":"po","line_items":[],"status":"pending","totals":{"subtotal_cents":3000,"shipping_cents":500,"tax_cents":200,"total_cents":3700},"order":null}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := OptimizerRequest{Model: OptimizerModelBalanced}
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		req.Cart = append(req.Cart, OptimizerCartItem{Type: "mtg_single", Name: name, QuantityRequested: 1})
+	}
+
+	t.Run("merged totals", func(t *testing.T) {
+		chunkSizes = nil
+		result, err := client.OptimizeLargeCart(ctx, req, LargeCartOptions{ChunkSize: 2})
+		if err != nil {
+			t.Fatalf("OptimizeLargeCart() error = %v", err)
+		}
+		if len(chunkSizes) != 3 || chunkSizes[0] != 2 || chunkSizes[2] != 1 {
+			t.Fatalf("chunk sizes = %v, want [2 2 1]", chunkSizes)
+		}
+		if len(result.Chunks) != 3 || result.DuplicateListings != 1 {
+			t.Errorf("chunks = %d, duplicates = %d", len(result.Chunks), result.DuplicateListings)
+		}
+		// The shared listing is selected 3 times but only has 2 in stock.
+		if len(result.Cart.Cart) != 4 || result.Cart.Cart[0].InventoryID != "shared" || result.Cart.Cart[0].QuantitySelected != 2 {
+			t.Errorf("unexpected merged cart: %+v", result.Cart.Cart)
+		}
+		if result.CappedQuantity != 1 {
+			t.Errorf("capped quantity = %d, want 1", result.CappedQuantity)
+		}
+		totals := result.Cart.Totals
+		if totals.SubtotalCents != 2900 || totals.TotalCents != 2900 || totals.ShippingCents != 0 || totals.SellerCount != 2 {
+			t.Errorf("unexpected totals: %+v", totals)
+		}
+		if result.PendingOrder != nil {
+			t.Error("pending order created without PriceWithPendingOrder")
+		}
+	})
+
+	t.Run("priced with pending order", func(t *testing.T) {
+		result, err := client.OptimizeLargeCart(ctx, req, LargeCartOptions{ChunkSize: 2, PriceWithPendingOrder: true})
+		if err != nil {
+			t.Fatalf("OptimizeLargeCart() error = %v", err)
+		}
+		if len(pendingLines) != 4 || pendingLines[0].QuantitySelected != 2 {
+			t.Errorf("unexpected pending order lines: %+v", pendingLines)
+		}
+		if result.PendingOrder == nil || result.Cart.Totals.ShippingCents != 500 || result.Cart.Totals.TotalCents != 3700 {
+			t.Errorf("totals not taken from pending order: %+v", result.Cart.Totals)
+		}
+	})
+
+	t.Run("single chunk", func(t *testing.T) {
+		chunkSizes = nil
+		if _, err := client.OptimizeLargeCart(ctx, req, LargeCartOptions{}); err != nil {
+			t.Fatalf("OptimizeLargeCart() error = %v", err)
+		}
+		if len(chunkSizes) != 1 || chunkSizes[0] != 5 {
+			t.Errorf("chunk sizes = %v, want [5]", chunkSizes)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.OptimizeLargeCart(ctx, OptimizerRequest{}, LargeCartOptions{}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty cart, got %v", err)
+		}
+		if _, err := client.OptimizeLargeCart(ctx, req, LargeCartOptions{ChunkSize: -1}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for chunk size, got %v", err)
+		}
+	})
+}
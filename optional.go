@@ -0,0 +1,103 @@
+package manapool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Optional holds a value that may be unset. Unlike a zero value, an unset
+// Optional is left out of request bodies, so an update can tell "leave this
+// field alone" apart from "set it to false, 0 or an empty string".
+//
+// Tag Optional fields with omitzero, which omits them when unset. In
+// responses, a missing or null field decodes as unset.
+//
+// Example:
+//
+//	type listingUpdate struct {
+//	    PriceCents manapool.Optional[int]  `json:"price_cents,omitzero"`
+//	    Live       manapool.Optional[bool] `json:"live,omitzero"`
+//	}
+//
+//	update := listingUpdate{Live: manapool.Some(false)} // {"live":false}
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// Some returns an Optional set to v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, set: true}
+}
+
+// OptionalFromPtr returns an Optional set to *p, or an unset Optional if p
+// is nil.
+func OptionalFromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return Optional[T]{}
+	}
+	return Some(*p)
+}
+
+// IsSet reports whether o holds a value.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsZero reports whether o is unset, so omitzero leaves it out of JSON.
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// Get returns the value and whether it is set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// ValueOr returns the value, or def if o is unset.
+func (o Optional[T]) ValueOr(def T) T {
+	if !o.set {
+		return def
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if o is unset.
+func (o Optional[T]) Ptr() *T {
+	if !o.set {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// String formats the value, or "unset".
+func (o Optional[T]) String() string {
+	if !o.set {
+		return "unset"
+	}
+	return fmt.Sprint(o.value)
+}
+
+// MarshalJSON implements json.Marshaler. An unset Optional marshals as null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null unsets o.
+func (o *Optional[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
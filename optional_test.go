@@ -0,0 +1,67 @@
+package manapool
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	var unset Optional[int]
+	if unset.IsSet() || unset.ValueOr(7) != 7 || unset.Ptr() != nil || unset.String() != "unset" {
+		t.Errorf("unset Optional = %+v", unset)
+	}
+
+	zero := Some(0)
+	if v, ok := zero.Get(); !ok || v != 0 || zero.ValueOr(7) != 0 || *zero.Ptr() != 0 || zero.String() != "0" {
+		t.Errorf("Some(0) = %+v", zero)
+	}
+
+	if o := OptionalFromPtr[string](nil); o.IsSet() {
+		t.Error("OptionalFromPtr(nil) should be unset")
+	}
+	if o := OptionalFromPtr(String("x")); o.ValueOr("") != "x" {
+		t.Errorf("OptionalFromPtr() = %v", o)
+	}
+}
+
+func TestOptional_JSON(t *testing.T) {
+	type update struct {
+		Live       Optional[bool] `json:"live,omitzero"`
+		PriceCents Optional[int]  `json:"price_cents,omitzero"`
+		Note       Optional[string]
+	}
+
+	tests := []struct {
+		name string
+		in   update
+		want string
+	}{
+		{"unset fields are omitted or null", update{}, `{"Note":null}`},
+		{"explicit zero values are sent", update{Live: Some(false), PriceCents: Some(0), Note: Some("")}, `{"live":false,"price_cents":0,"Note":""}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	var decoded update
+	if err := json.Unmarshal([]byte(`{"live":false,"price_cents":null}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v, ok := decoded.Live.Get(); !ok || v {
+		t.Errorf("live = %v", decoded.Live)
+	}
+	if decoded.PriceCents.IsSet() || decoded.Note.IsSet() {
+		t.Errorf("null and missing fields should be unset: %+v", decoded)
+	}
+	if err := json.Unmarshal([]byte(`{"price_cents":"ten"}`), &decoded); err == nil {
+		t.Error("expected an error for a mistyped value")
+	}
+}
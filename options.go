@@ -136,3 +136,110 @@ func WithLogger(logger Logger) ClientOption {
 		c.logger = logger
 	}
 }
+
+// WithAddressValidator checks and normalizes addresses before they are sent
+// with pending order and purchase requests, catching malformed addresses
+// before the API rejects them. Addresses are always checked with
+// Address.Validate afterwards, so a validator that normalizes, such as
+// NormalizeAddressValidator, lets state names like "Oregon" through.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithAddressValidator(manapool.ShippingAddressValidator),
+//	)
+func WithAddressValidator(validator AddressValidator) ClientOption {
+	return func(c *Client) {
+		c.addressValidator = validator
+	}
+}
+
+// WithStrictDecoding makes API calls fail with an UnknownFieldError when a
+// response contains a field the response type does not declare, so schema
+// drift is noticed immediately, for example in integration tests. Without
+// it, unknown fields are ignored; see WithSchemaDriftLogging to report them
+// without failing.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithStrictDecoding(),
+//	)
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithSchemaDriftLogging reports response fields the response type does not
+// declare with the Debugf method of the logger set with WithLogger, without
+// failing the call. Each successful response is decoded a second time to find
+// them, so enable it only while debugging. It has no effect with
+// WithStrictDecoding, which fails the call instead.
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithLogger(logger),
+//	    manapool.WithSchemaDriftLogging(),
+//	)
+func WithSchemaDriftLogging() ClientOption {
+	return func(c *Client) {
+		c.logSchemaDrift = true
+	}
+}
+
+// WithConcurrency sets the number of requests batch operations, such as
+// ApplyReprice, keep in flight. Requests still share the client's rate limit,
+// so raising it helps most when responses are slow relative to the rate.
+//
+// Default: 4
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithConcurrency(8),
+//	)
+func WithConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithTimestampLayout sets the format of times sent to the API in query
+// parameters, such as OrdersOptions.Since, and in fulfillment updates. The
+// layout is a time layout or TimestampLayoutUnix. Responses are parsed in
+// any supported format regardless.
+//
+// Default: time.RFC3339Nano
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithTimestampLayout(time.RFC3339),
+//	)
+func WithTimestampLayout(layout string) ClientOption {
+	return func(c *Client) {
+		c.timestampLayout = layout
+	}
+}
+
+// WithCardInfoBatchSize sets the maximum number of card names sent in one
+// card info request. GetCardInfo splits larger requests into batches.
+//
+// Default: 100 names per request
+//
+// Example:
+//
+//	client := manapool.NewClient(token, email,
+//	    manapool.WithCardInfoBatchSize(50),
+//	)
+func WithCardInfoBatchSize(size int) ClientOption {
+	return func(c *Client) {
+		if size > 0 {
+			c.cardInfoBatchSize = size
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OrderCache keeps an in-memory copy of seller orders. It is seeded with a
+// full fetch and then kept current from order_created webhook payloads or,
+// when webhooks are unavailable, by polling. Each poll fetches orders created
+// since the last sync and re-fetches the unfulfilled orders already cached,
+// so fulfillment changes to older orders are picked up too. OrderCache is
+// safe for concurrent use.
+//
+// Example:
+//
+//	cache := manapool.NewOrderCache(client)
+//	if err := cache.Seed(ctx, manapool.OrdersOptions{}); err != nil {
+//	    log.Fatal(err)
+//	}
+//	// In a webhook handler:
+//	cache.Put(payload.Order)
+//	// Or periodically as a fallback:
+//	_ = cache.Poll(ctx)
+//	for _, order := range cache.Unfulfilled() {
+//	    fmt.Println(order.Label)
+//	}
+type OrderCache struct {
+	client *Client
+
+	mu       sync.RWMutex
+	orders   map[string]OrderDetails
+	lastSync time.Time
+}
+
+// NewOrderCache creates an empty order cache backed by client.
+func NewOrderCache(client *Client) *OrderCache {
+	return &OrderCache{
+		client: client,
+		orders: make(map[string]OrderDetails),
+	}
+}
+
+// Seed replaces the cache contents with all seller orders matching opts.
+func (oc *OrderCache) Seed(ctx context.Context, opts OrdersOptions) error {
+	started := time.Now()
+	orders, err := oc.client.collectSellerOrderDetails(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to seed order cache: %w", err)
+	}
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.orders = make(map[string]OrderDetails, len(orders))
+	for _, order := range orders {
+		oc.orders[order.ID] = order
+	}
+	oc.lastSync = started
+
+	return nil
+}
+
+// Poll fetches orders created since the last Seed or Poll and re-fetches
+// every cached order that is still unfulfilled, so Unfulfilled reflects
+// fulfillments made since then. Use it as a fallback when webhook delivery
+// is not available.
+func (oc *OrderCache) Poll(ctx context.Context) error {
+	oc.mu.RLock()
+	since := oc.lastSync
+	oc.mu.RUnlock()
+
+	opts := OrdersOptions{}
+	if !since.IsZero() {
+		opts.Since = &Timestamp{Time: since}
+	}
+
+	started := time.Now()
+	seen := make(map[string]bool)
+	var ids []string
+	err := oc.client.IterateSellerOrders(ctx, opts, func(summary *OrderSummary) error {
+		if !seen[summary.ID] {
+			seen[summary.ID] = true
+			ids = append(ids, summary.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to poll order cache: %w", err)
+	}
+	for _, order := range oc.Unfulfilled() {
+		if !seen[order.ID] {
+			seen[order.ID] = true
+			ids = append(ids, order.ID)
+		}
+	}
+
+	orders, err := oc.client.getSellerOrderDetails(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to poll order cache: %w", err)
+	}
+
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	for _, order := range orders {
+		oc.orders[order.ID] = order
+	}
+	oc.lastSync = started
+
+	return nil
+}
+
+// Refresh re-fetches a single order and updates the cache.
+// Call it after changing an order, for example after a fulfillment update.
+func (oc *OrderCache) Refresh(ctx context.Context, id string) error {
+	resp, err := oc.client.GetSellerOrder(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to refresh cached order: %w", err)
+	}
+	oc.Put(resp.Order)
+	return nil
+}
+
+// Put adds or replaces an order, such as one delivered by an order_created webhook.
+func (oc *OrderCache) Put(order OrderDetails) {
+	if order.ID == "" {
+		return
+	}
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.orders[order.ID] = order
+}
+
+// Get returns the cached order with the given ID.
+func (oc *OrderCache) Get(id string) (OrderDetails, bool) {
+	oc.mu.RLock()
+	defer oc.mu.RUnlock()
+	order, ok := oc.orders[id]
+	return order, ok
+}
+
+// Len returns the number of cached orders.
+func (oc *OrderCache) Len() int {
+	oc.mu.RLock()
+	defer oc.mu.RUnlock()
+	return len(oc.orders)
+}
+
+// LastSync returns the time the last Seed or Poll started.
+func (oc *OrderCache) LastSync() time.Time {
+	oc.mu.RLock()
+	defer oc.mu.RUnlock()
+	return oc.lastSync
+}
+
+// All returns every cached order, oldest first.
+func (oc *OrderCache) All() []OrderDetails {
+	return oc.filter(func(OrderDetails) bool { return true })
+}
+
+// Unfulfilled returns cached orders without a shipped, delivered, refunded,
+// or replaced fulfillment, oldest first.
+func (oc *OrderCache) Unfulfilled() []OrderDetails {
+	return oc.filter(isUnfulfilledOrder)
+}
+
+func isUnfulfilledOrder(order OrderDetails) bool {
+	if order.LatestFulfillmentStatus == nil {
+		return true
+	}
+	switch FulfillmentStatus(*order.LatestFulfillmentStatus) {
+	case FulfillmentStatusProcessing, FulfillmentStatusError, "":
+		return true
+	}
+	return false
+}
+
+// ByLabel returns the cached order with the given label.
+func (oc *OrderCache) ByLabel(label string) (OrderDetails, bool) {
+	matches := oc.filter(func(order OrderDetails) bool { return order.Label == label })
+	if len(matches) == 0 {
+		return OrderDetails{}, false
+	}
+	return matches[0], true
+}
+
+// ByBuyer returns cached orders placed by the given buyer ID, oldest first.
+func (oc *OrderCache) ByBuyer(buyerID string) []OrderDetails {
+	return oc.filter(func(order OrderDetails) bool { return order.BuyerID == buyerID })
+}
+
+func (oc *OrderCache) filter(match func(OrderDetails) bool) []OrderDetails {
+	oc.mu.RLock()
+	var orders []OrderDetails
+	for _, order := range oc.orders {
+		if match(order) {
+			orders = append(orders, order)
+		}
+	}
+	oc.mu.RUnlock()
+
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt.Time) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt.Time)
+		}
+		return orders[i].ID < orders[j].ID
+	})
+	return orders
+}
@@ -0,0 +1,150 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOrderCache(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/seller/orders":
+			if r.URL.Query().Get("since") == "" {
+				_, _ = w.Write([]byte(`{"orders":[{"id":"a"},{"id":"b"}]}`))
+				return
+			}
+			atomic.AddInt32(&polls, 1)
+			_, _ = w.Write([]byte(`{"orders":[{"id":"c"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/seller/orders/"):
+			id := strings.TrimPrefix(r.URL.Path, "/seller/orders/")
+			created := map[string]string{"a": "2024-04-01", "b": "2024-04-02", "c": "2024-04-03"}[id]
+			status := map[string]string{"a": `"shipped"`, "b": "null", "c": `"processing"`}[id]
+			_, _ = fmt.Fprintf(w, `{"order":{"id":%q,"created_at":"%sT00:00:00Z","label":"L-%s","latest_fulfillment_status":%s,"buyer_id":"buyer-%s"}}`, id, created, id, status, map[string]string{"a": "1", "b": "2", "c": "1"}[id])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	cache := NewOrderCache(client)
+
+	if err := cache.Seed(ctx, OrdersOptions{}); err != nil {
+		t.Fatalf("Seed error: %v", err)
+	}
+	if cache.Len() != 2 || cache.LastSync().IsZero() {
+		t.Fatalf("after seed: len=%d lastSync=%v", cache.Len(), cache.LastSync())
+	}
+
+	unfulfilled := cache.Unfulfilled()
+	if len(unfulfilled) != 1 || unfulfilled[0].ID != "b" {
+		t.Fatalf("unfulfilled = %+v, want [b]", unfulfilled)
+	}
+
+	if err := cache.Poll(ctx); err != nil {
+		t.Fatalf("Poll error: %v", err)
+	}
+	if atomic.LoadInt32(&polls) != 1 {
+		t.Fatalf("poll requests = %d, want 1", polls)
+	}
+
+	all := cache.All()
+	if len(all) != 3 || all[0].ID != "a" || all[2].ID != "c" {
+		t.Fatalf("all orders not sorted oldest first: %+v", all)
+	}
+	if order, ok := cache.ByLabel("L-c"); !ok || order.ID != "c" {
+		t.Fatalf("ByLabel(L-c) = %+v, %v", order, ok)
+	}
+	if _, ok := cache.ByLabel("missing"); ok {
+		t.Fatal("ByLabel(missing) found an order")
+	}
+	if byBuyer := cache.ByBuyer("buyer-1"); len(byBuyer) != 2 {
+		t.Fatalf("ByBuyer(buyer-1) = %d orders, want 2", len(byBuyer))
+	}
+
+	shipped := "shipped"
+	cache.Put(OrderDetails{OrderSummary: OrderSummary{ID: "b", LatestFulfillmentStatus: &shipped}})
+	cache.Put(OrderDetails{})
+	if got, _ := cache.Get("b"); got.LatestFulfillmentStatus == nil || *got.LatestFulfillmentStatus != "shipped" {
+		t.Fatalf("Put did not replace order b: %+v", got)
+	}
+	if cache.Len() != 3 {
+		t.Fatalf("len = %d, want 3", cache.Len())
+	}
+
+	if err := cache.Refresh(ctx, "b"); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if got, _ := cache.Get("b"); got.LatestFulfillmentStatus != nil {
+		t.Fatalf("Refresh did not reload order b: %+v", got)
+	}
+	if err := cache.Refresh(ctx, ""); err == nil {
+		t.Fatal("expected Refresh error for empty id")
+	}
+}
+
+func TestOrderCache_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"unauthorized"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	cache := NewOrderCache(client)
+	if err := cache.Seed(context.Background(), OrdersOptions{}); err == nil {
+		t.Fatal("expected Seed error")
+	}
+	if err := cache.Poll(context.Background()); err == nil {
+		t.Fatal("expected Poll error")
+	}
+}
+
+func TestOrderCache_PollRefreshesUnfulfilled(t *testing.T) {
+	var shipped int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/seller/orders":
+			if r.URL.Query().Get("since") == "" {
+				_, _ = w.Write([]byte(`{"orders":[{"id":"a"}]}`))
+				return
+			}
+			// Order a was created before the last sync, so polls do not list it.
+			_, _ = w.Write([]byte(`{"orders":[]}`))
+		case r.URL.Path == "/seller/orders/a":
+			status := "null"
+			if atomic.LoadInt32(&shipped) == 1 {
+				status = `"shipped"`
+			}
+			_, _ = fmt.Fprintf(w, `{"order":{"id":"a","created_at":"2024-04-01T00:00:00Z","latest_fulfillment_status":%s}}`, status)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	cache := NewOrderCache(client)
+	if err := cache.Seed(ctx, OrdersOptions{}); err != nil {
+		t.Fatalf("Seed error: %v", err)
+	}
+	if got := cache.Unfulfilled(); len(got) != 1 {
+		t.Fatalf("unfulfilled = %+v, want [a]", got)
+	}
+
+	atomic.StoreInt32(&shipped, 1)
+	if err := cache.Poll(ctx); err != nil {
+		t.Fatalf("Poll error: %v", err)
+	}
+	if got := cache.Unfulfilled(); len(got) != 0 {
+		t.Fatalf("unfulfilled after poll = %+v, want none", got)
+	}
+}
@@ -0,0 +1,211 @@
+package manapool
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OrderQuery describes a local order search. Non-empty fields are combined
+// with AND. CardName and Buyer match case-insensitive substrings; Label and
+// TrackingNumber match exactly, ignoring case and surrounding whitespace.
+type OrderQuery struct {
+	CardName       string
+	Buyer          string
+	TrackingNumber string
+	Label          string
+}
+
+// OrderIndex is an in-memory search index over fetched orders. It supports
+// lookups the API does not filter on, such as card name and tracking number.
+// OrderIndex is safe for concurrent use.
+//
+// Example:
+//
+//	index := manapool.NewOrderIndex(cache.All()...)
+//	matches := index.Search(manapool.OrderQuery{CardName: "bolt"})
+type OrderIndex struct {
+	mu         sync.RWMutex
+	orders     map[string]indexedOrder
+	byLabel    map[string]string
+	byTracking map[string][]string
+}
+
+type indexedOrder struct {
+	order     OrderDetails
+	cardNames []string
+	buyer     string
+}
+
+// NewOrderIndex creates an index containing orders.
+func NewOrderIndex(orders ...OrderDetails) *OrderIndex {
+	idx := &OrderIndex{
+		orders:     make(map[string]indexedOrder),
+		byLabel:    make(map[string]string),
+		byTracking: make(map[string][]string),
+	}
+	idx.Add(orders...)
+	return idx
+}
+
+// Add indexes orders, replacing any previously indexed order with the same ID.
+func (idx *OrderIndex) Add(orders ...OrderDetails) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, order := range orders {
+		if order.ID == "" {
+			continue
+		}
+		idx.removeLocked(order.ID)
+
+		entry := indexedOrder{
+			order: order,
+			buyer: strings.ToLower(order.BuyerID + "\x00" + order.ShippingAddress.Name),
+		}
+		for _, item := range order.Items {
+			if name := productName(item.Product); name != "" {
+				entry.cardNames = append(entry.cardNames, strings.ToLower(name))
+			}
+		}
+		idx.orders[order.ID] = entry
+
+		if order.Label != "" {
+			idx.byLabel[normalizeSearchKey(order.Label)] = order.ID
+		}
+		for _, f := range order.Fulfillments {
+			if f.TrackingNumber != nil && *f.TrackingNumber != "" {
+				key := normalizeSearchKey(*f.TrackingNumber)
+				idx.byTracking[key] = append(idx.byTracking[key], order.ID)
+			}
+		}
+	}
+}
+
+// Remove drops an order from the index.
+func (idx *OrderIndex) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(id)
+}
+
+// Len returns the number of indexed orders.
+func (idx *OrderIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.orders)
+}
+
+// Search returns indexed orders matching q, newest first.
+// An empty query matches every order.
+func (idx *OrderIndex) Search(q OrderQuery) []OrderDetails {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	candidates := idx.candidatesLocked(q)
+	cardName := strings.ToLower(strings.TrimSpace(q.CardName))
+	buyer := strings.ToLower(strings.TrimSpace(q.Buyer))
+
+	var results []OrderDetails
+	for _, id := range candidates {
+		entry := idx.orders[id]
+		if buyer != "" && !strings.Contains(entry.buyer, buyer) {
+			continue
+		}
+		if cardName != "" && !containsSubstring(entry.cardNames, cardName) {
+			continue
+		}
+		results = append(results, entry.order)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if !results[i].CreatedAt.Equal(results[j].CreatedAt.Time) {
+			return results[i].CreatedAt.After(results[j].CreatedAt.Time)
+		}
+		return results[i].ID < results[j].ID
+	})
+	return results
+}
+
+// candidatesLocked narrows the search using the exact-match indexes.
+func (idx *OrderIndex) candidatesLocked(q OrderQuery) []string {
+	var ids []string
+	switch {
+	case q.Label != "":
+		if id, ok := idx.byLabel[normalizeSearchKey(q.Label)]; ok {
+			ids = []string{id}
+		}
+	default:
+		for id := range idx.orders {
+			ids = append(ids, id)
+		}
+	}
+
+	if q.TrackingNumber == "" {
+		return ids
+	}
+
+	tracked := make(map[string]bool)
+	for _, id := range idx.byTracking[normalizeSearchKey(q.TrackingNumber)] {
+		tracked[id] = true
+	}
+	filtered := ids[:0]
+	for _, id := range ids {
+		if tracked[id] {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+func (idx *OrderIndex) removeLocked(id string) {
+	entry, ok := idx.orders[id]
+	if !ok {
+		return
+	}
+	delete(idx.orders, id)
+	if key := normalizeSearchKey(entry.order.Label); idx.byLabel[key] == id {
+		delete(idx.byLabel, key)
+	}
+	for _, f := range entry.order.Fulfillments {
+		if f.TrackingNumber == nil {
+			continue
+		}
+		key := normalizeSearchKey(*f.TrackingNumber)
+		remaining := idx.byTracking[key][:0]
+		for _, other := range idx.byTracking[key] {
+			if other != id {
+				remaining = append(remaining, other)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(idx.byTracking, key)
+		} else {
+			idx.byTracking[key] = remaining
+		}
+	}
+}
+
+// productName returns the display name of a single or sealed product.
+func productName(p Product) string {
+	switch {
+	case p.Single != nil:
+		return p.Single.Name
+	case p.Sealed != nil:
+		return p.Sealed.Name
+	}
+	return ""
+}
+
+func normalizeSearchKey(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+func containsSubstring(values []string, sub string) bool {
+	for _, v := range values {
+		if strings.Contains(v, sub) {
+			return true
+		}
+	}
+	return false
+}
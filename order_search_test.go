@@ -0,0 +1,79 @@
+package manapool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrderIndex_Search(t *testing.T) {
+	tracking := "1Z999AA10123456784"
+	order := func(id, label, buyer, name string, day int, card string) OrderDetails {
+		return OrderDetails{
+			OrderSummary:    OrderSummary{ID: id, Label: label, CreatedAt: Timestamp{Time: time.Date(2024, 4, day, 0, 0, 0, 0, time.UTC)}},
+			BuyerID:         buyer,
+			ShippingAddress: Address{Name: name},
+			Items:           []OrderItem{{Product: Product{Single: &Single{Name: card}}}},
+		}
+	}
+
+	a := order("a", "1111-0001", "buyer-1", "Jane Doe", 1, "Lightning Bolt")
+	a.Fulfillments = []OrderFulfillment{{TrackingNumber: &tracking}}
+	b := order("b", "1111-0002", "buyer-2", "John Roe", 2, "Counterspell")
+	c := order("c", "1111-0003", "buyer-1", "Jane Doe", 3, "Chain Lightning")
+	c.Items = append(c.Items, OrderItem{Product: Product{Sealed: &Sealed{Name: "Alpha Booster"}}})
+
+	idx := NewOrderIndex(a, b, c, OrderDetails{})
+	if idx.Len() != 3 {
+		t.Fatalf("len = %d, want 3", idx.Len())
+	}
+
+	tests := []struct {
+		name  string
+		query OrderQuery
+		want  []string
+	}{
+		{"all newest first", OrderQuery{}, []string{"c", "b", "a"}},
+		{"card substring", OrderQuery{CardName: "lightning"}, []string{"c", "a"}},
+		{"sealed name", OrderQuery{CardName: "booster"}, []string{"c"}},
+		{"buyer id", OrderQuery{Buyer: "BUYER-2"}, []string{"b"}},
+		{"buyer name", OrderQuery{Buyer: "jane"}, []string{"c", "a"}},
+		{"label", OrderQuery{Label: " 1111-0002 "}, []string{"b"}},
+		{"tracking", OrderQuery{TrackingNumber: "1z999aa10123456784"}, []string{"a"}},
+		{"combined", OrderQuery{Buyer: "jane", CardName: "chain"}, []string{"c"}},
+		{"label and tracking mismatch", OrderQuery{Label: "1111-0002", TrackingNumber: tracking}, nil},
+		{"no match", OrderQuery{Label: "nope"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idx.Search(tt.query)
+			if len(got) != len(tt.want) {
+				t.Fatalf("results = %d, want %d", len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Fatalf("result[%d] = %s, want %s", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+
+	t.Run("ReplaceAndRemove", func(t *testing.T) {
+		updated := a
+		updated.Fulfillments = nil
+		idx.Add(updated)
+		if got := idx.Search(OrderQuery{TrackingNumber: tracking}); len(got) != 0 {
+			t.Fatalf("stale tracking entry after replace: %+v", got)
+		}
+
+		idx.Add(a)
+		idx.Remove("a")
+		idx.Remove("missing")
+		if got := idx.Search(OrderQuery{Label: "1111-0001"}); len(got) != 0 {
+			t.Fatalf("stale label entry after remove: %+v", got)
+		}
+		if idx.Len() != 2 {
+			t.Fatalf("len after remove = %d, want 2", idx.Len())
+		}
+	})
+}
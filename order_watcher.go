@@ -0,0 +1,385 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/repricah/manapool/internal/parallel"
+)
+
+// DefaultOrderWatchLookback is how far back OrderWatcher looks for seller
+// orders by default.
+const DefaultOrderWatchLookback = 14 * 24 * time.Hour
+
+// DefaultOrderReportInterval is how often OrderWatcher fetches the reports of
+// an open order whose status has not changed, by default.
+const DefaultOrderReportInterval = time.Hour
+
+// OrderEventType identifies something that happened to the seller's orders.
+// Its string value is used as the Notification event.
+type OrderEventType string
+
+// Order event types.
+const (
+	// OrderEventNew means a buyer placed an order.
+	OrderEventNew OrderEventType = "new_order"
+
+	// OrderEventIssueReported means an issue was reported on an order.
+	OrderEventIssueReported OrderEventType = "issue_reported"
+
+	// OrderEventPayout means charges were deducted in a payout not seen before.
+	OrderEventPayout OrderEventType = "payout"
+
+	// OrderEventShipped means an order's latest fulfillment became shipped.
+	OrderEventShipped OrderEventType = "order_shipped"
+
+	// OrderEventDelivered means an order's latest fulfillment became delivered.
+	OrderEventDelivered OrderEventType = "order_delivered"
+)
+
+// OrderEvent is a change to the seller's orders found by OrderWatcher or
+// received by webhook.
+type OrderEvent struct {
+	Type OrderEventType
+
+	// Order is the order the event is about. Its items, payment and
+	// fulfillments are set for new, shipped and delivered orders; issue
+	// events carry the order summary. It is empty for payouts, which span
+	// orders.
+	Order OrderDetails
+
+	// Report is the reported issue, for OrderEventIssueReported.
+	Report *OrderReport
+
+	// Payout is the payout's charges, for OrderEventPayout.
+	Payout *PayoutCharges
+}
+
+// Notification describes the event for a Notifier.
+func (e OrderEvent) Notification() Notification {
+	n := Notification{Event: string(e.Type), Data: e}
+	order := e.Order
+	switch e.Type {
+	case OrderEventNew:
+		n.Title = fmt.Sprintf("New order %s: %s", order.Label, formatDollars(order.TotalCents))
+		var body strings.Builder
+		fmt.Fprintf(&body, "Order %s (%s), %s shipping\n", order.Label, order.ID, order.ShippingMethod)
+		for _, item := range order.Items {
+			fmt.Fprintf(&body, "%dx %s at %s\n", item.Quantity, productName(item.Product), formatDollars(item.PriceCents))
+		}
+		n.Body = body.String()
+	case OrderEventIssueReported:
+		n.Title = fmt.Sprintf("Issue reported on order %s", order.Label)
+		var body strings.Builder
+		fmt.Fprintf(&body, "Order %s (%s)\n", order.Label, order.ID)
+		if e.Report != nil {
+			issues := e.Report.OrderReportedIssues
+			kind := "Issue"
+			if issues.IsNonDeliveryReport {
+				kind = "Non-delivery"
+			}
+			fmt.Fprintf(&body, "%s reported by %s", kind, issues.ReporterRole)
+			if issues.Comment != nil && *issues.Comment != "" {
+				fmt.Fprintf(&body, ": %s", *issues.Comment)
+			}
+			body.WriteString("\n")
+			if issues.ProposedRemediationMethod != nil {
+				fmt.Fprintf(&body, "Proposed remediation: %s\n", *issues.ProposedRemediationMethod)
+			}
+		}
+		n.Body = body.String()
+	case OrderEventShipped, OrderEventDelivered:
+		verb := "shipped"
+		if e.Type == OrderEventDelivered {
+			verb = "delivered"
+		}
+		n.Title = fmt.Sprintf("Order %s %s", order.Label, verb)
+		var body strings.Builder
+		fmt.Fprintf(&body, "Order %s (%s) %s\n", order.Label, order.ID, verb)
+		if len(order.Fulfillments) > 0 {
+			f := order.Fulfillments[len(order.Fulfillments)-1]
+			if number := StringValue(f.TrackingNumber); number != "" {
+				fmt.Fprintf(&body, "Tracking: %s %s\n", StringValue(f.TrackingCompany), number)
+			}
+			if url := StringValue(f.TrackingURL); url != "" {
+				fmt.Fprintf(&body, "%s\n", url)
+			}
+		}
+		n.Body = body.String()
+	case OrderEventPayout:
+		if e.Payout != nil {
+			n.Title = fmt.Sprintf("Payout %s: %s in charges", e.Payout.PayoutID, formatDollars(e.Payout.SellerChargeCents))
+			n.Body = fmt.Sprintf("%d charge(s) across orders %s\n", e.Payout.ChargeCount, strings.Join(e.Payout.OrderIDs, ", "))
+		}
+	}
+	return n
+}
+
+// OrderWatcher polls the seller's orders and reports new orders, orders
+// that shipped or were delivered, newly reported issues and payouts not seen
+// before. The first check records the current state without reporting events
+// unless EmitInitial is set, so restarting a watcher does not replay old
+// orders. When Notifier is set, each event's Notification is sent to it; an
+// event whose notification fails is kept and sent again, in order, on the
+// next check.
+//
+// Reports are fetched, one request per order, for new orders and orders
+// whose status changed, and otherwise at most once per ReportInterval for
+// open orders. Orders that are delivered, refunded or replaced are no longer
+// watched, and neither are orders older than Lookback, so issues reported
+// after that are not seen. Payouts are seen through report charges (see
+// GroupChargesByPayout) and only include charges on watched orders.
+//
+// Example:
+//
+//	watcher := &manapool.OrderWatcher{Client: client, Notifier: discord}
+//	err := watcher.Run(ctx, 5*time.Minute, nil, func(err error) {
+//	    log.Printf("order watch failed: %v", err)
+//	})
+type OrderWatcher struct {
+	// Client is used to list seller orders and fetch their reports.
+	Client *Client
+
+	// Lookback limits polling to orders created within this window (default: DefaultOrderWatchLookback).
+	Lookback time.Duration
+
+	// ReportInterval is how often the reports of an open order whose status
+	// has not changed are fetched (default: DefaultOrderReportInterval).
+	ReportInterval time.Duration
+
+	// EmitInitial reports events for open orders, issues and payouts already present on the first check.
+	EmitInitial bool
+
+	// Notifier, if set, is sent a notification for every event.
+	Notifier Notifier
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	primed  bool
+	orders  map[string]watchedOrder // open orders by ID
+	payouts map[string]time.Time    // when each payout was first seen
+	pending []OrderEvent            // events whose notification failed
+}
+
+// watchedOrder is what OrderWatcher remembers about an open order.
+type watchedOrder struct {
+	status    FulfillmentStatus
+	reports   []OrderReport
+	reportsAt time.Time // when reports were last fetched
+}
+
+// Check fetches seller orders and their reports and returns the events since
+// the previous check: new, shipped and delivered orders first, then reported
+// issues and payouts. If a notification cannot be sent, the events are still
+// returned with the error.
+func (w *OrderWatcher) Check(ctx context.Context) ([]OrderEvent, error) {
+	if w.Client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	lookback := w.Lookback
+	if lookback <= 0 {
+		lookback = DefaultOrderWatchLookback
+	}
+	reportInterval := w.ReportInterval
+	if reportInterval <= 0 {
+		reportInterval = DefaultOrderReportInterval
+	}
+	now := time.Now
+	if w.Now != nil {
+		now = w.Now
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.orders == nil {
+		w.orders = make(map[string]watchedOrder)
+		w.payouts = make(map[string]time.Time)
+	}
+
+	checkedAt := now()
+	since := Timestamp{Time: checkedAt.Add(-lookback)}
+	var orders []OrderSummary
+	err := w.Client.IterateSellerOrders(ctx, OrdersOptions{Since: &since}, func(order *OrderSummary) error {
+		orders = append(orders, *order)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check orders: %w", err)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt.Time)
+	})
+
+	// Work out what changed from the summaries, then fetch the details of
+	// changed orders and the reports that are due concurrently. An order
+	// that is final but not watched was forgotten after its last event, or
+	// was already final when first listed.
+	emit := w.primed || w.EmitInitial
+	watched := make([]bool, len(orders))
+	changes := make([]OrderEventType, len(orders))
+	reportsDue := make([]bool, len(orders))
+	for i, order := range orders {
+		status := FulfillmentStatus(StringValue(order.LatestFulfillmentStatus))
+		previous, known := w.orders[order.ID]
+		if !known && isFinalOrderStatus(status) {
+			continue
+		}
+		watched[i] = true
+		switch {
+		case !known:
+			changes[i] = OrderEventNew
+		case status == previous.status:
+		case status == FulfillmentStatusShipped:
+			changes[i] = OrderEventShipped
+		case status == FulfillmentStatusDelivered:
+			changes[i] = OrderEventDelivered
+		}
+		reportsDue[i] = !known || status != previous.status || checkedAt.Sub(previous.reportsAt) >= reportInterval
+	}
+	details := make([]*OrderDetails, len(orders))
+	reports := make([][]OrderReport, len(orders))
+	err = parallel.Run(ctx, len(orders), w.Client.parallelOptions(true), func(ctx context.Context, i int) error {
+		id := orders[i].ID
+		if emit && changes[i] != "" {
+			resp, err := w.Client.GetSellerOrder(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get order %s: %w", id, err)
+			}
+			details[i] = &resp.Order
+		}
+		if reportsDue[i] {
+			resp, err := w.Client.GetSellerOrderReports(ctx, id)
+			if err != nil {
+				return fmt.Errorf("failed to get reports for order %s: %w", id, err)
+			}
+			reports[i] = resp.Reports
+		}
+		return nil
+	})
+	if errs, ok := parallel.As(err); ok {
+		err = errs.Failures[0].Err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check orders: %w", err)
+	}
+
+	var events, issues []OrderEvent
+	var allReports []OrderReport
+	listed := make(map[string]bool, len(orders))
+	for i, order := range orders {
+		listed[order.ID] = true
+		if !watched[i] {
+			continue
+		}
+		state := w.orders[order.ID]
+		if emit && changes[i] != "" {
+			events = append(events, OrderEvent{Type: changes[i], Order: *details[i]})
+		}
+		if reportsDue[i] {
+			for j := range reports[i] {
+				report := reports[i][j]
+				if emit && !report.OrderReportedIssues.Rescinded && !hasReport(state.reports, report.ReportID) {
+					issues = append(issues, OrderEvent{Type: OrderEventIssueReported, Order: OrderDetails{OrderSummary: order}, Report: &report})
+				}
+			}
+			state.reports, state.reportsAt = reports[i], checkedAt
+		}
+		allReports = append(allReports, state.reports...)
+
+		state.status = FulfillmentStatus(StringValue(order.LatestFulfillmentStatus))
+		if isFinalOrderStatus(state.status) {
+			delete(w.orders, order.ID)
+		} else {
+			w.orders[order.ID] = state
+		}
+	}
+	for id := range w.orders {
+		if !listed[id] {
+			delete(w.orders, id)
+		}
+	}
+
+	events = append(events, issues...)
+	for _, payout := range GroupChargesByPayout(allReports) {
+		if _, seen := w.payouts[payout.PayoutID]; payout.PayoutID == "" || seen {
+			continue
+		}
+		w.payouts[payout.PayoutID] = checkedAt
+		if emit {
+			events = append(events, OrderEvent{Type: OrderEventPayout, Payout: &payout})
+		}
+	}
+	for id, seen := range w.payouts {
+		if seen.Before(since.Time) {
+			delete(w.payouts, id)
+		}
+	}
+	w.primed = true
+
+	if w.Notifier == nil {
+		return events, nil
+	}
+	queue := append(w.pending, events...)
+	w.pending = nil
+	for i, event := range queue {
+		if err := w.Notifier.Notify(ctx, event.Notification()); err != nil {
+			w.pending = append([]OrderEvent(nil), queue[i:]...)
+			return events, fmt.Errorf("failed to send %s notification: %w", event.Type, err)
+		}
+	}
+	return events, nil
+}
+
+// isFinalOrderStatus reports whether an order with latest fulfillment status
+// s has nothing left to watch for.
+func isFinalOrderStatus(s FulfillmentStatus) bool {
+	return s == FulfillmentStatusDelivered || s.IsTerminal()
+}
+
+// hasReport reports whether reports contains the report with id.
+func hasReport(reports []OrderReport, id string) bool {
+	for _, report := range reports {
+		if report.ReportID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Run calls Check every interval until ctx is cancelled, passing each event
+// to onEvent and each failed check's error to onError if they are not nil.
+func (w *OrderWatcher) Run(ctx context.Context, interval time.Duration, onEvent func(OrderEvent), onError func(error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		events, err := w.Check(ctx)
+		if onEvent != nil {
+			for _, event := range events {
+				onEvent(event)
+			}
+		}
+		return err
+	}, onError)
+}
+
+// NotifyOrderCreated returns a webhook handler that sends a new order
+// notification for every order_created webhook, for sellers who receive
+// webhooks rather than polling with OrderWatcher.
+//
+// Example:
+//
+//	handler := manapool.NewWebhookHandler(secret)
+//	handler.Handle(manapool.WebhookTopicOrderCreated, manapool.NotifyOrderCreated(discord))
+func NotifyOrderCreated(notifier Notifier) WebhookHandlerFunc {
+	return func(ctx context.Context, event WebhookEvent) error {
+		created, ok := event.(*OrderCreatedEvent)
+		if !ok {
+			return nil
+		}
+		return notifier.Notify(ctx, OrderEvent{Type: OrderEventNew, Order: created.Order}.Notification())
+	}
+}
@@ -0,0 +1,375 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSellerOrders serves seller orders and their reports from mutable state.
+type fakeSellerOrders struct {
+	mu      sync.Mutex
+	orders  []string            // order JSON objects
+	reports map[string][]string // order ID -> report JSON objects
+	fetched []string            // order IDs whose reports were requested
+}
+
+func (f *fakeSellerOrders) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/seller/orders")
+	switch {
+	case path == "":
+		fmt.Fprintf(w, `{"orders":[%s]}`, strings.Join(f.orders, ","))
+	case strings.HasSuffix(path, "/reports"):
+		id := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/reports")
+		f.fetched = append(f.fetched, id)
+		fmt.Fprintf(w, `{"reports":[%s]}`, strings.Join(f.reports[id], ","))
+	default:
+		id := strings.TrimPrefix(path, "/")
+		for _, order := range f.orders {
+			if strings.Contains(order, `"id":"`+id+`"`) {
+				fmt.Fprintf(w, `{"order":%s}`, strings.Replace(order, "}", `,"items":[{"quantity":2,"price_cents":150,"product":{"single":{"name":"Sol Ring"}}}],`+
+					`"fulfillments":[{"status":"shipped","tracking_company":"USPS","tracking_number":"9400","tracking_url":"https://example.com/track/9400"}]}`, 1))
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeSellerOrders) add(order string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders = append(f.orders, order)
+}
+
+func (f *fakeSellerOrders) set(i int, order string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.orders[i] = order
+}
+
+func (f *fakeSellerOrders) report(orderID, report string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reports[orderID] = append(f.reports[orderID], report)
+}
+
+func TestOrderWatcher_Check(t *testing.T) {
+	fake := &fakeSellerOrders{
+		orders:  []string{`{"id":"o1","label":"A1","total_cents":300,"created_at":"2024-05-01T00:00:00Z"}`},
+		reports: map[string][]string{"o1": {`{"report_id":"r1","order_id":"o1","order_reported_issues":{"charges":[{"seller_charge_cents":100,"payout_id":"p1"}]}}`}},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	var sent []Notification
+	now := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	watcher := &OrderWatcher{
+		Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/")),
+		Now:    func() time.Time { return now },
+		Notifier: NotifierFunc(func(ctx context.Context, n Notification) error {
+			sent = append(sent, n)
+			return nil
+		}),
+	}
+	ctx := context.Background()
+
+	events, err := watcher.Check(ctx)
+	if err != nil {
+		t.Fatalf("first Check() error = %v", err)
+	}
+	if len(events) != 0 || len(sent) != 0 {
+		t.Fatalf("first check reported %d events, %d notifications; want none", len(events), len(sent))
+	}
+
+	fake.add(`{"id":"o2","label":"B2","total_cents":1234,"shipping_method":"first_class","created_at":"2024-05-01T12:00:00Z"}`)
+	fake.report("o1", `{"report_id":"r2","order_id":"o1","order_reported_issues":{"reporter_role":"buyer","comment":"Card was bent","is_nondelivery_report":false}}`)
+	fake.report("o2", `{"report_id":"r3","order_id":"o2","order_reported_issues":{"rescinded":true,"charges":[{"seller_charge_cents":250,"payout_id":"p2"}]}}`)
+	now = now.Add(DefaultOrderReportInterval)
+
+	events, err = watcher.Check(ctx)
+	if err != nil {
+		t.Fatalf("second Check() error = %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(events), events)
+	}
+	if events[0].Type != OrderEventNew || events[0].Order.ID != "o2" || len(events[0].Order.Items) != 1 {
+		t.Errorf("events[0] = %+v", events[0])
+	}
+	if events[1].Type != OrderEventIssueReported || events[1].Order.ID != "o1" || events[1].Report.ReportID != "r2" {
+		t.Errorf("events[1] = %+v", events[1])
+	}
+	if events[2].Type != OrderEventPayout || events[2].Payout.PayoutID != "p2" || events[2].Payout.SellerChargeCents != 250 {
+		t.Errorf("events[2] = %+v", events[2])
+	}
+
+	if len(sent) != 3 {
+		t.Fatalf("sent %d notifications, want 3", len(sent))
+	}
+	if sent[0].Event != "new_order" || sent[0].Title != "New order B2: $12.34" || !strings.Contains(sent[0].Body, "2x Sol Ring at $1.50") {
+		t.Errorf("new order notification = %+v", sent[0])
+	}
+	if sent[1].Event != "issue_reported" || !strings.Contains(sent[1].Body, "Issue reported by buyer: Card was bent") {
+		t.Errorf("issue notification = %+v", sent[1])
+	}
+	if sent[2].Event != "payout" || sent[2].Title != "Payout p2: $2.50 in charges" {
+		t.Errorf("payout notification = %+v", sent[2])
+	}
+
+	if events, err := watcher.Check(ctx); err != nil || len(events) != 0 {
+		t.Errorf("third Check() = %d events, %v; want none", len(events), err)
+	}
+}
+
+func TestOrderWatcher_Fulfillment(t *testing.T) {
+	fake := &fakeSellerOrders{
+		orders:  []string{`{"id":"o1","label":"A1","created_at":"2024-05-01T00:00:00Z"}`},
+		reports: map[string][]string{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	watcher := &OrderWatcher{Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))}
+	ctx := context.Background()
+	if _, err := watcher.Check(ctx); err != nil {
+		t.Fatalf("first Check() error = %v", err)
+	}
+
+	fake.set(0, `{"id":"o1","label":"A1","latest_fulfillment_status":"processing","created_at":"2024-05-01T00:00:00Z"}`)
+	if events, err := watcher.Check(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("processing Check() = %+v, %v; want no events", events, err)
+	}
+
+	fake.set(0, `{"id":"o1","label":"A1","latest_fulfillment_status":"shipped","created_at":"2024-05-01T00:00:00Z"}`)
+	events, err := watcher.Check(ctx)
+	if err != nil || len(events) != 1 || events[0].Type != OrderEventShipped {
+		t.Fatalf("shipped Check() = %+v, %v", events, err)
+	}
+	n := events[0].Notification()
+	if n.Event != "order_shipped" || n.Title != "Order A1 shipped" || !strings.Contains(n.Body, "Tracking: USPS 9400\nhttps://example.com/track/9400") {
+		t.Errorf("shipped notification = %+v", n)
+	}
+
+	fake.set(0, `{"id":"o1","label":"A1","latest_fulfillment_status":"delivered","created_at":"2024-05-01T00:00:00Z"}`)
+	events, err = watcher.Check(ctx)
+	if err != nil || len(events) != 1 || events[0].Type != OrderEventDelivered {
+		t.Fatalf("delivered Check() = %+v, %v", events, err)
+	}
+	if n := events[0].Notification(); n.Title != "Order A1 delivered" {
+		t.Errorf("delivered notification = %+v", n)
+	}
+}
+
+func TestOrderWatcher_EmitInitial(t *testing.T) {
+	fake := &fakeSellerOrders{
+		orders:  []string{`{"id":"o1","label":"A1","created_at":"2024-05-01T00:00:00Z"}`},
+		reports: map[string][]string{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	watcher := &OrderWatcher{Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/")), EmitInitial: true}
+	events, err := watcher.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Type != OrderEventNew {
+		t.Errorf("events = %+v, want one new order", events)
+	}
+}
+
+func TestOrderWatcher_Errors(t *testing.T) {
+	if _, err := (&OrderWatcher{}).Check(context.Background()); err == nil {
+		t.Error("expected error for nil client")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/seller/orders" {
+			_, _ = w.Write([]byte(`{"orders":[{"id":"o1"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	watcher := &OrderWatcher{Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))}
+	var apiErr *APIError
+	if _, err := watcher.Check(context.Background()); !errors.As(err, &apiErr) {
+		t.Errorf("Check() error = %v, want APIError", err)
+	}
+
+	fake := &fakeSellerOrders{reports: map[string][]string{}}
+	server = httptest.NewServer(fake)
+	defer server.Close()
+	watcher = &OrderWatcher{
+		Client:      NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/")),
+		EmitInitial: true,
+		Notifier: NotifierFunc(func(ctx context.Context, n Notification) error {
+			return errors.New("webhook down")
+		}),
+	}
+	fake.add(`{"id":"o1","label":"A1"}`)
+	events, err := watcher.Check(context.Background())
+	if err == nil || len(events) != 1 {
+		t.Errorf("Check() = %d events, %v; want the event and a notifier error", len(events), err)
+	}
+}
+
+func TestOrderWatcher_RetriesNotifications(t *testing.T) {
+	fake := &fakeSellerOrders{reports: map[string][]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	down := true
+	var sent []string
+	watcher := &OrderWatcher{
+		Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/")),
+		Notifier: NotifierFunc(func(ctx context.Context, n Notification) error {
+			if down {
+				return errors.New("webhook down")
+			}
+			sent = append(sent, n.Title)
+			return nil
+		}),
+	}
+	ctx := context.Background()
+	if _, err := watcher.Check(ctx); err != nil {
+		t.Fatalf("first Check() error = %v", err)
+	}
+
+	fake.add(`{"id":"o1","label":"A1","total_cents":100}`)
+	fake.add(`{"id":"o2","label":"B2","total_cents":200}`)
+	if events, err := watcher.Check(ctx); err == nil || len(events) != 2 {
+		t.Fatalf("Check() = %d events, %v; want both events and a notifier error", len(events), err)
+	}
+
+	down = false
+	if events, err := watcher.Check(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("Check() after recovery = %d events, %v", len(events), err)
+	}
+	if len(sent) != 2 || sent[0] != "New order A1: $1.00" || sent[1] != "New order B2: $2.00" {
+		t.Errorf("sent = %q, want both held notifications in order", sent)
+	}
+}
+
+func TestOrderWatcher_ForgetsFinalOrders(t *testing.T) {
+	fake := &fakeSellerOrders{
+		orders:  []string{`{"id":"o1","label":"A1","latest_fulfillment_status":"shipped"}`, `{"id":"o2","label":"B2","latest_fulfillment_status":"delivered"}`},
+		reports: map[string][]string{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	now := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	watcher := &OrderWatcher{
+		Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/")),
+		Now:    func() time.Time { return now },
+	}
+	ctx := context.Background()
+	if _, err := watcher.Check(ctx); err != nil {
+		t.Fatalf("first Check() error = %v", err)
+	}
+	if len(watcher.orders) != 1 || strings.Join(fake.fetched, ",") != "o1" {
+		t.Fatalf("watching %d orders, fetched reports for %q; want only the open order", len(watcher.orders), fake.fetched)
+	}
+
+	// Unchanged orders are not fetched again until ReportInterval passes.
+	if _, err := watcher.Check(ctx); err != nil || len(fake.fetched) != 1 {
+		t.Fatalf("unchanged Check() fetched %q, %v", fake.fetched, err)
+	}
+	now = now.Add(DefaultOrderReportInterval)
+	if _, err := watcher.Check(ctx); err != nil || len(fake.fetched) != 2 {
+		t.Fatalf("Check() after interval fetched %q, %v", fake.fetched, err)
+	}
+
+	fake.set(0, `{"id":"o1","label":"A1","latest_fulfillment_status":"delivered"}`)
+	events, err := watcher.Check(ctx)
+	if err != nil || len(events) != 1 || events[0].Type != OrderEventDelivered {
+		t.Fatalf("delivered Check() = %+v, %v", events, err)
+	}
+	if len(watcher.orders) != 0 {
+		t.Errorf("still watching %d orders after delivery", len(watcher.orders))
+	}
+	if events, err := watcher.Check(ctx); err != nil || len(events) != 0 {
+		t.Errorf("Check() after delivery = %+v, %v; want no events", events, err)
+	}
+
+	fake.set(0, `{"id":"o3","label":"C3"}`)
+	if _, err := watcher.Check(ctx); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	fake.mu.Lock()
+	fake.orders = fake.orders[:1]
+	fake.mu.Unlock()
+	fake.set(0, `{"id":"o4","label":"D4"}`)
+	if _, err := watcher.Check(ctx); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if _, ok := watcher.orders["o3"]; ok || len(watcher.orders) != 1 {
+		t.Errorf("orders = %v, want o3 forgotten once it is no longer listed", watcher.orders)
+	}
+}
+
+func TestOrderWatcher_Run(t *testing.T) {
+	fake := &fakeSellerOrders{reports: map[string][]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	watcher := &OrderWatcher{Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))}
+	if err := watcher.Run(context.Background(), 0, nil, nil); err == nil {
+		t.Error("expected error for zero interval")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var events []OrderEvent
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		fake.add(`{"id":"o1","label":"A1"}`)
+	}()
+	err := watcher.Run(ctx, time.Millisecond, func(e OrderEvent) {
+		events = append(events, e)
+		cancel()
+	}, func(err error) {
+		t.Errorf("unexpected error: %v", err)
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	if len(events) != 1 || events[0].Order.ID != "o1" {
+		t.Errorf("events = %+v, want the new order", events)
+	}
+}
+
+func TestNotifyOrderCreated(t *testing.T) {
+	var sent []Notification
+	handler := NotifyOrderCreated(NotifierFunc(func(ctx context.Context, n Notification) error {
+		sent = append(sent, n)
+		return nil
+	}))
+
+	event := &OrderCreatedEvent{Order: OrderDetails{OrderSummary: OrderSummary{ID: "o1", Label: "A1", TotalCents: 500}}}
+	if err := handler(context.Background(), event); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if err := handler(context.Background(), &UnknownWebhookEvent{Topic: "other"}); err != nil {
+		t.Fatalf("handler error for unknown event = %v", err)
+	}
+	if len(sent) != 1 || sent[0].Event != "new_order" || sent[0].Title != "New order A1: $5.00" {
+		t.Errorf("sent = %+v", sent)
+	}
+	if data, ok := sent[0].Data.(OrderEvent); !ok || data.Order.ID != "o1" {
+		t.Errorf("Data = %#v", sent[0].Data)
+	}
+}
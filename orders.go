@@ -3,15 +3,21 @@ package manapool
 import (
 	"context"
 	"fmt"
-	"net/url"
-	"strconv"
-	"time"
+
+	"github.com/repricah/manapool/internal/parallel"
 )
 
+// defaultOrdersPageSize is the page size used when iterating order lists.
+const defaultOrdersPageSize = 100
+
 // GetOrders retrieves order summaries.
 func (c *Client) GetOrders(ctx context.Context, opts OrdersOptions) (*OrdersResponse, error) {
-	params := buildOrdersParams(opts)
-	resp, err := c.doRequest(ctx, "GET", "/orders", params)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := buildOrdersParams(opts, c.timestampLayout)
+	resp, err := c.doQueryRequest(ctx, "GET", "/orders", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get orders: %w", err)
 	}
@@ -30,7 +36,7 @@ func (c *Client) GetOrder(ctx context.Context, id string) (*OrderDetailsResponse
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/orders/%s", id)
+	endpoint := endpointPath("/orders/%s", id)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
@@ -45,13 +51,31 @@ func (c *Client) GetOrder(ctx context.Context, id string) (*OrderDetailsResponse
 }
 
 // UpdateOrderFulfillment updates the fulfillment for an order.
+// The request is checked with OrderFulfillmentRequest.Validate before it is sent.
+// When it sets a status, the order is fetched with GetOrder first and the change
+// from its latest fulfillment status is checked with ValidateFulfillmentTransition.
+// Use UpdateOrderFulfillmentFrom to skip the fetch when that status is known.
 func (c *Client) UpdateOrderFulfillment(ctx context.Context, id string, req OrderFulfillmentRequest) (*OrderFulfillmentResponse, error) {
+	return c.updateOrderFulfillment(ctx, id, req, nil)
+}
+
+// UpdateOrderFulfillmentFrom is UpdateOrderFulfillment for an order whose
+// latest fulfillment status is already known, for example from GetOrders:
+// the transition from current is checked without fetching the order.
+func (c *Client) UpdateOrderFulfillmentFrom(ctx context.Context, id string, current FulfillmentStatus, req OrderFulfillmentRequest) (*OrderFulfillmentResponse, error) {
+	return c.updateOrderFulfillment(ctx, id, req, &current)
+}
+
+func (c *Client) updateOrderFulfillment(ctx context.Context, id string, req OrderFulfillmentRequest, current *FulfillmentStatus) (*OrderFulfillmentResponse, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
+	if err := c.validateFulfillmentUpdate(ctx, id, req, current, c.GetOrder); err != nil {
+		return nil, err
+	}
 
-	endpoint := fmt.Sprintf("/orders/%s/fulfillment", id)
-	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, nil, req)
+	endpoint := endpointPath("/orders/%s/fulfillment", id)
+	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, nil, c.fulfillmentPayload(req))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update order fulfillment: %w", err)
 	}
@@ -64,10 +88,59 @@ func (c *Client) UpdateOrderFulfillment(ctx context.Context, id string, req Orde
 	return &fulfillment, nil
 }
 
+// validateFulfillmentUpdate validates req and, when it sets a status, checks
+// the transition from current, or if that is nil from the latest fulfillment
+// status of the order returned by get. A current status this package does not
+// know is not checked, so new API statuses do not block updates.
+func (c *Client) validateFulfillmentUpdate(ctx context.Context, id string, req OrderFulfillmentRequest, current *FulfillmentStatus, get func(context.Context, string) (*OrderDetailsResponse, error)) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if req.Status == nil {
+		return nil
+	}
+
+	if current == nil {
+		order, err := get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check fulfillment status: %w", err)
+		}
+		status := FulfillmentStatus(StringValue(order.Order.LatestFulfillmentStatus))
+		current = &status
+	}
+	if *current != "" && !current.IsValid() {
+		return nil
+	}
+	return ValidateFulfillmentTransition(*current, FulfillmentStatus(*req.Status))
+}
+
+// fulfillmentPayload returns req as sent to the API, with its timestamps in
+// the client's timestamp layout.
+func (c *Client) fulfillmentPayload(req OrderFulfillmentRequest) interface{} {
+	if c.timestampLayout == "" {
+		return req
+	}
+	return struct {
+		OrderFulfillmentRequest
+		InTransitAt         layoutTimestamp `json:"in_transit_at"`
+		EstimatedDeliveryAt layoutTimestamp `json:"estimated_delivery_at"`
+		DeliveredAt         layoutTimestamp `json:"delivered_at"`
+	}{
+		OrderFulfillmentRequest: req,
+		InTransitAt:             layoutTimestamp{req.InTransitAt, c.timestampLayout},
+		EstimatedDeliveryAt:     layoutTimestamp{req.EstimatedDeliveryAt, c.timestampLayout},
+		DeliveredAt:             layoutTimestamp{req.DeliveredAt, c.timestampLayout},
+	}
+}
+
 // GetSellerOrders retrieves seller order summaries.
 func (c *Client) GetSellerOrders(ctx context.Context, opts OrdersOptions) (*OrdersResponse, error) {
-	params := buildOrdersParams(opts)
-	resp, err := c.doRequest(ctx, "GET", "/seller/orders", params)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := buildOrdersParams(opts, c.timestampLayout)
+	resp, err := c.doQueryRequest(ctx, "GET", "/seller/orders", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seller orders: %w", err)
 	}
@@ -86,7 +159,7 @@ func (c *Client) GetSellerOrder(ctx context.Context, id string) (*OrderDetailsRe
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/seller/orders/%s", id)
+	endpoint := endpointPath("/seller/orders/%s", id)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seller order: %w", err)
@@ -101,13 +174,32 @@ func (c *Client) GetSellerOrder(ctx context.Context, id string) (*OrderDetailsRe
 }
 
 // UpdateSellerOrderFulfillment updates a seller order fulfillment.
+// The request is checked with OrderFulfillmentRequest.Validate before it is sent.
+// When it sets a status, the order is fetched with GetSellerOrder first and the change
+// from its latest fulfillment status is checked with ValidateFulfillmentTransition.
+// Use UpdateSellerOrderFulfillmentFrom to skip the fetch when that status is known.
 func (c *Client) UpdateSellerOrderFulfillment(ctx context.Context, id string, req OrderFulfillmentRequest) (*OrderFulfillmentResponse, error) {
+	return c.updateSellerOrderFulfillment(ctx, id, req, nil)
+}
+
+// UpdateSellerOrderFulfillmentFrom is UpdateSellerOrderFulfillment for an
+// order whose latest fulfillment status is already known, for example from
+// GetSellerOrders: the transition from current is checked without fetching
+// the order.
+func (c *Client) UpdateSellerOrderFulfillmentFrom(ctx context.Context, id string, current FulfillmentStatus, req OrderFulfillmentRequest) (*OrderFulfillmentResponse, error) {
+	return c.updateSellerOrderFulfillment(ctx, id, req, &current)
+}
+
+func (c *Client) updateSellerOrderFulfillment(ctx context.Context, id string, req OrderFulfillmentRequest, current *FulfillmentStatus) (*OrderFulfillmentResponse, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
+	if err := c.validateFulfillmentUpdate(ctx, id, req, current, c.GetSellerOrder); err != nil {
+		return nil, err
+	}
 
-	endpoint := fmt.Sprintf("/seller/orders/%s/fulfillment", id)
-	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, nil, req)
+	endpoint := endpointPath("/seller/orders/%s/fulfillment", id)
+	resp, err := c.doJSONRequest(ctx, "PUT", endpoint, nil, c.fulfillmentPayload(req))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update seller order fulfillment: %w", err)
 	}
@@ -121,12 +213,14 @@ func (c *Client) UpdateSellerOrderFulfillment(ctx context.Context, id string, re
 }
 
 // GetSellerOrderReports retrieves order reports for a seller order.
+// The API exposes reported issues read-only; there are no seller-side endpoints
+// for acknowledging, commenting on, or remediating a report.
 func (c *Client) GetSellerOrderReports(ctx context.Context, id string) (*OrderReportsResponse, error) {
 	if id == "" {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/seller/orders/%s/reports", id)
+	endpoint := endpointPath("/seller/orders/%s/reports", id)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get seller order reports: %w", err)
@@ -140,28 +234,133 @@ func (c *Client) GetSellerOrderReports(ctx context.Context, id string) (*OrderRe
 	return &reports, nil
 }
 
-func buildOrdersParams(opts OrdersOptions) url.Values {
-	params := url.Values{}
+func buildOrdersParams(opts OrdersOptions, timestampLayout string) *query {
+	params := newQuery()
 	if opts.Since != nil {
-		params.Add("since", opts.Since.Format(time.RFC3339Nano))
+		params.add("since", formatTimestamp(*opts.Since, timestampLayout))
 	}
 	if opts.IsUnfulfilled != nil {
-		params.Add("is_unfulfilled", strconv.FormatBool(*opts.IsUnfulfilled))
+		params.addBool("is_unfulfilled", *opts.IsUnfulfilled)
 	}
 	if opts.IsFulfilled != nil {
-		params.Add("is_fulfilled", strconv.FormatBool(*opts.IsFulfilled))
+		params.addBool("is_fulfilled", *opts.IsFulfilled)
 	}
 	if opts.HasFulfillments != nil {
-		params.Add("has_fulfillments", strconv.FormatBool(*opts.HasFulfillments))
+		params.addBool("has_fulfillments", *opts.HasFulfillments)
 	}
 	if opts.Label != "" {
-		params.Add("label", opts.Label)
+		params.add("label", opts.Label)
 	}
 	if opts.Limit > 0 {
-		params.Add("limit", strconv.Itoa(opts.Limit))
-	}
-	if opts.Offset > 0 {
-		params.Add("offset", strconv.Itoa(opts.Offset))
+		params.addInt("limit", opts.Limit)
 	}
+	params.addInt("offset", opts.Offset)
 	return params
 }
+
+// GetSellerOrdersCount returns the number of seller orders matching opts.
+// It requests a single order and uses the pagination total when the API
+// reports one; otherwise it counts the matching orders page by page at the
+// maximum page size. opts.Limit and opts.Offset are ignored.
+//
+// Example:
+//
+//	count, err := client.GetSellerOrdersCount(ctx, manapool.OrdersOptions{IsFulfilled: manapool.Bool(false)})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d orders awaiting fulfillment\n", count)
+func (c *Client) GetSellerOrdersCount(ctx context.Context, opts OrdersOptions) (int, error) {
+	opts.Limit = 1
+	opts.Offset = 0
+
+	head, err := c.GetSellerOrders(ctx, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count seller orders: %w", err)
+	}
+	if head.Pagination.Total > 0 || len(head.Orders) == 0 {
+		return head.Pagination.Total, nil
+	}
+
+	count := 0
+	opts.Limit = defaultOrdersPageSize
+	err = c.IterateSellerOrders(ctx, opts, func(*OrderSummary) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count seller orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// IterateSellerOrders pages through seller orders matching opts and calls
+// callback for each order summary. opts.Offset is used as the starting offset
+// and opts.Limit as the page size (default: 100).
+func (c *Client) IterateSellerOrders(ctx context.Context, opts OrdersOptions, callback func(*OrderSummary) error) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultOrdersPageSize
+	}
+
+	for {
+		resp, err := c.GetSellerOrders(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get seller orders at offset %d: %w", opts.Offset, err)
+		}
+
+		for i := range resp.Orders {
+			if err := callback(&resp.Orders[i]); err != nil {
+				return fmt.Errorf("callback error at offset %d: %w", opts.Offset, err)
+			}
+		}
+
+		if len(resp.Orders) == 0 || len(resp.Orders) < opts.Limit {
+			return nil
+		}
+		if resp.Pagination.Total > 0 && opts.Offset+len(resp.Orders) >= resp.Pagination.Total {
+			return nil
+		}
+
+		opts.Offset += len(resp.Orders)
+	}
+}
+
+// collectSellerOrderDetails fetches the details of every seller order matching
+// opts, in list order. Detail requests run concurrently, up to the client's
+// concurrency; after the first failure no new requests are started.
+func (c *Client) collectSellerOrderDetails(ctx context.Context, opts OrdersOptions) ([]OrderDetails, error) {
+	var ids []string
+	err := c.IterateSellerOrders(ctx, opts, func(summary *OrderSummary) error {
+		ids = append(ids, summary.ID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.getSellerOrderDetails(ctx, ids)
+}
+
+// getSellerOrderDetails fetches the full details of each seller order in ids.
+func (c *Client) getSellerOrderDetails(ctx context.Context, ids []string) ([]OrderDetails, error) {
+	orders := make([]OrderDetails, len(ids))
+	err := parallel.Run(ctx, len(ids), c.parallelOptions(true), func(ctx context.Context, i int) error {
+		resp, err := c.GetSellerOrder(ctx, ids[i])
+		if err != nil {
+			return err
+		}
+		orders[i] = resp.Order
+		return nil
+	})
+	if errs, ok := parallel.As(err); ok {
+		failure := errs.Failures[0]
+		err = fmt.Errorf("failed to get order %s: %w", ids[failure.Index], failure.Err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
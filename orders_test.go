@@ -175,3 +175,169 @@ func TestClient_OrderEndpoints(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_GetSellerOrders_Pagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("limit") {
+			t.Fatalf("limit = %q, want none", r.URL.Query().Get("limit"))
+		}
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a"},{"id":"b"}]}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"orders":[{"id":"c"}],"pagination":{"total":3,"returned":1,"offset":2,"limit":100}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	t.Run("Omitted", func(t *testing.T) {
+		orders, err := client.GetSellerOrders(ctx, OrdersOptions{})
+		if err != nil {
+			t.Fatalf("GetSellerOrders error: %v", err)
+		}
+		if orders.Pagination != (Pagination{}) {
+			t.Fatalf("pagination = %+v, want zero", orders.Pagination)
+		}
+	})
+
+	t.Run("FromAPI", func(t *testing.T) {
+		orders, err := client.GetSellerOrders(ctx, OrdersOptions{Offset: 2})
+		if err != nil {
+			t.Fatalf("GetSellerOrders error: %v", err)
+		}
+		want := Pagination{Total: 3, Returned: 1, Offset: 2, Limit: 100}
+		if orders.Pagination != want {
+			t.Fatalf("pagination = %+v, want %+v", orders.Pagination, want)
+		}
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.GetSellerOrders(ctx, OrdersOptions{Limit: -1}); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError for limit, got %v", err)
+		}
+		if _, err := client.GetOrders(ctx, OrdersOptions{Offset: -1}); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError for offset, got %v", err)
+		}
+		if _, err := client.GetBuyerOrders(ctx, BuyerOrdersOptions{Limit: -1}); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError for buyer limit, got %v", err)
+		}
+		if err := client.IterateSellerOrders(ctx, OrdersOptions{Limit: -1}, func(*OrderSummary) error { return nil }); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError from IterateSellerOrders, got %v", err)
+		}
+	})
+}
+
+func TestClient_IterateSellerOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a"},{"id":"b"}],"pagination":{"total":3,"returned":2,"offset":0,"limit":2}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"orders":[{"id":"c"}],"pagination":{"total":3,"returned":1,"offset":2,"limit":2}}`))
+		default:
+			t.Fatalf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	var ids []string
+	err := client.IterateSellerOrders(ctx, OrdersOptions{Limit: 2}, func(order *OrderSummary) error {
+		ids = append(ids, order.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateSellerOrders error: %v", err)
+	}
+	if len(ids) != 3 || ids[2] != "c" {
+		t.Fatalf("ids = %v, want [a b c]", ids)
+	}
+
+	err = client.IterateSellerOrders(ctx, OrdersOptions{Limit: 2}, func(order *OrderSummary) error {
+		return errors.New("stop")
+	})
+	if err == nil {
+		t.Fatal("expected callback error")
+	}
+}
+
+func TestClient_GetSellerOrdersCount(t *testing.T) {
+	t.Run("FromPaginationTotal", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("limit"); got != "1" {
+				t.Fatalf("limit = %q, want 1", got)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a"}],"pagination":{"total":42,"returned":1,"offset":0,"limit":1}}`))
+		}))
+		defer server.Close()
+
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+		count, err := client.GetSellerOrdersCount(context.Background(), OrdersOptions{Limit: 50, Offset: 10})
+		if err != nil {
+			t.Fatalf("GetSellerOrdersCount error: %v", err)
+		}
+		if count != 42 {
+			t.Fatalf("count = %d, want 42", count)
+		}
+	})
+
+	t.Run("CountsPages", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			if r.URL.Query().Get("limit") == "1" {
+				_, _ = w.Write([]byte(`{"orders":[{"id":"a"}]}`))
+				return
+			}
+			if got := r.URL.Query().Get("limit"); got != "100" {
+				t.Fatalf("limit = %q, want 100", got)
+			}
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a"},{"id":"b"},{"id":"c"}]}`))
+		}))
+		defer server.Close()
+
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+		count, err := client.GetSellerOrdersCount(context.Background(), OrdersOptions{})
+		if err != nil {
+			t.Fatalf("GetSellerOrdersCount error: %v", err)
+		}
+		if count != 3 {
+			t.Fatalf("count = %d, want 3", count)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"orders":[]}`))
+		}))
+		defer server.Close()
+
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+		count, err := client.GetSellerOrdersCount(context.Background(), OrdersOptions{})
+		if err != nil || count != 0 {
+			t.Fatalf("count = %d, err = %v, want 0 and nil", count, err)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+		if _, err := client.GetSellerOrdersCount(context.Background(), OrdersOptions{}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
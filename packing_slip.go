@@ -0,0 +1,234 @@
+package manapool
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// PackingSlipBranding holds seller branding printed on packing slips.
+type PackingSlipBranding struct {
+	StoreName     string
+	LogoURL       string
+	Website       string
+	SupportEmail  string
+	ReturnAddress *Address
+	Footer        string
+}
+
+// PackingSlipLine is a single printable line on a packing slip.
+type PackingSlipLine struct {
+	Name        string
+	Set         string
+	Number      string
+	ConditionID string
+	FinishID    string
+	LanguageID  string
+	Quantity    int
+	PriceCents  int
+}
+
+// PackingSlip is the data passed to a PackingSlipRenderer.
+type PackingSlip struct {
+	Branding    PackingSlipBranding
+	Order       OrderDetails
+	Lines       []PackingSlipLine
+	TotalItems  int
+	GeneratedAt time.Time
+}
+
+// PackingSlipRenderer renders a packing slip to a writer.
+// Implement this interface to plug in a custom layout.
+type PackingSlipRenderer interface {
+	Render(w io.Writer, slip PackingSlip) error
+}
+
+// PackingSlipRendererFunc adapts a function to the PackingSlipRenderer interface.
+type PackingSlipRendererFunc func(w io.Writer, slip PackingSlip) error
+
+// Render implements PackingSlipRenderer.
+func (f PackingSlipRendererFunc) Render(w io.Writer, slip PackingSlip) error {
+	return f(w, slip)
+}
+
+// NewPackingSlip builds packing slip data from an order.
+func NewPackingSlip(order OrderDetails, branding PackingSlipBranding) PackingSlip {
+	slip := PackingSlip{
+		Branding:    branding,
+		Order:       order,
+		Lines:       make([]PackingSlipLine, 0, len(order.Items)),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, item := range order.Items {
+		line := PackingSlipLine{
+			Quantity:   item.Quantity,
+			PriceCents: item.PriceCents,
+		}
+		switch {
+		case item.Product.Single != nil:
+			single := item.Product.Single
+			line.Name = single.Name
+			line.Set = single.Set
+			line.Number = single.Number
+			line.ConditionID = single.ConditionID
+			line.FinishID = single.FinishID
+			line.LanguageID = single.LanguageID
+		case item.Product.Sealed != nil:
+			sealed := item.Product.Sealed
+			line.Name = sealed.Name
+			line.Set = sealed.Set
+			line.LanguageID = sealed.LanguageID
+		default:
+			line.Name = item.ProductID
+		}
+		slip.Lines = append(slip.Lines, line)
+		slip.TotalItems += item.Quantity
+	}
+
+	return slip
+}
+
+// RenderPackingSlip renders the order as a packing slip using the given renderer.
+// If renderer is nil, the plain text renderer is used.
+//
+// Example:
+//
+//	order, err := client.GetSellerOrder(ctx, id)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	branding := manapool.PackingSlipBranding{StoreName: "My Card Shop"}
+//	err = manapool.RenderPackingSlip(os.Stdout, order.Order, branding, manapool.HTMLPackingSlipRenderer())
+func RenderPackingSlip(w io.Writer, order OrderDetails, branding PackingSlipBranding, renderer PackingSlipRenderer) error {
+	if renderer == nil {
+		renderer = TextPackingSlipRenderer()
+	}
+	if err := renderer.Render(w, NewPackingSlip(order, branding)); err != nil {
+		return fmt.Errorf("failed to render packing slip: %w", err)
+	}
+	return nil
+}
+
+var packingSlipFuncs = map[string]any{
+	"dollars": func(cents int) string {
+		return fmt.Sprintf("$%.2f", float64(cents)/100.0)
+	},
+	"upper": strings.ToUpper,
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+	"deref": StringValue,
+}
+
+const textPackingSlipTemplate = `{{with .Branding.StoreName}}{{upper .}}
+{{end}}{{with .Branding.Website}}{{.}}
+{{end}}{{with .Branding.SupportEmail}}{{.}}
+{{end}}
+PACKING SLIP
+Order: {{.Order.Label}}
+Date:  {{date .Order.CreatedAt.Time}}
+
+Ship To:
+{{template "address" .Order.ShippingAddress}}
+Qty  Item
+{{range .Lines}}{{printf "%-4d" .Quantity}} {{.Name}}{{with .Set}} [{{upper .}}{{end}}{{with .Number}} #{{.}}{{end}}{{if .Set}}]{{end}}{{with .ConditionID}} {{.}}{{end}}{{with .FinishID}} {{.}}{{end}}
+{{end}}
+Total items: {{.TotalItems}}
+{{with .Branding.ReturnAddress}}
+Return To:
+{{template "address" .}}{{end}}{{with .Branding.Footer}}
+{{.}}
+{{end}}{{define "address"}}{{with .Name}}  {{.}}
+{{end}}  {{.Line1}}
+{{with deref .Line2}}  {{.}}
+{{end}}{{with deref .Line3}}  {{.}}
+{{end}}  {{.City}}, {{.State}} {{.PostalCode}}
+  {{.Country}}
+{{end}}`
+
+const htmlPackingSlipTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Packing Slip {{.Order.Label}}</title></head>
+<body>
+<header>
+{{with .Branding.LogoURL}}<img src="{{.}}" alt="logo">{{end}}
+{{with .Branding.StoreName}}<h1>{{.}}</h1>{{end}}
+{{with .Branding.Website}}<p>{{.}}</p>{{end}}
+{{with .Branding.SupportEmail}}<p>{{.}}</p>{{end}}
+</header>
+<h2>Packing Slip</h2>
+<p>Order: {{.Order.Label}}<br>Date: {{date .Order.CreatedAt.Time}}</p>
+<address>
+{{template "address" .Order.ShippingAddress}}
+</address>
+<table>
+<thead><tr><th>Qty</th><th>Item</th><th>Set</th><th>Number</th><th>Condition</th><th>Finish</th></tr></thead>
+<tbody>
+{{range .Lines}}<tr><td>{{.Quantity}}</td><td>{{.Name}}</td><td>{{upper .Set}}</td><td>{{.Number}}</td><td>{{.ConditionID}}</td><td>{{.FinishID}}</td></tr>
+{{end}}</tbody>
+</table>
+<p>Total items: {{.TotalItems}}</p>
+{{with .Branding.ReturnAddress}}<p>Return to:</p>
+<address>
+{{template "address" .}}
+</address>
+{{end}}{{with .Branding.Footer}}<footer>{{.}}</footer>{{end}}
+</body>
+</html>
+{{define "address"}}{{with .Name}}{{.}}<br>{{end}}{{.Line1}}<br>{{with deref .Line2}}{{.}}<br>{{end}}{{with deref .Line3}}{{.}}<br>{{end}}{{.City}}, {{.State}} {{.PostalCode}}<br>{{.Country}}{{end}}`
+
+// TextPackingSlipRenderer returns the built-in plain text packing slip renderer.
+func TextPackingSlipRenderer() PackingSlipRenderer {
+	return &textTemplateRenderer{
+		tmpl: texttemplate.Must(texttemplate.New("packing_slip").Funcs(packingSlipFuncs).Parse(textPackingSlipTemplate)),
+	}
+}
+
+// HTMLPackingSlipRenderer returns the built-in HTML packing slip renderer.
+func HTMLPackingSlipRenderer() PackingSlipRenderer {
+	return &htmlTemplateRenderer{
+		tmpl: htmltemplate.Must(htmltemplate.New("packing_slip").Funcs(packingSlipFuncs).Parse(htmlPackingSlipTemplate)),
+	}
+}
+
+// NewTextPackingSlipRenderer parses a custom text/template packing slip layout.
+// The template is executed with a PackingSlip and may use the dollars, upper,
+// date, and deref helper functions.
+func NewTextPackingSlipRenderer(layout string) (PackingSlipRenderer, error) {
+	tmpl, err := texttemplate.New("packing_slip").Funcs(packingSlipFuncs).Parse(layout)
+	if err != nil {
+		return nil, NewValidationError("layout", "invalid packing slip template: "+err.Error())
+	}
+	return &textTemplateRenderer{tmpl: tmpl}, nil
+}
+
+// NewHTMLPackingSlipRenderer parses a custom html/template packing slip layout.
+// The template is executed with a PackingSlip and may use the dollars, upper,
+// date, and deref helper functions.
+func NewHTMLPackingSlipRenderer(layout string) (PackingSlipRenderer, error) {
+	tmpl, err := htmltemplate.New("packing_slip").Funcs(packingSlipFuncs).Parse(layout)
+	if err != nil {
+		return nil, NewValidationError("layout", "invalid packing slip template: "+err.Error())
+	}
+	return &htmlTemplateRenderer{tmpl: tmpl}, nil
+}
+
+type textTemplateRenderer struct {
+	tmpl *texttemplate.Template
+}
+
+func (r *textTemplateRenderer) Render(w io.Writer, slip PackingSlip) error {
+	return r.tmpl.Execute(w, slip)
+}
+
+type htmlTemplateRenderer struct {
+	tmpl *htmltemplate.Template
+}
+
+func (r *htmlTemplateRenderer) Render(w io.Writer, slip PackingSlip) error {
+	return r.tmpl.Execute(w, slip)
+}
@@ -0,0 +1,164 @@
+package manapool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testOrderDetails() OrderDetails {
+	line2 := "Apt 4"
+	sku := Int64(12345)
+	return OrderDetails{
+		OrderSummary: OrderSummary{
+			ID:             "order-1",
+			CreatedAt:      Timestamp{Time: time.Date(2024, 4, 1, 5, 44, 13, 0, time.UTC)},
+			Label:          "1234-5678",
+			TotalCents:     1100,
+			ShippingMethod: "first_class",
+		},
+		BuyerID: "buyer",
+		ShippingAddress: Address{
+			Name:       "Jane Doe",
+			Line1:      "123 Main St",
+			Line2:      &line2,
+			City:       "Springfield",
+			State:      "IL",
+			PostalCode: "62701",
+			Country:    "US",
+		},
+		Payment: OrderPayment{SubtotalCents: 1000, ShippingCents: 100, TotalCents: 1100, FeeCents: 50, NetCents: 1050},
+		Items: []OrderItem{
+			{
+				TCGSKU:      sku,
+				ProductID:   "prod-1",
+				ProductType: "mtg_single",
+				Quantity:    2,
+				PriceCents:  400,
+				Product: Product{Single: &Single{
+					Name: "Lightning Bolt", Set: "lea", Number: "161", ConditionID: "NM", FinishID: "NF", LanguageID: "EN",
+				}},
+			},
+			{
+				ProductID:   "prod-2",
+				ProductType: "mtg_sealed",
+				Quantity:    1,
+				PriceCents:  200,
+				Product:     Product{Sealed: &Sealed{Name: "Alpha Booster", Set: "lea", LanguageID: "EN"}},
+			},
+			{ProductID: "prod-3", Quantity: 1},
+		},
+	}
+}
+
+func TestNewPackingSlip(t *testing.T) {
+	slip := NewPackingSlip(testOrderDetails(), PackingSlipBranding{StoreName: "Shop"})
+	if len(slip.Lines) != 3 {
+		t.Fatalf("lines = %d, want 3", len(slip.Lines))
+	}
+	if slip.TotalItems != 4 {
+		t.Fatalf("total items = %d, want 4", slip.TotalItems)
+	}
+	if slip.Lines[0].Name != "Lightning Bolt" || slip.Lines[0].ConditionID != "NM" {
+		t.Fatalf("unexpected single line: %+v", slip.Lines[0])
+	}
+	if slip.Lines[1].Name != "Alpha Booster" {
+		t.Fatalf("unexpected sealed line: %+v", slip.Lines[1])
+	}
+	if slip.Lines[2].Name != "prod-3" {
+		t.Fatalf("unknown product name = %q, want product ID", slip.Lines[2].Name)
+	}
+}
+
+func TestRenderPackingSlip(t *testing.T) {
+	branding := PackingSlipBranding{
+		StoreName:    "Bolt & Co",
+		LogoURL:      "https://example.com/logo.png",
+		Website:      "https://example.com",
+		SupportEmail: "help@example.com",
+		ReturnAddress: &Address{
+			Name:       "Bolt & Co Returns",
+			Line1:      "1 Shop Rd",
+			City:       "Chicago",
+			State:      "IL",
+			PostalCode: "60601",
+			Country:    "US",
+		},
+		Footer: "Thanks for your order!",
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := RenderPackingSlip(&buf, testOrderDetails(), branding, nil); err != nil {
+			t.Fatalf("RenderPackingSlip error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{"BOLT & CO", "Order: 1234-5678", "Apt 4", "Springfield, IL 62701", "2    Lightning Bolt [LEA #161] NM NF", "Total items: 4", "Return To:\n  Bolt & Co Returns\n  1 Shop Rd\n  Chicago, IL 60601\n  US\n", "Thanks for your order!"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("text slip missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := RenderPackingSlip(&buf, testOrderDetails(), branding, HTMLPackingSlipRenderer()); err != nil {
+			t.Fatalf("RenderPackingSlip error: %v", err)
+		}
+		out := buf.String()
+		for _, want := range []string{"<h1>Bolt &amp; Co</h1>", `<img src="https://example.com/logo.png"`, "<td>Lightning Bolt</td>", "Total items: 4", "Bolt &amp; Co Returns<br>1 Shop Rd<br>Chicago, IL 60601<br>US"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("html slip missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("CustomTemplates", func(t *testing.T) {
+		textRenderer, err := NewTextPackingSlipRenderer(`{{.Order.Label}} {{dollars .Order.TotalCents}}`)
+		if err != nil {
+			t.Fatalf("NewTextPackingSlipRenderer error: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := RenderPackingSlip(&buf, testOrderDetails(), branding, textRenderer); err != nil {
+			t.Fatalf("RenderPackingSlip error: %v", err)
+		}
+		if buf.String() != "1234-5678 $11.00" {
+			t.Fatalf("custom text output = %q", buf.String())
+		}
+
+		htmlRenderer, err := NewHTMLPackingSlipRenderer(`<b>{{.Branding.StoreName}}</b>`)
+		if err != nil {
+			t.Fatalf("NewHTMLPackingSlipRenderer error: %v", err)
+		}
+		buf.Reset()
+		if err := RenderPackingSlip(&buf, testOrderDetails(), branding, htmlRenderer); err != nil {
+			t.Fatalf("RenderPackingSlip error: %v", err)
+		}
+		if buf.String() != "<b>Bolt &amp; Co</b>" {
+			t.Fatalf("custom html output = %q", buf.String())
+		}
+	})
+
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := NewTextPackingSlipRenderer(`{{.Order`); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if _, err := NewHTMLPackingSlipRenderer(`{{.Order`); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("RendererError", func(t *testing.T) {
+		failing := PackingSlipRendererFunc(func(w io.Writer, slip PackingSlip) error {
+			return errors.New("printer offline")
+		})
+		err := RenderPackingSlip(io.Discard, testOrderDetails(), branding, failing)
+		if err == nil || !strings.Contains(err.Error(), "printer offline") {
+			t.Fatalf("expected renderer error, got %v", err)
+		}
+	})
+}
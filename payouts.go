@@ -0,0 +1,71 @@
+package manapool
+
+import "sort"
+
+// PayoutCharges groups report charges deducted in one payout.
+//
+// The API has no payout endpoints: a payout ID appears only on
+// OrderReportedCharge, and payout amounts, periods and statuses cannot be
+// fetched. PayoutCharges is what can be reconstructed from order reports.
+type PayoutCharges struct {
+	// PayoutID is the payout the charges were deducted from. It is empty for
+	// charges not yet assigned to a payout.
+	PayoutID string
+
+	// OrderIDs lists the orders with charges in this payout, sorted.
+	OrderIDs []string
+
+	// ChargeCount is the number of charges.
+	ChargeCount int
+
+	// SellerChargeCents is the sum of the charges with a known amount.
+	SellerChargeCents int
+}
+
+// GroupChargesByPayout groups the charges in reports by payout ID, so a
+// payout_id seen on a report can be traced back to every order charged in
+// that payout. Results are sorted by payout ID, with unassigned charges
+// first.
+//
+// Example:
+//
+//	reports, err := client.GetSellerOrderReports(ctx, orderID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, payout := range manapool.GroupChargesByPayout(reports.Reports) {
+//	    fmt.Printf("%s: %d cents across %v\n", payout.PayoutID, payout.SellerChargeCents, payout.OrderIDs)
+//	}
+func GroupChargesByPayout(reports []OrderReport) []PayoutCharges {
+	groups := make(map[string]*PayoutCharges)
+	orders := make(map[string]map[string]bool)
+	for _, report := range reports {
+		for _, charge := range report.OrderReportedIssues.Charges {
+			id := StringValue(charge.PayoutID)
+			group, ok := groups[id]
+			if !ok {
+				group = &PayoutCharges{PayoutID: id}
+				groups[id] = group
+				orders[id] = make(map[string]bool)
+			}
+			group.ChargeCount++
+			if charge.SellerChargeCents != nil {
+				group.SellerChargeCents += *charge.SellerChargeCents
+			}
+			if report.OrderID != "" && !orders[id][report.OrderID] {
+				orders[id][report.OrderID] = true
+				group.OrderIDs = append(group.OrderIDs, report.OrderID)
+			}
+		}
+	}
+
+	payouts := make([]PayoutCharges, 0, len(groups))
+	for _, group := range groups {
+		sort.Strings(group.OrderIDs)
+		payouts = append(payouts, *group)
+	}
+	sort.Slice(payouts, func(i, j int) bool {
+		return payouts[i].PayoutID < payouts[j].PayoutID
+	})
+	return payouts
+}
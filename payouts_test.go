@@ -0,0 +1,34 @@
+package manapool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupChargesByPayout(t *testing.T) {
+	report := func(orderID string, charges ...OrderReportedCharge) OrderReport {
+		return OrderReport{OrderID: orderID, OrderReportedIssues: OrderReportedIssues{Charges: charges}}
+	}
+	reports := []OrderReport{
+		report("ord-2", OrderReportedCharge{SellerChargeCents: Int(300), PayoutID: String("p1")}),
+		report("ord-1",
+			OrderReportedCharge{SellerChargeCents: Int(150), PayoutID: String("p1")},
+			OrderReportedCharge{SellerChargeCents: Int(50), PayoutID: String("p1")},
+			OrderReportedCharge{SellerChargeCents: Int(99)},
+		),
+		report("ord-3", OrderReportedCharge{PayoutID: String("p2")}),
+	}
+
+	got := GroupChargesByPayout(reports)
+	want := []PayoutCharges{
+		{PayoutID: "", OrderIDs: []string{"ord-1"}, ChargeCount: 1, SellerChargeCents: 99},
+		{PayoutID: "p1", OrderIDs: []string{"ord-1", "ord-2"}, ChargeCount: 3, SellerChargeCents: 500},
+		{PayoutID: "p2", OrderIDs: []string{"ord-3"}, ChargeCount: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupChargesByPayout() = %+v, want %+v", got, want)
+	}
+	if got := GroupChargesByPayout(nil); len(got) != 0 {
+		t.Errorf("GroupChargesByPayout(nil) = %+v", got)
+	}
+}
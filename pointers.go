@@ -0,0 +1,75 @@
+package manapool
+
+import "time"
+
+// Bool returns a pointer to v, for optional *bool request fields.
+//
+// Example:
+//
+//	count, err := client.GetSellerOrdersCount(ctx, manapool.OrdersOptions{IsFulfilled: manapool.Bool(false)})
+func Bool(v bool) *bool { return &v }
+
+// Int returns a pointer to v, for optional *int request fields.
+func Int(v int) *int { return &v }
+
+// Int64 returns a pointer to v, for optional *int64 fields such as
+// Product.TCGPlayerSKU.
+func Int64(v int64) *int64 { return &v }
+
+// String returns a pointer to v, for optional *string request fields.
+func String(v string) *string { return &v }
+
+// Float64 returns a pointer to v, for optional *float64 request fields.
+func Float64(v float64) *float64 { return &v }
+
+// Time returns a Timestamp pointer for t, for optional *Timestamp request
+// fields such as OrderFulfillmentRequest.InTransitAt.
+func Time(t time.Time) *Timestamp { return &Timestamp{Time: t} }
+
+// BoolValue returns *p, or false if p is nil.
+func BoolValue(p *bool) bool {
+	if p == nil {
+		return false
+	}
+	return *p
+}
+
+// IntValue returns *p, or 0 if p is nil.
+func IntValue(p *int) int {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// Int64Value returns *p, or 0 if p is nil.
+func Int64Value(p *int64) int64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// StringValue returns *p, or "" if p is nil.
+func StringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+// Float64Value returns *p, or 0 if p is nil.
+func Float64Value(p *float64) float64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// TimeValue returns the time in p, or the zero time if p is nil.
+func TimeValue(p *Timestamp) time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return p.Time
+}
@@ -0,0 +1,37 @@
+package manapool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPointerHelpers(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if p := Bool(true); p == nil || !*p || !BoolValue(p) {
+		t.Errorf("Bool(true) = %v", p)
+	}
+	if p := Int(3); IntValue(p) != 3 {
+		t.Errorf("Int(3) = %v", p)
+	}
+	if p := Int64(1 << 40); Int64Value(p) != 1<<40 {
+		t.Errorf("Int64() = %v", p)
+	}
+	if p := String("shipped"); StringValue(p) != "shipped" {
+		t.Errorf("String() = %v", p)
+	}
+	if p := Float64(1.5); Float64Value(p) != 1.5 {
+		t.Errorf("Float64() = %v", p)
+	}
+	if p := Time(now); !TimeValue(p).Equal(now) {
+		t.Errorf("Time() = %v", p)
+	}
+
+	if BoolValue(nil) || IntValue(nil) != 0 || Int64Value(nil) != 0 || StringValue(nil) != "" || Float64Value(nil) != 0 || !TimeValue(nil).IsZero() {
+		t.Error("Value helpers should return the zero value for nil")
+	}
+
+	// Each call returns a distinct pointer.
+	if a, b := Int(1), Int(1); a == b {
+		t.Error("Int() returned the same pointer twice")
+	}
+}
@@ -0,0 +1,44 @@
+package manapool
+
+import "net/http"
+
+// Approximate encoded sizes of one price export listing, used to estimate
+// how many listings a response holds from its Content-Length. They sit a
+// little below the typical size so the estimate errs high: a few spare slots
+// are cheaper than regrowing and copying a 300k-element slice.
+const (
+	singlePriceListingBytes  = 400
+	variantPriceListingBytes = 330
+	sealedPriceListingBytes  = 230
+)
+
+// maxPresizeItems caps pre-allocation, so a bogus Content-Length or
+// pagination total cannot reserve unbounded memory up front.
+const maxPresizeItems = 1 << 20
+
+// presize returns an empty slice with room for n elements, or nil if n is
+// not positive. The decoder appends into the spare capacity instead of
+// growing the slice as it goes.
+func presize[T any](n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	if n > maxPresizeItems {
+		n = maxPresizeItems
+	}
+	return make([]T, 0, n)
+}
+
+// estimateItems estimates how many items of about itemBytes each resp holds.
+// It returns 0 when the length is unknown, as it is for chunked or
+// transparently decompressed responses.
+func estimateItems(resp *http.Response, itemBytes int) int {
+	if resp.ContentLength <= 0 || itemBytes <= 0 {
+		return 0
+	}
+	n := resp.ContentLength / int64(itemBytes)
+	if n > maxPresizeItems {
+		n = maxPresizeItems
+	}
+	return int(n)
+}
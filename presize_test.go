@@ -0,0 +1,165 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestPresize(t *testing.T) {
+	if got := presize[int](0); got != nil {
+		t.Errorf("presize(0) = %v, want nil", got)
+	}
+	if got := presize[int](10); len(got) != 0 || cap(got) != 10 {
+		t.Errorf("presize(10) len=%d cap=%d", len(got), cap(got))
+	}
+	if got := presize[byte](maxPresizeItems + 1); cap(got) != maxPresizeItems {
+		t.Errorf("presize cap = %d, want %d", cap(got), maxPresizeItems)
+	}
+}
+
+func TestEstimateItems(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int64
+		want   int
+	}{
+		{"unknown", -1, 0},
+		{"empty", 0, 0},
+		{"sized", 3300, 10},
+		{"capped", 1 << 40, maxPresizeItems},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{ContentLength: tt.length}
+			if got := estimateItems(resp, variantPriceListingBytes); got != tt.want {
+				t.Errorf("estimateItems() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetVariantPrices_Presized(t *testing.T) {
+	body := variantPricesExport(t, 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	prices, err := client.GetVariantPrices(context.Background())
+	if err != nil {
+		t.Fatalf("GetVariantPrices() error = %v", err)
+	}
+	if len(prices.Data) != 1000 {
+		t.Fatalf("len(Data) = %d, want 1000", len(prices.Data))
+	}
+	// A slice grown by append would have at least doubled past the count.
+	if c := cap(prices.Data); c > 2*len(prices.Data) {
+		t.Errorf("cap(Data) = %d, want close to %d", c, len(prices.Data))
+	}
+}
+
+func TestClient_CollectInventory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		page := InventoryResponse{Pagination: Pagination{Total: 700, Offset: offset}}
+		for i := offset; i < 700 && i < offset+inventoryPageSize; i++ {
+			page.Inventory = append(page.Inventory, InventoryItem{ID: fmt.Sprintf("inv-%d", i)})
+		}
+		page.Pagination.Returned = len(page.Inventory)
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	inventory, err := client.collectInventory(context.Background())
+	if err != nil {
+		t.Fatalf("collectInventory() error = %v", err)
+	}
+	if len(inventory) != 700 || cap(inventory) != 700 {
+		t.Errorf("len=%d cap=%d, want 700", len(inventory), cap(inventory))
+	}
+	if inventory[699].ID != "inv-699" {
+		t.Errorf("last item = %q", inventory[699].ID)
+	}
+}
+
+func variantPricesExport(tb testing.TB, n int) []byte {
+	tb.Helper()
+	tcgplayerID := int64(123456)
+	listings := make([]VariantPriceListing, n)
+	for i := range listings {
+		listings[i] = VariantPriceListing{
+			URL:                fmt.Sprintf("https://manapool.com/card/c21/%d/sol-ring", i),
+			ProductType:        ProductTypeSingle,
+			ProductID:          fmt.Sprintf("123e4567-e89b-12d3-a456-%012d", i),
+			SetCode:            "C21",
+			Number:             strconv.Itoa(i),
+			Name:               "Sol Ring",
+			ScryfallID:         "aee01e9c-0445-4228-a73a-3e5744844ed3",
+			TCGPlayerProductID: &tcgplayerID,
+			LanguageID:         "EN",
+			ConditionID:        String("NM"),
+			FinishID:           String("NF"),
+			LowPrice:           150,
+			AvailableQuantity:  3,
+		}
+	}
+	data, err := json.Marshal(VariantPricesList{Data: listings})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return data
+}
+
+// staticTransport answers every request with the same 200 response.
+type staticTransport struct {
+	body          []byte
+	contentLength int64
+}
+
+func (t staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode:    http.StatusOK,
+		Header:        http.Header{},
+		Body:          io.NopCloser(bytes.NewReader(t.body)),
+		ContentLength: t.contentLength,
+		Request:       req,
+	}, nil
+}
+
+// BenchmarkGetVariantPrices compares decoding a large variant export into a
+// slice sized from Content-Length with growing it from empty.
+func BenchmarkGetVariantPrices(b *testing.B) {
+	body := variantPricesExport(b, 50000)
+	for _, bm := range []struct {
+		name   string
+		length int64
+	}{
+		{"presized", int64(len(body))},
+		{"unsized", -1},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			client := NewClient("test-token", "test@example.com",
+				WithHTTPClient(&http.Client{Transport: staticTransport{body: body, contentLength: bm.length}}),
+				WithRateLimit(1e9, 1))
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.SetBytes(int64(len(body)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := client.GetVariantPrices(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+)
+
+// PriceCapOptions controls OptimizeCartWithPriceCaps.
+type PriceCapOptions struct {
+	// MaxPriceCents holds the highest acceptable price per copy of each item
+	// in the request cart, by position. Zero, or no entry for an item, means
+	// no cap.
+	MaxPriceCents []int
+
+	// Reoptimize retries instead of failing when a selected listing exceeds
+	// its item's cap. The first retry switches to the lowest_price model; if
+	// listings still exceed their caps, those items are dropped and the rest
+	// of the cart is optimized again.
+	Reoptimize bool
+}
+
+// PriceCapViolation is a selected listing priced above its cart item's cap.
+type PriceCapViolation struct {
+	// Index is the item's position in the original request cart.
+	Index         int
+	Name          string
+	InventoryID   string
+	PriceCents    int
+	MaxPriceCents int
+}
+
+// PriceCapOptimization is the result of OptimizeCartWithPriceCaps.
+type PriceCapOptimization struct {
+	// Cart is the optimized cart from the final attempt.
+	Cart *OptimizedCart
+
+	// Request is the request that produced Cart. Dropped items are removed.
+	Request OptimizerRequest
+
+	// Violations lists the listings in Cart that exceed their caps. It is
+	// only non-empty when an error is returned.
+	Violations []PriceCapViolation
+
+	// Dropped lists the violations that caused items to be dropped when
+	// re-optimizing.
+	Dropped []PriceCapViolation
+
+	// Attempts is the number of optimizer calls made.
+	Attempts int
+}
+
+// OptimizeCartWithPriceCaps runs OptimizeCart and checks every selected
+// listing against opts.MaxPriceCents of the cart items it fills. The API has
+// no per-item price limit, so caps are enforced on the result: by default a
+// cart with an overpriced listing is rejected, and with opts.Reoptimize the
+// cart is optimized again as described on PriceCapOptions.
+//
+// When the cart is rejected, the result is returned with Violations set
+// along with an error.
+//
+// Example:
+//
+//	result, err := client.OptimizeCartWithPriceCaps(ctx, req, manapool.PriceCapOptions{
+//	    MaxPriceCents: []int{500},
+//	    Reoptimize:    true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, v := range result.Dropped {
+//	    fmt.Printf("dropped %s: $%.2f over cap\n", v.Name, float64(v.PriceCents-v.MaxPriceCents)/100)
+//	}
+func (c *Client) OptimizeCartWithPriceCaps(ctx context.Context, req OptimizerRequest, opts PriceCapOptions) (*PriceCapOptimization, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	if len(opts.MaxPriceCents) > len(req.Cart) {
+		return nil, NewValidationError("max_price_cents", fmt.Sprintf("%d caps given for %d cart items", len(opts.MaxPriceCents), len(req.Cart)))
+	}
+	for i, maxPrice := range opts.MaxPriceCents {
+		if maxPrice < 0 {
+			return nil, NewValidationError(fmt.Sprintf("max_price_cents[%d]", i), "max price cannot be negative")
+		}
+	}
+
+	dropped := make([]bool, len(req.Cart))
+	// An empty model is the API's default, lowest_price, so name it here and
+	// the first retry is not spent on the same model.
+	model := req.Model
+	if model == "" {
+		model = OptimizerModelLowestPrice
+	}
+	result := &PriceCapOptimization{}
+
+	for {
+		current := req
+		current.Model = model
+		current.Cart = make([]OptimizerCartItem, 0, len(req.Cart))
+		positions := make([]int, 0, len(req.Cart))
+		for i, item := range req.Cart {
+			if !dropped[i] {
+				current.Cart = append(current.Cart, item)
+				positions = append(positions, i)
+			}
+		}
+		result.Request = current
+		result.Cart = nil
+
+		if len(current.Cart) == 0 {
+			return result, fmt.Errorf("failed to optimize cart within price caps: every item was dropped")
+		}
+
+		cart, err := c.OptimizeCart(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		result.Cart = cart
+		result.Attempts++
+
+		violations, err := c.priceCapViolations(ctx, current, positions, opts.MaxPriceCents, cart)
+		if err != nil {
+			return nil, err
+		}
+		if len(violations) == 0 {
+			return result, nil
+		}
+		if !opts.Reoptimize {
+			result.Violations = violations
+			return result, fmt.Errorf("failed to optimize cart within price caps: %d listings exceed their caps", len(violations))
+		}
+
+		if model != OptimizerModelLowestPrice {
+			model = OptimizerModelLowestPrice
+			continue
+		}
+		for _, v := range violations {
+			if !dropped[v.Index] {
+				dropped[v.Index] = true
+				result.Dropped = append(result.Dropped, v)
+			}
+		}
+	}
+}
+
+// priceCapViolations returns the listings in cart priced above the cap of a
+// capped item they fill. positions maps req.Cart indexes to original indexes,
+// which index caps.
+func (c *Client) priceCapViolations(ctx context.Context, req OptimizerRequest, positions []int, caps []int, cart *OptimizedCart) ([]PriceCapViolation, error) {
+	capOf := func(i int) int {
+		if positions[i] < len(caps) {
+			return caps[positions[i]]
+		}
+		return 0
+	}
+	capped := false
+	for i := range req.Cart {
+		if capOf(i) > 0 {
+			capped = true
+			break
+		}
+	}
+	if !capped {
+		return nil, nil
+	}
+
+	listings, selected, err := c.selectedListings(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []PriceCapViolation
+	for i, item := range req.Cart {
+		maxPrice := capOf(i)
+		if maxPrice == 0 {
+			continue
+		}
+		for _, listing := range listings {
+			if selected[listing.ID] == 0 || listing.PriceCents <= maxPrice || !cartItemMatches(item, listing) {
+				continue
+			}
+			violations = append(violations, PriceCapViolation{
+				Index:         positions[i],
+				Name:          item.Name,
+				InventoryID:   listing.ID,
+				PriceCents:    listing.PriceCents,
+				MaxPriceCents: maxPrice,
+			})
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,139 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_OptimizeCartWithPriceCaps(t *testing.T) {
+	cheapAvailable := true
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode optimizer request: %v", err)
+			}
+			models = append(models, req.Model)
+			items := []string{`{"inventory_id":"inv-sol","quantity_selected":1}`}
+			for _, item := range req.Cart {
+				if item.Name != "Lightning Bolt" {
+					continue
+				}
+				if req.Model == OptimizerModelLowestPrice && cheapAvailable {
+					items = append(items, `{"inventory_id":"inv-cheap","quantity_selected":1}`)
+				} else {
+					items = append(items, `{"inventory_id":"inv-exp","quantity_selected":1}`)
+				}
+			}
+			_, _ = w.Write([]byte(`{"cart":[` + strings.Join(items, ",") + `],"totals":{"subtotal_cents":1000,"shipping_cents":100,"total_cents":1100,"seller_count":1}}`))
+		case "/inventory/listings":
+			_, _ = w.Write([]byte(`{"inventory_items":[
+				{"id":"inv-sol","product_id":"p1","product":{"type":"mtg_single","id":"p1","single":{"name":"Sol Ring"}},"price_cents":150,"quantity":5},
+				{"id":"inv-exp","product_id":"p2","product":{"type":"mtg_single","id":"p2","single":{"name":"Lightning Bolt"}},"price_cents":900,"quantity":5},
+				{"id":"inv-cheap","product_id":"p3","product":{"type":"mtg_single","id":"p3","single":{"name":"Lightning Bolt"}},"price_cents":300,"quantity":5}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := OptimizerRequest{Model: OptimizerModelBalanced, Cart: []OptimizerCartItem{
+		{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 1},
+		{Type: "mtg_single", Name: "Lightning Bolt", QuantityRequested: 1},
+	}}
+	caps := []int{0, 500}
+
+	t.Run("rejects", func(t *testing.T) {
+		result, err := client.OptimizeCartWithPriceCaps(ctx, req, PriceCapOptions{MaxPriceCents: caps})
+		if err == nil {
+			t.Fatal("expected error for overpriced listing")
+		}
+		if result == nil || len(result.Violations) != 1 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+		v := result.Violations[0]
+		if v.Index != 1 || v.InventoryID != "inv-exp" || v.PriceCents != 900 || v.MaxPriceCents != 500 {
+			t.Errorf("unexpected violation: %+v", v)
+		}
+	})
+
+	t.Run("reoptimizes with lowest price", func(t *testing.T) {
+		models = nil
+		result, err := client.OptimizeCartWithPriceCaps(ctx, req, PriceCapOptions{MaxPriceCents: caps, Reoptimize: true})
+		if err != nil {
+			t.Fatalf("OptimizeCartWithPriceCaps() error = %v", err)
+		}
+		if result.Attempts != 2 || len(result.Dropped) != 0 || models[1] != OptimizerModelLowestPrice {
+			t.Errorf("unexpected result: attempts %d, dropped %+v, models %v", result.Attempts, result.Dropped, models)
+		}
+	})
+
+	t.Run("drops items still over cap", func(t *testing.T) {
+		cheapAvailable = false
+		defer func() { cheapAvailable = true }()
+		result, err := client.OptimizeCartWithPriceCaps(ctx, req, PriceCapOptions{MaxPriceCents: caps, Reoptimize: true})
+		if err != nil {
+			t.Fatalf("OptimizeCartWithPriceCaps() error = %v", err)
+		}
+		if result.Attempts != 3 || len(result.Dropped) != 1 || result.Dropped[0].Index != 1 {
+			t.Errorf("unexpected result: attempts %d, dropped %+v", result.Attempts, result.Dropped)
+		}
+		if len(result.Request.Cart) != 1 || result.Request.Cart[0].Name != "Sol Ring" {
+			t.Errorf("unexpected final request: %+v", result.Request.Cart)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.OptimizeCartWithPriceCaps(ctx, OptimizerRequest{}, PriceCapOptions{}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty cart, got %v", err)
+		}
+		if _, err := client.OptimizeCartWithPriceCaps(ctx, req, PriceCapOptions{MaxPriceCents: []int{0, -1}}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for negative cap, got %v", err)
+		}
+		if _, err := client.OptimizeCartWithPriceCaps(ctx, req, PriceCapOptions{MaxPriceCents: []int{0, 1, 2}}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for extra caps, got %v", err)
+		}
+	})
+}
+
+func TestClient_OptimizeCartWithPriceCaps_DefaultModel(t *testing.T) {
+	var models []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode optimizer request: %v", err)
+			}
+			models = append(models, req.Model)
+			_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv-exp","quantity_selected":1}],"totals":{"subtotal_cents":900,"total_cents":900,"seller_count":1}}`))
+		case "/inventory/listings":
+			_, _ = w.Write([]byte(`{"inventory_items":[{"id":"inv-exp","product_id":"p2","product":{"type":"mtg_single","id":"p2","single":{"name":"Lightning Bolt"}},"price_cents":900,"quantity":5}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	req := OptimizerRequest{Cart: []OptimizerCartItem{{Type: "mtg_single", Name: "Lightning Bolt", QuantityRequested: 1}}}
+	result, err := client.OptimizeCartWithPriceCaps(context.Background(), req, PriceCapOptions{MaxPriceCents: []int{500}, Reoptimize: true})
+	if err == nil {
+		t.Fatal("expected error once every item was dropped")
+	}
+	// The empty model is already lowest_price, so the item is dropped after one call.
+	if result.Attempts != 1 || len(models) != 1 || models[0] != OptimizerModelLowestPrice {
+		t.Errorf("attempts = %d, models = %v, want one lowest_price call", result.Attempts, models)
+	}
+}
@@ -3,49 +3,76 @@ package manapool
 import (
 	"context"
 	"fmt"
+
+	"github.com/repricah/manapool/internal/singleflight"
 )
 
 // GetSinglesPrices retrieves prices for all in-stock singles.
+//
+// The export is large, so its Data slice is allocated up front from the
+// response's Content-Length rather than grown while decoding. Concurrent
+// calls share one download and receive the same list, which must not be
+// modified.
 func (c *Client) GetSinglesPrices(ctx context.Context) (*SinglesPricesList, error) {
-	resp, err := c.doRequest(ctx, "GET", "/prices/singles", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get singles prices: %w", err)
-	}
+	return sharedCall(ctx, &c.priceCalls, "/prices/singles", func(ctx context.Context) (*SinglesPricesList, error) {
+		resp, err := c.doRequest(ctx, "GET", "/prices/singles", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get singles prices: %w", err)
+		}
 
-	var prices SinglesPricesList
-	if err := c.decodeResponse(resp, &prices); err != nil {
-		return nil, fmt.Errorf("failed to decode singles prices: %w", err)
-	}
+		prices := SinglesPricesList{Data: presize[SinglePriceListing](estimateItems(resp, singlePriceListingBytes))}
+		if err := c.decodeResponse(resp, &prices); err != nil {
+			return nil, fmt.Errorf("failed to decode singles prices: %w", err)
+		}
 
-	return &prices, nil
+		return &prices, nil
+	})
 }
 
-// GetVariantPrices retrieves prices for all in-stock variants.
+// GetVariantPrices retrieves prices for all in-stock variants. Like
+// GetSinglesPrices, concurrent calls share one download.
 func (c *Client) GetVariantPrices(ctx context.Context) (*VariantPricesList, error) {
-	resp, err := c.doRequest(ctx, "GET", "/prices/variants", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get variant prices: %w", err)
-	}
+	return sharedCall(ctx, &c.priceCalls, "/prices/variants", func(ctx context.Context) (*VariantPricesList, error) {
+		resp, err := c.doRequest(ctx, "GET", "/prices/variants", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get variant prices: %w", err)
+		}
 
-	var prices VariantPricesList
-	if err := c.decodeResponse(resp, &prices); err != nil {
-		return nil, fmt.Errorf("failed to decode variant prices: %w", err)
-	}
+		prices := VariantPricesList{Data: presize[VariantPriceListing](estimateItems(resp, variantPriceListingBytes))}
+		if err := c.decodeResponse(resp, &prices); err != nil {
+			return nil, fmt.Errorf("failed to decode variant prices: %w", err)
+		}
 
-	return &prices, nil
+		return &prices, nil
+	})
 }
 
-// GetSealedPrices retrieves prices for all in-stock sealed products.
+// GetSealedPrices retrieves prices for all in-stock sealed products. Like
+// GetSinglesPrices, concurrent calls share one download.
 func (c *Client) GetSealedPrices(ctx context.Context) (*SealedPricesList, error) {
-	resp, err := c.doRequest(ctx, "GET", "/prices/sealed", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get sealed prices: %w", err)
-	}
+	return sharedCall(ctx, &c.priceCalls, "/prices/sealed", func(ctx context.Context) (*SealedPricesList, error) {
+		resp, err := c.doRequest(ctx, "GET", "/prices/sealed", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sealed prices: %w", err)
+		}
 
-	var prices SealedPricesList
-	if err := c.decodeResponse(resp, &prices); err != nil {
-		return nil, fmt.Errorf("failed to decode sealed prices: %w", err)
-	}
+		prices := SealedPricesList{Data: presize[SealedPriceListing](estimateItems(resp, sealedPriceListingBytes))}
+		if err := c.decodeResponse(resp, &prices); err != nil {
+			return nil, fmt.Errorf("failed to decode sealed prices: %w", err)
+		}
 
-	return &prices, nil
+		return &prices, nil
+	})
+}
+
+// sharedCall runs fetch through group under key, so concurrent callers of
+// the same lookup share one API call and its result.
+func sharedCall[T any](ctx context.Context, group *singleflight.Group[any], key string, fetch func(ctx context.Context) (*T, error)) (*T, error) {
+	v, _, err := group.Do(ctx, key, func(ctx context.Context) (any, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*T), nil
 }
@@ -0,0 +1,25 @@
+package manapool
+
+// ProductType identifies the kind of product an inventory item, price
+// listing or order item refers to.
+type ProductType string
+
+// Product types accepted and returned by the API.
+const (
+	ProductTypeSingle ProductType = "mtg_single"
+	ProductTypeSealed ProductType = "mtg_sealed"
+)
+
+// IsValid reports whether t is a product type known to the API.
+func (t ProductType) IsValid() bool {
+	switch t {
+	case ProductTypeSingle, ProductTypeSealed:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (t ProductType) String() string {
+	return string(t)
+}
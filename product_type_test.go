@@ -0,0 +1,52 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestProductType(t *testing.T) {
+	for _, pt := range []ProductType{ProductTypeSingle, ProductTypeSealed} {
+		if !pt.IsValid() {
+			t.Errorf("%q should be valid", pt)
+		}
+	}
+	if ProductType("mtg_token").IsValid() || ProductType("").IsValid() {
+		t.Error("unknown product types should be invalid")
+	}
+	if got := fmt.Sprint(ProductTypeSealed); got != "mtg_sealed" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := fmt.Sprint(ShippingMethodGroundAdvantage); got != "ground_advantage" {
+		t.Errorf("ShippingMethod String() = %q", got)
+	}
+
+	var order OrderSummary
+	if err := json.Unmarshal([]byte(`{"shipping_method":"first_class"}`), &order); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if order.ShippingMethod != ShippingMethodFirstClass || !order.ShippingMethod.IsValid() {
+		t.Errorf("shipping method = %q", order.ShippingMethod)
+	}
+}
+
+func TestProductType_Validation(t *testing.T) {
+	client := NewClient("test-token", "test@example.com")
+	ctx := context.Background()
+
+	var valErr *ValidationError
+	_, err := client.GetSellerInventoryByProduct(ctx, "single", "p1")
+	if !errors.As(err, &valErr) || valErr.Field != "product_type" {
+		t.Errorf("GetSellerInventoryByProduct() error = %v", err)
+	}
+	_, err = client.CreateInventoryBulkByProduct(ctx, []InventoryBulkItemByProduct{
+		{ProductType: ProductTypeSingle, ProductID: "p1"},
+		{ProductType: "sealed", ProductID: "p2"},
+	})
+	if !errors.As(err, &valErr) || valErr.Field != "items[1].product_type" {
+		t.Errorf("CreateInventoryBulkByProduct() error = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package manapool
+
+import (
+	"strconv"
+	"sync"
+)
+
+// maxPooledQuerySize is the largest query buffer returned to the pool, so one
+// long list of IDs does not pin its memory.
+const maxPooledQuerySize = 4 << 10
+
+var queryPool = sync.Pool{
+	New: func() interface{} { return &query{buf: make([]byte, 0, 128)} },
+}
+
+// query builds an encoded query string in a pooled buffer. List endpoints
+// polled every few seconds use it instead of url.Values, which allocates a
+// map, a slice per key and a sorted key list on every call. Parameters are
+// encoded in the order they are added.
+type query struct {
+	buf []byte
+}
+
+// newQuery returns an empty query from the pool.
+func newQuery() *query {
+	return queryPool.Get().(*query)
+}
+
+// add appends key=value, escaping value. Keys are written as given.
+func (q *query) add(key, value string) {
+	q.key(key)
+	q.buf = appendQueryEscape(q.buf, value)
+}
+
+// addInt appends key=v.
+func (q *query) addInt(key string, v int) {
+	q.key(key)
+	q.buf = strconv.AppendInt(q.buf, int64(v), 10)
+}
+
+// addBool appends key=v.
+func (q *query) addBool(key string, v bool) {
+	q.key(key)
+	q.buf = strconv.AppendBool(q.buf, v)
+}
+
+func (q *query) key(key string) {
+	if len(q.buf) > 0 {
+		q.buf = append(q.buf, '&')
+	}
+	q.buf = append(q.buf, key...)
+	q.buf = append(q.buf, '=')
+}
+
+// encode returns the encoded query and puts q back in the pool; q must not
+// be used afterwards. A nil query encodes as "".
+func (q *query) encode() string {
+	if q == nil {
+		return ""
+	}
+	encoded := string(q.buf)
+	if cap(q.buf) <= maxPooledQuerySize {
+		q.buf = q.buf[:0]
+		queryPool.Put(q)
+	}
+	return encoded
+}
+
+const upperHex = "0123456789ABCDEF"
+
+// appendQueryEscape appends s to dst escaped as url.QueryEscape would,
+// without allocating.
+func appendQueryEscape(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			dst = append(dst, c)
+		case c == ' ':
+			dst = append(dst, '+')
+		default:
+			dst = append(dst, '%', upperHex[c>>4], upperHex[c&15])
+		}
+	}
+	return dst
+}
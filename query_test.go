@@ -0,0 +1,102 @@
+package manapool
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	q := newQuery()
+	q.add("label", "A&B c")
+	q.addBool("is_fulfilled", true)
+	q.addInt("limit", 100)
+	q.addInt("offset", -1)
+	if got, want := q.encode(), "label=A%26B+c&is_fulfilled=true&limit=100&offset=-1"; got != want {
+		t.Errorf("encode() = %q, want %q", got, want)
+	}
+
+	if got := newQuery().encode(); got != "" {
+		t.Errorf("empty query encode() = %q", got)
+	}
+	var nilQuery *query
+	if got := nilQuery.encode(); got != "" {
+		t.Errorf("nil query encode() = %q", got)
+	}
+}
+
+func TestAppendQueryEscape(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"plain-Value_1.2~",
+		"a b+c&d=e?f#g/h",
+		"100%",
+		"Jötun Grunt",
+		"2024-04-01T05:44:13Z",
+		"\x00\x7f\xff",
+	} {
+		if got, want := string(appendQueryEscape(nil, s)), url.QueryEscape(s); got != want {
+			t.Errorf("appendQueryEscape(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestBuildOrdersParams(t *testing.T) {
+	since := Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	opts := OrdersOptions{Since: &since, IsUnfulfilled: Bool(true), Label: "A 1", Limit: 50, Offset: 100}
+
+	got, err := url.ParseQuery(buildOrdersParams(opts, "").encode())
+	if err != nil {
+		t.Fatalf("ParseQuery() error = %v", err)
+	}
+	want := url.Values{
+		"since":          {"2025-01-02T03:04:05Z"},
+		"is_unfulfilled": {"true"},
+		"label":          {"A 1"},
+		"limit":          {"50"},
+		"offset":         {"100"},
+	}
+	if got.Encode() != want.Encode() {
+		t.Errorf("params = %v, want %v", got, want)
+	}
+}
+
+func TestQuery_Allocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		q := newQuery()
+		q.add("label", "A1")
+		q.addBool("is_unfulfilled", true)
+		q.addInt("limit", 500)
+		q.addInt("offset", 1000)
+		_ = q.encode()
+	})
+	// Only the returned string should be allocated.
+	if allocs > 1 {
+		t.Errorf("allocations = %v, want at most 1", allocs)
+	}
+}
+
+// BenchmarkOrdersQuery compares the pooled builder with url.Values for the
+// query of an order poll.
+func BenchmarkOrdersQuery(b *testing.B) {
+	opts := OrdersOptions{IsUnfulfilled: Bool(true), Label: "A1", Limit: 500, Offset: 1000}
+
+	b.Run("query", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = buildOrdersParams(opts, "").encode()
+		}
+	})
+	b.Run("url.Values", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			params := url.Values{}
+			params.Add("is_unfulfilled", strconv.FormatBool(*opts.IsUnfulfilled))
+			params.Add("label", opts.Label)
+			params.Add("limit", strconv.Itoa(opts.Limit))
+			params.Add("offset", strconv.Itoa(opts.Offset))
+			_ = params.Encode()
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// InventoryKey identifies an inventory listing by product.
+type InventoryKey struct {
+	ProductType ProductType
+	ProductID   string
+}
+
+// DiscrepancyReason describes why an inventory discrepancy was flagged.
+type DiscrepancyReason string
+
+const (
+	// DiscrepancyMissingListing means an order references a product that is not in inventory.
+	DiscrepancyMissingListing DiscrepancyReason = "missing_listing"
+
+	// DiscrepancyOversold means more units were sold than were in stock.
+	DiscrepancyOversold DiscrepancyReason = "oversold"
+
+	// DiscrepancyQuantityMismatch means the live quantity differs from the expected quantity.
+	DiscrepancyQuantityMismatch DiscrepancyReason = "quantity_mismatch"
+)
+
+// InventoryDiscrepancy describes a difference between expected and actual stock.
+type InventoryDiscrepancy struct {
+	Key      InventoryKey
+	OrderID  string
+	Expected int
+	Actual   int
+	Reason   DiscrepancyReason
+}
+
+// InventoryMirror is a local copy of inventory quantities that can be
+// decremented as orders arrive. It is safe for concurrent use.
+type InventoryMirror struct {
+	mu         sync.Mutex
+	quantities map[InventoryKey]int
+	applied    map[string]bool
+}
+
+// NewInventoryMirror creates a mirror seeded from an inventory snapshot.
+func NewInventoryMirror(items []InventoryItem) *InventoryMirror {
+	m := &InventoryMirror{
+		quantities: make(map[InventoryKey]int, len(items)),
+		applied:    make(map[string]bool),
+	}
+	for _, item := range items {
+		m.quantities[InventoryKey{ProductType: item.ProductType, ProductID: item.ProductID}] = item.Quantity
+	}
+	return m
+}
+
+// Quantity returns the mirrored quantity for key.
+func (m *InventoryMirror) Quantity(key InventoryKey) (int, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	qty, ok := m.quantities[key]
+	return qty, ok
+}
+
+// Set overrides the mirrored quantity for key.
+func (m *InventoryMirror) Set(key InventoryKey, quantity int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quantities[key] = quantity
+}
+
+// ApplyOrder decrements the mirror for each item in the order and returns
+// any missing listings or oversells. Orders are applied at most once; a
+// repeated order ID is ignored.
+func (m *InventoryMirror) ApplyOrder(order OrderDetails) []InventoryDiscrepancy {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if order.ID != "" {
+		if m.applied[order.ID] {
+			return nil
+		}
+		m.applied[order.ID] = true
+	}
+
+	var discrepancies []InventoryDiscrepancy
+	for _, item := range order.Items {
+		key := InventoryKey{ProductType: item.ProductType, ProductID: item.ProductID}
+		qty, ok := m.quantities[key]
+		if !ok {
+			discrepancies = append(discrepancies, InventoryDiscrepancy{
+				Key:      key,
+				OrderID:  order.ID,
+				Expected: item.Quantity,
+				Reason:   DiscrepancyMissingListing,
+			})
+			continue
+		}
+		remaining := qty - item.Quantity
+		if remaining < 0 {
+			discrepancies = append(discrepancies, InventoryDiscrepancy{
+				Key:      key,
+				OrderID:  order.ID,
+				Expected: item.Quantity,
+				Actual:   qty,
+				Reason:   DiscrepancyOversold,
+			})
+			remaining = 0
+		}
+		m.quantities[key] = remaining
+	}
+
+	return discrepancies
+}
+
+// Compare returns the listings whose live quantity differs from the mirror.
+// Only keys present in the mirror are checked; a mirrored key missing from
+// live is treated as a live quantity of zero.
+func (m *InventoryMirror) Compare(live []InventoryItem) []InventoryDiscrepancy {
+	actual := make(map[InventoryKey]int, len(live))
+	for _, item := range live {
+		actual[InventoryKey{ProductType: item.ProductType, ProductID: item.ProductID}] = item.Quantity
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var discrepancies []InventoryDiscrepancy
+	for key, expected := range m.quantities {
+		if got := actual[key]; got != expected {
+			discrepancies = append(discrepancies, InventoryDiscrepancy{
+				Key:      key,
+				Expected: expected,
+				Actual:   got,
+				Reason:   DiscrepancyQuantityMismatch,
+			})
+		}
+	}
+	sortDiscrepancies(discrepancies)
+
+	return discrepancies
+}
+
+// ReconcileOrders applies orders to the mirror and then verifies each touched
+// listing against the live seller inventory. It returns oversells and missing
+// listings found while applying orders, followed by quantity mismatches where
+// the live inventory was not decremented as expected.
+//
+// Example:
+//
+//	mirror := manapool.NewInventoryMirror(snapshot)
+//	discrepancies, err := client.ReconcileOrders(ctx, mirror, recentOrders)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, d := range discrepancies {
+//	    log.Printf("%s %s/%s: expected %d, got %d", d.Reason, d.Key.ProductType, d.Key.ProductID, d.Expected, d.Actual)
+//	}
+func (c *Client) ReconcileOrders(ctx context.Context, mirror *InventoryMirror, orders []OrderDetails) ([]InventoryDiscrepancy, error) {
+	if mirror == nil {
+		return nil, NewValidationError("mirror", "mirror cannot be nil")
+	}
+
+	var discrepancies []InventoryDiscrepancy
+	touched := make(map[InventoryKey]bool)
+	for _, order := range orders {
+		discrepancies = append(discrepancies, mirror.ApplyOrder(order)...)
+		for _, item := range order.Items {
+			touched[InventoryKey{ProductType: item.ProductType, ProductID: item.ProductID}] = true
+		}
+	}
+
+	var mismatches []InventoryDiscrepancy
+	for key := range touched {
+		expected, ok := mirror.Quantity(key)
+		if !ok {
+			continue
+		}
+
+		actual := 0
+		listing, err := c.GetSellerInventoryByProduct(ctx, key.ProductType, key.ProductID)
+		if err != nil {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+				return nil, fmt.Errorf("failed to reconcile %s/%s: %w", key.ProductType, key.ProductID, err)
+			}
+		} else {
+			actual = listing.Inventory.Quantity
+		}
+
+		if actual != expected {
+			mismatches = append(mismatches, InventoryDiscrepancy{
+				Key:      key,
+				Expected: expected,
+				Actual:   actual,
+				Reason:   DiscrepancyQuantityMismatch,
+			})
+		}
+	}
+	sortDiscrepancies(mismatches)
+
+	return append(discrepancies, mismatches...), nil
+}
+
+func sortDiscrepancies(discrepancies []InventoryDiscrepancy) {
+	sort.Slice(discrepancies, func(i, j int) bool {
+		a, b := discrepancies[i].Key, discrepancies[j].Key
+		if a.ProductType != b.ProductType {
+			return a.ProductType < b.ProductType
+		}
+		return a.ProductID < b.ProductID
+	})
+}
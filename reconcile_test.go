@@ -0,0 +1,143 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInventoryMirror_ApplyOrder(t *testing.T) {
+	mirror := NewInventoryMirror([]InventoryItem{
+		{ProductType: "mtg_single", ProductID: "a", Quantity: 3},
+		{ProductType: "mtg_single", ProductID: "b", Quantity: 1},
+	})
+
+	order := OrderDetails{
+		OrderSummary: OrderSummary{ID: "order-1"},
+		Items: []OrderItem{
+			{ProductType: "mtg_single", ProductID: "a", Quantity: 2},
+			{ProductType: "mtg_single", ProductID: "b", Quantity: 2},
+			{ProductType: "mtg_single", ProductID: "c", Quantity: 1},
+		},
+	}
+
+	discrepancies := mirror.ApplyOrder(order)
+	if len(discrepancies) != 2 {
+		t.Fatalf("discrepancies = %d, want 2: %+v", len(discrepancies), discrepancies)
+	}
+	if discrepancies[0].Reason != DiscrepancyOversold || discrepancies[0].Actual != 1 {
+		t.Fatalf("unexpected oversell: %+v", discrepancies[0])
+	}
+	if discrepancies[1].Reason != DiscrepancyMissingListing {
+		t.Fatalf("unexpected missing listing: %+v", discrepancies[1])
+	}
+
+	if qty, _ := mirror.Quantity(InventoryKey{"mtg_single", "a"}); qty != 1 {
+		t.Fatalf("a quantity = %d, want 1", qty)
+	}
+	if qty, _ := mirror.Quantity(InventoryKey{"mtg_single", "b"}); qty != 0 {
+		t.Fatalf("b quantity = %d, want 0", qty)
+	}
+
+	if again := mirror.ApplyOrder(order); again != nil {
+		t.Fatalf("expected repeated order to be ignored, got %+v", again)
+	}
+	if qty, _ := mirror.Quantity(InventoryKey{"mtg_single", "a"}); qty != 1 {
+		t.Fatalf("a quantity after repeat = %d, want 1", qty)
+	}
+}
+
+func TestInventoryMirror_Compare(t *testing.T) {
+	mirror := NewInventoryMirror([]InventoryItem{
+		{ProductType: "mtg_single", ProductID: "a", Quantity: 3},
+		{ProductType: "mtg_single", ProductID: "b", Quantity: 1},
+	})
+	mirror.Set(InventoryKey{"mtg_single", "c"}, 2)
+
+	discrepancies := mirror.Compare([]InventoryItem{
+		{ProductType: "mtg_single", ProductID: "a", Quantity: 3},
+		{ProductType: "mtg_single", ProductID: "b", Quantity: 4},
+	})
+	if len(discrepancies) != 2 {
+		t.Fatalf("discrepancies = %d, want 2: %+v", len(discrepancies), discrepancies)
+	}
+	if discrepancies[0].Key.ProductID != "b" || discrepancies[0].Expected != 1 || discrepancies[0].Actual != 4 {
+		t.Fatalf("unexpected b discrepancy: %+v", discrepancies[0])
+	}
+	if discrepancies[1].Key.ProductID != "c" || discrepancies[1].Actual != 0 {
+		t.Fatalf("unexpected c discrepancy: %+v", discrepancies[1])
+	}
+}
+
+func TestClient_ReconcileOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/seller/inventory/product/mtg_single/a":
+			_, _ = w.Write([]byte(`{"inventory":{"id":"inv-a","product_type":"mtg_single","product_id":"a","quantity":1}}`))
+		case "/seller/inventory/product/mtg_single/b":
+			_, _ = w.Write([]byte(`{"inventory":{"id":"inv-b","product_type":"mtg_single","product_id":"b","quantity":5}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	mirror := NewInventoryMirror([]InventoryItem{
+		{ProductType: "mtg_single", ProductID: "a", Quantity: 3},
+		{ProductType: "mtg_single", ProductID: "b", Quantity: 6},
+		{ProductType: "mtg_single", ProductID: "c", Quantity: 2},
+	})
+	orders := []OrderDetails{{
+		OrderSummary: OrderSummary{ID: "order-1"},
+		Items: []OrderItem{
+			{ProductType: "mtg_single", ProductID: "a", Quantity: 2},
+			{ProductType: "mtg_single", ProductID: "b", Quantity: 3},
+			{ProductType: "mtg_single", ProductID: "c", Quantity: 1},
+		},
+	}}
+
+	discrepancies, err := client.ReconcileOrders(ctx, mirror, orders)
+	if err != nil {
+		t.Fatalf("ReconcileOrders error: %v", err)
+	}
+	if len(discrepancies) != 2 {
+		t.Fatalf("discrepancies = %d, want 2: %+v", len(discrepancies), discrepancies)
+	}
+	if discrepancies[0].Key.ProductID != "b" || discrepancies[0].Expected != 3 || discrepancies[0].Actual != 5 {
+		t.Fatalf("unexpected b discrepancy: %+v", discrepancies[0])
+	}
+	if discrepancies[1].Key.ProductID != "c" || discrepancies[1].Expected != 1 || discrepancies[1].Actual != 0 {
+		t.Fatalf("unexpected c discrepancy: %+v", discrepancies[1])
+	}
+
+	t.Run("NilMirror", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.ReconcileOrders(ctx, nil, orders); !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+	})
+}
+
+func TestClient_ReconcileOrders_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"forbidden"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	mirror := NewInventoryMirror([]InventoryItem{{ProductType: "mtg_single", ProductID: "a", Quantity: 3}})
+	orders := []OrderDetails{{Items: []OrderItem{{ProductType: "mtg_single", ProductID: "a", Quantity: 1}}}}
+
+	_, err := client.ReconcileOrders(context.Background(), mirror, orders)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsForbidden() {
+		t.Fatalf("expected forbidden APIError, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"github.com/repricah/manapool/internal/parallel"
+)
+
+// RepriceRule selects how RepriceOptions computes a listing's new price.
+type RepriceRule string
+
+// Repricing rules.
+const (
+	// RepriceMatchLow prices a listing at the market low price minus
+	// RepriceOptions.UndercutCents.
+	RepriceMatchLow RepriceRule = "match-low"
+)
+
+// IsValid reports whether r is a known repricing rule.
+func (r RepriceRule) IsValid() bool {
+	return r == RepriceMatchLow
+}
+
+// RepriceOptions controls PlanReprice.
+type RepriceOptions struct {
+	// Rule is the pricing rule (default: RepriceMatchLow).
+	Rule RepriceRule
+
+	// UndercutCents is subtracted from the market low price.
+	UndercutCents int
+
+	// FloorCents is the lowest price a listing is set to.
+	FloorCents int
+
+	// CeilingCents, if positive, is the highest price a listing is set to.
+	CeilingCents int
+}
+
+// Validate checks the options and fills in the default rule.
+func (o *RepriceOptions) Validate() error {
+	if o.Rule == "" {
+		o.Rule = RepriceMatchLow
+	}
+	if !o.Rule.IsValid() {
+		return NewValidationError("rule", fmt.Sprintf("unknown repricing rule %q", o.Rule))
+	}
+	if o.UndercutCents < 0 {
+		return NewValidationError("undercut_cents", "undercut cannot be negative")
+	}
+	if o.FloorCents < 0 {
+		return NewValidationError("floor_cents", "floor cannot be negative")
+	}
+	if o.CeilingCents < 0 || (o.CeilingCents > 0 && o.CeilingCents < o.FloorCents) {
+		return NewValidationError("ceiling_cents", "ceiling must be zero or at least the floor")
+	}
+	return nil
+}
+
+// PriceChange is a planned price update for one listing.
+type PriceChange struct {
+	Item InventoryItem
+
+	// MarketLowCents is the lowest price on the market for the listing's product.
+	MarketLowCents int
+
+	// NewPriceCents is the price the listing will be set to.
+	NewPriceCents int
+}
+
+// DeltaCents returns the change from the current price.
+func (c PriceChange) DeltaCents() int {
+	return c.NewPriceCents - c.Item.PriceCents
+}
+
+// PlanReprice computes price changes for inventory from variant market
+// prices, matched by product type and ID. Listings that are out of stock,
+// have no market price, or already have the computed price are left out.
+//
+// The market low includes the seller's own listings, so a listing already
+// priced at or below the market low is left alone rather than undercut
+// again, which would otherwise lower its price on every run. Changes are
+// sorted by product name.
+func PlanReprice(inventory []InventoryItem, market []VariantPriceListing, opts RepriceOptions) ([]PriceChange, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	type productKey struct {
+		productType ProductType
+		productID   string
+	}
+	lows := make(map[productKey]int, len(market))
+	for _, listing := range market {
+		lows[productKey{listing.ProductType, listing.ProductID}] = listing.LowPrice
+	}
+
+	var changes []PriceChange
+	for _, item := range inventory {
+		low, ok := lows[productKey{item.ProductType, item.ProductID}]
+		if !ok || low <= 0 || item.Quantity <= 0 || item.PriceCents <= low {
+			continue
+		}
+		price := low - opts.UndercutCents
+		if price < opts.FloorCents {
+			price = opts.FloorCents
+		}
+		if opts.CeilingCents > 0 && price > opts.CeilingCents {
+			price = opts.CeilingCents
+		}
+		if price <= 0 || price == item.PriceCents {
+			continue
+		}
+		changes = append(changes, PriceChange{Item: item, MarketLowCents: low, NewPriceCents: price})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return productName(changes[i].Item.Product) < productName(changes[j].Item.Product)
+	})
+	return changes, nil
+}
+
+// PlanInventoryReprice fetches the seller's inventory and variant market
+// prices and returns the changes PlanReprice would make. Nothing is updated.
+//
+// Example:
+//
+//	changes, err := client.PlanInventoryReprice(ctx, manapool.RepriceOptions{UndercutCents: 5, FloorCents: 25})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if _, err := client.ApplyReprice(ctx, changes); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) PlanInventoryReprice(ctx context.Context, opts RepriceOptions) ([]PriceChange, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	inventory, err := c.collectInventory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan reprice: %w", err)
+	}
+	market, err := c.GetVariantPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan reprice: %w", err)
+	}
+	return PlanReprice(inventory, market.Data, opts)
+}
+
+// ApplyReprice updates each listing's price, keeping its quantity. The
+// listing is fetched right before its update and its current quantity sent,
+// so sales made after the plan was built are not undone. Updates run
+// concurrently, up to the client's concurrency (see WithConcurrency). After
+// the first failure no new updates are started; it returns the number of
+// listings updated and the error for the first listing that failed.
+func (c *Client) ApplyReprice(ctx context.Context, changes []PriceChange) (int, error) {
+	var updated atomic.Int64
+	err := parallel.Run(ctx, len(changes), c.parallelOptions(true), func(ctx context.Context, i int) error {
+		change := changes[i]
+		current, err := c.GetSellerInventoryByProduct(ctx, change.Item.ProductType, change.Item.ProductID)
+		if err != nil {
+			return err
+		}
+		update := InventoryUpdateRequest{PriceCents: change.NewPriceCents, Quantity: current.Inventory.Quantity}
+		if _, err := c.UpdateSellerInventoryByProduct(ctx, change.Item.ProductType, change.Item.ProductID, update); err != nil {
+			return err
+		}
+		updated.Add(1)
+		return nil
+	})
+	if errs, ok := parallel.As(err); ok {
+		failure := errs.Failures[0]
+		return int(updated.Load()), fmt.Errorf("failed to reprice %s: %w", productName(changes[failure.Index].Item.Product), failure.Err)
+	}
+	return int(updated.Load()), err
+}
@@ -0,0 +1,136 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPlanReprice(t *testing.T) {
+	item := func(id, name string, price, quantity int) InventoryItem {
+		return InventoryItem{
+			ProductType: "mtg_single", ProductID: id, PriceCents: price, Quantity: quantity,
+			Product: Product{Single: &Single{Name: name}},
+		}
+	}
+	inventory := []InventoryItem{
+		item("p1", "Sol Ring", 200, 2),     // undercut to 145
+		item("p2", "Brainstorm", 30, 1),    // clamped to the floor
+		item("p3", "Counterspell", 100, 1), // already the lowest
+		item("p4", "Ponder", 100, 0),       // out of stock
+		item("p5", "Opt", 100, 1),          // no market price
+		item("p6", "Arcane Signet", 25, 4), // already at the floor
+	}
+	market := []VariantPriceListing{
+		{ProductType: "mtg_single", ProductID: "p1", LowPrice: 150},
+		{ProductType: "mtg_single", ProductID: "p2", LowPrice: 20},
+		{ProductType: "mtg_single", ProductID: "p3", LowPrice: 100},
+		{ProductType: "mtg_single", ProductID: "p4", LowPrice: 50},
+		{ProductType: "mtg_single", ProductID: "p6", LowPrice: 20},
+	}
+
+	changes, err := PlanReprice(inventory, market, RepriceOptions{UndercutCents: 5, FloorCents: 25})
+	if err != nil {
+		t.Fatalf("PlanReprice() error = %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("changes = %+v, want 2", changes)
+	}
+	if changes[0].Item.ProductID != "p2" || changes[0].NewPriceCents != 25 || changes[0].DeltaCents() != -5 {
+		t.Errorf("changes[0] = %+v", changes[0])
+	}
+	if changes[1].Item.ProductID != "p1" || changes[1].NewPriceCents != 145 || changes[1].MarketLowCents != 150 {
+		t.Errorf("changes[1] = %+v", changes[1])
+	}
+
+	for _, opts := range []RepriceOptions{
+		{Rule: "match-high"},
+		{UndercutCents: -1},
+		{FloorCents: 100, CeilingCents: 50},
+	} {
+		var valErr *ValidationError
+		if _, err := PlanReprice(inventory, market, opts); !errors.As(err, &valErr) {
+			t.Errorf("PlanReprice(%+v) error = %v, want validation error", opts, err)
+		}
+	}
+}
+
+func TestClient_Reprice(t *testing.T) {
+	var updates []string
+	quantity := 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/seller/inventory":
+			_, _ = w.Write([]byte(`{"inventory":[{"id":"i1","product_type":"mtg_single","product_id":"p1","price_cents":200,"quantity":3,"product":{"single":{"name":"Sol Ring"}}}],"pagination":{"total":1,"returned":1}}`))
+		case r.URL.Path == "/prices/variants":
+			_, _ = w.Write([]byte(`{"data":[{"product_type":"mtg_single","product_id":"p1","low_price":150}]}`))
+		case strings.HasPrefix(r.URL.Path, "/seller/inventory/product/") && r.Method == http.MethodGet:
+			fmt.Fprintf(w, `{"inventory":{"id":"i1","product_type":"mtg_single","product_id":"p1","price_cents":200,"quantity":%d}}`, quantity)
+		case strings.HasPrefix(r.URL.Path, "/seller/inventory/product/") && r.Method == http.MethodPut:
+			var update InventoryUpdateRequest
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("decode update: %v", err)
+			}
+			if update.PriceCents != 149 || update.Quantity != quantity {
+				t.Errorf("update = %+v", update)
+			}
+			updates = append(updates, r.URL.Path)
+			_, _ = w.Write([]byte(`{"inventory":{"id":"i1"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	changes, err := client.PlanInventoryReprice(ctx, RepriceOptions{UndercutCents: 1})
+	if err != nil {
+		t.Fatalf("PlanInventoryReprice() error = %v", err)
+	}
+	if len(changes) != 1 || len(updates) != 0 {
+		t.Fatalf("changes = %+v, updates = %v", changes, updates)
+	}
+
+	// Two copies sell between planning and applying.
+	quantity = 1
+	n, err := client.ApplyReprice(ctx, changes)
+	if err != nil || n != 1 {
+		t.Fatalf("ApplyReprice() = %d, %v", n, err)
+	}
+	if len(updates) != 1 || updates[0] != "/seller/inventory/product/mtg_single/p1" {
+		t.Errorf("updates = %v", updates)
+	}
+}
+
+func TestClient_ApplyReprice_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/p2") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"bad price"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"inventory":{"id":"i1"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithConcurrency(1), WithRetry(0, 0))
+	changes := []PriceChange{
+		{Item: InventoryItem{ProductType: "mtg_single", ProductID: "p1"}, NewPriceCents: 100},
+		{Item: InventoryItem{ProductType: "mtg_single", ProductID: "p2"}, NewPriceCents: 100},
+		{Item: InventoryItem{ProductType: "mtg_single", ProductID: "p3"}, NewPriceCents: 100},
+	}
+	n, err := client.ApplyReprice(context.Background(), changes)
+	if n != 1 {
+		t.Errorf("ApplyReprice() updated %d, want 1", n)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("ApplyReprice() error = %v, want APIError", err)
+	}
+}
@@ -0,0 +1,129 @@
+package manapool
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta describes the HTTP response to an API call. Pass a pointer to
+// one with WithResponseMeta and the client fills it in when the call
+// completes, whether it succeeds or fails with an APIError. For calls that
+// make several requests, such as iterators and batched lookups, it describes
+// the last one.
+//
+// Example:
+//
+//	var meta manapool.ResponseMeta
+//	account, err := client.GetSellerAccount(manapool.WithResponseMeta(ctx, &meta))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Printf("status %d, request %s, %d requests left", meta.StatusCode, meta.RequestID, meta.RateLimit.Remaining)
+type ResponseMeta struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// RequestID is the X-Request-Id response header, if any.
+	RequestID string
+
+	// CorrelationID is the client-generated ID sent in the X-Correlation-Id
+	// request header.
+	CorrelationID string
+
+	// Attempts is the number of times the request was sent, including retries.
+	Attempts int
+
+	// Duration is the time from the first attempt to the final response.
+	Duration time.Duration
+
+	// RateLimit is the rate limit reported by the response headers.
+	RateLimit RateLimitInfo
+}
+
+// RateLimitInfo is the rate limit state reported by response headers. The
+// API does not document rate limit headers, so fields are zero unless the
+// response carries X-RateLimit-* or RateLimit-* headers, or Retry-After.
+type RateLimitInfo struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+
+	// Reset is when the current window ends.
+	Reset time.Time
+
+	// RetryAfter is how long the server asked the client to wait.
+	RetryAfter time.Duration
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context that makes API calls made with it fill
+// in meta. The same context must not be shared by concurrent calls.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// recordResponseMeta fills in the ResponseMeta attached to ctx, if any.
+func recordResponseMeta(ctx context.Context, resp *http.Response, attempts int, started, now time.Time) {
+	meta, ok := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	if !ok || meta == nil || resp == nil {
+		return
+	}
+	*meta = ResponseMeta{
+		StatusCode:    resp.StatusCode,
+		Header:        resp.Header.Clone(),
+		RequestID:     resp.Header.Get("X-Request-Id"),
+		Attempts:      attempts,
+		Duration:      now.Sub(started),
+		CorrelationID: correlationIDOf(resp),
+		RateLimit:     parseRateLimit(resp.Header, now),
+	}
+}
+
+// parseRateLimit reads the common rate limit headers. Reset may be given as
+// Unix seconds or as seconds from now.
+func parseRateLimit(h http.Header, now time.Time) RateLimitInfo {
+	header := func(names ...string) string {
+		for _, name := range names {
+			if v := h.Get(name); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	var info RateLimitInfo
+	info.Limit, _ = strconv.Atoi(header("X-RateLimit-Limit", "RateLimit-Limit"))
+	info.Remaining, _ = strconv.Atoi(header("X-RateLimit-Remaining", "RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(header("X-RateLimit-Reset", "RateLimit-Reset"), 10, 64); err == nil {
+		// Values below a year of seconds are relative.
+		if reset < 365*24*60*60 {
+			info.Reset = now.Add(time.Duration(reset) * time.Second)
+		} else {
+			info.Reset = time.Unix(reset, 0)
+		}
+	}
+	if retryAfter := h.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			info.RetryAfter = time.Duration(seconds) * time.Second
+		} else if at, err := http.ParseTime(retryAfter); err == nil && at.After(now) {
+			info.RetryAfter = at.Sub(now)
+		}
+	}
+	return info
+}
+
+// correlationIDOf returns the correlation ID sent with the request for resp.
+func correlationIDOf(resp *http.Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+	return resp.Request.Header.Get(CorrelationIDHeader)
+}
@@ -0,0 +1,73 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithResponseMeta(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "97")
+		w.Header().Set("X-RateLimit-Reset", "1754426334")
+		_, _ = w.Write([]byte(`{"username":"seller"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(1, time.Millisecond))
+	var meta ResponseMeta
+	if _, err := client.GetSellerAccount(WithResponseMeta(context.Background(), &meta)); err != nil {
+		t.Fatalf("GetSellerAccount() error = %v", err)
+	}
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-123" || meta.CorrelationID == "" || meta.Attempts != 2 || meta.Duration <= 0 {
+		t.Errorf("meta = %+v", meta)
+	}
+	want := RateLimitInfo{Limit: 100, Remaining: 97, Reset: time.Unix(1754426334, 0)}
+	if meta.RateLimit != want {
+		t.Errorf("rate limit = %+v, want %+v", meta.RateLimit, want)
+	}
+	if meta.Header.Get("X-RateLimit-Limit") != "100" {
+		t.Errorf("header = %v", meta.Header)
+	}
+}
+
+func TestWithResponseMeta_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	var meta ResponseMeta
+	_, err := client.GetSellerAccount(WithResponseMeta(context.Background(), &meta))
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if meta.StatusCode != http.StatusTooManyRequests || meta.RateLimit.RetryAfter != 30*time.Second {
+		t.Errorf("meta = %+v", meta)
+	}
+}
+
+func TestParseRateLimit_RelativeReset(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := http.Header{}
+	h.Set("RateLimit-Remaining", "4")
+	h.Set("RateLimit-Reset", "60")
+	info := parseRateLimit(h, now)
+	if info.Remaining != 4 || !info.Reset.Equal(now.Add(time.Minute)) {
+		t.Errorf("info = %+v", info)
+	}
+}
@@ -0,0 +1,283 @@
+package manapool
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadSHA256 is the hex SHA-256 digest of an empty request body.
+const emptyPayloadSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Store is an ObjectStore backed by an Amazon S3 bucket, or a bucket on an
+// S3-compatible service such as MinIO, Cloudflare R2 or Backblaze B2 when
+// Endpoint is set. Requests are signed with AWS Signature Version 4 using the
+// access key, so no AWS SDK is needed.
+//
+// Example:
+//
+//	store := &manapool.S3Store{
+//	    Bucket:          "my-store-backups",
+//	    Region:          "us-east-2",
+//	    AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+//	    SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+//	}
+type S3Store struct {
+	// Bucket is the bucket name.
+	Bucket string
+
+	// Region is the bucket's region (default: "us-east-1").
+	Region string
+
+	// Endpoint is the base URL of an S3-compatible service, e.g.
+	// "http://localhost:9000". Buckets on it are addressed by path rather
+	// than by host name. When empty, AWS's regional endpoint is used.
+	Endpoint string
+
+	// AccessKeyID and SecretAccessKey sign requests.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is sent with temporary credentials.
+	SessionToken string
+
+	// HTTPClient is used for requests (default: a client with a 5 minute
+	// timeout, long enough to upload a price export).
+	HTTPClient *http.Client
+}
+
+// Put implements ObjectStore. The body is streamed without being hashed,
+// so it is sent as an unsigned payload; use an HTTPS endpoint.
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, "PUT", key, nil, header, body, size)
+	if err != nil {
+		return fmt.Errorf("failed to put %s: %w", key, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// Get implements ObjectStore.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, "GET", key, nil, nil, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+// List implements ObjectStore.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	query := map[string]string{"list-type": "2", "prefix": prefix}
+	for {
+		resp, err := s.do(ctx, "GET", "", query, nil, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode S3 listing: %w", err)
+		}
+		for _, object := range result.Contents {
+			objects = append(objects, ObjectInfo{Key: object.Key, Size: object.Size, LastModified: object.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query["continuation-token"] = result.NextContinuationToken
+	}
+}
+
+// Delete implements ObjectStore.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, "DELETE", key, nil, nil, nil, 0)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// do sends a signed request for key, or for the bucket when key is empty,
+// and returns the response if its status is successful.
+func (s *S3Store) do(ctx context.Context, method, key string, query map[string]string, header http.Header, body io.Reader, size int64) (*http.Response, error) {
+	if s.Bucket == "" {
+		return nil, NewValidationError("Bucket", "bucket cannot be empty")
+	}
+	if s.AccessKeyID == "" || s.SecretAccessKey == "" {
+		return nil, NewValidationError("AccessKeyID", "access key ID and secret access key are required")
+	}
+	region := s.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	var u *url.URL
+	path := "/" + s3Escape(key, true)
+	if s.Endpoint == "" {
+		u = &url.URL{Scheme: "https", Host: s.Bucket + ".s3." + region + ".amazonaws.com"}
+	} else {
+		var err error
+		if u, err = url.Parse(strings.TrimSuffix(s.Endpoint, "/")); err != nil || u.Host == "" {
+			return nil, NewValidationError("Endpoint", fmt.Sprintf("invalid S3 endpoint %q", s.Endpoint))
+		}
+		bucketPath := u.EscapedPath() + "/" + s3Escape(s.Bucket, false)
+		if key == "" {
+			path = bucketPath
+		} else {
+			path = bucketPath + path
+		}
+	}
+	u.RawPath = path
+	if u.Path, _ = url.PathUnescape(path); u.Path == path {
+		u.RawPath = ""
+	}
+	u.RawQuery = s3Query(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	payloadHash := emptyPayloadSHA256
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = "UNSIGNED-PAYLOAD"
+	}
+	signS3Request(req, s.AccessKeyID, s.SecretAccessKey, s.SessionToken, region, payloadHash, time.Now())
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Minute}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, NewNetworkError("failed to call S3", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var apiErr struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	message := ""
+	if xml.Unmarshal(data, &apiErr) == nil && apiErr.Code != "" {
+		message = apiErr.Code + ": " + apiErr.Message
+	}
+	return nil, objectStoreError("S3", resp.StatusCode, message, data)
+}
+
+// signS3Request adds AWS Signature Version 4 headers to req, whose URL path
+// and query must already be in canonical form.
+func signS3Request(req *http.Request, accessKeyID, secretAccessKey, sessionToken, region, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		if name = strings.ToLower(name); strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method, path, req.URL.RawQuery, canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Query encodes query parameters in the sorted, percent-encoded form
+// Signature Version 4 signs.
+func s3Query(query map[string]string) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = s3Escape(name, false) + "=" + s3Escape(query[name], false)
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes every byte of s except RFC 3986 unreserved
+// characters and, if keepSlash is set, slashes.
+func s3Escape(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && keepSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package manapool
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SalesTaxSummary aggregates gross order totals for one destination and
+// month. The seller order API does not report the tax collected on an order,
+// so the summary has no tax amount; it is meant for tracking sales per state
+// against economic nexus thresholds.
+type SalesTaxSummary struct {
+	// Month is the order month in YYYY-MM format (UTC).
+	Month         string
+	Country       string
+	State         string
+	OrderCount    int
+	SubtotalCents int
+	ShippingCents int
+	TotalCents    int
+}
+
+// SummarizeSalesTax aggregates gross order totals by destination
+// country, state, and month. Results are sorted by month, country, and state.
+func SummarizeSalesTax(orders []OrderDetails) []SalesTaxSummary {
+	type key struct{ month, country, state string }
+
+	totals := make(map[key]*SalesTaxSummary)
+	for _, order := range orders {
+		k := key{
+			month:   order.CreatedAt.UTC().Format("2006-01"),
+			country: strings.ToUpper(strings.TrimSpace(order.ShippingAddress.Country)),
+			state:   strings.ToUpper(strings.TrimSpace(order.ShippingAddress.State)),
+		}
+		row, ok := totals[k]
+		if !ok {
+			row = &SalesTaxSummary{Month: k.month, Country: k.country, State: k.state}
+			totals[k] = row
+		}
+		row.OrderCount++
+		row.SubtotalCents += order.Payment.SubtotalCents
+		row.ShippingCents += order.Payment.ShippingCents
+		row.TotalCents += order.Payment.TotalCents
+	}
+
+	summaries := make([]SalesTaxSummary, 0, len(totals))
+	for _, row := range totals {
+		summaries = append(summaries, *row)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.Country != b.Country {
+			return a.Country < b.Country
+		}
+		return a.State < b.State
+	})
+
+	return summaries
+}
+
+// GetSalesTaxReport builds a sales tax summary for seller orders matching opts.
+// The report holds gross totals only; see SalesTaxSummary. Each order's
+// details are fetched to obtain its destination and payment totals, so this
+// issues one request per order in addition to the list requests. Detail
+// requests run concurrently, up to the client's concurrency (see
+// WithConcurrency).
+//
+// Example:
+//
+//	since := manapool.Timestamp{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+//	report, err := client.GetSalesTaxReport(ctx, manapool.OrdersOptions{Since: &since})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = manapool.WriteSalesTaxCSV(os.Stdout, report)
+func (c *Client) GetSalesTaxReport(ctx context.Context, opts OrdersOptions) ([]SalesTaxSummary, error) {
+	orders, err := c.collectSellerOrderDetails(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sales tax report: %w", err)
+	}
+
+	return SummarizeSalesTax(orders), nil
+}
+
+// WriteSalesTaxCSV writes sales tax summaries as CSV with a header row.
+// Monetary columns are written in cents and hold gross order totals.
+func WriteSalesTaxCSV(w io.Writer, summaries []SalesTaxSummary) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"month", "country", "state", "order_count", "subtotal_cents", "shipping_cents", "total_cents"}); err != nil {
+		return fmt.Errorf("failed to write sales tax header: %w", err)
+	}
+	for _, s := range summaries {
+		record := []string{
+			s.Month,
+			s.Country,
+			s.State,
+			strconv.Itoa(s.OrderCount),
+			strconv.Itoa(s.SubtotalCents),
+			strconv.Itoa(s.ShippingCents),
+			strconv.Itoa(s.TotalCents),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write sales tax row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
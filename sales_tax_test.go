@@ -0,0 +1,100 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeSalesTax(t *testing.T) {
+	order := func(created time.Time, country, state string, shipping, total int) OrderDetails {
+		return OrderDetails{
+			OrderSummary:    OrderSummary{CreatedAt: Timestamp{Time: created}},
+			ShippingAddress: Address{Country: country, State: state},
+			Payment:         OrderPayment{SubtotalCents: total - shipping, ShippingCents: shipping, TotalCents: total},
+		}
+	}
+
+	summaries := SummarizeSalesTax([]OrderDetails{
+		order(time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), "US", "CA", 80, 1080),
+		order(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), "us", "ny ", 50, 1050),
+		order(time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), "US", "NY", 25, 525),
+		order(time.Date(2025, 1, 31, 23, 0, 0, 0, time.FixedZone("EST", -5*3600)), "US", "NY", 10, 110),
+	})
+
+	if len(summaries) != 3 {
+		t.Fatalf("summaries = %d, want 3: %+v", len(summaries), summaries)
+	}
+	first := summaries[0]
+	if first.Month != "2025-01" || first.Country != "US" || first.State != "NY" {
+		t.Fatalf("unexpected first summary: %+v", first)
+	}
+	if first.OrderCount != 2 || first.ShippingCents != 75 || first.TotalCents != 1575 {
+		t.Fatalf("unexpected NY totals: %+v", first)
+	}
+	if summaries[1].Month != "2025-02" || summaries[1].State != "CA" {
+		t.Fatalf("unexpected second summary: %+v", summaries[1])
+	}
+	if summaries[2].Month != "2025-02" || summaries[2].State != "NY" || summaries[2].ShippingCents != 10 {
+		t.Fatalf("timezone month bucketing mismatch: %+v", summaries[2])
+	}
+}
+
+func TestClient_GetSalesTaxReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/seller/orders":
+			if r.URL.Query().Get("offset") != "0" {
+				_, _ = w.Write([]byte(`{"orders":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a","created_at":"2025-01-05T00:00:00Z"},{"id":"b","created_at":"2025-01-06T00:00:00Z"}]}`))
+		case strings.HasPrefix(r.URL.Path, "/seller/orders/"):
+			id := strings.TrimPrefix(r.URL.Path, "/seller/orders/")
+			_, _ = fmt.Fprintf(w, `{"order":{"id":%q,"created_at":"2025-01-05T00:00:00Z","shipping_address":{"line1":"1","city":"c","state":"WA","postal_code":"98101","country":"US"},"payment":{"subtotal_cents":1000,"shipping_cents":100,"total_cents":1190,"fee_cents":0,"net_cents":0}}}`, id)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	report, err := client.GetSalesTaxReport(context.Background(), OrdersOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetSalesTaxReport error: %v", err)
+	}
+	if len(report) != 1 || report[0].OrderCount != 2 || report[0].TotalCents != 2380 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSalesTaxCSV(&buf, report); err != nil {
+		t.Fatalf("WriteSalesTaxCSV error: %v", err)
+	}
+	want := "month,country,state,order_count,subtotal_cents,shipping_cents,total_cents\n2025-01,US,WA,2,2000,200,2380\n"
+	if buf.String() != want {
+		t.Fatalf("csv = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestClient_GetSalesTaxReport_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/seller/orders" {
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	if _, err := client.GetSalesTaxReport(context.Background(), OrdersOptions{}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
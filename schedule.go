@@ -0,0 +1,33 @@
+package manapool
+
+import (
+	"context"
+	"time"
+)
+
+// runEvery calls fn immediately and then every interval until ctx is
+// cancelled, passing each error fn returns to onError if it is not nil. It
+// returns ctx's error, or a ValidationError if interval is not positive.
+func runEvery(ctx context.Context, interval time.Duration, fn func(context.Context) error, onError func(error)) error {
+	if interval <= 0 {
+		return NewValidationError("interval", "interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := fn(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
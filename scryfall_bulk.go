@@ -0,0 +1,389 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultScryfallBulkURL is the Scryfall bulk data endpoint describing the
+// default cards file used by ScryfallEnricher.
+const DefaultScryfallBulkURL = "https://api.scryfall.com/bulk-data/default-cards"
+
+// DefaultScryfallBulkMaxAge is how long ScryfallEnricher uses its disk cache
+// before checking for new bulk data. Scryfall publishes it daily.
+const DefaultScryfallBulkMaxAge = 24 * time.Hour
+
+// scryfallBulkCacheFile is the name of ScryfallEnricher's cache in CacheDir.
+const scryfallBulkCacheFile = "scryfall-default-cards.json"
+
+// CardDetails is display information about a card printing from Scryfall.
+type CardDetails struct {
+	ScryfallID string `json:"scryfall_id"`
+	Name       string `json:"name"`
+	SetName    string `json:"set_name"`
+	Rarity     string `json:"rarity"`
+
+	// OracleText is the card's rules text. The faces of a double-faced card
+	// are separated by a line containing "//".
+	OracleText string `json:"oracle_text,omitempty"`
+
+	// ImageURL and SmallImageURL link to Scryfall's normal (488x680) and
+	// small (146x204) images of the card's front.
+	ImageURL      string `json:"image_url,omitempty"`
+	SmallImageURL string `json:"small_image_url,omitempty"`
+
+	// ScryfallURL is the card's page on Scryfall.
+	ScryfallURL string `json:"scryfall_url,omitempty"`
+}
+
+// EnrichedInventoryItem is an inventory item with its card's details. Details
+// is nil for sealed products and cards the enricher does not know.
+type EnrichedInventoryItem struct {
+	InventoryItem
+	Details *CardDetails `json:"details,omitempty"`
+}
+
+// EnrichedSinglePriceListing is a single price listing with its card's
+// details, or nil Details if the enricher does not know the card.
+type EnrichedSinglePriceListing struct {
+	SinglePriceListing
+	Details *CardDetails `json:"details,omitempty"`
+}
+
+// EnrichedVariantPriceListing is a variant price listing with its card's
+// details, or nil Details if the enricher does not know the card.
+type EnrichedVariantPriceListing struct {
+	VariantPriceListing
+	Details *CardDetails `json:"details,omitempty"`
+}
+
+// ScryfallEnricher adds images, oracle text, rarity and set names from
+// Scryfall's default cards bulk data to inventory and price listings, for
+// applications that show them to people. The bulk data is downloaded the
+// first time it is needed, not when the enricher is created.
+//
+// The download is several hundred megabytes, so set CacheDir to keep the
+// details, a small part of it, on disk. The cache is used without checking
+// Scryfall until it is MaxAge old, then kept if Scryfall has not published
+// newer data. A stale cache is also used when Scryfall cannot be reached.
+//
+// Example:
+//
+//	enricher := &manapool.ScryfallEnricher{CacheDir: filepath.Join(os.TempDir(), "manapool")}
+//	inventory, err := client.GetSellerInventory(ctx, manapool.InventoryOptions{Limit: 100})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	items, err := enricher.EnrichInventory(ctx, inventory.Inventory)
+//	for _, item := range items {
+//	    if item.Details != nil {
+//	        fmt.Println(item.Details.Name, item.Details.ImageURL)
+//	    }
+//	}
+type ScryfallEnricher struct {
+	// HTTPClient is used for requests (default: a client with a 10 minute
+	// timeout, long enough to download the bulk data).
+	HTTPClient *http.Client
+
+	// URL is the bulk data endpoint (default: DefaultScryfallBulkURL).
+	URL string
+
+	// UserAgent identifies the application to Scryfall, which requires one
+	// (default: the manapool-go user agent).
+	UserAgent string
+
+	// CacheDir, if set, is the directory the details are cached in.
+	CacheDir string
+
+	// MaxAge is how long the cache is used before checking for new bulk
+	// data (default: DefaultScryfallBulkMaxAge).
+	MaxAge time.Duration
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	cards map[string]*CardDetails
+}
+
+// scryfallBulkCache is the file format of the disk cache.
+type scryfallBulkCache struct {
+	UpdatedAt string        `json:"updated_at"`
+	Cards     []CardDetails `json:"cards"`
+}
+
+// Details returns the details of the card with a Scryfall ID, loading the
+// bulk data if it has not been loaded yet.
+func (e *ScryfallEnricher) Details(ctx context.Context, scryfallID string) (*CardDetails, bool, error) {
+	cards, err := e.load(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	details, ok := cards[strings.ToLower(scryfallID)]
+	return details, ok, nil
+}
+
+// Load loads the bulk data now rather than on first use, so the first
+// request served is not slowed down by a download.
+func (e *ScryfallEnricher) Load(ctx context.Context) error {
+	_, err := e.load(ctx)
+	return err
+}
+
+// EnrichInventory returns items with the details of their cards.
+func (e *ScryfallEnricher) EnrichInventory(ctx context.Context, items []InventoryItem) ([]EnrichedInventoryItem, error) {
+	cards, err := e.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enriched := make([]EnrichedInventoryItem, len(items))
+	for i, item := range items {
+		enriched[i].InventoryItem = item
+		if item.Product.Single != nil {
+			enriched[i].Details = cards[strings.ToLower(item.Product.Single.ScryfallID)]
+		}
+	}
+	return enriched, nil
+}
+
+// EnrichSinglePrices returns listings with the details of their cards.
+func (e *ScryfallEnricher) EnrichSinglePrices(ctx context.Context, listings []SinglePriceListing) ([]EnrichedSinglePriceListing, error) {
+	cards, err := e.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enriched := make([]EnrichedSinglePriceListing, len(listings))
+	for i, listing := range listings {
+		enriched[i] = EnrichedSinglePriceListing{SinglePriceListing: listing, Details: cards[strings.ToLower(listing.ScryfallID)]}
+	}
+	return enriched, nil
+}
+
+// EnrichVariantPrices returns listings with the details of their cards.
+func (e *ScryfallEnricher) EnrichVariantPrices(ctx context.Context, listings []VariantPriceListing) ([]EnrichedVariantPriceListing, error) {
+	cards, err := e.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enriched := make([]EnrichedVariantPriceListing, len(listings))
+	for i, listing := range listings {
+		enriched[i] = EnrichedVariantPriceListing{VariantPriceListing: listing, Details: cards[strings.ToLower(listing.ScryfallID)]}
+	}
+	return enriched, nil
+}
+
+// load returns the card details by Scryfall ID, loading them on first use.
+// A failed load is tried again on the next call.
+func (e *ScryfallEnricher) load(ctx context.Context) (map[string]*CardDetails, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cards != nil {
+		return e.cards, nil
+	}
+
+	now := time.Now
+	if e.Now != nil {
+		now = e.Now
+	}
+	maxAge := e.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultScryfallBulkMaxAge
+	}
+	cachePath := ""
+	if e.CacheDir != "" {
+		cachePath = filepath.Join(e.CacheDir, scryfallBulkCacheFile)
+	}
+
+	cache, cachedAt, cacheErr := readScryfallBulkCache(cachePath)
+	if cacheErr != nil || now().Sub(cachedAt) > maxAge {
+		fresh, err := e.fetch(ctx, cache)
+		switch {
+		case err == nil:
+			cache = fresh
+			if cachePath != "" {
+				// The cache only saves a later download; failing to write
+				// it does not fail the load.
+				_ = writeScryfallBulkCache(cachePath, cache, now())
+			}
+		case cacheErr != nil:
+			return nil, err
+		}
+	}
+
+	cards := make(map[string]*CardDetails, len(cache.Cards))
+	for i := range cache.Cards {
+		card := &cache.Cards[i]
+		cards[strings.ToLower(card.ScryfallID)] = card
+	}
+	e.cards = cards
+	return cards, nil
+}
+
+// fetch checks Scryfall's bulk data and downloads it unless cached already
+// has the latest.
+func (e *ScryfallEnricher) fetch(ctx context.Context, cached *scryfallBulkCache) (*scryfallBulkCache, error) {
+	endpoint := e.URL
+	if endpoint == "" {
+		endpoint = DefaultScryfallBulkURL
+	}
+	resp, err := e.get(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var meta struct {
+		UpdatedAt   string `json:"updated_at"`
+		DownloadURI string `json:"download_uri"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&meta)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Scryfall bulk data: %w", err)
+	}
+	if meta.DownloadURI == "" {
+		return nil, fmt.Errorf("failed to decode Scryfall bulk data: no download URI")
+	}
+	if cached != nil && cached.UpdatedAt == meta.UpdatedAt {
+		return cached, nil
+	}
+
+	resp, err = e.get(ctx, meta.DownloadURI)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	cards, err := decodeScryfallBulkCards(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Scryfall bulk cards: %w", err)
+	}
+	return &scryfallBulkCache{UpdatedAt: meta.UpdatedAt, Cards: cards}, nil
+}
+
+func (e *ScryfallEnricher) get(ctx context.Context, endpoint string) (*http.Response, error) {
+	httpClient := e.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Minute}
+	}
+	userAgent := e.UserAgent
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("manapool-go/%s", Version)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, NewNetworkError("failed to download Scryfall bulk data", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to download Scryfall bulk data: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
+
+// decodeScryfallBulkCards streams a Scryfall bulk data array, keeping only
+// the details of each card.
+func decodeScryfallBulkCards(r io.Reader) ([]CardDetails, error) {
+	type imageURIs struct {
+		Small  string `json:"small"`
+		Normal string `json:"normal"`
+	}
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '['); err != nil {
+		return nil, err
+	}
+	var cards []CardDetails
+	for dec.More() {
+		var card struct {
+			ID          string     `json:"id"`
+			Name        string     `json:"name"`
+			SetName     string     `json:"set_name"`
+			Rarity      string     `json:"rarity"`
+			OracleText  string     `json:"oracle_text"`
+			ImageURIs   *imageURIs `json:"image_uris"`
+			ScryfallURI string     `json:"scryfall_uri"`
+			CardFaces   []struct {
+				OracleText string     `json:"oracle_text"`
+				ImageURIs  *imageURIs `json:"image_uris"`
+			} `json:"card_faces"`
+		}
+		if err := dec.Decode(&card); err != nil {
+			return nil, err
+		}
+
+		details := CardDetails{
+			ScryfallID:  card.ID,
+			Name:        card.Name,
+			SetName:     card.SetName,
+			Rarity:      card.Rarity,
+			OracleText:  card.OracleText,
+			ScryfallURL: card.ScryfallURI,
+		}
+		images := card.ImageURIs
+		if len(card.CardFaces) > 0 {
+			if images == nil {
+				images = card.CardFaces[0].ImageURIs
+			}
+			if details.OracleText == "" {
+				texts := make([]string, len(card.CardFaces))
+				for i, face := range card.CardFaces {
+					texts[i] = face.OracleText
+				}
+				details.OracleText = strings.Join(texts, "\n//\n")
+			}
+		}
+		if images != nil {
+			details.ImageURL, details.SmallImageURL = images.Normal, images.Small
+		}
+		cards = append(cards, details)
+	}
+	return cards, expectJSONDelim(dec, ']')
+}
+
+// readScryfallBulkCache reads the cache at path and returns when it was
+// written.
+func readScryfallBulkCache(path string) (*scryfallBulkCache, time.Time, error) {
+	if path == "" {
+		return nil, time.Time{}, os.ErrNotExist
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer func() { _ = file.Close() }()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var cache scryfallBulkCache
+	if err := json.NewDecoder(file).Decode(&cache); err != nil {
+		return nil, time.Time{}, err
+	}
+	return &cache, info.ModTime(), nil
+}
+
+// writeScryfallBulkCache writes cache to path through a temporary file, so
+// a reader never sees a partial cache, and marks it as written at now.
+func writeScryfallBulkCache(path string, cache *scryfallBulkCache, now time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := writeJSONFile(path, cache); err != nil {
+		return err
+	}
+	return os.Chtimes(path, now, now)
+}
@@ -0,0 +1,170 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeScryfallBulk serves bulk data metadata and a default cards file.
+type fakeScryfallBulk struct {
+	updatedAt atomic.Value // string
+	fail      atomic.Bool
+	metaHits  atomic.Int32
+	bulkHits  atomic.Int32
+}
+
+func (f *fakeScryfallBulk) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.fail.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	switch r.URL.Path {
+	case "/bulk-data/default-cards":
+		f.metaHits.Add(1)
+		fmt.Fprintf(w, `{"object":"bulk_data","type":"default_cards","updated_at":%q,"download_uri":"http://%s/default-cards.json"}`, f.updatedAt.Load(), r.Host)
+	case "/default-cards.json":
+		f.bulkHits.Add(1)
+		fmt.Fprint(w, `[
+{"object":"card","id":"`+solRingScryfallID+`","name":"Sol Ring","set_name":"Commander 2021","rarity":"uncommon",
+ "oracle_text":"{T}: Add {C}{C}.","scryfall_uri":"https://scryfall.com/card/c21/263/sol-ring",
+ "image_uris":{"small":"https://cards.example/small/sol.jpg","normal":"https://cards.example/normal/sol.jpg"}},
+{"object":"card","id":"DFC-ID","name":"Delver of Secrets // Insectile Aberration","set_name":"Innistrad","rarity":"common",
+ "card_faces":[
+  {"oracle_text":"Look at the top card.","image_uris":{"small":"https://cards.example/small/delver.jpg","normal":"https://cards.example/normal/delver.jpg"}},
+  {"oracle_text":"Flying","image_uris":{"small":"https://cards.example/small/insectile.jpg"}}]}
+]`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestScryfallEnricher_Enrich(t *testing.T) {
+	fake := &fakeScryfallBulk{}
+	fake.updatedAt.Store("2024-05-01T09:00:00Z")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	enricher := &ScryfallEnricher{URL: server.URL + "/bulk-data/default-cards"}
+	if fake.metaHits.Load() != 0 {
+		t.Fatal("enricher loaded before first use")
+	}
+	ctx := context.Background()
+
+	items, err := enricher.EnrichInventory(ctx, []InventoryItem{
+		{ID: "l1", Product: Product{Single: &Single{ScryfallID: solRingScryfallID}}},
+		{ID: "l2", Product: Product{Sealed: &Sealed{Name: "Booster Box"}}},
+		{ID: "l3", Product: Product{Single: &Single{ScryfallID: "unknown"}}},
+	})
+	if err != nil {
+		t.Fatalf("EnrichInventory() error = %v", err)
+	}
+	want := CardDetails{
+		ScryfallID:    solRingScryfallID,
+		Name:          "Sol Ring",
+		SetName:       "Commander 2021",
+		Rarity:        "uncommon",
+		OracleText:    "{T}: Add {C}{C}.",
+		ImageURL:      "https://cards.example/normal/sol.jpg",
+		SmallImageURL: "https://cards.example/small/sol.jpg",
+		ScryfallURL:   "https://scryfall.com/card/c21/263/sol-ring",
+	}
+	if items[0].ID != "l1" || items[0].Details == nil || *items[0].Details != want {
+		t.Errorf("items[0] = %+v", items[0])
+	}
+	if items[1].Details != nil || items[2].Details != nil {
+		t.Errorf("sealed or unknown items enriched: %+v, %+v", items[1].Details, items[2].Details)
+	}
+
+	prices, err := enricher.EnrichSinglePrices(ctx, []SinglePriceListing{{ScryfallID: "dfc-id"}})
+	if err != nil {
+		t.Fatalf("EnrichSinglePrices() error = %v", err)
+	}
+	delver := prices[0].Details
+	if delver == nil || delver.OracleText != "Look at the top card.\n//\nFlying" || delver.ImageURL != "https://cards.example/normal/delver.jpg" {
+		t.Errorf("double-faced details = %+v", delver)
+	}
+
+	variants, err := enricher.EnrichVariantPrices(ctx, []VariantPriceListing{{ScryfallID: solRingScryfallID}})
+	if err != nil || variants[0].Details == nil || variants[0].Details.Rarity != "uncommon" {
+		t.Errorf("EnrichVariantPrices() = %+v, %v", variants, err)
+	}
+
+	if fake.metaHits.Load() != 1 || fake.bulkHits.Load() != 1 {
+		t.Errorf("bulk data fetched %d/%d times, want once", fake.metaHits.Load(), fake.bulkHits.Load())
+	}
+}
+
+func TestScryfallEnricher_DiskCache(t *testing.T) {
+	fake := &fakeScryfallBulk{}
+	fake.updatedAt.Store("2024-05-01T09:00:00Z")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	dir := t.TempDir()
+	now := time.Now()
+	newEnricher := func() *ScryfallEnricher {
+		return &ScryfallEnricher{
+			URL:      server.URL + "/bulk-data/default-cards",
+			CacheDir: dir,
+			MaxAge:   time.Hour,
+			Now:      func() time.Time { return now },
+		}
+	}
+	ctx := context.Background()
+	lookup := func() {
+		t.Helper()
+		details, ok, err := newEnricher().Details(ctx, solRingScryfallID)
+		if err != nil || !ok || details.Name != "Sol Ring" {
+			t.Fatalf("Details() = %+v, %v, %v", details, ok, err)
+		}
+	}
+
+	lookup()
+	lookup()
+	if fake.metaHits.Load() != 1 || fake.bulkHits.Load() != 1 {
+		t.Errorf("fresh cache: fetched %d/%d times, want once", fake.metaHits.Load(), fake.bulkHits.Load())
+	}
+
+	// An expired cache is kept when Scryfall has nothing newer.
+	now = now.Add(2 * time.Hour)
+	lookup()
+	if fake.metaHits.Load() != 2 || fake.bulkHits.Load() != 1 {
+		t.Errorf("unchanged bulk data: fetched %d/%d times, want 2/1", fake.metaHits.Load(), fake.bulkHits.Load())
+	}
+
+	now = now.Add(2 * time.Hour)
+	fake.updatedAt.Store("2024-05-02T09:00:00Z")
+	lookup()
+	if fake.bulkHits.Load() != 2 {
+		t.Errorf("new bulk data downloaded %d times, want 2", fake.bulkHits.Load())
+	}
+
+	// A stale cache is used when Scryfall is unavailable.
+	now = now.Add(2 * time.Hour)
+	fake.fail.Store(true)
+	lookup()
+}
+
+func TestScryfallEnricher_Errors(t *testing.T) {
+	fake := &fakeScryfallBulk{}
+	fake.updatedAt.Store("2024-05-01T09:00:00Z")
+	fake.fail.Store(true)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	enricher := &ScryfallEnricher{URL: server.URL + "/bulk-data/default-cards"}
+	if err := enricher.Load(context.Background()); err == nil {
+		t.Fatal("Load() error = nil, want error")
+	}
+
+	// A failed load is retried.
+	fake.fail.Store(false)
+	if err := enricher.Load(context.Background()); err != nil {
+		t.Fatalf("Load() after recovery error = %v", err)
+	}
+}
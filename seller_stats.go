@@ -0,0 +1,143 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DefaultSellerStatsWindow is the order window used by GetSellerStats when
+// none is given.
+const DefaultSellerStatsWindow = 30 * 24 * time.Hour
+
+// SellerStats is a dashboard summary of a seller's account, listings and
+// recent orders. The API has no stats endpoint, so it is computed client-side.
+type SellerStats struct {
+	// Account is the seller account.
+	Account Account
+
+	// ListingCount is the number of listings with at least one copy in stock.
+	ListingCount int
+
+	// ListedQuantity is the total number of copies in stock.
+	ListedQuantity int
+
+	// ListedValueCents is the sum of price times quantity over all listings.
+	ListedValueCents int
+
+	// Since is the start of the order window.
+	Since time.Time
+
+	// OrderCount is the number of orders placed since Since.
+	OrderCount int
+
+	// OrderTotalCents is the sum of those orders' totals.
+	OrderTotalCents int
+
+	// ShippedCount is the number of those orders that have been shipped.
+	ShippedCount int
+
+	// AverageTimeToShip and MedianTimeToShip measure the time from order
+	// creation to the first fulfillment marked in transit, over shipped orders.
+	AverageTimeToShip time.Duration
+	MedianTimeToShip  time.Duration
+}
+
+// SummarizeSellerStats computes seller stats from already-fetched data.
+// Orders created before since are ignored.
+func SummarizeSellerStats(account Account, inventory []InventoryItem, orders []OrderDetails, since time.Time) SellerStats {
+	stats := SellerStats{Account: account, Since: since}
+	for _, item := range inventory {
+		if item.Quantity <= 0 {
+			continue
+		}
+		stats.ListingCount++
+		stats.ListedQuantity += item.Quantity
+		stats.ListedValueCents += item.PriceCents * item.Quantity
+	}
+
+	var shipTimes []time.Duration
+	for _, order := range orders {
+		if order.CreatedAt.Before(since) {
+			continue
+		}
+		stats.OrderCount++
+		stats.OrderTotalCents += order.TotalCents
+		if shipped, ok := firstInTransit(order); ok {
+			shipTimes = append(shipTimes, shipped.Sub(order.CreatedAt.Time))
+		}
+	}
+
+	stats.ShippedCount = len(shipTimes)
+	if len(shipTimes) > 0 {
+		var total time.Duration
+		for _, d := range shipTimes {
+			total += d
+		}
+		stats.AverageTimeToShip = total / time.Duration(len(shipTimes))
+		sort.Slice(shipTimes, func(i, j int) bool { return shipTimes[i] < shipTimes[j] })
+		mid := len(shipTimes) / 2
+		stats.MedianTimeToShip = shipTimes[mid]
+		if len(shipTimes)%2 == 0 {
+			stats.MedianTimeToShip = (shipTimes[mid-1] + shipTimes[mid]) / 2
+		}
+	}
+	return stats
+}
+
+// firstInTransit returns the earliest in-transit time among the order's fulfillments.
+func firstInTransit(order OrderDetails) (time.Time, bool) {
+	var first time.Time
+	for _, f := range order.Fulfillments {
+		if f.InTransitAt == nil || f.InTransitAt.IsZero() {
+			continue
+		}
+		if first.IsZero() || f.InTransitAt.Before(first) {
+			first = f.InTransitAt.Time
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// GetSellerStats fetches the seller account, the full inventory and the
+// orders placed within window (default: DefaultSellerStatsWindow) and
+// summarizes them. Each order's details are fetched to obtain its
+// fulfillment times, so this issues one request per order in addition to the
+// list requests.
+//
+// Example:
+//
+//	stats, err := client.GetSellerStats(ctx, 0)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d listings worth $%.2f, %d orders in 30 days, median %s to ship\n",
+//	    stats.ListingCount, float64(stats.ListedValueCents)/100, stats.OrderCount, stats.MedianTimeToShip)
+func (c *Client) GetSellerStats(ctx context.Context, window time.Duration) (*SellerStats, error) {
+	if window < 0 {
+		return nil, NewValidationError("window", "window cannot be negative")
+	}
+	if window == 0 {
+		window = DefaultSellerStatsWindow
+	}
+	since := time.Now().Add(-window)
+
+	account, err := c.GetSellerAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seller stats: %w", err)
+	}
+
+	inventory, err := c.collectInventory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seller stats: %w", err)
+	}
+
+	orders, err := c.collectSellerOrderDetails(ctx, OrdersOptions{Since: &Timestamp{Time: since}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seller stats: %w", err)
+	}
+
+	stats := SummarizeSellerStats(*account, inventory, orders, since)
+	return &stats, nil
+}
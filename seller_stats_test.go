@@ -0,0 +1,84 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSummarizeSellerStats(t *testing.T) {
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	order := func(created time.Time, total int, shipped ...time.Duration) OrderDetails {
+		o := OrderDetails{OrderSummary: OrderSummary{CreatedAt: Timestamp{Time: created}, TotalCents: total}}
+		for _, d := range shipped {
+			o.Fulfillments = append(o.Fulfillments, OrderFulfillment{InTransitAt: &Timestamp{Time: created.Add(d)}})
+		}
+		return o
+	}
+
+	stats := SummarizeSellerStats(
+		Account{Username: "seller"},
+		[]InventoryItem{{PriceCents: 250, Quantity: 4}, {PriceCents: 1000, Quantity: 1}, {PriceCents: 500, Quantity: 0}},
+		[]OrderDetails{
+			order(since.Add(-time.Hour), 9999, time.Hour),
+			order(since.Add(time.Hour), 1000, 48*time.Hour, 24*time.Hour),
+			order(since.Add(2*time.Hour), 500, 12*time.Hour),
+			order(since.Add(3*time.Hour), 700),
+		},
+		since,
+	)
+
+	if stats.Account.Username != "seller" || stats.ListingCount != 2 || stats.ListedQuantity != 5 || stats.ListedValueCents != 2000 {
+		t.Errorf("listing stats = %+v", stats)
+	}
+	if stats.OrderCount != 3 || stats.OrderTotalCents != 2200 || stats.ShippedCount != 2 {
+		t.Errorf("order stats = %+v", stats)
+	}
+	if stats.AverageTimeToShip != 18*time.Hour || stats.MedianTimeToShip != 18*time.Hour {
+		t.Errorf("time to ship = %s avg, %s median", stats.AverageTimeToShip, stats.MedianTimeToShip)
+	}
+}
+
+func TestClient_GetSellerStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/account":
+			_, _ = w.Write([]byte(`{"username":"seller","singles_live":true}`))
+		case "/seller/inventory":
+			_, _ = w.Write([]byte(`{"inventory":[{"id":"i1","price_cents":300,"quantity":2}],"pagination":{"total":1,"returned":1,"offset":0,"limit":500}}`))
+		case "/seller/orders":
+			if r.URL.Query().Get("since") == "" {
+				t.Errorf("expected since parameter")
+			}
+			if r.URL.Query().Get("offset") != "0" {
+				_, _ = w.Write([]byte(`{"orders":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"orders":[{"id":"a"}]}`))
+		case "/seller/orders/a":
+			created := time.Now().Add(-48 * time.Hour).UTC()
+			shipped := created.Add(6 * time.Hour)
+			_, _ = w.Write([]byte(`{"order":{"id":"a","created_at":"` + created.Format(time.RFC3339) + `","total_cents":600,"fulfillments":[{"in_transit_at":"` + shipped.Format(time.RFC3339) + `"}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	stats, err := client.GetSellerStats(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("GetSellerStats() error = %v", err)
+	}
+	if stats.ListedValueCents != 600 || stats.OrderCount != 1 || stats.MedianTimeToShip != 6*time.Hour {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.GetSellerStats(context.Background(), -time.Hour); !errors.As(err, &valErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
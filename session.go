@@ -0,0 +1,110 @@
+package manapool
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PurchaseSession is the saved state of a purchasing workflow: the optimizer
+// request, the cart it produced, and the pending order built from it. Events
+// form an audit trail of what was done and why, so an interrupted session can
+// be resumed and a completed one reviewed later.
+type PurchaseSession struct {
+	Request             *OptimizerRequest    `json:"request,omitempty"`
+	Cart                *OptimizedCart       `json:"cart,omitempty"`
+	PendingOrderRequest *PendingOrderRequest `json:"pending_order_request,omitempty"`
+	PendingOrder        *PendingOrder        `json:"pending_order,omitempty"`
+	Notes               string               `json:"notes,omitempty"`
+	Events              []SessionEvent       `json:"events,omitempty"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+}
+
+// SessionEvent is one entry in a purchase session's audit trail.
+type SessionEvent struct {
+	Time   time.Time `json:"time"`
+	Step   string    `json:"step"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// Record appends an event to the session's audit trail.
+func (s *PurchaseSession) Record(step, detail string) {
+	s.Events = append(s.Events, SessionEvent{Time: time.Now().UTC(), Step: step, Detail: detail})
+}
+
+// SavePurchaseSession writes the session to path as indented JSON, replacing
+// any existing file. The file is written to a temporary file in the same
+// directory and renamed into place so a crash never leaves a partial session.
+//
+// Example:
+//
+//	session := &manapool.PurchaseSession{Request: &req}
+//	cart, err := client.OptimizeCart(ctx, req)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	session.Cart = cart
+//	session.Record("optimized", "lowest_price model")
+//	if err := manapool.SavePurchaseSession("session.json", session); err != nil {
+//	    log.Fatal(err)
+//	}
+func SavePurchaseSession(path string, session *PurchaseSession) error {
+	if path == "" {
+		return NewValidationError("path", "path cannot be empty")
+	}
+	if session == nil {
+		return NewValidationError("session", "session cannot be nil")
+	}
+
+	session.UpdatedAt = time.Now().UTC()
+	if err := writeJSONFile(path, session); err != nil {
+		return fmt.Errorf("failed to save purchase session: %w", err)
+	}
+
+	return nil
+}
+
+// LoadPurchaseSession reads a session written by SavePurchaseSession.
+func LoadPurchaseSession(path string) (*PurchaseSession, error) {
+	if path == "" {
+		return nil, NewValidationError("path", "path cannot be empty")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load purchase session: %w", err)
+	}
+
+	var session PurchaseSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode purchase session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// writeJSONFile writes v to path as indented JSON via a temporary file in the
+// same directory, so readers never observe a partially written file.
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,92 @@
+package manapool
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPurchaseSession_SaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.json")
+
+	session := &PurchaseSession{
+		Request: &OptimizerRequest{
+			Model: OptimizerModelBalanced,
+			Cart:  []OptimizerCartItem{{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 1}},
+		},
+		Cart: &OptimizedCart{
+			Cart:   []OptimizedCartItem{{InventoryID: "inv", QuantitySelected: 1}},
+			Totals: OptimizedCartTotals{SubtotalCents: 100, TotalCents: 100, SellerCount: 1},
+		},
+		PendingOrder: &PendingOrder{ID: "pending", Status: "pending", LineItems: []PendingOrderLineItem{{InventoryID: "inv", QuantitySelected: 1}}},
+		Notes:        "commander upgrades",
+	}
+	session.Record("optimized", "balanced model")
+	session.Record("pending_order_created", "pending")
+
+	if err := SavePurchaseSession(path, session); err != nil {
+		t.Fatalf("SavePurchaseSession() error = %v", err)
+	}
+	if session.UpdatedAt.IsZero() {
+		t.Error("UpdatedAt not set")
+	}
+
+	// Saving again replaces the file without leaving temporary files behind.
+	session.Record("saved", "")
+	if err := SavePurchaseSession(path, session); err != nil {
+		t.Fatalf("SavePurchaseSession() error = %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("directory has %d entries, want 1", len(entries))
+	}
+
+	loaded, err := LoadPurchaseSession(path)
+	if err != nil {
+		t.Fatalf("LoadPurchaseSession() error = %v", err)
+	}
+	if loaded.Request == nil || loaded.Request.Cart[0].Name != "Sol Ring" || loaded.Request.Model != OptimizerModelBalanced {
+		t.Errorf("request not restored: %+v", loaded.Request)
+	}
+	if loaded.Cart == nil || loaded.Cart.Totals.TotalCents != 100 {
+		t.Errorf("cart not restored: %+v", loaded.Cart)
+	}
+	if loaded.PendingOrder == nil || loaded.PendingOrder.ID != "pending" || loaded.PendingOrderRequest != nil {
+		t.Errorf("pending order not restored: %+v", loaded.PendingOrder)
+	}
+	if len(loaded.Events) != 3 || loaded.Events[1].Step != "pending_order_created" || loaded.Notes != "commander upgrades" {
+		t.Errorf("events not restored: %+v", loaded.Events)
+	}
+	if !loaded.UpdatedAt.Equal(session.UpdatedAt) {
+		t.Errorf("UpdatedAt = %v, want %v", loaded.UpdatedAt, session.UpdatedAt)
+	}
+}
+
+func TestPurchaseSession_Errors(t *testing.T) {
+	var valErr *ValidationError
+	if err := SavePurchaseSession("", &PurchaseSession{}); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for empty path, got %v", err)
+	}
+	if err := SavePurchaseSession(filepath.Join(t.TempDir(), "s.json"), nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for nil session, got %v", err)
+	}
+	if _, err := LoadPurchaseSession(""); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for empty path, got %v", err)
+	}
+	if _, err := LoadPurchaseSession(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPurchaseSession(path); err == nil {
+		t.Error("expected decode error")
+	}
+}
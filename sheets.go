@@ -0,0 +1,329 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSheetsURL is the Google Sheets API base URL used by SheetsClient.
+const DefaultSheetsURL = "https://sheets.googleapis.com/v4/"
+
+// SheetsClient reads and writes Google Sheets through the Sheets API v4, so
+// reprice plans and order exports can go straight to a spreadsheet instead of
+// through a CSV file. It only sends requests; authentication is left to
+// HTTPClient, which keeps OAuth libraries out of this module.
+//
+// Example:
+//
+//	// With golang.org/x/oauth2/google:
+//	creds, err := google.CredentialsFromJSON(ctx, keyJSON, "https://www.googleapis.com/auth/spreadsheets")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	sheets := &manapool.SheetsClient{HTTPClient: oauth2.NewClient(ctx, creds.TokenSource)}
+//	changes, err := client.PlanInventoryReprice(ctx, opts)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = sheets.WriteRepricePlan(ctx, spreadsheetID, "Reprice", changes)
+type SheetsClient struct {
+	// HTTPClient sends requests and must authenticate them with a token
+	// that has the spreadsheets scope. It is required.
+	HTTPClient *http.Client
+
+	// URL is the API base URL (default: DefaultSheetsURL).
+	URL string
+}
+
+// ReadRange returns the formatted values in rng, an A1 range such as
+// "Inventory!A1:F" or a sheet name. Trailing empty rows and cells are
+// omitted, as the Sheets API does.
+func (s *SheetsClient) ReadRange(ctx context.Context, spreadsheetID, rng string) ([][]string, error) {
+	var result struct {
+		Values [][]string `json:"values"`
+	}
+	if err := s.do(ctx, "GET", spreadsheetID, rng, "", nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rng, err)
+	}
+	return result.Values, nil
+}
+
+// WriteRange writes rows starting at the top left of rng. Values are stored
+// as given: numbers stay numbers and strings are not parsed as formulas.
+func (s *SheetsClient) WriteRange(ctx context.Context, spreadsheetID, rng string, rows [][]any) error {
+	body := sheetsValues{Range: rng, MajorDimension: "ROWS", Values: rows}
+	if err := s.do(ctx, "PUT", spreadsheetID, rng, "", body, nil); err != nil {
+		return fmt.Errorf("failed to write %s: %w", rng, err)
+	}
+	return nil
+}
+
+// AppendRows appends rows after the last row of the table in rng.
+func (s *SheetsClient) AppendRows(ctx context.Context, spreadsheetID, rng string, rows [][]any) error {
+	body := sheetsValues{Range: rng, MajorDimension: "ROWS", Values: rows}
+	if err := s.do(ctx, "POST", spreadsheetID, rng, ":append", body, nil); err != nil {
+		return fmt.Errorf("failed to append to %s: %w", rng, err)
+	}
+	return nil
+}
+
+// ClearRange clears the values in rng, keeping its formatting.
+func (s *SheetsClient) ClearRange(ctx context.Context, spreadsheetID, rng string) error {
+	if err := s.do(ctx, "POST", spreadsheetID, rng, ":clear", struct{}{}, nil); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", rng, err)
+	}
+	return nil
+}
+
+// repricePlanColumns is the header row of a reprice plan sheet.
+var repricePlanColumns = []any{
+	"Listing ID", "Product Type", "Product ID", "Name", "Set", "Condition", "Finish",
+	"Quantity", "Current Price", "Market Low", "New Price", "Change",
+}
+
+// WriteRepricePlan replaces the contents of sheet with changes, one row per
+// listing with prices in dollars. The sheet can be reviewed and edited, then
+// read back with ReadRepricePlan and applied with Client.ApplyReprice.
+func (s *SheetsClient) WriteRepricePlan(ctx context.Context, spreadsheetID, sheet string, changes []PriceChange) error {
+	rows := make([][]any, 0, len(changes)+1)
+	rows = append(rows, repricePlanColumns)
+	for _, change := range changes {
+		item := change.Item
+		var set, condition, finish string
+		if single := item.Product.Single; single != nil {
+			set, condition, finish = single.Set, single.ConditionID, single.FinishID
+		} else if sealed := item.Product.Sealed; sealed != nil {
+			set = sealed.Set
+		}
+		rows = append(rows, []any{
+			item.ID, string(item.ProductType), item.ProductID, productName(item.Product), set, condition, finish,
+			item.Quantity, sheetsDollars(item.PriceCents), sheetsDollars(change.MarketLowCents),
+			sheetsDollars(change.NewPriceCents), sheetsDollars(change.DeltaCents()),
+		})
+	}
+	return s.replaceSheet(ctx, spreadsheetID, sheet, rows)
+}
+
+// ReadRepricePlan reads a reprice plan written by WriteRepricePlan, picking
+// up any edits. Columns are found by header name, so they may be reordered
+// and others added. Rows whose New Price is blank are left out, which lets a
+// reviewer drop a change by clearing its price. Every other row needs a
+// Quantity, the stock the plan was made for.
+func (s *SheetsClient) ReadRepricePlan(ctx context.Context, spreadsheetID, sheet string) ([]PriceChange, error) {
+	rows, err := s.ReadRange(ctx, spreadsheetID, sheetsRange(sheet))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, NewValidationError("sheet", fmt.Sprintf("sheet %q is empty", sheet))
+	}
+
+	header := rows[0]
+	column := func(name string) int {
+		col, _ := buylistColumn(header, "", []string{name})
+		return col
+	}
+	typeCol, productCol, newPriceCol, qtyCol := column("Product Type"), column("Product ID"), column("New Price"), column("Quantity")
+	if typeCol < 0 || productCol < 0 || newPriceCol < 0 || qtyCol < 0 {
+		return nil, NewValidationError("sheet", "reprice plan needs Product Type, Product ID, New Price and Quantity columns")
+	}
+	idCol, nameCol, setCol := column("Listing ID"), column("Name"), column("Set")
+	conditionCol, finishCol := column("Condition"), column("Finish")
+	priceCol, marketCol := column("Current Price"), column("Market Low")
+
+	var changes []PriceChange
+	for i, row := range rows[1:] {
+		line := i + 2
+		field := func(col int) string {
+			if col < 0 || col >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[col])
+		}
+		cents := func(col int) (int, error) {
+			value := field(col)
+			if value == "" {
+				return 0, nil
+			}
+			n, err := parseDollarsToCents(value)
+			if err != nil {
+				return 0, NewValidationError("sheet", fmt.Sprintf("row %d: invalid price %q", line, value))
+			}
+			return n, nil
+		}
+
+		if field(newPriceCol) == "" || field(productCol) == "" {
+			continue
+		}
+		item := InventoryItem{
+			ID:          field(idCol),
+			ProductType: ProductType(field(typeCol)),
+			ProductID:   field(productCol),
+		}
+		item.Product = Product{Type: string(item.ProductType), ID: item.ProductID}
+		if item.ProductType == ProductTypeSealed {
+			item.Product.Sealed = &Sealed{Name: field(nameCol), Set: field(setCol)}
+		} else {
+			item.Product.Single = &Single{Name: field(nameCol), Set: field(setCol), ConditionID: field(conditionCol), FinishID: field(finishCol)}
+		}
+		qty := field(qtyCol)
+		if qty == "" {
+			return nil, NewValidationError("sheet", fmt.Sprintf("row %d: quantity is blank", line))
+		}
+		if item.Quantity, err = strconv.Atoi(strings.ReplaceAll(qty, ",", "")); err != nil || item.Quantity < 0 {
+			return nil, NewValidationError("sheet", fmt.Sprintf("row %d: invalid quantity %q", line, qty))
+		}
+
+		change := PriceChange{}
+		if item.PriceCents, err = cents(priceCol); err != nil {
+			return nil, err
+		}
+		if change.MarketLowCents, err = cents(marketCol); err != nil {
+			return nil, err
+		}
+		if change.NewPriceCents, err = cents(newPriceCol); err != nil {
+			return nil, err
+		}
+		change.Item = item
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// orderSheetColumns is the header row of an order export sheet.
+var orderSheetColumns = []any{"Order ID", "Created At", "Label", "Total", "Shipping Method", "Status"}
+
+// WriteOrders replaces the contents of sheet with orders, one row per order
+// with totals in dollars and times in RFC 3339 format.
+//
+// Example:
+//
+//	orders, err := client.GetSellerOrders(ctx, manapool.OrdersOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = sheets.WriteOrders(ctx, spreadsheetID, "Orders", orders.Orders)
+func (s *SheetsClient) WriteOrders(ctx context.Context, spreadsheetID, sheet string, orders []OrderSummary) error {
+	rows := make([][]any, 0, len(orders)+1)
+	rows = append(rows, orderSheetColumns)
+	for _, order := range orders {
+		status := StringValue(order.LatestFulfillmentStatus)
+		created := ""
+		if !order.CreatedAt.IsZero() {
+			created = order.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		rows = append(rows, []any{
+			order.ID, created, order.Label, sheetsDollars(order.TotalCents), string(order.ShippingMethod), status,
+		})
+	}
+	return s.replaceSheet(ctx, spreadsheetID, sheet, rows)
+}
+
+// replaceSheet clears sheet and writes rows from its first cell.
+func (s *SheetsClient) replaceSheet(ctx context.Context, spreadsheetID, sheet string, rows [][]any) error {
+	if sheet == "" {
+		return NewValidationError("sheet", "sheet name cannot be empty")
+	}
+	rng := sheetsRange(sheet)
+	if err := s.ClearRange(ctx, spreadsheetID, rng); err != nil {
+		return err
+	}
+	return s.WriteRange(ctx, spreadsheetID, rng+"!A1", rows)
+}
+
+// sheetsValues is the Sheets API ValueRange object.
+type sheetsValues struct {
+	Range          string  `json:"range"`
+	MajorDimension string  `json:"majorDimension"`
+	Values         [][]any `json:"values"`
+}
+
+// do sends a request for the values in rng. action is appended to the range
+// for custom methods such as ":clear"; a non-nil payload is sent as JSON and
+// the response is decoded into result when it is not nil.
+func (s *SheetsClient) do(ctx context.Context, method, spreadsheetID, rng, action string, payload, result any) error {
+	if s.HTTPClient == nil {
+		return NewValidationError("HTTPClient", "an authenticated HTTP client is required")
+	}
+	if spreadsheetID == "" {
+		return NewValidationError("spreadsheetID", "spreadsheet ID cannot be empty")
+	}
+	if rng == "" {
+		return NewValidationError("range", "range cannot be empty")
+	}
+
+	base := s.URL
+	if base == "" {
+		base = DefaultSheetsURL
+	}
+	endpoint := strings.TrimSuffix(base, "/") + "/spreadsheets/" + url.PathEscape(spreadsheetID) + "/values/" + url.PathEscape(rng) + action
+	switch {
+	case method == "PUT":
+		endpoint += "?valueInputOption=RAW"
+	case action == ":append":
+		endpoint += "?valueInputOption=RAW&insertDataOption=INSERT_ROWS"
+	}
+
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return NewNetworkError("failed to call the Sheets API", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		message := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error.Message != "" {
+			message = apiErr.Error.Message
+		}
+		return fmt.Errorf("sheets API status %d: %s", resp.StatusCode, message)
+	}
+	if result == nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode Sheets API response: %w", err)
+	}
+	return nil
+}
+
+// sheetsRange quotes a sheet name for use in an A1 range.
+func sheetsRange(sheet string) string {
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
+
+// sheetsDollars converts cents to a dollar amount for a numeric cell.
+func sheetsDollars(cents int) float64 {
+	return float64(cents) / 100
+}
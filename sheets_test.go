@@ -0,0 +1,216 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSheets is an in-memory Sheets API holding one spreadsheet whose
+// sheets are keyed by quoted sheet name.
+type fakeSheets struct {
+	mu     sync.Mutex
+	sheets map[string][][]any
+	calls  []string
+}
+
+func (f *fakeSheets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rest, ok := strings.CutPrefix(r.URL.Path, "/spreadsheets/sheet-1/values/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found."}}`))
+		return
+	}
+	rng, action, _ := strings.Cut(rest, ":")
+	sheet, _, _ := strings.Cut(rng, "!")
+	f.calls = append(f.calls, r.Method+" "+rest+" "+r.URL.RawQuery)
+
+	switch {
+	case r.Method == "GET":
+		values := [][]string{}
+		for _, row := range f.sheets[sheet] {
+			cells := []string{}
+			for _, cell := range row {
+				b, _ := json.Marshal(cell)
+				cells = append(cells, strings.Trim(string(b), `"`))
+			}
+			values = append(values, cells)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"range": rng, "values": values})
+	case action == "clear":
+		delete(f.sheets, sheet)
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		var body sheetsValues
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.sheets[sheet] = append(f.sheets[sheet], body.Values...)
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func newFakeSheets(t *testing.T) (*fakeSheets, *SheetsClient) {
+	t.Helper()
+	fake := &fakeSheets{sheets: map[string][][]any{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, &SheetsClient{HTTPClient: server.Client(), URL: server.URL + "/"}
+}
+
+func TestSheetsClient_RepricePlanRoundTrip(t *testing.T) {
+	fake, sheets := newFakeSheets(t)
+	fake.sheets["'Reprice'"] = [][]any{{"stale"}, {"rows"}, {"left over"}}
+	ctx := context.Background()
+
+	changes := []PriceChange{
+		{
+			Item:           InventoryItem{ID: "inv-1", ProductType: ProductTypeSingle, ProductID: "p1", PriceCents: 250, Quantity: 3, Product: Product{Single: &Single{Name: "Sol Ring", Set: "C21", ConditionID: "NM", FinishID: "NF"}}},
+			MarketLowCents: 199,
+			NewPriceCents:  199,
+		},
+		{
+			Item:           InventoryItem{ID: "inv-2", ProductType: ProductTypeSealed, ProductID: "p2", PriceCents: 10000, Quantity: 1, Product: Product{Sealed: &Sealed{Name: "Booster Box", Set: "MH3"}}},
+			MarketLowCents: 11000,
+			NewPriceCents:  10950,
+		},
+	}
+	if err := sheets.WriteRepricePlan(ctx, "sheet-1", "Reprice", changes); err != nil {
+		t.Fatalf("WriteRepricePlan() error = %v", err)
+	}
+	if rows := fake.sheets["'Reprice'"]; len(rows) != 3 || rows[0][0] != "Listing ID" {
+		t.Fatalf("sheet rows = %v", rows)
+	}
+	if got := fake.calls[1]; got != "PUT 'Reprice'!A1 valueInputOption=RAW" {
+		t.Errorf("write call = %q", got)
+	}
+
+	// A reviewer drops the second change and edits the first.
+	fake.sheets["'Reprice'"][1][10] = "$1.89"
+	fake.sheets["'Reprice'"][2][10] = ""
+
+	got, err := sheets.ReadRepricePlan(ctx, "sheet-1", "Reprice")
+	if err != nil {
+		t.Fatalf("ReadRepricePlan() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d changes, want 1", len(got))
+	}
+	change := got[0]
+	if change.Item.ID != "inv-1" || change.Item.ProductType != ProductTypeSingle || change.Item.ProductID != "p1" ||
+		change.Item.Quantity != 3 || change.Item.PriceCents != 250 || change.MarketLowCents != 199 || change.NewPriceCents != 189 {
+		t.Errorf("change = %+v", change)
+	}
+	if single := change.Item.Product.Single; single == nil || single.Name != "Sol Ring" || single.ConditionID != "NM" {
+		t.Errorf("product = %+v", change.Item.Product)
+	}
+}
+
+func TestSheetsClient_ReadRepricePlan_Errors(t *testing.T) {
+	fake, sheets := newFakeSheets(t)
+	ctx := context.Background()
+	var validationErr *ValidationError
+
+	if _, err := sheets.ReadRepricePlan(ctx, "sheet-1", "Empty"); !errors.As(err, &validationErr) {
+		t.Errorf("empty sheet error = %v, want ValidationError", err)
+	}
+	fake.sheets["'NoColumns'"] = [][]any{{"Name"}, {"Sol Ring"}}
+	if _, err := sheets.ReadRepricePlan(ctx, "sheet-1", "NoColumns"); !errors.As(err, &validationErr) {
+		t.Errorf("missing columns error = %v, want ValidationError", err)
+	}
+	fake.sheets["'NoQtyColumn'"] = [][]any{{"Product Type", "Product ID", "New Price"}, {"mtg_single", "p1", "1"}}
+	if _, err := sheets.ReadRepricePlan(ctx, "sheet-1", "NoQtyColumn"); !errors.As(err, &validationErr) {
+		t.Errorf("missing quantity column error = %v, want ValidationError", err)
+	}
+	fake.sheets["'BadPrice'"] = [][]any{{"Product Type", "Product ID", "New Price", "Quantity"}, {"mtg_single", "p1", "cheap", "1"}}
+	if _, err := sheets.ReadRepricePlan(ctx, "sheet-1", "BadPrice"); !errors.As(err, &validationErr) {
+		t.Errorf("bad price error = %v, want ValidationError", err)
+	}
+	fake.sheets["'BadQty'"] = [][]any{{"Product Type", "Product ID", "New Price", "Quantity"}, {"mtg_single", "p1", "1", "many"}}
+	if _, err := sheets.ReadRepricePlan(ctx, "sheet-1", "BadQty"); !errors.As(err, &validationErr) {
+		t.Errorf("bad quantity error = %v, want ValidationError", err)
+	}
+	fake.sheets["'BlankQty'"] = [][]any{{"Product Type", "Product ID", "New Price", "Quantity"}, {"mtg_single", "p1", "1", ""}}
+	if _, err := sheets.ReadRepricePlan(ctx, "sheet-1", "BlankQty"); !errors.As(err, &validationErr) {
+		t.Errorf("blank quantity error = %v, want ValidationError", err)
+	}
+}
+
+func TestSheetsClient_WriteOrders(t *testing.T) {
+	fake, sheets := newFakeSheets(t)
+	status := "shipped"
+	orders := []OrderSummary{
+		{ID: "o1", CreatedAt: Timestamp{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}, Label: "A1", TotalCents: 1234, ShippingMethod: ShippingMethodFirstClass, LatestFulfillmentStatus: &status},
+		{ID: "o2", TotalCents: 99},
+	}
+	if err := sheets.WriteOrders(context.Background(), "sheet-1", "Orders", orders); err != nil {
+		t.Fatalf("WriteOrders() error = %v", err)
+	}
+	rows := fake.sheets["'Orders'"]
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+	want := []any{"o1", "2024-05-01T12:00:00Z", "A1", 12.34, "first_class", "shipped"}
+	for i, cell := range want {
+		if rows[1][i] != cell {
+			t.Errorf("row 1 cell %d = %v, want %v", i, rows[1][i], cell)
+		}
+	}
+	if rows[2][1] != "" || rows[2][5] != "" {
+		t.Errorf("row 2 = %v", rows[2])
+	}
+}
+
+func TestSheetsClient_AppendRows(t *testing.T) {
+	fake, sheets := newFakeSheets(t)
+	fake.sheets["Log"] = [][]any{{"first"}}
+	if err := sheets.AppendRows(context.Background(), "sheet-1", "Log!A:A", [][]any{{"second"}}); err != nil {
+		t.Fatalf("AppendRows() error = %v", err)
+	}
+	if len(fake.sheets["Log"]) != 2 {
+		t.Errorf("rows = %v", fake.sheets["Log"])
+	}
+	if got := fake.calls[0]; got != "POST Log!A:A:append valueInputOption=RAW&insertDataOption=INSERT_ROWS" {
+		t.Errorf("append call = %q", got)
+	}
+}
+
+func TestSheetsClient_Errors(t *testing.T) {
+	_, sheets := newFakeSheets(t)
+	ctx := context.Background()
+
+	_, err := sheets.ReadRange(ctx, "other-sheet", "A1")
+	if err == nil || !strings.Contains(err.Error(), "Requested entity was not found.") {
+		t.Errorf("API error = %v", err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := (&SheetsClient{}).ReadRange(ctx, "sheet-1", "A1"); !errors.As(err, &validationErr) {
+		t.Errorf("nil HTTPClient error = %v, want ValidationError", err)
+	}
+	if _, err := sheets.ReadRange(ctx, "", "A1"); !errors.As(err, &validationErr) {
+		t.Errorf("empty spreadsheet error = %v, want ValidationError", err)
+	}
+	if _, err := sheets.ReadRange(ctx, "sheet-1", ""); !errors.As(err, &validationErr) {
+		t.Errorf("empty range error = %v, want ValidationError", err)
+	}
+	if err := sheets.WriteOrders(ctx, "sheet-1", "", nil); !errors.As(err, &validationErr) {
+		t.Errorf("empty sheet name error = %v, want ValidationError", err)
+	}
+}
+
+func TestSheetsRange(t *testing.T) {
+	if got := sheetsRange("Bob's Orders"); got != "'Bob''s Orders'" {
+		t.Errorf("sheetsRange() = %q", got)
+	}
+}
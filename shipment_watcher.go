@@ -0,0 +1,229 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultShipmentLookback is how far back ShipmentWatcher looks for buyer orders by default.
+const DefaultShipmentLookback = 30 * 24 * time.Hour
+
+// ShipmentEventType identifies a change to a seller sub-order's fulfillment.
+type ShipmentEventType string
+
+// Shipment event types.
+const (
+	// ShipmentShipped means the fulfillment became shipped or gained a tracking number.
+	ShipmentShipped ShipmentEventType = "shipped"
+
+	// ShipmentInTransit means the carrier reported the package in transit.
+	ShipmentInTransit ShipmentEventType = "in_transit"
+
+	// ShipmentDelivered means the fulfillment became delivered.
+	ShipmentDelivered ShipmentEventType = "delivered"
+
+	// ShipmentTrackingUpdated means the carrier or tracking number changed
+	// on a fulfillment that was already shipped.
+	ShipmentTrackingUpdated ShipmentEventType = "tracking_updated"
+)
+
+// ShipmentEvent is a fulfillment change on one seller's part of a buyer order.
+type ShipmentEvent struct {
+	Type ShipmentEventType
+
+	OrderID        string
+	OrderNumber    string
+	SellerID       string
+	SellerUsername string
+
+	// Fulfillment is the current fulfillment state.
+	Fulfillment BuyerOrderFulfillment
+}
+
+// ShipmentWatcher polls buyer orders and reports fulfillment changes per
+// seller sub-order. The first check records the current state without
+// reporting events unless EmitInitial is set, so restarting a watcher does
+// not replay old shipments. Orders are no longer fetched once every
+// fulfillment is delivered, refunded or replaced, and are forgotten once they
+// leave the lookback window.
+//
+// Example:
+//
+//	watcher := &manapool.ShipmentWatcher{Client: client}
+//	err := watcher.Run(ctx, 15*time.Minute, func(e manapool.ShipmentEvent) {
+//	    fmt.Printf("%s from %s is %s\n", e.OrderNumber, e.SellerUsername, e.Type)
+//	}, nil)
+type ShipmentWatcher struct {
+	// Client is used to list and fetch buyer orders.
+	Client *Client
+
+	// Lookback limits polling to orders created within this window (default: DefaultShipmentLookback).
+	Lookback time.Duration
+
+	// EmitInitial reports events for fulfillments already present on the first check.
+	EmitInitial bool
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	primed bool
+	seen   map[string]map[string]BuyerOrderFulfillment
+	done   map[string]bool
+}
+
+// Check fetches buyer orders and returns the fulfillment changes since the
+// previous check, ordered by order and seller.
+func (w *ShipmentWatcher) Check(ctx context.Context) ([]ShipmentEvent, error) {
+	if w.Client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	lookback := w.Lookback
+	if lookback <= 0 {
+		lookback = DefaultShipmentLookback
+	}
+	now := time.Now
+	if w.Now != nil {
+		now = w.Now
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen == nil {
+		w.seen = make(map[string]map[string]BuyerOrderFulfillment)
+		w.done = make(map[string]bool)
+	}
+
+	since := Timestamp{Time: now().Add(-lookback)}
+	listed := make(map[string]bool)
+	var ids []string
+	err := w.Client.IterateBuyerOrders(ctx, BuyerOrdersOptions{Since: &since}, func(order *BuyerOrderSummary) error {
+		listed[order.ID] = true
+		if !w.done[order.ID] {
+			ids = append(ids, order.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check shipments: %w", err)
+	}
+	sort.Strings(ids)
+
+	emit := w.primed || w.EmitInitial
+	var events []ShipmentEvent
+	for _, id := range ids {
+		resp, err := w.Client.GetBuyerOrder(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check shipments for order %s: %w", id, err)
+		}
+
+		seen := w.seen[id]
+		if seen == nil {
+			seen = make(map[string]BuyerOrderFulfillment)
+			w.seen[id] = seen
+		}
+		complete := len(resp.Order.OrderSellerDetail) > 0
+		for _, detail := range resp.Order.OrderSellerDetail {
+			if len(detail.Fulfillments) == 0 {
+				complete = false
+			}
+			for i, fulfillment := range detail.Fulfillments {
+				key := fmt.Sprintf("%s|%s|%d", detail.SellerID, detail.OrderNumber, i)
+				previous, ok := seen[key]
+				seen[key] = fulfillment
+				if !isFinalFulfillment(fulfillment) {
+					complete = false
+				}
+				if !emit {
+					continue
+				}
+				for _, eventType := range shipmentChanges(previous, ok, fulfillment) {
+					events = append(events, ShipmentEvent{
+						Type:           eventType,
+						OrderID:        id,
+						OrderNumber:    detail.OrderNumber,
+						SellerID:       detail.SellerID,
+						SellerUsername: detail.SellerUsername,
+						Fulfillment:    fulfillment,
+					})
+				}
+			}
+		}
+		if complete {
+			// Final fulfillments cannot change, so only the order ID is kept,
+			// to skip it while it is still listed.
+			w.done[id] = true
+			delete(w.seen, id)
+		}
+	}
+
+	// Forget orders that have left the lookback window.
+	for id := range w.seen {
+		if !listed[id] {
+			delete(w.seen, id)
+		}
+	}
+	for id := range w.done {
+		if !listed[id] {
+			delete(w.done, id)
+		}
+	}
+	w.primed = true
+
+	return events, nil
+}
+
+// Run calls Check every interval until ctx is cancelled, passing each event
+// to onEvent and each failed check's error to onError if it is not nil.
+func (w *ShipmentWatcher) Run(ctx context.Context, interval time.Duration, onEvent func(ShipmentEvent), onError func(error)) error {
+	if onEvent == nil {
+		return NewValidationError("onEvent", "event handler cannot be nil")
+	}
+
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		events, err := w.Check(ctx)
+		for _, event := range events {
+			onEvent(event)
+		}
+		return err
+	}, onError)
+}
+
+// shipmentChanges returns the events implied by a fulfillment moving from
+// previous (if known) to current.
+func shipmentChanges(previous BuyerOrderFulfillment, known bool, current BuyerOrderFulfillment) []ShipmentEventType {
+	prevStatus := FulfillmentStatus(StringValue(previous.Status))
+	status := FulfillmentStatus(StringValue(current.Status))
+	prevShipped := known && isShippedFulfillment(previous)
+
+	var events []ShipmentEventType
+	if isShippedFulfillment(current) && !prevShipped {
+		events = append(events, ShipmentShipped)
+	} else if prevShipped && (StringValue(previous.TrackingNumber) != StringValue(current.TrackingNumber) ||
+		StringValue(previous.TrackingCompany) != StringValue(current.TrackingCompany)) {
+		events = append(events, ShipmentTrackingUpdated)
+	}
+	if current.InTransitAt != nil && (!known || previous.InTransitAt == nil) {
+		events = append(events, ShipmentInTransit)
+	}
+	if status == FulfillmentStatusDelivered && (!known || prevStatus != FulfillmentStatusDelivered) {
+		events = append(events, ShipmentDelivered)
+	}
+	return events
+}
+
+func isShippedFulfillment(f BuyerOrderFulfillment) bool {
+	switch FulfillmentStatus(StringValue(f.Status)) {
+	case FulfillmentStatusShipped, FulfillmentStatusDelivered:
+		return true
+	}
+	return StringValue(f.TrackingNumber) != ""
+}
+
+func isFinalFulfillment(f BuyerOrderFulfillment) bool {
+	status := FulfillmentStatus(StringValue(f.Status))
+	return status == FulfillmentStatusDelivered || status.IsTerminal()
+}
@@ -0,0 +1,144 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShipmentWatcher_Check(t *testing.T) {
+	var mu sync.Mutex
+	details := map[string]string{
+		"o1": `{"order":{"id":"o1","order_seller_details":[
+			{"order_number":"1-1","seller_id":"s1","seller_username":"alpha","fulfillments":[{"status":"processing"}]},
+			{"order_number":"1-2","seller_id":"s2","seller_username":"beta","fulfillments":[{"status":"shipped","tracking_number":"T1","tracking_company":"usps"}]}]}}`,
+		"o2": `{"order":{"id":"o2","order_seller_details":[
+			{"order_number":"2-1","seller_id":"s1","seller_username":"alpha","fulfillments":[{"status":"delivered"}]}]}}`,
+	}
+	fetches := map[string]int{}
+	listing := `{"orders":[{"id":"o2"},{"id":"o1"}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/buyer/orders":
+			if r.URL.Query().Get("since") == "" {
+				t.Errorf("missing since")
+			}
+			_, _ = w.Write([]byte(listing))
+		case len(r.URL.Path) > len("/buyer/orders/"):
+			id := r.URL.Path[len("/buyer/orders/"):]
+			fetches[id]++
+			_, _ = w.Write([]byte(details[id]))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	watcher := &ShipmentWatcher{Client: client}
+	ctx := context.Background()
+
+	events, err := watcher.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("first check emitted %d events, want 0", len(events))
+	}
+
+	mu.Lock()
+	details["o1"] = `{"order":{"id":"o1","order_seller_details":[
+		{"order_number":"1-1","seller_id":"s1","seller_username":"alpha","fulfillments":[{"status":"shipped","tracking_number":"T2","in_transit_at":"2024-04-02T00:00:00Z"}]},
+		{"order_number":"1-2","seller_id":"s2","seller_username":"beta","fulfillments":[{"status":"delivered","tracking_number":"T9","tracking_company":"usps"}]}]}}`
+	mu.Unlock()
+
+	events, err = watcher.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	want := []struct {
+		typ    ShipmentEventType
+		number string
+	}{
+		{ShipmentShipped, "1-1"},
+		{ShipmentInTransit, "1-1"},
+		{ShipmentTrackingUpdated, "1-2"},
+		{ShipmentDelivered, "1-2"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want %d", events, len(want))
+	}
+	for i, w := range want {
+		if events[i].Type != w.typ || events[i].OrderNumber != w.number || events[i].OrderID != "o1" {
+			t.Errorf("event %d = %+v, want %s for %s", i, events[i], w.typ, w.number)
+		}
+	}
+	if events[0].SellerUsername != "alpha" || StringValue(events[0].Fulfillment.TrackingNumber) != "T2" {
+		t.Errorf("unexpected event details: %+v", events[0])
+	}
+
+	mu.Lock()
+	if fetches["o2"] != 1 {
+		t.Errorf("delivered order fetched %d times, want 1", fetches["o2"])
+	}
+	listing = `{"orders":[{"id":"o1"}]}`
+	mu.Unlock()
+	if _, ok := watcher.seen["o2"]; ok || !watcher.done["o2"] {
+		t.Errorf("delivered order state not pruned: seen=%v done=%v", watcher.seen, watcher.done)
+	}
+
+	if _, err := watcher.Check(ctx); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(watcher.done) != 0 || len(watcher.seen) != 1 {
+		t.Errorf("unlisted order not forgotten: seen=%v done=%v", watcher.seen, watcher.done)
+	}
+}
+
+func TestShipmentWatcher_EmitInitialAndRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/orders":
+			_, _ = w.Write([]byte(`{"orders":[{"id":"o1"}]}`))
+		case "/buyer/orders/o1":
+			_, _ = w.Write([]byte(`{"order":{"id":"o1","order_seller_details":[{"order_number":"1-1","seller_id":"s1","fulfillments":[{"status":"delivered"}]}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	watcher := &ShipmentWatcher{Client: client, EmitInitial: true, Lookback: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var events []ShipmentEvent
+	err := watcher.Run(ctx, time.Hour, func(e ShipmentEvent) {
+		events = append(events, e)
+		cancel()
+	}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want context.Canceled", err)
+	}
+	if len(events) != 2 || events[0].Type != ShipmentShipped || events[1].Type != ShipmentDelivered {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	var valErr *ValidationError
+	if err := watcher.Run(context.Background(), 0, func(ShipmentEvent) {}, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for interval, got %v", err)
+	}
+	if err := watcher.Run(context.Background(), time.Second, nil, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for handler, got %v", err)
+	}
+	if _, err := (&ShipmentWatcher{}).Check(context.Background()); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for client, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package manapool
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// ShippingMethod is a shipping method used for orders and shipping overrides.
+type ShippingMethod string
+
+// Shipping methods accepted by the API, from lowest to highest service level.
+const (
+	ShippingMethodFirstClass      ShippingMethod = "first_class"
+	ShippingMethodGroundAdvantage ShippingMethod = "ground_advantage"
+)
+
+// IsValid reports whether m is a shipping method known to the API.
+func (m ShippingMethod) IsValid() bool {
+	switch m {
+	case ShippingMethodFirstClass, ShippingMethodGroundAdvantage:
+		return true
+	}
+	return false
+}
+
+// String implements fmt.Stringer.
+func (m ShippingMethod) String() string {
+	return string(m)
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateShippingOverrides checks that every override is keyed by a seller
+// UUID and names a known shipping method.
+func ValidateShippingOverrides(overrides map[string]string) error {
+	sellerIDs := make([]string, 0, len(overrides))
+	for sellerID := range overrides {
+		sellerIDs = append(sellerIDs, sellerID)
+	}
+	sort.Strings(sellerIDs)
+
+	for _, sellerID := range sellerIDs {
+		if !uuidPattern.MatchString(sellerID) {
+			return NewValidationError("shipping_overrides", fmt.Sprintf("seller ID %q is not a UUID", sellerID))
+		}
+		if method := ShippingMethod(overrides[sellerID]); !method.IsValid() {
+			return NewValidationError("shipping_overrides", fmt.Sprintf("unknown shipping method %q for seller %s", method, sellerID))
+		}
+	}
+	return nil
+}
+
+// SetShippingOverride upgrades the shipping method used for a seller's
+// portion of the order. The API ignores overrides below the method Manapool
+// already requires for that seller.
+func (r *PendingOrderRequest) SetShippingOverride(sellerID string, method ShippingMethod) error {
+	if !uuidPattern.MatchString(sellerID) {
+		return NewValidationError("seller_id", fmt.Sprintf("seller ID %q is not a UUID", sellerID))
+	}
+	if !method.IsValid() {
+		return NewValidationError("shipping_method", fmt.Sprintf("unknown shipping method %q", method))
+	}
+	if r.ShippingOverrides == nil {
+		r.ShippingOverrides = make(map[string]string)
+	}
+	r.ShippingOverrides[sellerID] = string(method)
+	return nil
+}
+
+// ClearShippingOverride removes any shipping override for a seller.
+func (r *PendingOrderRequest) ClearShippingOverride(sellerID string) {
+	delete(r.ShippingOverrides, sellerID)
+	if len(r.ShippingOverrides) == 0 {
+		r.ShippingOverrides = nil
+	}
+}
+
+// ShippingOverride returns the shipping override for a seller, if any.
+func (r PendingOrderRequest) ShippingOverride(sellerID string) (ShippingMethod, bool) {
+	method, ok := r.ShippingOverrides[sellerID]
+	return ShippingMethod(method), ok
+}
@@ -0,0 +1,91 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testSellerID = "f4b3b9c5-250e-4c3a-815d-6e41577f28e3"
+
+func TestShippingMethod_IsValid(t *testing.T) {
+	if !ShippingMethodFirstClass.IsValid() || !ShippingMethodGroundAdvantage.IsValid() {
+		t.Error("known shipping methods reported invalid")
+	}
+	if ShippingMethod("overnight").IsValid() {
+		t.Error("unknown shipping method reported valid")
+	}
+}
+
+func TestPendingOrderRequest_ShippingOverrides(t *testing.T) {
+	var req PendingOrderRequest
+
+	if err := req.SetShippingOverride(testSellerID, ShippingMethodGroundAdvantage); err != nil {
+		t.Fatalf("SetShippingOverride error: %v", err)
+	}
+	if method, ok := req.ShippingOverride(testSellerID); !ok || method != ShippingMethodGroundAdvantage {
+		t.Fatalf("ShippingOverride = %q, %v", method, ok)
+	}
+	if err := ValidateShippingOverrides(req.ShippingOverrides); err != nil {
+		t.Fatalf("ValidateShippingOverrides error: %v", err)
+	}
+
+	var valErr *ValidationError
+	if err := req.SetShippingOverride("seller", ShippingMethodFirstClass); !errors.As(err, &valErr) || valErr.Field != "seller_id" {
+		t.Fatalf("expected seller_id ValidationError, got %v", err)
+	}
+	if err := req.SetShippingOverride(testSellerID, "overnight"); !errors.As(err, &valErr) || valErr.Field != "shipping_method" {
+		t.Fatalf("expected shipping_method ValidationError, got %v", err)
+	}
+
+	req.ClearShippingOverride(testSellerID)
+	if req.ShippingOverrides != nil {
+		t.Fatalf("ShippingOverrides = %v, want nil", req.ShippingOverrides)
+	}
+	if _, ok := req.ShippingOverride(testSellerID); ok {
+		t.Fatal("override still present after clear")
+	}
+}
+
+func TestValidateShippingOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		wantErr   bool
+	}{
+		{"nil", nil, false},
+		{"valid", map[string]string{testSellerID: "first_class"}, false},
+		{"bad seller", map[string]string{"not-a-uuid": "first_class"}, true},
+		{"bad method", map[string]string{testSellerID: "priority"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateShippingOverrides(tt.overrides)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateShippingOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_PendingOrder_ValidatesOverrides(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := PendingOrderRequest{ShippingOverrides: map[string]string{testSellerID: "teleport"}}
+
+	var valErr *ValidationError
+	if _, err := client.CreatePendingOrder(ctx, req); !errors.As(err, &valErr) {
+		t.Fatalf("CreatePendingOrder: expected ValidationError, got %v", err)
+	}
+	if _, err := client.UpdatePendingOrder(ctx, "123", req); !errors.As(err, &valErr) {
+		t.Fatalf("UpdatePendingOrder: expected ValidationError, got %v", err)
+	}
+}
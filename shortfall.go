@@ -0,0 +1,234 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ShortfallReason is the likely reason a cart item was not fully filled.
+type ShortfallReason string
+
+// Shortfall reasons.
+const (
+	// ShortfallNoStock means no listings were found for an unrestricted item.
+	ShortfallNoStock ShortfallReason = "no_stock"
+
+	// ShortfallLimitedStock means some, but not enough, copies were found for
+	// an unrestricted item.
+	ShortfallLimitedStock ShortfallReason = "limited_stock"
+
+	// ShortfallFiltersTooStrict means the item or request has printing,
+	// finish, condition, language or seller restrictions that may have
+	// excluded available copies.
+	ShortfallFiltersTooStrict ShortfallReason = "filters_too_strict"
+)
+
+// ShortfallItem is a requested cart item that was not fully filled.
+type ShortfallItem struct {
+	// Index is the item's position in the request cart.
+	Index     int
+	Item      OptimizerCartItem
+	Requested int
+	Filled    int
+	Reason    ShortfallReason
+}
+
+// Missing returns the number of copies not filled.
+func (s ShortfallItem) Missing() int {
+	return s.Requested - s.Filled
+}
+
+// ShortfallReport lists the cart items an optimizer result did not fully fill.
+type ShortfallReport struct {
+	Items []ShortfallItem
+}
+
+// Complete reports whether every requested item was filled.
+func (r *ShortfallReport) Complete() bool {
+	return len(r.Items) == 0
+}
+
+// MissingQuantity returns the total number of copies not filled.
+func (r *ShortfallReport) MissingQuantity() int {
+	total := 0
+	for _, item := range r.Items {
+		total += item.Missing()
+	}
+	return total
+}
+
+// ShortfallReport compares an optimized cart with the request that produced
+// it and reports the items that received fewer copies than requested. Selected
+// listings are fetched to match them to requested items by product ID, SKU,
+// MTGJSON ID or name, set and collector number.
+//
+// Example:
+//
+//	cart, err := client.OptimizeCart(ctx, req)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	report, err := client.ShortfallReport(ctx, req, cart)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range report.Items {
+//	    fmt.Printf("%s: %d of %d (%s)\n", s.Item.Name, s.Filled, s.Requested, s.Reason)
+//	}
+func (c *Client) ShortfallReport(ctx context.Context, req OptimizerRequest, cart *OptimizedCart) (*ShortfallReport, error) {
+	if cart == nil {
+		return nil, NewValidationError("cart", "optimized cart cannot be nil")
+	}
+
+	listings, remaining, err := c.selectedListings(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ShortfallReport{}
+	for i, item := range req.Cart {
+		filled := 0
+		for _, listing := range listings {
+			if filled >= item.QuantityRequested {
+				break
+			}
+			if remaining[listing.ID] == 0 || !cartItemMatches(item, listing) {
+				continue
+			}
+			take := item.QuantityRequested - filled
+			if take > remaining[listing.ID] {
+				take = remaining[listing.ID]
+			}
+			remaining[listing.ID] -= take
+			filled += take
+		}
+		if filled < item.QuantityRequested {
+			report.Items = append(report.Items, newShortfallItem(req, i, filled))
+		}
+	}
+	return report, nil
+}
+
+// ShortfallReportFromError builds a ShortfallReport from an optimizer
+// conflict error, using each item's reported available quantity as Filled.
+// It returns false if err is not an optimizer conflict.
+func ShortfallReportFromError(req OptimizerRequest, err error) (*ShortfallReport, bool) {
+	shortages, ok := OptimizerShortages(err)
+	if !ok {
+		return nil, false
+	}
+
+	report := &ShortfallReport{}
+	for _, shortage := range shortages {
+		index := -1
+		if shortage.Item.Index != nil {
+			index = *shortage.Item.Index
+		}
+		if index >= 0 && index < len(req.Cart) {
+			report.Items = append(report.Items, newShortfallItem(req, index, shortage.TotalAvailable))
+			continue
+		}
+		item := shortage.Item
+		report.Items = append(report.Items, ShortfallItem{
+			Index:     index,
+			Item:      item,
+			Requested: item.QuantityRequested,
+			Filled:    shortage.TotalAvailable,
+			Reason:    shortfallReason(req, item, shortage.TotalAvailable),
+		})
+	}
+	return report, true
+}
+
+func newShortfallItem(req OptimizerRequest, index, filled int) ShortfallItem {
+	item := req.Cart[index]
+	return ShortfallItem{
+		Index:     index,
+		Item:      item,
+		Requested: item.QuantityRequested,
+		Filled:    filled,
+		Reason:    shortfallReason(req, item, filled),
+	}
+}
+
+func shortfallReason(req OptimizerRequest, item OptimizerCartItem, filled int) ShortfallReason {
+	switch {
+	case hasPrintingRestriction(item) || len(item.ConditionIDs) > 0 || len(item.LanguageIDs) > 0 ||
+		len(req.AllowSellerIDs) > 0 || len(req.ExcludeSellerIDs) > 0 || len(req.ShipFromCountries) > 0:
+		return ShortfallFiltersTooStrict
+	case filled == 0:
+		return ShortfallNoStock
+	default:
+		return ShortfallLimitedStock
+	}
+}
+
+// selectedListings fetches the listings in an optimized cart and returns them
+// with the quantity selected from each, keyed by inventory ID.
+func (c *Client) selectedListings(ctx context.Context, cart *OptimizedCart) ([]InventoryItem, map[string]int, error) {
+	ids := make([]string, 0, len(cart.Cart))
+	selected := make(map[string]int, len(cart.Cart))
+	for _, item := range cart.Cart {
+		if _, ok := selected[item.InventoryID]; !ok {
+			ids = append(ids, item.InventoryID)
+		}
+		selected[item.InventoryID] += item.QuantitySelected
+	}
+	if len(ids) == 0 {
+		return nil, selected, nil
+	}
+
+	resp, err := c.GetInventoryListings(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch optimized cart listings: %w", err)
+	}
+	return resp.InventoryItems, selected, nil
+}
+
+// cartItemMatches reports whether listing satisfies the identifying fields
+// of a requested cart item.
+func cartItemMatches(item OptimizerCartItem, listing InventoryItem) bool {
+	if len(item.ProductIDs) > 0 {
+		return containsString(item.ProductIDs, listing.ProductID) || containsString(item.ProductIDs, listing.Product.ID)
+	}
+	if len(item.TCGPlayerSKUIds) > 0 {
+		return listing.Product.TCGPlayerSKU != nil && containsInt64(item.TCGPlayerSKUIds, *listing.Product.TCGPlayerSKU)
+	}
+
+	single := listing.Product.Single
+	if item.MTGJsonID != nil {
+		return single != nil && single.MTGJsonID == *item.MTGJsonID
+	}
+	if item.Name == "" || collectionKey(item.Name) != collectionKey(productName(listing.Product)) {
+		return false
+	}
+	if single == nil {
+		return item.SetCode == "" && item.CollectorNumber == ""
+	}
+	if item.SetCode != "" && !strings.EqualFold(item.SetCode, single.Set) {
+		return false
+	}
+	if item.CollectorNumber != "" && !strings.EqualFold(item.CollectorNumber, single.Number) {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt64(values []int64, n int64) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ShortfallReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/inventory/listings" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"inventory_items":[
+			{"id":"inv-bolt","product_id":"p1","product":{"type":"mtg_single","id":"p1","single":{"name":"Lightning Bolt","set":"M10","number":"146"}},"price_cents":200,"quantity":10},
+			{"id":"inv-sol","product_id":"p2","product":{"type":"mtg_single","id":"p2","single":{"name":"Sol Ring","set":"C21","number":"263"}},"price_cents":150,"quantity":10}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	req := OptimizerRequest{Cart: []OptimizerCartItem{
+		{Type: "mtg_single", Name: "Lightning Bolt", QuantityRequested: 4},
+		{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 1},
+		{Type: "mtg_single", Name: "Black Lotus", QuantityRequested: 1},
+		{Type: "mtg_single", Name: "Mox Pearl", ConditionIDs: []string{"NM"}, QuantityRequested: 1},
+	}}
+	cart := &OptimizedCart{Cart: []OptimizedCartItem{
+		{InventoryID: "inv-bolt", QuantitySelected: 3},
+		{InventoryID: "inv-sol", QuantitySelected: 1},
+	}}
+
+	report, err := client.ShortfallReport(context.Background(), req, cart)
+	if err != nil {
+		t.Fatalf("ShortfallReport() error = %v", err)
+	}
+	if report.Complete() || len(report.Items) != 3 {
+		t.Fatalf("unexpected report: %+v", report.Items)
+	}
+	want := []struct {
+		index, filled int
+		reason        ShortfallReason
+	}{
+		{0, 3, ShortfallLimitedStock},
+		{2, 0, ShortfallNoStock},
+		{3, 0, ShortfallFiltersTooStrict},
+	}
+	for i, w := range want {
+		got := report.Items[i]
+		if got.Index != w.index || got.Filled != w.filled || got.Reason != w.reason {
+			t.Errorf("item %d = %+v, want index %d filled %d reason %s", i, got, w.index, w.filled, w.reason)
+		}
+	}
+	if report.MissingQuantity() != 3 {
+		t.Errorf("MissingQuantity() = %d, want 3", report.MissingQuantity())
+	}
+
+	var valErr *ValidationError
+	if _, err := client.ShortfallReport(context.Background(), req, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for nil cart, got %v", err)
+	}
+}
+
+func TestShortfallReportFromError(t *testing.T) {
+	req := OptimizerRequest{Cart: []OptimizerCartItem{
+		{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 1},
+		{Type: "mtg_single", Name: "Black Lotus", QuantityRequested: 2},
+	}}
+	details, _ := json.Marshal([]map[string]any{
+		{"item": map[string]any{"type": "mtg_single", "name": "Black Lotus", "quantity_requested": 2, "index": 1}, "total_available": 1},
+	})
+	err := &APIError{StatusCode: http.StatusConflict, Message: "unable to fill cart", Details: details}
+
+	report, ok := ShortfallReportFromError(req, err)
+	if !ok {
+		t.Fatal("ShortfallReportFromError() returned false for conflict")
+	}
+	if len(report.Items) != 1 || report.Items[0].Index != 1 || report.Items[0].Filled != 1 || report.Items[0].Reason != ShortfallLimitedStock {
+		t.Errorf("unexpected report: %+v", report.Items)
+	}
+
+	if _, ok := ShortfallReportFromError(req, errors.New("boom")); ok {
+		t.Error("ShortfallReportFromError() returned true for non-conflict error")
+	}
+}
@@ -0,0 +1,174 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultSingleSellerCandidates is the number of sellers from the buyer's
+// order history tried by CompareSingleSeller when no sellers are given.
+const DefaultSingleSellerCandidates = 10
+
+// SingleSellerOptions controls CompareSingleSeller.
+type SingleSellerOptions struct {
+	// SellerIDs lists the sellers to try. The API cannot search for sellers
+	// that stock a whole cart, so when empty the sellers the buyer has spent
+	// the most with are tried instead.
+	SellerIDs []string
+
+	// MaxCandidates limits the sellers taken from order history
+	// (default: DefaultSingleSellerCandidates).
+	MaxCandidates int
+
+	// HistorySince limits the order history searched for sellers. Zero
+	// searches all orders.
+	HistorySince time.Time
+}
+
+// SingleSellerCandidate is the result of optimizing the cart with one seller.
+type SingleSellerCandidate struct {
+	SellerID string
+
+	// Cart is nil if the seller could not fill the cart.
+	Cart *OptimizedCart
+	Err  error
+
+	// Shortfall lists the items the seller could only partly fill, or is
+	// nil if the optimizer failed or the cart was filled.
+	Shortfall *ShortfallReport
+}
+
+// SingleSellerComparison is the result of CompareSingleSeller.
+type SingleSellerComparison struct {
+	// Optimized is the unrestricted optimizer result.
+	Optimized *OptimizedCart
+
+	// SellerID and SingleSeller are the cheapest single-seller cart, or
+	// empty and nil if no candidate could fill the cart.
+	SellerID     string
+	SingleSeller *OptimizedCart
+
+	// DeltaCents is the single-seller total minus the optimized total: the
+	// premium paid for receiving one package.
+	DeltaCents int
+
+	// Candidates lists every seller tried, in order.
+	Candidates []SingleSellerCandidate
+}
+
+// Found reports whether any candidate seller could fill the whole cart.
+func (c *SingleSellerComparison) Found() bool {
+	return c.SingleSeller != nil
+}
+
+// CompareSingleSeller optimizes req normally and again restricted to each
+// candidate seller, and reports the cheapest seller that can fill the whole
+// cart along with the price difference from the multi-seller result.
+// Each single-seller cart is checked against req.Cart with ShortfallReport;
+// candidates that cannot fill every requested copy are recorded with their
+// error.
+//
+// Example:
+//
+//	cmp, err := client.CompareSingleSeller(ctx, req, manapool.SingleSellerOptions{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if cmp.Found() {
+//	    fmt.Printf("One package from %s costs $%.2f more\n", cmp.SellerID, float64(cmp.DeltaCents)/100)
+//	}
+func (c *Client) CompareSingleSeller(ctx context.Context, req OptimizerRequest, opts SingleSellerOptions) (*SingleSellerComparison, error) {
+	if len(req.Cart) == 0 {
+		return nil, NewValidationError("cart", "cart cannot be empty")
+	}
+	if opts.MaxCandidates < 0 {
+		return nil, NewValidationError("max_candidates", "max candidates cannot be negative")
+	}
+
+	optimized, err := c.OptimizeCart(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := &SingleSellerComparison{Optimized: optimized}
+
+	sellers := opts.SellerIDs
+	if len(sellers) == 0 {
+		sellers, err = c.historySellerIDs(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sellerID := range sellers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if containsString(req.ExcludeSellerIDs, sellerID) {
+			continue
+		}
+
+		candidate := SingleSellerCandidate{SellerID: sellerID}
+		candidate.Cart, candidate.Shortfall, candidate.Err = c.singleSellerCart(ctx, req, sellerID)
+		if cart := candidate.Cart; cart != nil {
+			if result.SingleSeller == nil || cart.Totals.TotalCents < result.SingleSeller.Totals.TotalCents {
+				result.SellerID = sellerID
+				result.SingleSeller = cart
+			}
+		}
+		result.Candidates = append(result.Candidates, candidate)
+	}
+
+	if result.SingleSeller != nil {
+		result.DeltaCents = result.SingleSeller.Totals.TotalCents - optimized.Totals.TotalCents
+	}
+	return result, nil
+}
+
+// singleSellerCart optimizes req restricted to sellerID and checks that the
+// result fills every requested copy. Partial fills are returned as an error
+// along with their shortfall report.
+func (c *Client) singleSellerCart(ctx context.Context, req OptimizerRequest, sellerID string) (*OptimizedCart, *ShortfallReport, error) {
+	single := req
+	single.AllowSellerIDs = []string{sellerID}
+	cart, err := c.OptimizeCart(ctx, single)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cart.Totals.SellerCount > 1 {
+		return nil, nil, fmt.Errorf("optimizer returned %d sellers for seller %s", cart.Totals.SellerCount, sellerID)
+	}
+
+	report, err := c.ShortfallReport(ctx, single, cart)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check cart for seller %s: %w", sellerID, err)
+	}
+	if !report.Complete() {
+		return nil, report, fmt.Errorf("seller %s is missing %d requested copies", sellerID, report.MissingQuantity())
+	}
+	return cart, nil, nil
+}
+
+// historySellerIDs returns the sellers the buyer has spent the most with.
+func (c *Client) historySellerIDs(ctx context.Context, opts SingleSellerOptions) ([]string, error) {
+	limit := opts.MaxCandidates
+	if limit == 0 {
+		limit = DefaultSingleSellerCandidates
+	}
+
+	spends, err := c.BuyerSpendBySeller(ctx, opts.HistorySince, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find candidate sellers: %w", err)
+	}
+
+	var ids []string
+	for _, spend := range spends {
+		if len(ids) == limit {
+			break
+		}
+		if spend.SellerID != "" {
+			ids = append(ids, spend.SellerID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,107 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_CompareSingleSeller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/buyer/orders":
+			_, _ = w.Write([]byte(buyerSellerOrdersJSON))
+		case "/buyer/optimizer":
+			var req OptimizerRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("failed to decode optimizer request: %v", err)
+			}
+			if len(req.AllowSellerIDs) == 0 {
+				_, _ = w.Write([]byte(`{"cart":[],"totals":{"subtotal_cents":1000,"shipping_cents":300,"total_cents":1300,"seller_count":2}}`))
+				return
+			}
+			switch req.AllowSellerIDs[0] {
+			case "s1":
+				_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv-s1","quantity_selected":1}],"totals":{"subtotal_cents":1200,"shipping_cents":150,"total_cents":1350,"seller_count":1}}`))
+			case "s2":
+				_, _ = w.Write([]byte(`{"cart":[{"inventory_id":"inv-s2","quantity_selected":1}],"totals":{"subtotal_cents":1400,"shipping_cents":150,"total_cents":1550,"seller_count":1}}`))
+			case "s4":
+				_, _ = w.Write([]byte(`{"cart":[],"totals":{"subtotal_cents":0,"shipping_cents":0,"total_cents":0,"seller_count":0}}`))
+			default:
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"error":"unable to fill cart"}`))
+			}
+		case "/inventory/listings":
+			_, _ = w.Write([]byte(`{"inventory_items":[
+				{"id":"inv-s1","product_id":"p1","product":{"type":"mtg_single","id":"p1","single":{"name":"Sol Ring"}},"price_cents":1200,"quantity":1},
+				{"id":"inv-s2","product_id":"p1","product":{"type":"mtg_single","id":"p1","single":{"name":"Sol Ring"}},"price_cents":1400,"quantity":1}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+	req := OptimizerRequest{Cart: []OptimizerCartItem{{Type: "mtg_single", Name: "Sol Ring", QuantityRequested: 1}}}
+
+	t.Run("explicit sellers", func(t *testing.T) {
+		cmp, err := client.CompareSingleSeller(ctx, req, SingleSellerOptions{SellerIDs: []string{"s2", "s3", "s1"}})
+		if err != nil {
+			t.Fatalf("CompareSingleSeller() error = %v", err)
+		}
+		if !cmp.Found() || cmp.SellerID != "s1" || cmp.DeltaCents != 50 {
+			t.Errorf("unexpected comparison: seller %q, delta %d", cmp.SellerID, cmp.DeltaCents)
+		}
+		if len(cmp.Candidates) != 3 || cmp.Candidates[1].Err == nil || cmp.Candidates[1].Cart != nil {
+			t.Errorf("unexpected candidates: %+v", cmp.Candidates)
+		}
+	})
+
+	t.Run("sellers from order history", func(t *testing.T) {
+		excluding := req
+		excluding.ExcludeSellerIDs = []string{"s1"}
+		cmp, err := client.CompareSingleSeller(ctx, excluding, SingleSellerOptions{})
+		if err != nil {
+			t.Fatalf("CompareSingleSeller() error = %v", err)
+		}
+		if cmp.SellerID != "s2" || cmp.DeltaCents != 250 || len(cmp.Candidates) != 1 {
+			t.Errorf("unexpected comparison: seller %q, delta %d, candidates %+v", cmp.SellerID, cmp.DeltaCents, cmp.Candidates)
+		}
+	})
+
+	t.Run("no seller can fill", func(t *testing.T) {
+		cmp, err := client.CompareSingleSeller(ctx, req, SingleSellerOptions{SellerIDs: []string{"s3"}})
+		if err != nil {
+			t.Fatalf("CompareSingleSeller() error = %v", err)
+		}
+		if cmp.Found() || cmp.DeltaCents != 0 {
+			t.Errorf("unexpected comparison: %+v", cmp)
+		}
+	})
+
+	t.Run("partial fill", func(t *testing.T) {
+		cmp, err := client.CompareSingleSeller(ctx, req, SingleSellerOptions{SellerIDs: []string{"s4"}})
+		if err != nil {
+			t.Fatalf("CompareSingleSeller() error = %v", err)
+		}
+		candidate := cmp.Candidates[0]
+		if cmp.Found() || candidate.Err == nil || candidate.Shortfall == nil || candidate.Shortfall.MissingQuantity() != 1 {
+			t.Errorf("unexpected candidate: %+v", candidate)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.CompareSingleSeller(ctx, OptimizerRequest{}, SingleSellerOptions{}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty cart, got %v", err)
+		}
+		if _, err := client.CompareSingleSeller(ctx, req, SingleSellerOptions{MaxCandidates: -1}); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for max candidates, got %v", err)
+		}
+	})
+}
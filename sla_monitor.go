@@ -0,0 +1,124 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultSLAThreshold is the default age after which an unfulfilled order is flagged.
+const DefaultSLAThreshold = 48 * time.Hour
+
+// SLABreach is an unfulfilled order older than the monitor threshold.
+type SLABreach struct {
+	Order OrderSummary
+	Age   time.Duration
+}
+
+// SLAReport is the result of a single SLA check.
+type SLAReport struct {
+	CheckedAt time.Time
+	Threshold time.Duration
+	Checked   int
+	Breaches  []SLABreach
+}
+
+// SLAMonitor flags seller orders that have been unfulfilled for longer than
+// Threshold. When Notifier is set, a notification is sent for every check
+// that finds at least one breach.
+//
+// Example:
+//
+//	monitor := &manapool.SLAMonitor{
+//	    Client:    client,
+//	    Threshold: 36 * time.Hour,
+//	    Notifier:  notifier,
+//	}
+//	report, err := monitor.Check(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, b := range report.Breaches {
+//	    fmt.Printf("%s unfulfilled for %s\n", b.Order.Label, b.Age.Round(time.Hour))
+//	}
+type SLAMonitor struct {
+	// Client is used to list unfulfilled seller orders.
+	Client *Client
+
+	// Threshold is the maximum allowed unfulfilled age (default: DefaultSLAThreshold).
+	Threshold time.Duration
+
+	// Notifier, if set, is called with a summary whenever breaches are found.
+	Notifier Notifier
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+}
+
+// Check lists unfulfilled seller orders and reports those older than the threshold,
+// oldest first.
+func (m *SLAMonitor) Check(ctx context.Context) (*SLAReport, error) {
+	if m.Client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	threshold := m.Threshold
+	if threshold <= 0 {
+		threshold = DefaultSLAThreshold
+	}
+	now := time.Now
+	if m.Now != nil {
+		now = m.Now
+	}
+
+	report := &SLAReport{CheckedAt: now(), Threshold: threshold}
+	err := m.Client.IterateSellerOrders(ctx, OrdersOptions{IsFulfilled: Bool(false)}, func(order *OrderSummary) error {
+		report.Checked++
+		if age := report.CheckedAt.Sub(order.CreatedAt.Time); age > threshold {
+			report.Breaches = append(report.Breaches, SLABreach{Order: *order, Age: age})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check order SLA: %w", err)
+	}
+
+	sort.Slice(report.Breaches, func(i, j int) bool {
+		return report.Breaches[i].Age > report.Breaches[j].Age
+	})
+
+	if m.Notifier != nil && len(report.Breaches) > 0 {
+		if err := m.Notifier.Notify(ctx, report.Notification()); err != nil {
+			return report, fmt.Errorf("failed to send SLA notification: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// Run calls Check every interval until ctx is cancelled. Each report, or the
+// error from a failed check, is passed to onReport if it is not nil.
+func (m *SLAMonitor) Run(ctx context.Context, interval time.Duration, onReport func(*SLAReport, error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		report, err := m.Check(ctx)
+		if onReport != nil {
+			onReport(report, err)
+		}
+		return nil
+	}, nil)
+}
+
+// Notification summarizes the report for a Notifier.
+func (r *SLAReport) Notification() Notification {
+	var body strings.Builder
+	for _, b := range r.Breaches {
+		fmt.Fprintf(&body, "Order %s (%s) unfulfilled for %s\n", b.Order.Label, b.Order.ID, b.Age.Round(time.Minute))
+	}
+	return Notification{
+		Event: "sla_breach",
+		Title: fmt.Sprintf("%d order(s) unfulfilled for more than %s", len(r.Breaches), r.Threshold),
+		Body:  body.String(),
+		Data:  r,
+	}
+}
@@ -0,0 +1,125 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSLAMonitor_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("is_fulfilled"); got != "false" {
+			t.Fatalf("is_fulfilled = %q, want false", got)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"orders":[
+			{"id":"a","label":"A","created_at":"2024-04-01T00:00:00Z"},
+			{"id":"b","label":"B","created_at":"2024-04-03T00:00:00Z"},
+			{"id":"c","label":"C","created_at":"2024-03-30T00:00:00Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	now := time.Date(2024, 4, 3, 12, 0, 0, 0, time.UTC)
+
+	var sent []Notification
+	monitor := &SLAMonitor{
+		Client:    client,
+		Threshold: 24 * time.Hour,
+		Now:       func() time.Time { return now },
+		Notifier: NotifierFunc(func(ctx context.Context, n Notification) error {
+			sent = append(sent, n)
+			return nil
+		}),
+	}
+
+	report, err := monitor.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check error: %v", err)
+	}
+	if report.Checked != 3 || len(report.Breaches) != 2 {
+		t.Fatalf("checked=%d breaches=%d, want 3 and 2", report.Checked, len(report.Breaches))
+	}
+	if report.Breaches[0].Order.ID != "c" || report.Breaches[1].Order.ID != "a" {
+		t.Fatalf("breaches not sorted oldest first: %+v", report.Breaches)
+	}
+	if report.Breaches[1].Age != 60*time.Hour {
+		t.Fatalf("age = %v, want 60h", report.Breaches[1].Age)
+	}
+	if len(sent) != 1 || sent[0].Event != "sla_breach" || !strings.Contains(sent[0].Body, "Order A (a)") {
+		t.Fatalf("unexpected notifications: %+v", sent)
+	}
+
+	t.Run("NotifierError", func(t *testing.T) {
+		monitor.Notifier = NotifierFunc(func(ctx context.Context, n Notification) error {
+			return errors.New("webhook down")
+		})
+		report, err := monitor.Check(context.Background())
+		if err == nil || report == nil {
+			t.Fatalf("expected report and notifier error, got %v, %v", report, err)
+		}
+	})
+
+	t.Run("DefaultThreshold", func(t *testing.T) {
+		m := &SLAMonitor{Client: client, Now: func() time.Time { return now }}
+		report, err := m.Check(context.Background())
+		if err != nil {
+			t.Fatalf("Check error: %v", err)
+		}
+		if report.Threshold != DefaultSLAThreshold || len(report.Breaches) != 2 {
+			t.Fatalf("threshold=%v breaches=%d", report.Threshold, len(report.Breaches))
+		}
+	})
+}
+
+func TestSLAMonitor_Errors(t *testing.T) {
+	var valErr *ValidationError
+	if _, err := (&SLAMonitor{}).Check(context.Background()); !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError for nil client, got %v", err)
+	}
+	if err := (&SLAMonitor{}).Run(context.Background(), 0, nil); !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError for interval, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	if _, err := (&SLAMonitor{Client: client}).Check(context.Background()); err == nil {
+		t.Fatal("expected API error")
+	}
+}
+
+func TestSLAMonitor_Run(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"orders":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runs := 0
+	err := (&SLAMonitor{Client: client}).Run(ctx, time.Millisecond, func(report *SLAReport, err error) {
+		if err != nil {
+			t.Errorf("check error: %v", err)
+		}
+		runs++
+		if runs == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run error = %v, want context.Canceled", err)
+	}
+	if runs != 2 {
+		t.Fatalf("runs = %d, want 2", runs)
+	}
+}
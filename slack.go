@@ -0,0 +1,172 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultSlackAPIURL is the Slack chat.postMessage endpoint used by
+// SlackNotifier with a bot token.
+const DefaultSlackAPIURL = "https://slack.com/api/chat.postMessage"
+
+// slackTextLimit is the longest message text Slack accepts.
+const slackTextLimit = 40000
+
+// SlackNotifier is a Notifier that posts to Slack, either through an
+// incoming webhook or with a bot token. With a bot token, Channels can send
+// each event to its own channel, for example new orders to #fulfillment and
+// payouts to #accounts; an incoming webhook always posts to the channel it
+// was created for. Messages show the title in bold above the body unless a
+// template in Templates renders them.
+//
+// Posts to each channel are rate limited to MessagesPerSecond, and a post
+// Slack rate-limits anyway is retried once after the delay it asks for.
+//
+// Example:
+//
+//	slack := &manapool.SlackNotifier{
+//	    Token:   os.Getenv("SLACK_BOT_TOKEN"),
+//	    Channel: "#store",
+//	    Channels: map[string]string{
+//	        string(manapool.OrderEventNew):           "#fulfillment",
+//	        string(manapool.OrderEventIssueReported): "#fulfillment",
+//	    },
+//	}
+//	watcher := &manapool.OrderWatcher{Client: client, Notifier: slack}
+type SlackNotifier struct {
+	// WebhookURL is an incoming webhook URL. It is used when Token is empty.
+	WebhookURL string
+
+	// Token is a bot token with the chat:write scope.
+	Token string
+
+	// Channel is the channel ID or name posted to with a bot token for
+	// events not listed in Channels.
+	Channel string
+
+	// Channels maps events to the channel posted to with a bot token.
+	Channels map[string]string
+
+	// APIURL is the chat.postMessage endpoint (default: DefaultSlackAPIURL).
+	APIURL string
+
+	// HTTPClient is used for requests (default: a client with a 30 second timeout).
+	HTTPClient *http.Client
+
+	// Templates, if set, render the message text per event.
+	Templates NotificationTemplates
+
+	// Events, if set, limits the notifications posted to these events;
+	// others are dropped.
+	Events []string
+
+	// MessagesPerSecond limits posts to each channel (default: 1, Slack's
+	// limit for posting messages).
+	MessagesPerSecond float64
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// Notify implements Notifier. It blocks while the channel's rate limit is
+// exhausted.
+func (s *SlackNotifier) Notify(ctx context.Context, n Notification) error {
+	if !notificationWanted(s.Events, n.Event) {
+		return nil
+	}
+
+	text, ok, err := s.Templates.Render(n)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		text = "*" + slackEscape(n.Title) + "*"
+		if body := strings.TrimSpace(n.Body); body != "" {
+			text += "\n" + slackEscape(body)
+		}
+	}
+	text = truncateRunes(text, slackTextLimit)
+
+	if s.Token == "" {
+		if s.WebhookURL == "" {
+			return NewValidationError("WebhookURL", "a webhook URL or bot token is required")
+		}
+		if err := s.wait(ctx, s.WebhookURL); err != nil {
+			return err
+		}
+		body, err := json.Marshal(map[string]string{"text": text})
+		if err != nil {
+			return fmt.Errorf("failed to encode Slack message: %w", err)
+		}
+		if _, err := postNotification(ctx, s.HTTPClient, s.WebhookURL, nil, body); err != nil {
+			return fmt.Errorf("failed to post to Slack: %w", err)
+		}
+		return nil
+	}
+
+	channel := s.Channels[n.Event]
+	if channel == "" {
+		channel = s.Channel
+	}
+	if channel == "" {
+		return NewValidationError("Channel", fmt.Sprintf("no Slack channel for %q notifications", n.Event))
+	}
+	if err := s.wait(ctx, channel); err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"channel": channel, "text": text})
+	if err != nil {
+		return fmt.Errorf("failed to encode Slack message: %w", err)
+	}
+	endpoint := s.APIURL
+	if endpoint == "" {
+		endpoint = DefaultSlackAPIURL
+	}
+	header := http.Header{"Authorization": {"Bearer " + s.Token}}
+	data, err := postNotification(ctx, s.HTTPClient, endpoint, header, body)
+	if err != nil {
+		return fmt.Errorf("failed to post to Slack channel %s: %w", channel, err)
+	}
+	// The Web API reports failures with HTTP 200 and ok=false.
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to decode Slack response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("failed to post to Slack channel %s: %s", channel, result.Error)
+	}
+	return nil
+}
+
+// wait blocks until the rate limit for channel allows another post.
+func (s *SlackNotifier) wait(ctx context.Context, channel string) error {
+	s.mu.Lock()
+	if s.limiters == nil {
+		s.limiters = make(map[string]*rate.Limiter)
+	}
+	limiter, ok := s.limiters[channel]
+	if !ok {
+		perSecond := s.MessagesPerSecond
+		if perSecond <= 0 {
+			perSecond = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(perSecond), 1)
+		s.limiters[channel] = limiter
+	}
+	s.mu.Unlock()
+	return limiter.Wait(ctx)
+}
+
+// slackEscape escapes the characters Slack treats as markup in message text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,136 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestSlackNotifier_Webhook(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	slack := &SlackNotifier{WebhookURL: server.URL}
+	n := Notification{Event: "new_order", Title: "New order A&B", Body: "1x <Sol Ring>\n"}
+	if err := slack.Notify(context.Background(), n); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if want := "*New order A&amp;B*\n1x &lt;Sol Ring&gt;"; got["text"] != want {
+		t.Errorf("text = %q, want %q", got["text"], want)
+	}
+	if _, ok := got["channel"]; ok {
+		t.Error("webhook message should not name a channel")
+	}
+}
+
+func TestSlackNotifier_BotChannels(t *testing.T) {
+	var mu sync.Mutex
+	var posts []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer xoxb-test" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		var msg map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+		mu.Lock()
+		posts = append(posts, msg)
+		mu.Unlock()
+		if msg["channel"] == "#missing" {
+			_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	slack := &SlackNotifier{
+		Token:             "xoxb-test",
+		APIURL:            server.URL,
+		Channel:           "#store",
+		Channels:          map[string]string{"new_order": "#fulfillment", "payout": "#missing"},
+		Templates:         NotificationTemplates{"new_order": template.Must(template.New("").Parse("sold {{.Title}}"))},
+		MessagesPerSecond: 1000,
+	}
+	ctx := context.Background()
+	if err := slack.Notify(ctx, Notification{Event: "new_order", Title: "A1"}); err != nil {
+		t.Fatalf("Notify(new_order) error = %v", err)
+	}
+	if err := slack.Notify(ctx, Notification{Event: "sla_breach", Title: "late"}); err != nil {
+		t.Fatalf("Notify(sla_breach) error = %v", err)
+	}
+	err := slack.Notify(ctx, Notification{Event: "payout", Title: "p1"})
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("Notify(payout) error = %v, want channel_not_found", err)
+	}
+
+	if len(posts) != 3 {
+		t.Fatalf("got %d posts, want 3", len(posts))
+	}
+	if posts[0]["channel"] != "#fulfillment" || posts[0]["text"] != "sold A1" {
+		t.Errorf("posts[0] = %v", posts[0])
+	}
+	if posts[1]["channel"] != "#store" || posts[1]["text"] != "*late*" {
+		t.Errorf("posts[1] = %v", posts[1])
+	}
+}
+
+func TestSlackNotifier_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	slack := &SlackNotifier{WebhookURL: server.URL, MessagesPerSecond: 20}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := slack.Notify(context.Background(), Notification{Title: "hi"}); err != nil {
+			t.Fatalf("Notify() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 posts at 20/s took %v, want at least 100ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := slack.Notify(ctx, Notification{Title: "hi"}); err == nil {
+		t.Error("expected error when waiting with a cancelled context")
+	}
+}
+
+func TestSlackNotifier_Errors(t *testing.T) {
+	ctx := context.Background()
+	var validationErr *ValidationError
+	if err := (&SlackNotifier{}).Notify(ctx, Notification{}); !errors.As(err, &validationErr) {
+		t.Errorf("no destination error = %v, want ValidationError", err)
+	}
+	if err := (&SlackNotifier{Token: "xoxb-test"}).Notify(ctx, Notification{Event: "payout"}); !errors.As(err, &validationErr) {
+		t.Errorf("no channel error = %v, want ValidationError", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("invalid_token"))
+	}))
+	defer server.Close()
+	err := (&SlackNotifier{WebhookURL: server.URL}).Notify(ctx, Notification{Title: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("Notify() error = %v", err)
+	}
+
+	filtered := &SlackNotifier{Events: []string{"payout"}}
+	if err := filtered.Notify(ctx, Notification{Event: "new_order"}); err != nil {
+		t.Errorf("filtered event error = %v, want nil", err)
+	}
+}
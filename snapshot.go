@@ -0,0 +1,172 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotTimeLayout names snapshot objects so keys sort by time.
+const snapshotTimeLayout = "20060102T150405Z"
+
+// SnapshotPrices downloads a price export and stores it unchanged under key.
+// The export is staged in a temporary file, so it is verified as by
+// DownloadPricesToFile and not held in memory.
+//
+// Example:
+//
+//	err := client.SnapshotPrices(ctx, manapool.PriceExportSingles, store, "prices/singles/latest.json")
+func (c *Client) SnapshotPrices(ctx context.Context, export PriceExport, store ObjectStore, key string) error {
+	if store == nil {
+		return NewValidationError("store", "store cannot be nil")
+	}
+	dir, err := os.MkdirTemp("", "manapool-snapshot-")
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s prices: %w", export, err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	path := filepath.Join(dir, string(export)+".json")
+	result, err := c.DownloadPricesToFile(ctx, export, path, DownloadOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s prices: %w", export, err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s prices: %w", export, err)
+	}
+	defer func() { _ = file.Close() }()
+	if err := store.Put(ctx, key, file, result.Bytes, "application/json"); err != nil {
+		return fmt.Errorf("failed to snapshot %s prices: %w", export, err)
+	}
+	return nil
+}
+
+// BackupInventory stores the seller's whole inventory under key as a JSON
+// array of InventoryItem, which can be read back with json.Unmarshal.
+//
+// Example:
+//
+//	err := client.BackupInventory(ctx, store, "inventory/latest.json")
+func (c *Client) BackupInventory(ctx context.Context, store ObjectStore, key string) error {
+	if store == nil {
+		return NewValidationError("store", "store cannot be nil")
+	}
+	inventory, err := c.collectInventory(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to back up inventory: %w", err)
+	}
+	if inventory == nil {
+		inventory = []InventoryItem{}
+	}
+	data, err := json.Marshal(inventory)
+	if err != nil {
+		return fmt.Errorf("failed to back up inventory: %w", err)
+	}
+	if err := store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "application/json"); err != nil {
+		return fmt.Errorf("failed to back up inventory: %w", err)
+	}
+	return nil
+}
+
+// SnapshotScheduler periodically stores price snapshots and inventory
+// backups in an ObjectStore and prunes old ones. Each snapshot is written to
+// a new key named by its UTC time:
+//
+//	<Prefix>prices/<export>/20240502T150000Z.json
+//	<Prefix>inventory/20240502T150000Z.json
+//
+// Retention is applied separately to each export and to the backups, after
+// a new snapshot has been stored, so a failing snapshot never prunes the
+// last good one.
+//
+// Example:
+//
+//	scheduler := &manapool.SnapshotScheduler{
+//	    Client:    client,
+//	    Store:     store,
+//	    Prefix:    "manapool/",
+//	    Prices:    []manapool.PriceExport{manapool.PriceExportSingles, manapool.PriceExportSealed},
+//	    Inventory: true,
+//	    Retention: manapool.RetentionPolicy{MaxAge: 90 * 24 * time.Hour},
+//	}
+//	err := scheduler.Run(ctx, 24*time.Hour, func(err error) {
+//	    log.Printf("snapshot failed: %v", err)
+//	})
+type SnapshotScheduler struct {
+	// Client is used to download prices and list inventory.
+	Client *Client
+
+	// Store receives the snapshots.
+	Store ObjectStore
+
+	// Prefix is prepended to every key, e.g. "manapool/".
+	Prefix string
+
+	// Prices lists the price exports to snapshot.
+	Prices []PriceExport
+
+	// Inventory enables inventory backups.
+	Inventory bool
+
+	// Retention limits the snapshots kept of each kind.
+	Retention RetentionPolicy
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+}
+
+// Snapshot stores one snapshot of each configured kind and applies the
+// retention policy, returning the keys written. It keeps going after a
+// failure and returns the first error.
+func (s *SnapshotScheduler) Snapshot(ctx context.Context) ([]string, error) {
+	if s.Client == nil {
+		return nil, NewValidationError("client", "client cannot be nil")
+	}
+	if s.Store == nil {
+		return nil, NewValidationError("store", "store cannot be nil")
+	}
+	now := time.Now()
+	if s.Now != nil {
+		now = s.Now()
+	}
+	name := now.UTC().Format(snapshotTimeLayout) + ".json"
+
+	var written []string
+	var firstErr error
+	store := func(prefix string, snapshot func(key string) error) {
+		key := prefix + name
+		err := snapshot(key)
+		if err == nil {
+			written = append(written, key)
+			_, err = s.Retention.Prune(ctx, s.Store, prefix, now)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, export := range s.Prices {
+		store(s.Prefix+"prices/"+string(export)+"/", func(key string) error {
+			return s.Client.SnapshotPrices(ctx, export, s.Store, key)
+		})
+	}
+	if s.Inventory {
+		store(s.Prefix+"inventory/", func(key string) error {
+			return s.Client.BackupInventory(ctx, s.Store, key)
+		})
+	}
+	return written, firstErr
+}
+
+// Run calls Snapshot every interval until ctx is cancelled, passing each
+// failure to onError if it is not nil.
+func (s *SnapshotScheduler) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		_, err := s.Snapshot(ctx)
+		return err
+	}, onError)
+}
@@ -0,0 +1,121 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newSnapshotServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/prices/singles":
+			fmt.Fprint(w, `{"meta":{},"data":[]}`)
+		case "/prices/sealed":
+			w.WriteHeader(http.StatusBadRequest)
+		case "/seller/inventory":
+			fmt.Fprint(w, `{"inventory":[{"id":"l1","price_cents":250,"quantity":3}],"pagination":{"total":1,"returned":1,"offset":0,"limit":500}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_BackupInventory(t *testing.T) {
+	server := newSnapshotServer(t)
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	store := &memoryStore{}
+
+	if err := client.BackupInventory(context.Background(), store, "inventory.json"); err != nil {
+		t.Fatalf("BackupInventory() error = %v", err)
+	}
+	body, err := store.Get(context.Background(), "inventory.json")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	var inventory []InventoryItem
+	if err := json.NewDecoder(body).Decode(&inventory); err != nil {
+		t.Fatalf("decode backup: %v", err)
+	}
+	if len(inventory) != 1 || inventory[0].ID != "l1" || inventory[0].Quantity != 3 {
+		t.Errorf("backup = %+v", inventory)
+	}
+
+	if err := client.BackupInventory(context.Background(), nil, "inventory.json"); err == nil {
+		t.Error("BackupInventory() with nil store error = nil")
+	}
+}
+
+func TestSnapshotScheduler_Snapshot(t *testing.T) {
+	server := newSnapshotServer(t)
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithRetry(0, 0))
+	store := &memoryStore{}
+	now := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+	scheduler := &SnapshotScheduler{
+		Client:    client,
+		Store:     store,
+		Prefix:    "mp/",
+		Prices:    []PriceExport{PriceExportSealed, PriceExportSingles},
+		Inventory: true,
+		Retention: RetentionPolicy{MaxCount: 2},
+		Now:       func() time.Time { return now },
+	}
+
+	for day := 0; day < 3; day++ {
+		now = now.Add(24 * time.Hour)
+		store.now = now
+		written, err := scheduler.Snapshot(context.Background())
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Snapshot() error = %v, want sealed prices APIError", err)
+		}
+		name := now.Format("20060102T150405Z") + ".json"
+		if want := []string{"mp/prices/singles/" + name, "mp/inventory/" + name}; !reflect.DeepEqual(written, want) {
+			t.Errorf("Snapshot() wrote %v, want %v", written, want)
+		}
+	}
+
+	want := []string{
+		"mp/inventory/20240503T150000Z.json", "mp/inventory/20240504T150000Z.json",
+		"mp/prices/singles/20240503T150000Z.json", "mp/prices/singles/20240504T150000Z.json",
+	}
+	if keys := store.keys(); !reflect.DeepEqual(keys, want) {
+		t.Errorf("stored keys = %v, want %v", keys, want)
+	}
+	body, _ := store.Get(context.Background(), want[3])
+	data, _ := io.ReadAll(body)
+	if string(data) != `{"meta":{},"data":[]}` {
+		t.Errorf("price snapshot = %s", data)
+	}
+}
+
+func TestSnapshotScheduler_Run(t *testing.T) {
+	scheduler := &SnapshotScheduler{}
+	if err := scheduler.Run(context.Background(), 0, nil); err == nil {
+		t.Error("Run() with zero interval error = nil, want error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var got error
+	err := scheduler.Run(ctx, time.Hour, func(err error) {
+		got = err
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want context.Canceled", err)
+	}
+	var validationErr *ValidationError
+	if !errors.As(got, &validationErr) {
+		t.Errorf("onError got %v, want *ValidationError", got)
+	}
+}
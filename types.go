@@ -43,6 +43,7 @@ package manapool
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -65,20 +66,20 @@ type InventoryResponse struct {
 
 // InventoryItem represents a single inventory item in the Manapool system.
 type InventoryItem struct {
-	ID            string    `json:"id"`
-	ProductType   string    `json:"product_type"`
-	ProductID     string    `json:"product_id"`
-	Product       Product   `json:"product"`
-	PriceCents    int       `json:"price_cents"`
-	Quantity      int       `json:"quantity"`
-	EffectiveAsOf Timestamp `json:"effective_as_of"`
+	ID            string      `json:"id"`
+	ProductType   ProductType `json:"product_type"`
+	ProductID     string      `json:"product_id"`
+	Product       Product     `json:"product"`
+	PriceCents    int         `json:"price_cents"`
+	Quantity      int         `json:"quantity"`
+	EffectiveAsOf Timestamp   `json:"effective_as_of"`
 }
 
 // Product represents a product in the Manapool inventory.
 type Product struct {
 	Type         string  `json:"type"`
 	ID           string  `json:"id"`
-	TCGPlayerSKU *int    `json:"tcgplayer_sku"`
+	TCGPlayerSKU *int64  `json:"tcgplayer_sku"`
 	Single       *Single `json:"single"`
 	Sealed       *Sealed `json:"sealed"`
 }
@@ -87,7 +88,7 @@ type Product struct {
 type Single struct {
 	ScryfallID  string `json:"scryfall_id"`
 	MTGJsonID   string `json:"mtgjson_id"`
-	TCGPlayerID *int   `json:"tcgplayer_id"`
+	TCGPlayerID *int64 `json:"tcgplayer_id"`
 	Name        string `json:"name"`
 	Set         string `json:"set"`
 	Number      string `json:"number"`
@@ -99,7 +100,7 @@ type Single struct {
 // Sealed represents a sealed product (booster boxes, etc.).
 type Sealed struct {
 	MTGJsonID   string `json:"mtgjson_id"`
-	TCGPlayerID *int   `json:"tcgplayer_id"`
+	TCGPlayerID *int64 `json:"tcgplayer_id"`
 	Name        string `json:"name"`
 	Set         string `json:"set"`
 	LanguageID  string `json:"language_id"`
@@ -113,26 +114,56 @@ type Pagination struct {
 	Limit    int `json:"limit"`
 }
 
+// TimestampLayoutUnix is a layout for WithTimestampLayout that sends times as
+// integer seconds since the Unix epoch.
+const TimestampLayoutUnix = "unix"
+
+// timestampLayouts are the layouts Timestamp.UnmarshalJSON accepts, in the
+// order they are tried. Fractional seconds are accepted by all of them.
+// Layouts without an offset are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,            // "2025-08-05T20:38:54.549229Z"
+	"2006-01-02T15:04:05Z0700",  // "2025-08-05T20:38:54.549229+0000"
+	"2006-01-02T15:04:05Z07",    // "2025-03-28T19:29:30.633842+00"
+	"2006-01-02 15:04:05Z07:00", // "2025-08-05 20:38:54.549229+00:00"
+	"2006-01-02 15:04:05Z0700",  // "2025-08-05 20:38:54.549229+0000"
+	"2006-01-02 15:04:05Z07",    // "2025-08-05 20:38:54.549229+00"
+	"2006-01-02T15:04:05",       // "2025-08-05T20:38:54.549229"
+	"2006-01-02 15:04:05",       // "2025-08-05 20:38:54.549229"
+}
+
 // Timestamp is a custom time type that handles Manapool's timestamp format.
 // The Manapool API returns timestamps in multiple formats:
 //   - RFC3339Nano: "2025-08-05T20:38:54.549229Z"
 //   - No-colon offset: "2025-08-05T20:38:54.549229+0000"
+//   - Hour-only offset: "2025-03-28T19:29:30.633842+00"
+//   - A space instead of "T", or no offset at all (read as UTC)
+//   - Unix epoch seconds or milliseconds, as a number or a string
+//
+// JSON null leaves the Timestamp unchanged, and a zero Timestamp marshals as
+// null.
+//
+// Timestamps marshal as RFC3339Nano; use WithTimestampLayout to send request
+// times in another format.
 type Timestamp struct {
 	time.Time
 }
 
 // UnmarshalJSON implements json.Unmarshaler for Timestamp.
 func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
 	s := strings.Trim(string(b), `"`) // strip quotes
 
-	// Try standard RFC3339Nano first
-	if parsed, err := time.Parse(time.RFC3339Nano, s); err == nil {
-		t.Time = parsed
-		return nil
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
 	}
 
-	// Fallback: no-colon offset like +0000
-	if parsed, err := time.Parse("2006-01-02T15:04:05.999999-0700", s); err == nil {
+	if parsed, ok := parseEpoch(s); ok {
 		t.Time = parsed
 		return nil
 	}
@@ -148,6 +179,62 @@ func (t Timestamp) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.Format(time.RFC3339Nano))
 }
 
+// formatTimestamp formats t with layout, a time layout or
+// TimestampLayoutUnix. An empty layout uses time.RFC3339Nano.
+func formatTimestamp(t Timestamp, layout string) string {
+	switch layout {
+	case "":
+		return t.Format(time.RFC3339Nano)
+	case TimestampLayoutUnix:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// layoutTimestamp marshals a request timestamp with the client's timestamp
+// layout. A nil or zero time marshals as null.
+type layoutTimestamp struct {
+	time   *Timestamp
+	layout string
+}
+
+// MarshalJSON implements json.Marshaler for layoutTimestamp.
+func (t layoutTimestamp) MarshalJSON() ([]byte, error) {
+	if t.time == nil || t.time.IsZero() {
+		return []byte("null"), nil
+	}
+	text := formatTimestamp(*t.time, t.layout)
+	if t.layout == TimestampLayoutUnix {
+		return []byte(text), nil
+	}
+	return json.Marshal(text)
+}
+
+// parseEpoch parses s as Unix epoch seconds, with an optional fraction, or
+// as integer milliseconds when it is too large to be seconds.
+func parseEpoch(s string) (time.Time, bool) {
+	seconds, fraction, hasFraction := strings.Cut(s, ".")
+	n, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	if !hasFraction {
+		if n >= 1e12 || n <= -1e12 {
+			return time.UnixMilli(n).UTC(), true
+		}
+		return time.Unix(n, 0).UTC(), true
+	}
+	if fraction == "" || len(fraction) > 9 || strings.Trim(fraction, "0123456789") != "" {
+		return time.Time{}, false
+	}
+	nanos, _ := strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+	if strings.HasPrefix(seconds, "-") {
+		nanos = -nanos
+	}
+	return time.Unix(n, nanos).UTC(), true
+}
+
 // InventoryOptions contains options for querying seller inventory.
 type InventoryOptions struct {
 	// Limit specifies the maximum number of items to return (default: 500, max: 500)
@@ -1,6 +1,10 @@
 package manapool
 
-import "net/url"
+import (
+	"fmt"
+	"io"
+	"net/url"
+)
 
 // PricesMeta describes price export metadata.
 type PricesMeta struct {
@@ -41,19 +45,19 @@ type VariantPricesList struct {
 
 // VariantPriceListing represents a variant price listing.
 type VariantPriceListing struct {
-	URL                string  `json:"url"`
-	ProductType        string  `json:"product_type"`
-	ProductID          string  `json:"product_id"`
-	SetCode            string  `json:"set_code"`
-	Number             string  `json:"number"`
-	Name               string  `json:"name"`
-	ScryfallID         string  `json:"scryfall_id"`
-	TCGPlayerProductID *int    `json:"tcgplayer_product_id"`
-	LanguageID         string  `json:"language_id"`
-	ConditionID        *string `json:"condition_id"`
-	FinishID           *string `json:"finish_id"`
-	LowPrice           int     `json:"low_price"`
-	AvailableQuantity  int     `json:"available_quantity"`
+	URL                string      `json:"url"`
+	ProductType        ProductType `json:"product_type"`
+	ProductID          string      `json:"product_id"`
+	SetCode            string      `json:"set_code"`
+	Number             string      `json:"number"`
+	Name               string      `json:"name"`
+	ScryfallID         string      `json:"scryfall_id"`
+	TCGPlayerProductID *int64      `json:"tcgplayer_product_id"`
+	LanguageID         string      `json:"language_id"`
+	ConditionID        *string     `json:"condition_id"`
+	FinishID           *string     `json:"finish_id"`
+	LowPrice           int         `json:"low_price"`
+	AvailableQuantity  int         `json:"available_quantity"`
 }
 
 // SealedPricesList represents the sealed prices export.
@@ -64,15 +68,15 @@ type SealedPricesList struct {
 
 // SealedPriceListing represents a sealed price listing.
 type SealedPriceListing struct {
-	URL                string `json:"url"`
-	ProductType        string `json:"product_type"`
-	ProductID          string `json:"product_id"`
-	SetCode            string `json:"set_code"`
-	Name               string `json:"name"`
-	TCGPlayerProductID *int   `json:"tcgplayer_product_id"`
-	LanguageID         string `json:"language_id"`
-	LowPrice           int    `json:"low_price"`
-	AvailableQuantity  int    `json:"available_quantity"`
+	URL                string      `json:"url"`
+	ProductType        ProductType `json:"product_type"`
+	ProductID          string      `json:"product_id"`
+	SetCode            string      `json:"set_code"`
+	Name               string      `json:"name"`
+	TCGPlayerProductID *int64      `json:"tcgplayer_product_id"`
+	LanguageID         string      `json:"language_id"`
+	LowPrice           int         `json:"low_price"`
+	AvailableQuantity  int         `json:"available_quantity"`
 }
 
 // OptimizerRequest represents a cart optimization request.
@@ -87,22 +91,22 @@ type OptimizerRequest struct {
 
 // OptimizerCartItem represents an item requested by the optimizer.
 type OptimizerCartItem struct {
-	Type                      string   `json:"type"`
-	Name                      string   `json:"name,omitempty"`
-	SetCode                   string   `json:"set_code,omitempty"`
-	CollectorNumber           string   `json:"collector_number,omitempty"`
-	IsToken                   *bool    `json:"is_token,omitempty"`
-	IncludeNonSanctionedLegal *bool    `json:"include_non_sanctioned_legal,omitempty"`
-	MTGJsonID                 *string  `json:"mtgjson_id,omitempty"`
-	LanguageIDs               []string `json:"language_ids,omitempty"`
-	FinishIDs                 []string `json:"finish_ids,omitempty"`
-	ConditionIDs              []string `json:"condition_ids,omitempty"`
-	URI                       string   `json:"uri,omitempty"`
-	TCGPlayerSKUIds           []int    `json:"tcgplayer_sku_ids,omitempty"`
-	ProductType               string   `json:"product_type,omitempty"`
-	ProductIDs                []string `json:"product_ids,omitempty"`
-	QuantityRequested         int      `json:"quantity_requested"`
-	Index                     *int     `json:"index,omitempty"`
+	Type                      string      `json:"type"`
+	Name                      string      `json:"name,omitempty"`
+	SetCode                   string      `json:"set_code,omitempty"`
+	CollectorNumber           string      `json:"collector_number,omitempty"`
+	IsToken                   *bool       `json:"is_token,omitempty"`
+	IncludeNonSanctionedLegal *bool       `json:"include_non_sanctioned_legal,omitempty"`
+	MTGJsonID                 *string     `json:"mtgjson_id,omitempty"`
+	LanguageIDs               []string    `json:"language_ids,omitempty"`
+	FinishIDs                 []string    `json:"finish_ids,omitempty"`
+	ConditionIDs              []string    `json:"condition_ids,omitempty"`
+	URI                       string      `json:"uri,omitempty"`
+	TCGPlayerSKUIds           []int64     `json:"tcgplayer_sku_ids,omitempty"`
+	ProductType               ProductType `json:"product_type,omitempty"`
+	ProductIDs                []string    `json:"product_ids,omitempty"`
+	QuantityRequested         int         `json:"quantity_requested"`
+	Index                     *int        `json:"index,omitempty"`
 }
 
 // OptimizedCart represents an optimized cart response.
@@ -127,14 +131,26 @@ type OptimizedCartTotals struct {
 
 // BuyerOrdersOptions defines filters for buyer orders.
 type BuyerOrdersOptions struct {
-	Since  *Timestamp
-	Limit  int
+	Since *Timestamp
+
+	// Limit specifies the maximum number of orders to return; zero leaves it to the API
+	Limit int
+
+	// Offset specifies the starting position in the result set (default: 0)
 	Offset int
 }
 
+// Validate validates the buyer order options.
+func (o *BuyerOrdersOptions) Validate() error {
+	return validateOrderPage(o.Limit, o.Offset)
+}
+
 // BuyerOrdersResponse represents a list of buyer orders.
+// Pagination holds the pagination metadata the API returned, if any; its
+// fields are zero when the API omits them.
 type BuyerOrdersResponse struct {
-	Orders []BuyerOrderSummary `json:"orders"`
+	Orders     []BuyerOrderSummary `json:"orders"`
+	Pagination Pagination          `json:"pagination"`
 }
 
 // BuyerOrderSummary represents a summary of a buyer order.
@@ -177,7 +193,7 @@ type BuyerOrderItem struct {
 
 // BuyerOrderProduct represents a product in a buyer order item.
 type BuyerOrderProduct struct {
-	ProductType string            `json:"product_type"`
+	ProductType ProductType       `json:"product_type"`
 	ProductID   string            `json:"product_id"`
 	Single      *BuyerOrderSingle `json:"single"`
 	Sealed      *BuyerOrderSealed `json:"sealed"`
@@ -283,9 +299,15 @@ type Address struct {
 }
 
 // SellerAccountUpdate represents a seller account update request.
+// The API only supports toggling listings live; shipping methods, rates and
+// free-shipping thresholds, and store policies such as returns, processing
+// time and announcements, can only be changed in the seller dashboard.
+//
+// Nil fields are left out of the request and keep their current value. Fields
+// added to update requests in future use Optional for the same effect.
 type SellerAccountUpdate struct {
-	SinglesLive *bool `json:"singles_live"`
-	SealedLive  *bool `json:"sealed_live"`
+	SinglesLive *bool `json:"singles_live,omitempty"`
+	SealedLive  *bool `json:"sealed_live,omitempty"`
 }
 
 // DeckCreateRequest represents a deck create request.
@@ -364,17 +386,17 @@ type InventoryUpdateRequest struct {
 
 // InventoryBulkItemBySKU represents bulk inventory items by SKU.
 type InventoryBulkItemBySKU struct {
-	TCGPlayerSKU int `json:"tcgplayer_sku"`
-	PriceCents   int `json:"price_cents"`
-	Quantity     int `json:"quantity"`
+	TCGPlayerSKU int64 `json:"tcgplayer_sku"`
+	PriceCents   int   `json:"price_cents"`
+	Quantity     int   `json:"quantity"`
 }
 
 // InventoryBulkItemByProduct represents bulk inventory items by product.
 type InventoryBulkItemByProduct struct {
-	ProductType string `json:"product_type"`
-	ProductID   string `json:"product_id"`
-	PriceCents  int    `json:"price_cents"`
-	Quantity    int    `json:"quantity"`
+	ProductType ProductType `json:"product_type"`
+	ProductID   string      `json:"product_id"`
+	PriceCents  int         `json:"price_cents"`
+	Quantity    int         `json:"quantity"`
 }
 
 // InventoryBulkItemByScryfall represents bulk inventory items by Scryfall ID.
@@ -389,7 +411,7 @@ type InventoryBulkItemByScryfall struct {
 
 // InventoryBulkItemByTCGPlayerID represents bulk inventory items by TCGPlayer ID.
 type InventoryBulkItemByTCGPlayerID struct {
-	TCGPlayerID int     `json:"tcgplayer_id"`
+	TCGPlayerID int64   `json:"tcgplayer_id"`
 	LanguageID  string  `json:"language_id"`
 	FinishID    *string `json:"finish_id"`
 	ConditionID *string `json:"condition_id"`
@@ -446,23 +468,35 @@ type OrdersOptions struct {
 	IsFulfilled     *bool
 	HasFulfillments *bool
 	Label           string
-	Limit           int
-	Offset          int
+
+	// Limit specifies the maximum number of orders to return; zero leaves it to the API
+	Limit int
+
+	// Offset specifies the starting position in the result set (default: 0)
+	Offset int
+}
+
+// Validate validates the order options.
+func (o *OrdersOptions) Validate() error {
+	return validateOrderPage(o.Limit, o.Offset)
 }
 
 // OrdersResponse represents order summaries.
+// Pagination holds the pagination metadata the API returned, if any; its
+// fields are zero when the API omits them.
 type OrdersResponse struct {
-	Orders []OrderSummary `json:"orders"`
+	Orders     []OrderSummary `json:"orders"`
+	Pagination Pagination     `json:"pagination"`
 }
 
 // OrderSummary represents order summary information.
 type OrderSummary struct {
-	ID                      string    `json:"id"`
-	CreatedAt               Timestamp `json:"created_at"`
-	Label                   string    `json:"label"`
-	TotalCents              int       `json:"total_cents"`
-	ShippingMethod          string    `json:"shipping_method"`
-	LatestFulfillmentStatus *string   `json:"latest_fulfillment_status"`
+	ID                      string         `json:"id"`
+	CreatedAt               Timestamp      `json:"created_at"`
+	Label                   string         `json:"label"`
+	TotalCents              int            `json:"total_cents"`
+	ShippingMethod          ShippingMethod `json:"shipping_method"`
+	LatestFulfillmentStatus *string        `json:"latest_fulfillment_status"`
 }
 
 // OrderDetailsResponse represents detailed order response.
@@ -502,12 +536,12 @@ type OrderFulfillment struct {
 
 // OrderItem represents an order item.
 type OrderItem struct {
-	TCGSKU      *int    `json:"tcgsku"`
-	ProductID   string  `json:"product_id"`
-	ProductType string  `json:"product_type"`
-	Product     Product `json:"product"`
-	Quantity    int     `json:"quantity"`
-	PriceCents  int     `json:"price_cents"`
+	TCGSKU      *int64      `json:"tcgsku"`
+	ProductID   string      `json:"product_id"`
+	ProductType ProductType `json:"product_type"`
+	Product     Product     `json:"product"`
+	Quantity    int         `json:"quantity"`
+	PriceCents  int         `json:"price_cents"`
 }
 
 // OrderFulfillmentRequest represents a fulfillment update request.
@@ -575,6 +609,10 @@ type Webhook struct {
 	ID          string `json:"id"`
 	Topic       string `json:"topic"`
 	CallbackURL string `json:"callback_url"`
+
+	// Secret is the shared secret used to sign webhook requests. It is only
+	// returned by RegisterWebhook; store it to verify deliveries.
+	Secret string `json:"secret,omitempty"`
 }
 
 // WebhooksResponse represents webhooks list response.
@@ -624,7 +662,9 @@ type CardInfo struct {
 	ManaValue         *string  `json:"mana_value"`
 }
 
-// JobApplicationRequest represents a job application.
+// JobApplicationRequest represents a job application. The application file
+// is either Application or, for files that should not be held in memory,
+// ApplicationReader with its exact ApplicationSize.
 type JobApplicationRequest struct {
 	FirstName           string
 	LastName            string
@@ -632,6 +672,8 @@ type JobApplicationRequest struct {
 	LinkedInURL         string
 	GitHubURL           string
 	Application         []byte
+	ApplicationReader   io.Reader
+	ApplicationSize     int64
 	ApplicationFilename string
 }
 
@@ -640,3 +682,14 @@ type JobApplicationResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
+
+// validateOrderPage validates order list paging options.
+func validateOrderPage(limit, offset int) error {
+	if limit < 0 {
+		return NewValidationError("limit", fmt.Sprintf("limit must be non-negative, got %d", limit))
+	}
+	if offset < 0 {
+		return NewValidationError("offset", fmt.Sprintf("offset must be non-negative, got %d", offset))
+	}
+	return nil
+}
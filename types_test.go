@@ -1,7 +1,10 @@
 package manapool
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -31,6 +34,54 @@ func TestTimestamp_UnmarshalJSON(t *testing.T) {
 			want:    time.Date(2025, 8, 5, 20, 38, 54, 549229000, time.FixedZone("", -5*3600)),
 			wantErr: false,
 		},
+		{
+			name:    "hour-only offset",
+			input:   `"2025-03-28T19:29:30.633842+00"`,
+			want:    time.Date(2025, 3, 28, 19, 29, 30, 633842000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "space separator with colon offset",
+			input:   `"2025-08-05 20:38:54.549229+02:00"`,
+			want:    time.Date(2025, 8, 5, 18, 38, 54, 549229000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "no offset",
+			input:   `"2025-08-05T20:38:54"`,
+			want:    time.Date(2025, 8, 5, 20, 38, 54, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch seconds",
+			input:   `1754426334`,
+			want:    time.Date(2025, 8, 5, 20, 38, 54, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch seconds with fraction",
+			input:   `1754426334.5`,
+			want:    time.Date(2025, 8, 5, 20, 38, 54, 500000000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch milliseconds as string",
+			input:   `"1754426334549"`,
+			want:    time.Date(2025, 8, 5, 20, 38, 54, 549000000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "null",
+			input:   `null`,
+			want:    time.Time{},
+			wantErr: false,
+		},
+		{
+			name:    "invalid epoch fraction",
+			input:   `1754426334.5e3`,
+			want:    time.Time{},
+			wantErr: true,
+		},
 		{
 			name:    "invalid format",
 			input:   `"not-a-timestamp"`,
@@ -100,6 +151,63 @@ func TestTimestamp_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestLayoutTimestamp_MarshalJSON(t *testing.T) {
+	ts := &Timestamp{Time: time.Date(2025, 8, 5, 20, 38, 54, 549229000, time.UTC)}
+	tests := []struct {
+		name string
+		ts   layoutTimestamp
+		want string
+	}{
+		{"default layout", layoutTimestamp{ts, ""}, `"2025-08-05T20:38:54.549229Z"`},
+		{"custom layout", layoutTimestamp{ts, time.RFC3339}, `"2025-08-05T20:38:54Z"`},
+		{"unix layout", layoutTimestamp{ts, TimestampLayoutUnix}, `1754426334`},
+		{"nil time", layoutTimestamp{nil, TimestampLayoutUnix}, `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.ts)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("MarshalJSON() = %s, %v; want %s", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTimestampLayout(t *testing.T) {
+	var query string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(`{"fulfillment":{}}`))
+			return
+		}
+		query = r.URL.Query().Get("since")
+		_, _ = w.Write([]byte(`{"orders":[],"order":{"id":"abc","latest_fulfillment_status":"shipped"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithTimestampLayout(TimestampLayoutUnix))
+	ctx := context.Background()
+	since := Timestamp{Time: time.Unix(1754426334, 0)}
+	if _, err := client.GetSellerOrders(ctx, OrdersOptions{Since: &since}); err != nil {
+		t.Fatalf("GetSellerOrders() error = %v", err)
+	}
+	if query != "1754426334" {
+		t.Errorf("since = %q, want 1754426334", query)
+	}
+
+	status := string(FulfillmentStatusDelivered)
+	req := OrderFulfillmentRequest{Status: &status, TrackingNumber: String("1Z"), DeliveredAt: &since}
+	if _, err := client.UpdateSellerOrderFulfillment(ctx, "abc", req); err != nil {
+		t.Fatalf("UpdateSellerOrderFulfillment() error = %v", err)
+	}
+	if body["delivered_at"] != float64(1754426334) || body["in_transit_at"] != nil || body["tracking_number"] != "1Z" {
+		t.Errorf("fulfillment body = %v", body)
+	}
+}
+
 func TestInventoryOptions_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
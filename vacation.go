@@ -0,0 +1,96 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVacationModeActive is returned by EnableVacationMode when no listings
+// are live, as after an earlier call.
+var ErrVacationModeActive = errors.New("vacation mode is already enabled")
+
+// VacationState records which listing types were live before vacation mode
+// was enabled, so DisableVacationMode can restore them. It is JSON-encodable
+// so it can be saved across restarts.
+type VacationState struct {
+	SinglesLive bool      `json:"singles_live"`
+	SealedLive  bool      `json:"sealed_live"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// EnableVacationMode takes singles and sealed listings off the marketplace
+// and returns the previous live settings. Keep the state to pass to
+// DisableVacationMode; otherwise both listing types are turned back on.
+//
+// If neither singles nor sealed listings are live, vacation mode is already
+// on: the account is left alone and ErrVacationModeActive is returned, so
+// the state saved by the first call is not overwritten.
+//
+// Example:
+//
+//	state, err := client.EnableVacationMode(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	go func() {
+//	    if _, err := client.DisableVacationModeAt(ctx, returnDate, state); err != nil {
+//	        log.Printf("failed to end vacation mode: %v", err)
+//	    }
+//	}()
+func (c *Client) EnableVacationMode(ctx context.Context) (*VacationState, error) {
+	account, err := c.GetSellerAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable vacation mode: %w", err)
+	}
+	if !account.SinglesLive && !account.SealedLive {
+		return nil, ErrVacationModeActive
+	}
+	state := &VacationState{
+		SinglesLive: account.SinglesLive,
+		SealedLive:  account.SealedLive,
+		StartedAt:   time.Now(),
+	}
+
+	if _, err := c.UpdateSellerAccount(ctx, SellerAccountUpdate{SinglesLive: Bool(false), SealedLive: Bool(false)}); err != nil {
+		return nil, fmt.Errorf("failed to enable vacation mode: %w", err)
+	}
+	return state, nil
+}
+
+// DisableVacationMode puts listings back on the marketplace. If previous is
+// nil both singles and sealed are made live; otherwise the settings recorded
+// by EnableVacationMode are restored.
+func (c *Client) DisableVacationMode(ctx context.Context, previous *VacationState) (*Account, error) {
+	singles, sealed := true, true
+	if previous != nil {
+		singles, sealed = previous.SinglesLive, previous.SealedLive
+	}
+	account, err := c.UpdateSellerAccount(ctx, SellerAccountUpdate{SinglesLive: Bool(singles), SealedLive: Bool(sealed)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable vacation mode: %w", err)
+	}
+	return account, nil
+}
+
+// DisableVacationModeAt waits until at and then calls DisableVacationMode.
+// It returns immediately if at has passed, and returns ctx.Err() without
+// changing the account if ctx is cancelled first. The wait only lasts as
+// long as the process does; a long-running service should persist the
+// VacationState and schedule again after a restart.
+func (c *Client) DisableVacationModeAt(ctx context.Context, at time.Time, previous *VacationState) (*Account, error) {
+	if at.IsZero() {
+		return nil, NewValidationError("at", "time cannot be zero")
+	}
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+	}
+	return c.DisableVacationMode(ctx, previous)
+}
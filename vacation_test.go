@@ -0,0 +1,92 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_VacationMode(t *testing.T) {
+	var mu sync.Mutex
+	account := Account{Username: "seller", SinglesLive: true, SealedLive: false}
+	var updates []SellerAccountUpdate
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			var update SellerAccountUpdate
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("decode update: %v", err)
+				return
+			}
+			updates = append(updates, update)
+			if update.SinglesLive != nil {
+				account.SinglesLive = *update.SinglesLive
+			}
+			if update.SealedLive != nil {
+				account.SealedLive = *update.SealedLive
+			}
+		}
+		_ = json.NewEncoder(w).Encode(account)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	state, err := client.EnableVacationMode(ctx)
+	if err != nil {
+		t.Fatalf("EnableVacationMode() error = %v", err)
+	}
+	if !state.SinglesLive || state.SealedLive || state.StartedAt.IsZero() {
+		t.Errorf("state = %+v", state)
+	}
+	if account.SinglesLive || account.SealedLive {
+		t.Errorf("account still live: %+v", account)
+	}
+	if again, err := client.EnableVacationMode(ctx); !errors.Is(err, ErrVacationModeActive) || again != nil {
+		t.Errorf("second EnableVacationMode() = %+v, %v, want ErrVacationModeActive", again, err)
+	}
+
+	restored, err := client.DisableVacationModeAt(ctx, time.Now().Add(10*time.Millisecond), state)
+	if err != nil {
+		t.Fatalf("DisableVacationModeAt() error = %v", err)
+	}
+	if !restored.SinglesLive || restored.SealedLive {
+		t.Errorf("restored = %+v, want singles only", restored)
+	}
+
+	if _, err := client.DisableVacationMode(ctx, nil); err != nil {
+		t.Fatalf("DisableVacationMode() error = %v", err)
+	}
+	if !account.SinglesLive || !account.SealedLive {
+		t.Errorf("account = %+v, want both live", account)
+	}
+	if len(updates) != 3 {
+		t.Errorf("updates = %d, want 3", len(updates))
+	}
+}
+
+func TestClient_DisableVacationModeAt_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.DisableVacationModeAt(ctx, time.Now().Add(time.Hour), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.DisableVacationModeAt(context.Background(), time.Time{}, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// WantlistItem is a card the user wants to buy.
+type WantlistItem struct {
+	Name            string `json:"name"`
+	SetCode         string `json:"set_code,omitempty"`
+	CollectorNumber string `json:"collector_number,omitempty"`
+	Quantity        int    `json:"quantity"`
+
+	// MaxPriceCents is the most the user will pay per copy (0 means no limit).
+	MaxPriceCents int `json:"max_price_cents,omitempty"`
+
+	FinishIDs    []string `json:"finish_ids,omitempty"`
+	ConditionIDs []string `json:"condition_ids,omitempty"`
+	LanguageIDs  []string `json:"language_ids,omitempty"`
+
+	Notes   string    `json:"notes,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// Wantlist is a persistent list of wanted cards. Items are keyed by name,
+// set code and collector number; adding an existing card updates it.
+//
+// Example:
+//
+//	wants, err := manapool.LoadWantlist("wants.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := wants.Add(manapool.WantlistItem{Name: "Mana Crypt", Quantity: 1, MaxPriceCents: 15000}); err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := manapool.SaveWantlist("wants.json", wants); err != nil {
+//	    log.Fatal(err)
+//	}
+//	report, err := client.CheckWantlist(ctx, wants)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	cart, err := client.OptimizeCart(ctx, report.OptimizerRequest())
+type Wantlist struct {
+	Items []WantlistItem `json:"items"`
+}
+
+// Add adds item to the wantlist, replacing any existing entry for the same
+// card and printing. It returns a ValidationError if the item has no name or a
+// non-positive quantity.
+func (w *Wantlist) Add(item WantlistItem) error {
+	if strings.TrimSpace(item.Name) == "" {
+		return NewValidationError("name", "name cannot be empty")
+	}
+	if item.Quantity <= 0 {
+		return NewValidationError("quantity", "quantity must be positive")
+	}
+	if item.MaxPriceCents < 0 {
+		return NewValidationError("max_price_cents", "max price cannot be negative")
+	}
+	if item.AddedAt.IsZero() {
+		item.AddedAt = time.Now().UTC()
+	}
+
+	key := wantlistKey(item)
+	for i := range w.Items {
+		if wantlistKey(w.Items[i]) == key {
+			item.AddedAt = w.Items[i].AddedAt
+			w.Items[i] = item
+			return nil
+		}
+	}
+	w.Items = append(w.Items, item)
+	return nil
+}
+
+// Remove deletes the entry for the given card and printing, reporting whether
+// it was present. Use empty setCode and collectorNumber for an any-printing entry.
+func (w *Wantlist) Remove(name, setCode, collectorNumber string) bool {
+	key := wantlistKey(WantlistItem{Name: name, SetCode: setCode, CollectorNumber: collectorNumber})
+	for i := range w.Items {
+		if wantlistKey(w.Items[i]) == key {
+			w.Items = append(w.Items[:i], w.Items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of entries in the wantlist.
+func (w *Wantlist) Len() int {
+	return len(w.Items)
+}
+
+// SaveWantlist writes the wantlist to path as JSON, replacing any existing file.
+func SaveWantlist(path string, w *Wantlist) error {
+	if path == "" {
+		return NewValidationError("path", "path cannot be empty")
+	}
+	if w == nil {
+		return NewValidationError("wantlist", "wantlist cannot be nil")
+	}
+	if err := writeJSONFile(path, w); err != nil {
+		return fmt.Errorf("failed to save wantlist: %w", err)
+	}
+	return nil
+}
+
+// LoadWantlist reads a wantlist written by SaveWantlist. A missing file
+// yields an empty wantlist.
+func LoadWantlist(path string) (*Wantlist, error) {
+	if path == "" {
+		return nil, NewValidationError("path", "path cannot be empty")
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Wantlist{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load wantlist: %w", err)
+	}
+
+	var w Wantlist
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, fmt.Errorf("failed to decode wantlist: %w", err)
+	}
+	return &w, nil
+}
+
+// WantlistAvailability is the market availability of one wantlist item.
+type WantlistAvailability struct {
+	Item WantlistItem
+
+	// AvailableQuantity is the number of copies listed across matching printings.
+	AvailableQuantity int
+
+	// LowestPriceCents is the lowest price matching the item's finish and
+	// condition preferences, or nil if none is listed.
+	LowestPriceCents *int
+
+	// Affordable reports whether copies are available at or below the item's max price.
+	Affordable bool
+}
+
+// WantlistReport is the result of checking a wantlist against a price export.
+type WantlistReport struct {
+	AsOf  Timestamp
+	Items []WantlistAvailability
+}
+
+// CheckAvailability matches each wantlist item against a singles price
+// export. Items without a set code match every printing of the card.
+func (w *Wantlist) CheckAvailability(prices *SinglesPricesList) *WantlistReport {
+	report := &WantlistReport{Items: make([]WantlistAvailability, 0, len(w.Items))}
+	if prices == nil {
+		prices = &SinglesPricesList{}
+	}
+	report.AsOf = prices.Meta.AsOf
+
+	byName := make(map[string][]*SinglePriceListing)
+	for i := range prices.Data {
+		key := collectionKey(prices.Data[i].Name)
+		byName[key] = append(byName[key], &prices.Data[i])
+	}
+
+	for _, item := range w.Items {
+		availability := WantlistAvailability{Item: item}
+		for _, listing := range byName[collectionKey(item.Name)] {
+			if item.SetCode != "" && !strings.EqualFold(item.SetCode, listing.SetCode) {
+				continue
+			}
+			if item.CollectorNumber != "" && item.CollectorNumber != listing.Number {
+				continue
+			}
+			availability.AvailableQuantity += listing.AvailableQuantity
+			if price := wantlistPrice(item, listing); price != nil {
+				if availability.LowestPriceCents == nil || *price < *availability.LowestPriceCents {
+					p := *price
+					availability.LowestPriceCents = &p
+				}
+			}
+		}
+		availability.Affordable = availability.AvailableQuantity > 0 && availability.LowestPriceCents != nil &&
+			(item.MaxPriceCents == 0 || *availability.LowestPriceCents <= item.MaxPriceCents)
+		report.Items = append(report.Items, availability)
+	}
+
+	return report
+}
+
+// CheckWantlist downloads the current singles price export and checks the wantlist against it.
+func (c *Client) CheckWantlist(ctx context.Context, w *Wantlist) (*WantlistReport, error) {
+	if w == nil {
+		return nil, NewValidationError("wantlist", "wantlist cannot be nil")
+	}
+	prices, err := c.GetSinglesPrices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check wantlist: %w", err)
+	}
+	return w.CheckAvailability(prices), nil
+}
+
+// Affordable returns the items that are currently available within their max price.
+func (r *WantlistReport) Affordable() []WantlistAvailability {
+	var items []WantlistAvailability
+	for _, item := range r.Items {
+		if item.Affordable {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// OptimizerRequest builds an optimizer request for the affordable items.
+// Requested quantities are capped at the available quantity.
+func (r *WantlistReport) OptimizerRequest() OptimizerRequest {
+	req := OptimizerRequest{}
+	for _, a := range r.Affordable() {
+		quantity := a.Item.Quantity
+		if quantity > a.AvailableQuantity {
+			quantity = a.AvailableQuantity
+		}
+		req.Cart = append(req.Cart, OptimizerCartItem{
+			Type:              "mtg_single",
+			Name:              a.Item.Name,
+			SetCode:           strings.ToUpper(a.Item.SetCode),
+			CollectorNumber:   a.Item.CollectorNumber,
+			FinishIDs:         a.Item.FinishIDs,
+			ConditionIDs:      a.Item.ConditionIDs,
+			LanguageIDs:       a.Item.LanguageIDs,
+			QuantityRequested: quantity,
+		})
+	}
+	return req
+}
+
+// wantlistPrice returns the lowest listing price that satisfies the item's
+// finish and condition preferences. The export only distinguishes near mint,
+// lightly played or better, and any condition, so condition preferences are
+// mapped to the narrowest of those tiers that covers them.
+func wantlistPrice(item WantlistItem, listing *SinglePriceListing) *int {
+	tier := 0 // any condition
+	if len(item.ConditionIDs) > 0 {
+		tier = 2 // near mint
+		for _, condition := range item.ConditionIDs {
+			switch strings.ToUpper(condition) {
+			case "NM":
+			case "LP":
+				if tier > 1 {
+					tier = 1 // lightly played or better
+				}
+			default:
+				tier = 0
+			}
+		}
+	}
+
+	finishes := item.FinishIDs
+	if len(finishes) == 0 {
+		finishes = []string{"NF", "FO", "EF"}
+	}
+
+	var lowest *int
+	for _, finish := range finishes {
+		var prices [3]*int
+		switch strings.ToUpper(finish) {
+		case "NF":
+			prices = [3]*int{listing.PriceCents, listing.PriceCentsLPPlus, listing.PriceCentsNM}
+		case "FO":
+			prices = [3]*int{listing.PriceCentsFoil, listing.PriceCentsLPPlusFoil, listing.PriceCentsNMFoil}
+		case "EF":
+			prices = [3]*int{listing.PriceCentsEtched, listing.PriceCentsLPPlusEtched, listing.PriceCentsNMEtched}
+		default:
+			continue
+		}
+		if p := prices[tier]; p != nil && (lowest == nil || *p < *lowest) {
+			lowest = p
+		}
+	}
+	return lowest
+}
+
+func wantlistKey(item WantlistItem) string {
+	return collectionKey(item.Name) + "|" + strings.ToUpper(item.SetCode) + "|" + item.CollectorNumber
+}
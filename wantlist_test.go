@@ -0,0 +1,166 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestWantlist_AddRemove(t *testing.T) {
+	var w Wantlist
+	if err := w.Add(WantlistItem{Name: "Sol Ring", Quantity: 2}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	added := w.Items[0].AddedAt
+	if added.IsZero() {
+		t.Error("AddedAt not set")
+	}
+	if err := w.Add(WantlistItem{Name: "sol ring", Quantity: 3, MaxPriceCents: 200}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if w.Len() != 1 || w.Items[0].Quantity != 3 || !w.Items[0].AddedAt.Equal(added) {
+		t.Fatalf("expected entry to be updated in place: %+v", w.Items)
+	}
+	if err := w.Add(WantlistItem{Name: "Sol Ring", SetCode: "LEA", Quantity: 1}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if w.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", w.Len())
+	}
+
+	if !w.Remove("Sol Ring", "lea", "") || w.Len() != 1 {
+		t.Error("Remove() did not remove the LEA entry")
+	}
+	if w.Remove("Sol Ring", "LEA", "") {
+		t.Error("Remove() reported a missing entry as removed")
+	}
+
+	tests := []struct {
+		name  string
+		item  WantlistItem
+		field string
+	}{
+		{"no name", WantlistItem{Quantity: 1}, "name"},
+		{"no quantity", WantlistItem{Name: "Card"}, "quantity"},
+		{"negative price", WantlistItem{Name: "Card", Quantity: 1, MaxPriceCents: -1}, "max_price_cents"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := w.Add(tt.item); !errors.As(err, &valErr) || valErr.Field != tt.field {
+				t.Fatalf("expected %s ValidationError, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestWantlist_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wants.json")
+
+	empty, err := LoadWantlist(path)
+	if err != nil || empty.Len() != 0 {
+		t.Fatalf("LoadWantlist() on missing file = %+v, %v", empty, err)
+	}
+
+	w := &Wantlist{}
+	_ = w.Add(WantlistItem{Name: "Sol Ring", Quantity: 1, FinishIDs: []string{"FO"}})
+	if err := SaveWantlist(path, w); err != nil {
+		t.Fatalf("SaveWantlist() error = %v", err)
+	}
+	loaded, err := LoadWantlist(path)
+	if err != nil {
+		t.Fatalf("LoadWantlist() error = %v", err)
+	}
+	if loaded.Len() != 1 || loaded.Items[0].FinishIDs[0] != "FO" {
+		t.Fatalf("unexpected loaded wantlist: %+v", loaded)
+	}
+
+	var valErr *ValidationError
+	if err := SaveWantlist("", w); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+	if err := SaveWantlist(path, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestWantlist_CheckAvailability(t *testing.T) {
+	prices := &SinglesPricesList{Data: []SinglePriceListing{
+		{Name: "Sol Ring", SetCode: "C21", Number: "263", AvailableQuantity: 10, PriceCents: Int(150), PriceCentsNM: Int(200), PriceCentsFoil: Int(900)},
+		{Name: "Sol Ring", SetCode: "LEA", Number: "270", AvailableQuantity: 1, PriceCents: Int(200000)},
+		{Name: "Mana Crypt", SetCode: "2XM", AvailableQuantity: 2, PriceCents: Int(20000)},
+		{Name: "Black Lotus", SetCode: "LEA", AvailableQuantity: 0},
+	}}
+
+	w := &Wantlist{}
+	_ = w.Add(WantlistItem{Name: "Sol Ring", Quantity: 20, MaxPriceCents: 300})
+	_ = w.Add(WantlistItem{Name: "Sol Ring", SetCode: "lea", Quantity: 1, MaxPriceCents: 1000})
+	_ = w.Add(WantlistItem{Name: "Sol Ring", SetCode: "C21", Quantity: 1, ConditionIDs: []string{"NM"}})
+	_ = w.Add(WantlistItem{Name: "Sol Ring", SetCode: "C21", CollectorNumber: "263", Quantity: 1, FinishIDs: []string{"FO"}, MaxPriceCents: 500})
+	_ = w.Add(WantlistItem{Name: "Mana Crypt", Quantity: 1})
+	_ = w.Add(WantlistItem{Name: "Black Lotus", Quantity: 1})
+
+	report := w.CheckAvailability(prices)
+	want := []struct {
+		available  int
+		lowest     int
+		affordable bool
+	}{
+		{11, 150, true},
+		{1, 200000, false},
+		{10, 200, true},
+		{10, 900, false},
+		{2, 20000, true},
+		{0, -1, false},
+	}
+	for i, tt := range want {
+		got := report.Items[i]
+		lowest := -1
+		if got.LowestPriceCents != nil {
+			lowest = *got.LowestPriceCents
+		}
+		if got.AvailableQuantity != tt.available || lowest != tt.lowest || got.Affordable != tt.affordable {
+			t.Errorf("item %d (%s) = %d available, %d lowest, affordable %v; want %+v",
+				i, got.Item.Name, got.AvailableQuantity, lowest, got.Affordable, tt)
+		}
+	}
+
+	req := report.OptimizerRequest()
+	if len(req.Cart) != 3 {
+		t.Fatalf("cart items = %d, want 3", len(req.Cart))
+	}
+	if req.Cart[0].QuantityRequested != 11 || req.Cart[1].SetCode != "C21" || req.Cart[1].ConditionIDs[0] != "NM" {
+		t.Errorf("unexpected optimizer request: %+v", req.Cart)
+	}
+}
+
+func TestClient_CheckWantlist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prices/singles" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"as_of":"2024-04-01T00:00:00Z"},"data":[{"name":"Sol Ring","set_code":"C21","number":"263","available_quantity":3,"price_cents":150}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	w := &Wantlist{}
+	_ = w.Add(WantlistItem{Name: "Sol Ring", Quantity: 1})
+
+	report, err := client.CheckWantlist(context.Background(), w)
+	if err != nil {
+		t.Fatalf("CheckWantlist() error = %v", err)
+	}
+	if report.AsOf.IsZero() || len(report.Affordable()) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	var valErr *ValidationError
+	if _, err := client.CheckWantlist(context.Background(), nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/repricah/manapool/internal/parallel"
+)
+
+// WarmUp opens connections to the API ahead of a latency-sensitive burst,
+// such as a restock sync at a set's release time, so the burst's first calls
+// do not pay for DNS, TCP and TLS handshakes. It sends up to the client's
+// concurrency (see WithConcurrency) HEAD requests to the base URL at once.
+// They carry no credentials and do not use the rate limit; any HTTP response,
+// whatever its status, counts as success.
+//
+// The default transport keeps two idle connections per host. To keep more
+// warm, pass WithHTTPClient an http.Client whose Transport raises
+// MaxIdleConnsPerHost.
+//
+// Example:
+//
+//	if err := client.WarmUp(ctx); err != nil {
+//	    log.Printf("warm-up failed: %v", err)
+//	}
+//	n, err := client.ApplyReprice(ctx, changes)
+func (c *Client) WarmUp(ctx context.Context) error {
+	ctx, _ = ensureCorrelationID(ctx)
+	n := c.concurrency
+	err := parallel.Run(ctx, n, parallel.Options{Concurrency: n}, func(ctx context.Context, i int) error {
+		return c.ping(ctx)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to warm up connections: %w", err)
+	}
+	c.loggerFor(ctx).Debugf("Warmed up %d connections", n)
+	return nil
+}
+
+// KeepWarm calls WarmUp every interval until ctx is cancelled, so pooled
+// connections are not closed as idle between bursts. The interval should be
+// shorter than the transport's IdleConnTimeout (90 seconds by default).
+// Failed warm-ups are passed to onError if it is not nil. KeepWarm returns
+// ctx's error once it is cancelled.
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	go client.KeepWarm(ctx, 30*time.Second, nil)
+func (c *Client) KeepWarm(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		if err := c.WarmUp(ctx); err != nil && ctx.Err() == nil {
+			return err
+		}
+		return nil
+	}, onError)
+}
+
+// ping sends a HEAD request to the base URL and discards the response.
+func (c *Client) ping(ctx context.Context) error {
+	correlationID := CorrelationID(ctx)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURL, nil)
+	if err != nil {
+		netErr := NewNetworkError("failed to create request", err)
+		netErr.CorrelationID = correlationID
+		return netErr
+	}
+	req.Header.Set("User-Agent", c.config().userAgent)
+	req.Header.Set(CorrelationIDHeader, correlationID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		netErr := NewNetworkError("warm-up request failed", err)
+		netErr.CorrelationID = correlationID
+		return netErr
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+	return resp.Body.Close()
+}
@@ -0,0 +1,94 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_WarmUp(t *testing.T) {
+	var pings, conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		if r.Header.Get("X-ManaPool-Access-Token") != "" {
+			t.Error("warm-up request should not carry credentials")
+		}
+		if r.Header.Get(CorrelationIDHeader) == "" {
+			t.Error("missing correlation ID header")
+		}
+		pings.Add(1)
+		// Hold each request briefly so they need separate connections.
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithConcurrency(3))
+	if err := client.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+	if got := pings.Load(); got != 3 {
+		t.Errorf("pings = %d, want 3", got)
+	}
+	if got := conns.Load(); got != 3 {
+		t.Errorf("connections = %d, want 3", got)
+	}
+}
+
+func TestClient_WarmUp_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(url+"/"), WithConcurrency(2))
+	err := client.WarmUp(context.Background())
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("WarmUp() error = %v, want NetworkError", err)
+	}
+	if netErr.CorrelationID == "" {
+		t.Error("NetworkError.CorrelationID should be set")
+	}
+}
+
+func TestClient_KeepWarm(t *testing.T) {
+	var pings atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pings.Add(1)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"), WithConcurrency(1))
+	ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+	defer cancel()
+	err := client.KeepWarm(ctx, 10*time.Millisecond, func(err error) {
+		t.Errorf("unexpected warm-up error: %v", err)
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("KeepWarm() error = %v, want deadline exceeded", err)
+	}
+	if got := pings.Load(); got < 3 {
+		t.Errorf("pings = %d, want at least 3", got)
+	}
+}
+
+func TestClient_KeepWarm_InvalidInterval(t *testing.T) {
+	client := NewClient("test-token", "test@example.com")
+	var validationErr *ValidationError
+	if err := client.KeepWarm(context.Background(), 0, nil); !errors.As(err, &validationErr) {
+		t.Errorf("KeepWarm() error = %v, want ValidationError", err)
+	}
+}
@@ -0,0 +1,190 @@
+package manapool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultOrderBackfillLookback is how far back OrderEventSource polls for
+// orders by default.
+const DefaultOrderBackfillLookback = 24 * time.Hour
+
+// OrderEventSource merges order_created webhooks with periodic polling of
+// seller orders, so orders created while the webhook receiver was down are
+// still delivered. Every order is passed to Handler once, whichever path sees
+// it first; events are de-duplicated by order ID and creation time.
+//
+// Register HandleWebhook with a WebhookHandler and call Run to poll. Other
+// webhook topics are passed through to Handler unchanged.
+//
+// Example:
+//
+//	source := &manapool.OrderEventSource{
+//	    Client: client,
+//	    Since:  lastProcessed, // backfill orders missed since the last run
+//	    Handler: func(ctx context.Context, event manapool.WebhookEvent) error {
+//	        return fulfil(event.(*manapool.OrderCreatedEvent).Order)
+//	    },
+//	}
+//	webhooks := manapool.NewWebhookHandler(secret)
+//	webhooks.Handle(manapool.WebhookTopicOrderCreated, source.HandleWebhook)
+//	go source.Run(ctx, 5*time.Minute, func(err error) { log.Println(err) })
+type OrderEventSource struct {
+	// Client is used to list and fetch seller orders.
+	Client *Client
+
+	// Handler receives the unified event stream. Returning an error leaves
+	// the order unmarked so a later webhook retry or poll delivers it again.
+	Handler WebhookHandlerFunc
+
+	// Lookback limits polling to orders created within this window (default: DefaultOrderBackfillLookback).
+	Lookback time.Duration
+
+	// Since makes the first poll deliver orders created after this time.
+	// When zero, the first poll only records existing orders.
+	Since time.Time
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	primed bool
+	seen   map[string]time.Time
+}
+
+// HandleWebhook delivers a webhook event to Handler unless the order was
+// already delivered. It has the WebhookHandlerFunc signature.
+func (s *OrderEventSource) HandleWebhook(ctx context.Context, event WebhookEvent) error {
+	created, ok := event.(*OrderCreatedEvent)
+	if !ok {
+		return s.Handler(ctx, event)
+	}
+	return s.deliver(ctx, created)
+}
+
+// Poll lists recent seller orders and delivers those not seen before. It
+// returns the number of orders delivered.
+func (s *OrderEventSource) Poll(ctx context.Context) (int, error) {
+	if s.Client == nil {
+		return 0, NewValidationError("client", "client cannot be nil")
+	}
+	if s.Handler == nil {
+		return 0, NewValidationError("handler", "handler cannot be nil")
+	}
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	lookback := s.Lookback
+	if lookback <= 0 {
+		lookback = DefaultOrderBackfillLookback
+	}
+
+	since := now().Add(-lookback)
+	s.mu.Lock()
+	primed := s.primed
+	s.mu.Unlock()
+	if !primed && !s.Since.IsZero() && s.Since.Before(since) {
+		since = s.Since
+	}
+
+	var summaries []OrderSummary
+	opts := OrdersOptions{Since: &Timestamp{Time: since}}
+	err := s.Client.IterateSellerOrders(ctx, opts, func(order *OrderSummary) error {
+		summaries = append(summaries, *order)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to poll seller orders: %w", err)
+	}
+
+	delivered := 0
+	var firstErr error
+	for _, summary := range summaries {
+		key := orderEventKey(summary.ID, summary.CreatedAt.Time)
+		s.mu.Lock()
+		_, seen := s.seen[key]
+		if !primed && !seen && (s.Since.IsZero() || !summary.CreatedAt.After(s.Since)) {
+			s.markLocked(key, summary.CreatedAt.Time)
+			seen = true
+		}
+		s.mu.Unlock()
+		if seen {
+			continue
+		}
+
+		resp, err := s.Client.GetSellerOrder(ctx, summary.ID)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to fetch order %s: %w", summary.ID, err)
+			}
+			continue
+		}
+		if err := s.deliver(ctx, &OrderCreatedEvent{Order: resp.Order}); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("handler failed for order %s: %w", summary.ID, err)
+			}
+			continue
+		}
+		delivered++
+	}
+
+	s.mu.Lock()
+	s.primed = true
+	for key, created := range s.seen {
+		if created.Before(since) {
+			delete(s.seen, key)
+		}
+	}
+	s.mu.Unlock()
+
+	return delivered, firstErr
+}
+
+// Run calls Poll every interval until ctx is cancelled, passing each failed
+// poll's error to onError if it is not nil.
+func (s *OrderEventSource) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		_, err := s.Poll(ctx)
+		return err
+	}, onError)
+}
+
+// deliver passes event to Handler if its order has not been delivered. The
+// order is marked before calling Handler so concurrent webhook and poll
+// deliveries do not both go through, and unmarked if Handler fails.
+func (s *OrderEventSource) deliver(ctx context.Context, event *OrderCreatedEvent) error {
+	if s.Handler == nil {
+		return NewValidationError("handler", "handler cannot be nil")
+	}
+
+	key := orderEventKey(event.Order.ID, event.Order.CreatedAt.Time)
+	s.mu.Lock()
+	if _, ok := s.seen[key]; ok {
+		s.mu.Unlock()
+		return nil
+	}
+	s.markLocked(key, event.Order.CreatedAt.Time)
+	s.mu.Unlock()
+
+	if err := s.Handler(ctx, event); err != nil {
+		s.mu.Lock()
+		delete(s.seen, key)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *OrderEventSource) markLocked(key string, created time.Time) {
+	if s.seen == nil {
+		s.seen = make(map[string]time.Time)
+	}
+	s.seen[key] = created
+}
+
+func orderEventKey(id string, created time.Time) string {
+	return id + "|" + created.UTC().Format(time.RFC3339Nano)
+}
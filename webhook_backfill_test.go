@@ -0,0 +1,145 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOrderEventSource(t *testing.T) {
+	var mu sync.Mutex
+	orders := []string{"o1"}
+	created := map[string]string{
+		"o1": "2024-05-01T10:00:00Z",
+		"o2": "2024-05-01T11:00:00Z",
+		"o3": "2024-05-01T11:30:00Z",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.URL.Path == "/seller/orders":
+			if r.URL.Query().Get("since") == "" {
+				t.Error("poll did not set since")
+			}
+			var items []string
+			for _, id := range orders {
+				items = append(items, fmt.Sprintf(`{"id":%q,"created_at":%q}`, id, created[id]))
+			}
+			_, _ = fmt.Fprintf(w, `{"orders":[%s]}`, strings.Join(items, ","))
+		case strings.HasPrefix(r.URL.Path, "/seller/orders/"):
+			id := strings.TrimPrefix(r.URL.Path, "/seller/orders/")
+			_, _ = fmt.Fprintf(w, `{"order":{"id":%q,"created_at":%q}}`, id, created[id])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var delivered []string
+	failNext := false
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	source := &OrderEventSource{
+		Client: client,
+		Now:    func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) },
+		Handler: func(ctx context.Context, event WebhookEvent) error {
+			if failNext {
+				failNext = false
+				return errors.New("consumer bug")
+			}
+			if e, ok := event.(*OrderCreatedEvent); ok {
+				delivered = append(delivered, e.Order.ID)
+			} else {
+				delivered = append(delivered, "other")
+			}
+			return nil
+		},
+	}
+	ctx := context.Background()
+
+	// The first poll records existing orders without delivering them.
+	if n, err := source.Poll(ctx); err != nil || n != 0 {
+		t.Fatalf("first Poll() = %d, %v; want 0, nil", n, err)
+	}
+
+	// A webhook for a new order is delivered once, even if repeated.
+	webhookEvent, err := DecodeWebhookEvent([]byte(fmt.Sprintf(`{"order":{"id":"o2","created_at":%q}}`, created["o2"])))
+	if err != nil {
+		t.Fatalf("DecodeWebhookEvent() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := source.HandleWebhook(ctx, webhookEvent); err != nil {
+			t.Fatalf("HandleWebhook() error = %v", err)
+		}
+	}
+
+	// o3's webhook was missed; polling backfills it and skips o2.
+	mu.Lock()
+	orders = []string{"o1", "o2", "o3"}
+	mu.Unlock()
+	failNext = true
+	if n, err := source.Poll(ctx); err == nil || n != 0 {
+		t.Fatalf("Poll() with failing handler = %d, %v; want 0 and an error", n, err)
+	}
+	if n, err := source.Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() = %d, %v; want 1, nil", n, err)
+	}
+	if n, err := source.Poll(ctx); err != nil || n != 0 {
+		t.Fatalf("repeat Poll() = %d, %v; want 0, nil", n, err)
+	}
+
+	if err := source.HandleWebhook(ctx, &UnknownWebhookEvent{Topic: "payout_sent"}); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+	if strings.Join(delivered, ",") != "o2,o3,other" {
+		t.Errorf("delivered = %v, want [o2 o3 other]", delivered)
+	}
+}
+
+func TestOrderEventSource_Since(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/seller/orders":
+			if got := r.URL.Query().Get("since"); !strings.HasPrefix(got, "2024-04-01") {
+				t.Errorf("since = %q, want the configured Since", got)
+			}
+			_, _ = w.Write([]byte(`{"orders":[{"id":"old","created_at":"2024-03-31T00:00:00Z"},{"id":"missed","created_at":"2024-04-15T00:00:00Z"}]}`))
+		case "/seller/orders/missed":
+			_, _ = w.Write([]byte(`{"order":{"id":"missed","created_at":"2024-04-15T00:00:00Z"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var delivered []string
+	source := &OrderEventSource{
+		Client: NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/")),
+		Since:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		Now:    func() time.Time { return time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) },
+		Handler: func(ctx context.Context, event WebhookEvent) error {
+			delivered = append(delivered, event.(*OrderCreatedEvent).Order.ID)
+			return nil
+		},
+	}
+	if n, err := source.Poll(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Poll() = %d, %v; want 1, nil", n, err)
+	}
+	if len(delivered) != 1 || delivered[0] != "missed" {
+		t.Errorf("delivered = %v, want [missed]", delivered)
+	}
+
+	var valErr *ValidationError
+	if _, err := (&OrderEventSource{}).Poll(context.Background()); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for missing client, got %v", err)
+	}
+	if err := source.Run(context.Background(), 0, nil); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for interval, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrWebhookSubscriptionClosed is returned for events whose
+	// subscription's context has been cancelled.
+	ErrWebhookSubscriptionClosed = errors.New("webhook subscription closed")
+
+	// ErrWebhookSubscriptionFull is returned for events that arrive while a
+	// buffered subscription's buffer is full.
+	ErrWebhookSubscriptionFull = errors.New("webhook subscription buffer full")
+)
+
+// Subscribe registers a handler for topic that sends each event to the
+// returned channel, replacing any previous handler for topic. Several
+// goroutines may receive from the channel to process events concurrently.
+//
+// With a zero buffer, a request is acknowledged only once a consumer has
+// received its event; if none does before the request is cancelled, the
+// handler fails so the delivery is retried.
+//
+// With a positive buffer, a request is acknowledged as soon as its event is
+// buffered, so delivery is at most once: events still buffered when ctx is
+// cancelled are dropped. Events that arrive while the buffer is full fail
+// with ErrWebhookSubscriptionFull and are answered with 503 Service
+// Unavailable, so the sender retries them later.
+//
+// When ctx is cancelled the channel is closed and later events for topic
+// fail with ErrWebhookSubscriptionClosed, also answered with 503, until
+// another handler is registered.
+//
+// Example:
+//
+//	orders := handler.Subscribe(ctx, manapool.WebhookTopicOrderCreated, 16)
+//	for i := 0; i < 4; i++ {
+//	    go func() {
+//	        for event := range orders {
+//	            fulfil(event.(*manapool.OrderCreatedEvent).Order)
+//	        }
+//	    }()
+//	}
+func (h *WebhookHandler) Subscribe(ctx context.Context, topic string, buffer int) <-chan WebhookEvent {
+	if buffer < 0 {
+		buffer = 0
+	}
+	events := make(chan WebhookEvent, buffer)
+
+	// closed is guarded by mu; senders hold the read lock so the channel is
+	// never closed while a send is in progress.
+	var mu sync.RWMutex
+	closed := false
+
+	h.Handle(topic, func(reqCtx context.Context, event WebhookEvent) error {
+		mu.RLock()
+		defer mu.RUnlock()
+		if closed {
+			return ErrWebhookSubscriptionClosed
+		}
+		if buffer > 0 {
+			select {
+			case events <- event:
+				return nil
+			default:
+				return ErrWebhookSubscriptionFull
+			}
+		}
+		select {
+		case events <- event:
+			return nil
+		case <-ctx.Done():
+			return ErrWebhookSubscriptionClosed
+		case <-reqCtx.Done():
+			return reqCtx.Err()
+		}
+	})
+
+	go func() {
+		<-ctx.Done()
+		mu.Lock()
+		closed = true
+		close(events)
+		mu.Unlock()
+	}()
+
+	return events
+}
@@ -0,0 +1,118 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler_Subscribe(t *testing.T) {
+	handler := NewWebhookHandler("secret")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := handler.Subscribe(ctx, WebhookTopicOrderCreated, 0)
+
+	send := func(reqCtx context.Context) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(orderCreatedBody)).WithContext(reqCtx)
+		req.Header.Set(WebhookSignatureHeader, WebhookSignatureHeaderValue("secret", time.Now().Unix(), []byte(orderCreatedBody)))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Events fan out to concurrent consumers.
+	var mu sync.Mutex
+	var received []string
+	var consumers sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for event := range events {
+				mu.Lock()
+				received = append(received, event.(*OrderCreatedEvent).Order.ID)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var senders sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			if code := send(context.Background()); code != http.StatusOK {
+				t.Errorf("status = %d, want 200", code)
+			}
+		}()
+	}
+	senders.Wait()
+
+	// Cancelling the subscription closes the channel and fails later events.
+	cancel()
+	consumers.Wait()
+	if len(received) != 5 {
+		t.Errorf("received %d events, want 5", len(received))
+	}
+	if code := send(context.Background()); code != http.StatusServiceUnavailable {
+		t.Errorf("status after cancel = %d, want 503", code)
+	}
+}
+
+func TestWebhookHandler_SubscribeBufferFull(t *testing.T) {
+	var handlerErr error
+	handler := NewWebhookHandler("secret")
+	handler.OnError = func(err error) { handlerErr = err }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := handler.Subscribe(ctx, WebhookTopicOrderCreated, 1)
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(orderCreatedBody))
+		req.Header.Set(WebhookSignatureHeader, WebhookSignatureHeaderValue("secret", time.Now().Unix(), []byte(orderCreatedBody)))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send(); code != http.StatusOK {
+		t.Fatalf("buffered status = %d, want 200", code)
+	}
+	if code := send(); code != http.StatusServiceUnavailable {
+		t.Errorf("full buffer status = %d, want 503", code)
+	}
+	if !errors.Is(handlerErr, ErrWebhookSubscriptionFull) {
+		t.Errorf("OnError got %v, want ErrWebhookSubscriptionFull", handlerErr)
+	}
+
+	<-events
+	if code := send(); code != http.StatusOK {
+		t.Errorf("status after drain = %d, want 200", code)
+	}
+}
+
+func TestWebhookHandler_SubscribeNoConsumer(t *testing.T) {
+	var handlerErr error
+	handler := NewWebhookHandler("secret")
+	handler.OnError = func(err error) { handlerErr = err }
+	handler.Subscribe(context.Background(), WebhookTopicOrderCreated, 0)
+
+	reqCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(orderCreatedBody)).WithContext(reqCtx)
+	req.Header.Set(WebhookSignatureHeader, WebhookSignatureHeaderValue("secret", time.Now().Unix(), []byte(orderCreatedBody)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	if !errors.Is(handlerErr, context.DeadlineExceeded) {
+		t.Errorf("OnError got %v, want deadline exceeded", handlerErr)
+	}
+}
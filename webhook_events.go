@@ -0,0 +1,92 @@
+package manapool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// WebhookTopicOrderCreated is sent when a buyer places an order with the seller.
+// It is currently the only topic the API delivers; there are no payout
+// topics, so settlement data is limited to the payout IDs on order report
+// charges (see GroupChargesByPayout). Topics added later are decoded as
+// *UnknownWebhookEvent until this package supports them.
+const WebhookTopicOrderCreated = "order_created"
+
+// WebhookEvent is a decoded webhook payload. Use a type switch on the
+// concrete event types to handle each topic.
+type WebhookEvent interface {
+	// WebhookTopic returns the topic the event was delivered for.
+	WebhookTopic() string
+}
+
+// OrderCreatedEvent is the payload of an order_created webhook.
+type OrderCreatedEvent struct {
+	Order OrderDetails `json:"order"`
+}
+
+// WebhookTopic returns WebhookTopicOrderCreated.
+func (e *OrderCreatedEvent) WebhookTopic() string {
+	return WebhookTopicOrderCreated
+}
+
+// UnknownWebhookEvent holds a payload for a topic this package does not
+// recognize, so new topics can be handled before the package supports them.
+type UnknownWebhookEvent struct {
+	Topic   string
+	Payload json.RawMessage
+}
+
+// WebhookTopic returns the topic the event was delivered for, which may be
+// empty if the topic could not be determined.
+func (e *UnknownWebhookEvent) WebhookTopic() string {
+	return e.Topic
+}
+
+// DecodeWebhookEvent decodes a webhook body, identifying the topic from the
+// payload's shape. Bodies that match no known topic are returned as an
+// *UnknownWebhookEvent. When the X-ManaPool-Event header is available, prefer
+// DecodeWebhookEventForTopic.
+//
+// Example:
+//
+//	event, err := manapool.DecodeWebhookEvent(body)
+//	if err != nil {
+//	    return err
+//	}
+//	switch e := event.(type) {
+//	case *manapool.OrderCreatedEvent:
+//	    fmt.Printf("New order %s\n", e.Order.ID)
+//	}
+func DecodeWebhookEvent(body []byte) (WebhookEvent, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	if _, ok := fields["order"]; ok {
+		return DecodeWebhookEventForTopic(WebhookTopicOrderCreated, body)
+	}
+	return &UnknownWebhookEvent{Payload: append(json.RawMessage(nil), body...)}, nil
+}
+
+// DecodeWebhookEventForTopic decodes a webhook body delivered for topic.
+// Unknown topics are returned as an *UnknownWebhookEvent.
+func DecodeWebhookEventForTopic(topic string, body []byte) (WebhookEvent, error) {
+	switch topic {
+	case WebhookTopicOrderCreated:
+		var event OrderCreatedEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return nil, fmt.Errorf("failed to decode %s webhook event: %w", topic, err)
+		}
+		if event.Order.ID == "" {
+			return nil, NewValidationError("order", "order_created event has no order")
+		}
+		return &event, nil
+	default:
+		if !json.Valid(bytes.TrimSpace(body)) {
+			return nil, fmt.Errorf("failed to decode %s webhook event: invalid JSON", topic)
+		}
+		return &UnknownWebhookEvent{Topic: topic, Payload: append(json.RawMessage(nil), body...)}, nil
+	}
+}
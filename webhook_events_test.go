@@ -0,0 +1,65 @@
+package manapool
+
+import (
+	"errors"
+	"testing"
+)
+
+const orderCreatedBody = `{"order":{"id":"order-1","created_at":"2024-05-01T12:00:00Z","label":"ABC","total_cents":1500,"shipping_method":"USPS","latest_fulfillment_status":null,"buyer_id":"buyer-1","shipping_address":{"name":"Jane","line1":"1 Main St","city":"Springfield","state":"IL","postal_code":"62701","country":"US"},"payment":{},"fulfillments":[],"items":[]}}`
+
+func TestDecodeWebhookEvent(t *testing.T) {
+	event, err := DecodeWebhookEvent([]byte(orderCreatedBody))
+	if err != nil {
+		t.Fatalf("DecodeWebhookEvent() error = %v", err)
+	}
+	created, ok := event.(*OrderCreatedEvent)
+	if !ok {
+		t.Fatalf("event type = %T, want *OrderCreatedEvent", event)
+	}
+	if created.WebhookTopic() != WebhookTopicOrderCreated || created.Order.ID != "order-1" ||
+		created.Order.TotalCents != 1500 || created.Order.ShippingAddress.City != "Springfield" {
+		t.Errorf("unexpected event: %+v", created)
+	}
+
+	event, err = DecodeWebhookEvent([]byte(`{"payout":{"id":"p1"}}`))
+	if err != nil {
+		t.Fatalf("DecodeWebhookEvent() error = %v", err)
+	}
+	unknown, ok := event.(*UnknownWebhookEvent)
+	if !ok || unknown.WebhookTopic() != "" || string(unknown.Payload) != `{"payout":{"id":"p1"}}` {
+		t.Errorf("unexpected unknown event: %#v", event)
+	}
+
+	if _, err := DecodeWebhookEvent([]byte(`not json`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestDecodeWebhookEventForTopic(t *testing.T) {
+	event, err := DecodeWebhookEventForTopic(WebhookTopicOrderCreated, []byte(orderCreatedBody))
+	if err != nil {
+		t.Fatalf("DecodeWebhookEventForTopic() error = %v", err)
+	}
+	if _, ok := event.(*OrderCreatedEvent); !ok {
+		t.Errorf("event type = %T, want *OrderCreatedEvent", event)
+	}
+
+	event, err = DecodeWebhookEventForTopic("payout_sent", []byte(`{"id":"p1"}`))
+	if err != nil {
+		t.Fatalf("DecodeWebhookEventForTopic() error = %v", err)
+	}
+	if event.WebhookTopic() != "payout_sent" {
+		t.Errorf("WebhookTopic() = %q, want payout_sent", event.WebhookTopic())
+	}
+
+	var valErr *ValidationError
+	if _, err := DecodeWebhookEventForTopic(WebhookTopicOrderCreated, []byte(`{}`)); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for missing order, got %v", err)
+	}
+	if _, err := DecodeWebhookEventForTopic(WebhookTopicOrderCreated, []byte(`{"order":`)); err == nil {
+		t.Error("expected error for truncated body")
+	}
+	if _, err := DecodeWebhookEventForTopic("other", []byte(`{"id":`)); err == nil {
+		t.Error("expected error for invalid JSON on unknown topic")
+	}
+}
@@ -0,0 +1,339 @@
+package manapool
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers sent with every webhook request.
+const (
+	WebhookEventHeader     = "X-ManaPool-Event"
+	WebhookTimestampHeader = "X-ManaPool-Timestamp"
+	WebhookSignatureHeader = "X-ManaPool-Signature"
+)
+
+const (
+	// DefaultWebhookTolerance is the maximum age of a webhook request accepted
+	// by WebhookHandler.
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	// DefaultWebhookMaxBodyBytes is the largest webhook body read by WebhookHandler.
+	DefaultWebhookMaxBodyBytes = 1 << 20
+)
+
+// SignWebhookPayload returns the v1 signature for body sent at timestamp
+// (Unix seconds): the hex-encoded HMAC SHA-256 of "v1:<timestamp>:<body>".
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v1:%d:", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSignatureHeaderValue returns the X-ManaPool-Signature header value
+// for body sent at timestamp. It is useful for testing webhook receivers.
+func WebhookSignatureHeaderValue(secret string, timestamp int64, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, SignWebhookPayload(secret, timestamp, body))
+}
+
+// VerifyWebhookSignature checks a webhook request's X-ManaPool-Signature
+// header against body. Requests signed more than tolerance before or after
+// now are rejected; a zero or negative tolerance disables the age check.
+//
+// Example:
+//
+//	err := manapool.VerifyWebhookSignature(secret, r.Header.Get(manapool.WebhookSignatureHeader),
+//	    body, time.Now(), 5*time.Minute)
+func VerifyWebhookSignature(secret, signatureHeader string, body []byte, now time.Time, tolerance time.Duration) error {
+	if secret == "" {
+		return NewValidationError("secret", "webhook secret cannot be empty")
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signatureHeader, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return NewValidationError("signature", "signature header is missing t or v1")
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return NewValidationError("signature", fmt.Sprintf("invalid signature timestamp %q", timestamp))
+	}
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(ts, 0))
+		if age > tolerance || age < -tolerance {
+			return NewValidationError("signature", fmt.Sprintf("signature timestamp is outside the %s tolerance", tolerance))
+		}
+	}
+
+	expected := []byte(SignWebhookPayload(secret, ts, body))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+	return NewValidationError("signature", "signature does not match")
+}
+
+// WebhookHandlerFunc handles a decoded webhook event. Returning an error
+// responds with 500 so the delivery is not acknowledged, unless
+// WebhookHandler.Store is set, in which case the event is retried later.
+type WebhookHandlerFunc func(ctx context.Context, event WebhookEvent) error
+
+// WebhookHandler is an http.Handler that receives ManaPool webhooks. It
+// verifies each request's signature, decodes the event and calls the
+// function registered for its topic. Responses are:
+//
+//   - 200 when the event was handled, or no handler is registered for its topic
+//   - 400 for a body that cannot be decoded
+//   - 401 for a missing, invalid or expired signature
+//   - 405 for methods other than POST
+//   - 413 for bodies larger than MaxBodyBytes
+//   - 500 when the topic's handler returns an error, or when Store is set
+//     and the event cannot be saved
+//
+// Example:
+//
+//	handler := manapool.NewWebhookHandler(os.Getenv("MANAPOOL_WEBHOOK_SECRET"))
+//	handler.Handle(manapool.WebhookTopicOrderCreated, func(ctx context.Context, event manapool.WebhookEvent) error {
+//	    order := event.(*manapool.OrderCreatedEvent).Order
+//	    log.Printf("New order %s", order.ID)
+//	    return nil
+//	})
+//	http.Handle("/webhooks/manapool", handler)
+//	log.Fatal(http.ListenAndServe(":8080", nil))
+type WebhookHandler struct {
+	// Secret is the webhook secret returned by RegisterWebhook.
+	Secret string
+
+	// Tolerance is the maximum signature age (default: DefaultWebhookTolerance).
+	// A negative Tolerance disables the age check, for replaying old
+	// deliveries; the signature itself is still verified.
+	Tolerance time.Duration
+
+	// MaxBodyBytes limits the request body (default: DefaultWebhookMaxBodyBytes).
+	MaxBodyBytes int64
+
+	// OnError, if set, is called with every rejected request's or failed
+	// handler's error.
+	OnError func(error)
+
+	// Store, if set, records every verified event and its outcome. A failed
+	// handler is then acknowledged with 200 and retried by RetryPending with
+	// exponential backoff, until it has failed MaxAttempts times and becomes
+	// a dead letter. Redeliveries are acknowledged without calling the
+	// handler when the event was processed, is a dead letter (see Replay),
+	// is waiting for its next retry or is being handled at the same time.
+	Store WebhookEventStore
+
+	// MaxAttempts is the number of handler attempts before an event becomes
+	// a dead letter (default: DefaultWebhookMaxAttempts).
+	MaxAttempts int
+
+	// RetryBackoff is the delay before the first retry, doubling for each
+	// later retry up to an hour (default: DefaultWebhookRetryBackoff).
+	RetryBackoff time.Duration
+
+	// Now returns the current time (default: time.Now). Useful in tests.
+	Now func() time.Time
+
+	mu         sync.RWMutex
+	handlers   map[string]WebhookHandlerFunc
+	processing map[string]bool // IDs of stored events being handled
+}
+
+// NewWebhookHandler creates a webhook handler that verifies requests with secret.
+func NewWebhookHandler(secret string) *WebhookHandler {
+	return &WebhookHandler{Secret: secret}
+}
+
+// Handle registers fn for events with topic, replacing any previous handler.
+// Events for unknown topics are delivered as *UnknownWebhookEvent.
+func (h *WebhookHandler) Handle(topic string, fn WebhookHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.handlers == nil {
+		h.handlers = make(map[string]WebhookHandlerFunc)
+	}
+	h.handlers[topic] = fn
+}
+
+// ServeHTTP implements http.Handler.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.fail(w, http.StatusMethodNotAllowed, fmt.Errorf("webhook: method %s not allowed", r.Method))
+		return
+	}
+
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultWebhookMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.fail(w, http.StatusRequestEntityTooLarge, fmt.Errorf("webhook: body exceeds %d bytes", maxBytes))
+			return
+		}
+		h.fail(w, http.StatusBadRequest, fmt.Errorf("webhook: failed to read body: %w", err))
+		return
+	}
+
+	tolerance := h.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+	if err := VerifyWebhookSignature(h.Secret, r.Header.Get(WebhookSignatureHeader), body, h.now(), tolerance); err != nil {
+		h.fail(w, http.StatusUnauthorized, fmt.Errorf("webhook: %w", err))
+		return
+	}
+
+	var event WebhookEvent
+	if topic := r.Header.Get(WebhookEventHeader); topic != "" {
+		event, err = DecodeWebhookEventForTopic(topic, body)
+	} else {
+		event, err = DecodeWebhookEvent(body)
+	}
+	if err != nil {
+		h.fail(w, http.StatusBadRequest, fmt.Errorf("webhook: %w", err))
+		return
+	}
+
+	if h.Store != nil {
+		h.serveStored(w, r, event.WebhookTopic(), body)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), event); err != nil {
+		h.fail(w, webhookErrorStatus(err), fmt.Errorf("webhook: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// serveStored records the event in Store before running its handler, so a
+// failed handler can be retried later instead of relying on redelivery.
+func (h *WebhookHandler) serveStored(w http.ResponseWriter, r *http.Request, topic string, body []byte) {
+	id := webhookEventID(topic, body)
+	if !h.claim(id) {
+		// Another delivery or RetryPending is handling the event.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	defer h.unclaim(id)
+
+	now := h.now()
+	record, found, err := h.Store.LoadWebhookEvent(id)
+	if err != nil {
+		h.fail(w, http.StatusInternalServerError, fmt.Errorf("webhook: failed to load event %s: %w", id, err))
+		return
+	}
+	if found && (record.Status != WebhookEventPending || record.NextAttempt.After(now)) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !found {
+		record = WebhookEventRecord{
+			ID:         id,
+			Topic:      topic,
+			Payload:    append([]byte(nil), body...),
+			Status:     WebhookEventPending,
+			ReceivedAt: now,
+			UpdatedAt:  now,
+		}
+		if err := h.Store.SaveWebhookEvent(record); err != nil {
+			h.fail(w, http.StatusInternalServerError, fmt.Errorf("webhook: failed to save event %s: %w", id, err))
+			return
+		}
+	}
+
+	if _, err := h.process(r.Context(), record); err != nil {
+		h.fail(w, webhookErrorStatus(err), fmt.Errorf("webhook: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch calls the handler registered for the event's topic, if any.
+func (h *WebhookHandler) dispatch(ctx context.Context, event WebhookEvent) error {
+	h.mu.RLock()
+	fn := h.handlers[event.WebhookTopic()]
+	h.mu.RUnlock()
+	if fn == nil {
+		return nil
+	}
+	if err := fn(ctx, event); err != nil {
+		return fmt.Errorf("%s handler failed: %w", event.WebhookTopic(), err)
+	}
+	return nil
+}
+
+// claim marks the stored event id as being handled, reporting false if it
+// already is.
+func (h *WebhookHandler) claim(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.processing[id] {
+		return false
+	}
+	if h.processing == nil {
+		h.processing = make(map[string]bool)
+	}
+	h.processing[id] = true
+	return true
+}
+
+// unclaim releases a claim taken with claim.
+func (h *WebhookHandler) unclaim(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.processing, id)
+}
+
+func (h *WebhookHandler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+// webhookErrorStatus returns the response status for a failed handler:
+// 503 when a subscription cannot take the event right now, 500 otherwise.
+func webhookErrorStatus(err error) int {
+	if errors.Is(err, ErrWebhookSubscriptionFull) || errors.Is(err, ErrWebhookSubscriptionClosed) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *WebhookHandler) fail(w http.ResponseWriter, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
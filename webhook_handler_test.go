@@ -0,0 +1,133 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	now := time.Unix(1709680834, 0)
+	body := []byte(orderCreatedBody)
+	header := WebhookSignatureHeaderValue("secret", now.Unix(), body)
+
+	if err := VerifyWebhookSignature("secret", header, body, now, time.Minute); err != nil {
+		t.Errorf("VerifyWebhookSignature() error = %v", err)
+	}
+	if err := VerifyWebhookSignature("secret", "t=1709680834,v1=bad,"+header[len("t=1709680834,"):], body, now, time.Minute); err != nil {
+		t.Errorf("VerifyWebhookSignature() with multiple v1 values error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+	}{
+		{"wrong secret", "other", header, body, now},
+		{"tampered body", "secret", header, []byte(`{"order":{}}`), now},
+		{"expired", "secret", header, body, now.Add(2 * time.Minute)},
+		{"missing v1", "secret", "t=1709680834", body, now},
+		{"bad timestamp", "secret", "t=abc,v1=00", body, now},
+		{"empty secret", "", header, body, now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := VerifyWebhookSignature(tt.secret, tt.header, tt.body, tt.now, time.Minute); !errors.As(err, &valErr) {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+
+	if err := VerifyWebhookSignature("secret", header, body, now.Add(time.Hour), 0); err != nil {
+		t.Errorf("zero tolerance should skip the age check, got %v", err)
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	now := time.Unix(1709680834, 0)
+	var received []string
+	var errs []error
+	handler := NewWebhookHandler("secret")
+	handler.Now = func() time.Time { return now }
+	handler.OnError = func(err error) { errs = append(errs, err) }
+	handler.Handle(WebhookTopicOrderCreated, func(ctx context.Context, event WebhookEvent) error {
+		order := event.(*OrderCreatedEvent).Order
+		if order.ID == "fail" {
+			return errors.New("database down")
+		}
+		received = append(received, order.ID)
+		return nil
+	})
+
+	send := func(method, body, signature, topic string) int {
+		req := httptest.NewRequest(method, "/webhooks", strings.NewReader(body))
+		if signature != "" {
+			req.Header.Set(WebhookSignatureHeader, signature)
+		}
+		if topic != "" {
+			req.Header.Set(WebhookEventHeader, topic)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	sign := func(body string) string {
+		return WebhookSignatureHeaderValue("secret", now.Unix(), []byte(body))
+	}
+
+	failing := strings.Replace(orderCreatedBody, "order-1", "fail", 1)
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		signature string
+		topic     string
+		want      int
+	}{
+		{"handled", http.MethodPost, orderCreatedBody, sign(orderCreatedBody), WebhookTopicOrderCreated, http.StatusOK},
+		{"topic from payload", http.MethodPost, orderCreatedBody, sign(orderCreatedBody), "", http.StatusOK},
+		{"unhandled topic", http.MethodPost, `{"id":"p1"}`, sign(`{"id":"p1"}`), "payout_sent", http.StatusOK},
+		{"handler error", http.MethodPost, failing, sign(failing), WebhookTopicOrderCreated, http.StatusInternalServerError},
+		{"bad signature", http.MethodPost, orderCreatedBody, sign("other"), WebhookTopicOrderCreated, http.StatusUnauthorized},
+		{"missing signature", http.MethodPost, orderCreatedBody, "", WebhookTopicOrderCreated, http.StatusUnauthorized},
+		{"undecodable", http.MethodPost, `{"order":{}}`, sign(`{"order":{}}`), WebhookTopicOrderCreated, http.StatusBadRequest},
+		{"wrong method", http.MethodGet, "", "", "", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := send(tt.method, tt.body, tt.signature, tt.topic); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if len(received) != 2 || received[0] != "order-1" {
+		t.Errorf("received = %v, want two order-1 events", received)
+	}
+	if len(errs) != 5 {
+		t.Errorf("OnError called %d times, want 5: %v", len(errs), errs)
+	}
+
+	// A negative tolerance accepts old signatures; the default rejects them.
+	stale := WebhookSignatureHeaderValue("secret", now.Add(-time.Hour).Unix(), []byte(orderCreatedBody))
+	if got := send(http.MethodPost, orderCreatedBody, stale, ""); got != http.StatusUnauthorized {
+		t.Errorf("stale status = %d, want 401", got)
+	}
+	handler.Tolerance = -1
+	if got := send(http.MethodPost, orderCreatedBody, stale, ""); got != http.StatusOK {
+		t.Errorf("stale status with age check disabled = %d, want 200", got)
+	}
+	handler.Tolerance = 0
+
+	handler.MaxBodyBytes = 10
+	if got := send(http.MethodPost, orderCreatedBody, sign(orderCreatedBody), ""); got != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", got)
+	}
+}
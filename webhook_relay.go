@@ -0,0 +1,334 @@
+package manapool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults for WebhookRelay and WebhookRelayForwarder.
+const (
+	DefaultWebhookRelayPollTimeout = 30 * time.Second
+	DefaultWebhookRelayMaxQueued   = 1000
+	DefaultWebhookRelayRetryDelay  = 5 * time.Second
+)
+
+// RelayedWebhook is a webhook request queued by WebhookRelay.
+type RelayedWebhook struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// relayPollResponse is the body of a WebhookRelay poll response.
+type relayPollResponse struct {
+	Deliveries []RelayedWebhook `json:"deliveries"`
+}
+
+// WebhookRelay lets webhooks reach a development machine without a public
+// URL. Deploy it somewhere public: it queues every POST it receives, and a
+// WebhookRelayForwarder on the developer's machine long-polls the same URL
+// with GET and replays the deliveries into a local handler. Signatures are
+// passed through untouched and verified locally.
+//
+// Example:
+//
+//	// On the public host:
+//	http.Handle("/manapool-relay", manapool.NewWebhookRelay(os.Getenv("RELAY_TOKEN")))
+//	log.Fatal(http.ListenAndServe(":8080", nil))
+type WebhookRelay struct {
+	// Token, if set, must be sent as a bearer token by pollers.
+	Token string
+
+	// PollTimeout is how long a poll waits for a delivery (default: DefaultWebhookRelayPollTimeout).
+	PollTimeout time.Duration
+
+	// MaxQueued limits undelivered requests; the oldest are dropped first
+	// (default: DefaultWebhookRelayMaxQueued).
+	MaxQueued int
+
+	// MaxBodyBytes limits each queued body (default: DefaultWebhookMaxBodyBytes).
+	MaxBodyBytes int64
+
+	mu     sync.Mutex
+	queue  []RelayedWebhook
+	notify chan struct{}
+}
+
+// NewWebhookRelay creates a relay whose pollers must authenticate with token.
+func NewWebhookRelay(token string) *WebhookRelay {
+	return &WebhookRelay{Token: token}
+}
+
+// ServeHTTP implements http.Handler. POST queues a webhook and GET polls.
+func (r *WebhookRelay) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		r.enqueue(w, req)
+	case http.MethodGet:
+		r.poll(w, req)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *WebhookRelay) enqueue(w http.ResponseWriter, req *http.Request) {
+	maxBytes := r.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultWebhookMaxBodyBytes
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBytes))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	header := make(http.Header)
+	for _, name := range []string{"Content-Type", WebhookEventHeader, WebhookTimestampHeader, WebhookSignatureHeader} {
+		if values := req.Header.Values(name); len(values) > 0 {
+			header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+		}
+	}
+
+	maxQueued := r.MaxQueued
+	if maxQueued <= 0 {
+		maxQueued = DefaultWebhookRelayMaxQueued
+	}
+
+	r.mu.Lock()
+	r.queue = append(r.queue, RelayedWebhook{Header: header, Body: body})
+	if len(r.queue) > maxQueued {
+		r.queue = r.queue[len(r.queue)-maxQueued:]
+	}
+	if r.notify != nil {
+		close(r.notify)
+		r.notify = nil
+	}
+	r.mu.Unlock()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *WebhookRelay) poll(w http.ResponseWriter, req *http.Request) {
+	if r.Token != "" && req.Header.Get("Authorization") != "Bearer "+r.Token {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	timeout := r.PollTimeout
+	if timeout <= 0 {
+		timeout = DefaultWebhookRelayPollTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var deliveries []RelayedWebhook
+	for {
+		r.mu.Lock()
+		if len(r.queue) > 0 {
+			deliveries, r.queue = r.queue, nil
+			r.mu.Unlock()
+			break
+		}
+		if r.notify == nil {
+			r.notify = make(chan struct{})
+		}
+		notify := r.notify
+		r.mu.Unlock()
+
+		select {
+		case <-notify:
+			continue
+		case <-timer.C:
+		case <-req.Context().Done():
+			return
+		}
+		break
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(relayPollResponse{Deliveries: deliveries})
+}
+
+// WebhookRelayForwarder long-polls a WebhookRelay and replays each delivery
+// into Handler as a POST request. Deliveries are removed from the relay when
+// polled, so a delivery the local handler rejects is not retried; it is
+// reported to OnError instead.
+type WebhookRelayForwarder struct {
+	// URL is the relay's public URL.
+	URL string
+
+	// Token is the relay's bearer token, if it has one.
+	Token string
+
+	// Handler receives the relayed requests, typically a *WebhookHandler.
+	Handler http.Handler
+
+	// HTTPClient polls the relay (default: a client without a timeout, since polls are long).
+	HTTPClient *http.Client
+
+	// RetryDelay is the wait after a failed poll (default: DefaultWebhookRelayRetryDelay).
+	RetryDelay time.Duration
+
+	// OnError, if set, is called with failed polls and rejected deliveries.
+	OnError func(error)
+}
+
+// Run polls the relay until ctx is cancelled.
+func (f *WebhookRelayForwarder) Run(ctx context.Context) error {
+	if f.URL == "" {
+		return NewValidationError("url", "relay URL cannot be empty")
+	}
+	if f.Handler == nil {
+		return NewValidationError("handler", "handler cannot be nil")
+	}
+	retryDelay := f.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = DefaultWebhookRelayRetryDelay
+	}
+
+	for {
+		deliveries, err := f.poll(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			f.report(err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		for _, delivery := range deliveries {
+			f.forward(ctx, delivery)
+		}
+	}
+}
+
+func (f *WebhookRelayForwarder) poll(ctx context.Context) ([]RelayedWebhook, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create relay poll request: %w", err)
+	}
+	if f.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.Token)
+	}
+
+	httpClient := f.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, NewNetworkError("relay poll failed", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("relay poll failed: %s", resp.Status)
+	}
+	var result relayPollResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode relay poll: %w", err)
+	}
+	return result.Deliveries, nil
+}
+
+func (f *WebhookRelayForwarder) forward(ctx context.Context, delivery RelayedWebhook) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", bytes.NewReader(delivery.Body))
+	if err != nil {
+		f.report(fmt.Errorf("failed to create relayed request: %w", err))
+		return
+	}
+	for name, values := range delivery.Header {
+		req.Header[name] = values
+	}
+
+	w := &relayResponseWriter{header: make(http.Header)}
+	f.Handler.ServeHTTP(w, req)
+	if w.status >= 300 {
+		f.report(fmt.Errorf("relayed %s webhook rejected with status %d", delivery.Header.Get(WebhookEventHeader), w.status))
+	}
+}
+
+func (f *WebhookRelayForwarder) report(err error) {
+	if f.OnError != nil {
+		f.OnError(err)
+	}
+}
+
+// relayResponseWriter records the status written by the local handler.
+type relayResponseWriter struct {
+	header http.Header
+	status int
+}
+
+func (w *relayResponseWriter) Header() http.Header { return w.header }
+
+func (w *relayResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return len(p), nil
+}
+
+func (w *relayResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// RelayWebhooks registers a temporary webhook for topic pointing at a
+// WebhookRelay, forwards its deliveries to handler until ctx is cancelled,
+// and then deletes the webhook. handler.Secret is set from the registration.
+// It is meant for local development: registering replaces the webhook
+// currently registered for topic, so use a test account rather than one with
+// a production webhook.
+//
+// Example:
+//
+//	handler := manapool.NewWebhookHandler("")
+//	handler.Handle(manapool.WebhookTopicOrderCreated, printOrder)
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	err := client.RelayWebhooks(ctx, manapool.WebhookTopicOrderCreated,
+//	    "https://relay.example.com/manapool-relay", os.Getenv("RELAY_TOKEN"), handler)
+func (c *Client) RelayWebhooks(ctx context.Context, topic, relayURL, token string, handler *WebhookHandler) error {
+	if topic == "" {
+		return NewValidationError("topic", "topic cannot be empty")
+	}
+	if relayURL == "" {
+		return NewValidationError("relay_url", "relay URL cannot be empty")
+	}
+	if handler == nil {
+		return NewValidationError("handler", "handler cannot be nil")
+	}
+
+	webhook, err := c.RegisterWebhook(ctx, WebhookRegisterRequest{Topic: topic, CallbackURL: relayURL})
+	if err != nil {
+		return err
+	}
+	handler.Secret = webhook.Secret
+
+	forwarder := &WebhookRelayForwarder{URL: relayURL, Token: token, Handler: handler, OnError: handler.OnError}
+	runErr := forwarder.Run(ctx)
+
+	// ctx is already cancelled, so unregister with a fresh deadline.
+	cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := c.DeleteWebhook(cleanupCtx, webhook.ID); err != nil {
+		return fmt.Errorf("failed to unregister relay webhook %s: %w", webhook.ID, err)
+	}
+	if errors.Is(runErr, context.Canceled) || errors.Is(runErr, context.DeadlineExceeded) {
+		return nil
+	}
+	return runErr
+}
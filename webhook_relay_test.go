@@ -0,0 +1,145 @@
+package manapool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_RelayWebhooks(t *testing.T) {
+	relay := NewWebhookRelay("relay-token")
+	relay.PollTimeout = 50 * time.Millisecond
+	relayServer := httptest.NewServer(relay)
+	defer relayServer.Close()
+
+	var mu sync.Mutex
+	var registered, deleted string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/webhooks/register":
+			registered = relayServer.URL
+			_, _ = w.Write([]byte(`{"id":"wh1","topic":"order_created","callback_url":"` + relayServer.URL + `","secret":"whsec"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/webhooks/wh1":
+			deleted = "wh1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer api.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var received string
+	handler := NewWebhookHandler("")
+	handler.Handle(WebhookTopicOrderCreated, func(_ context.Context, event WebhookEvent) error {
+		received = event.(*OrderCreatedEvent).Order.ID
+		cancel()
+		return nil
+	})
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(api.URL+"/"))
+	done := make(chan error, 1)
+	go func() {
+		done <- client.RelayWebhooks(ctx, WebhookTopicOrderCreated, relayServer.URL, "relay-token", handler)
+	}()
+
+	// Deliver a webhook to the relay as ManaPool would, once registered.
+	for {
+		mu.Lock()
+		ready := registered != ""
+		mu.Unlock()
+		if ready {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	req, _ := http.NewRequest(http.MethodPost, relayServer.URL, strings.NewReader(orderCreatedBody))
+	req.Header.Set(WebhookEventHeader, WebhookTopicOrderCreated)
+	req.Header.Set(WebhookSignatureHeader, WebhookSignatureHeaderValue("whsec", time.Now().Unix(), []byte(orderCreatedBody)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("relay POST error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if err := <-done; err != nil {
+		t.Fatalf("RelayWebhooks() error = %v", err)
+	}
+	if received == "" {
+		t.Error("relayed event was not handled")
+	}
+	if deleted != "wh1" {
+		t.Error("temporary webhook was not deleted")
+	}
+}
+
+func TestWebhookRelay(t *testing.T) {
+	relay := NewWebhookRelay("relay-token")
+	relay.PollTimeout = 10 * time.Millisecond
+	relay.MaxQueued = 2
+
+	for _, body := range []string{"a", "b", "c"} {
+		rec := httptest.NewRecorder()
+		relay.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("POST status = %d, want 200", rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	relay.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated poll status = %d, want 401", rec.Code)
+	}
+
+	var bodies []string
+	forwarder := &WebhookRelayForwarder{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+		}),
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer relay-token")
+	rec = httptest.NewRecorder()
+	relay.ServeHTTP(rec, req)
+	deliveries, err := decodeRelayPoll(rec)
+	if err != nil {
+		t.Fatalf("decode poll: %v", err)
+	}
+	for _, delivery := range deliveries {
+		forwarder.forward(context.Background(), delivery)
+	}
+	if strings.Join(bodies, ",") != "b,c" {
+		t.Errorf("forwarded bodies = %v, want [b c] (oldest dropped)", bodies)
+	}
+
+	// An empty queue returns no deliveries after the poll timeout.
+	rec = httptest.NewRecorder()
+	relay.ServeHTTP(rec, req)
+	if deliveries, err := decodeRelayPoll(rec); err != nil || len(deliveries) != 0 {
+		t.Errorf("empty poll = %v, %v", deliveries, err)
+	}
+
+	var valErr *ValidationError
+	if err := (&WebhookRelayForwarder{}).Run(context.Background()); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for missing URL, got %v", err)
+	}
+}
+
+func decodeRelayPoll(rec *httptest.ResponseRecorder) ([]RelayedWebhook, error) {
+	var result relayPollResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &result)
+	return result.Deliveries, err
+}
@@ -0,0 +1,398 @@
+package manapool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Defaults for retrying failed webhook handlers when WebhookHandler.Store is set.
+const (
+	DefaultWebhookMaxAttempts  = 5
+	DefaultWebhookRetryBackoff = time.Minute
+	maxWebhookRetryBackoff     = time.Hour
+)
+
+// DefaultWebhookEventRetention is how long the package's stores keep
+// processed events, so redeliveries within it are recognised, by default.
+const DefaultWebhookEventRetention = 7 * 24 * time.Hour
+
+// WebhookEventStatus is the processing state of a stored webhook event.
+type WebhookEventStatus string
+
+// Webhook event statuses.
+const (
+	// WebhookEventPending means the handler has not yet succeeded and will be retried.
+	WebhookEventPending WebhookEventStatus = "pending"
+
+	// WebhookEventProcessed means the handler succeeded.
+	WebhookEventProcessed WebhookEventStatus = "processed"
+
+	// WebhookEventDead means the handler failed MaxAttempts times. Dead
+	// events are kept until replayed with WebhookHandler.Replay.
+	WebhookEventDead WebhookEventStatus = "dead"
+)
+
+// WebhookEventRecord is a received webhook event and its processing outcome.
+type WebhookEventRecord struct {
+	// ID identifies the delivery: the topic and a hash of the body, so
+	// repeated deliveries of the same event share an ID.
+	ID          string             `json:"id"`
+	Topic       string             `json:"topic"`
+	Payload     json.RawMessage    `json:"payload"`
+	Status      WebhookEventStatus `json:"status"`
+	Attempts    int                `json:"attempts"`
+	LastError   string             `json:"last_error,omitempty"`
+	ReceivedAt  time.Time          `json:"received_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	NextAttempt time.Time          `json:"next_attempt,omitempty"`
+}
+
+// WebhookEventStore persists webhook events for WebhookHandler. The package
+// provides in-memory and JSON file stores; implement this interface to keep
+// events in a database such as SQLite.
+type WebhookEventStore interface {
+	// SaveWebhookEvent creates or replaces the record with record.ID.
+	SaveWebhookEvent(record WebhookEventRecord) error
+
+	// LoadWebhookEvent returns the record with id, or false if there is none.
+	LoadWebhookEvent(id string) (WebhookEventRecord, bool, error)
+
+	// WebhookEvents returns the records with status, oldest first.
+	WebhookEvents(status WebhookEventStatus) ([]WebhookEventRecord, error)
+}
+
+// MemoryWebhookEventStore is an in-memory WebhookEventStore. Events are lost
+// when the process exits; use FileWebhookEventStore to keep them. Processed
+// events last updated more than Retention before the event being saved are
+// dropped.
+type MemoryWebhookEventStore struct {
+	// Retention is how long processed events are kept after their last
+	// update (default: DefaultWebhookEventRetention).
+	Retention time.Duration
+
+	mu      sync.Mutex
+	records map[string]WebhookEventRecord
+}
+
+// NewMemoryWebhookEventStore creates an empty in-memory store.
+func NewMemoryWebhookEventStore() *MemoryWebhookEventStore {
+	return &MemoryWebhookEventStore{records: make(map[string]WebhookEventRecord)}
+}
+
+// SaveWebhookEvent implements WebhookEventStore.
+func (s *MemoryWebhookEventStore) SaveWebhookEvent(record WebhookEventRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.ID] = record
+	pruneWebhookEvents(s.records, s.Retention, record.UpdatedAt)
+	return nil
+}
+
+// LoadWebhookEvent implements WebhookEventStore.
+func (s *MemoryWebhookEventStore) LoadWebhookEvent(id string) (WebhookEventRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[id]
+	return record, ok, nil
+}
+
+// WebhookEvents implements WebhookEventStore.
+func (s *MemoryWebhookEventStore) WebhookEvents(status WebhookEventStatus) ([]WebhookEventRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return filterWebhookEvents(s.records, status), nil
+}
+
+// FileWebhookEventStore is a WebhookEventStore backed by a JSON file. The
+// file is read once and kept in memory, so the store must be the only writer
+// of Path. Every change rewrites the file atomically, which suits the low
+// volume of webhook deliveries; processed events last updated more than
+// Retention before the event being saved are dropped, so the file stays
+// small. A missing file is treated
+// as an empty store.
+type FileWebhookEventStore struct {
+	Path string
+
+	// Retention is how long processed events are kept after their last
+	// update (default: DefaultWebhookEventRetention).
+	Retention time.Duration
+
+	mu      sync.Mutex
+	records map[string]WebhookEventRecord // nil until loaded
+}
+
+// NewFileWebhookEventStore creates a store that keeps events in path.
+func NewFileWebhookEventStore(path string) *FileWebhookEventStore {
+	return &FileWebhookEventStore{Path: path}
+}
+
+// SaveWebhookEvent implements WebhookEventStore.
+func (s *FileWebhookEventStore) SaveWebhookEvent(record WebhookEventRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return err
+	}
+	records[record.ID] = record
+	pruneWebhookEvents(records, s.Retention, record.UpdatedAt)
+	if err := writeJSONFile(s.Path, records); err != nil {
+		s.records = nil // reread the file rather than trust unsaved changes
+		return fmt.Errorf("failed to save webhook event: %w", err)
+	}
+	return nil
+}
+
+// LoadWebhookEvent implements WebhookEventStore.
+func (s *FileWebhookEventStore) LoadWebhookEvent(id string) (WebhookEventRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return WebhookEventRecord{}, false, err
+	}
+	record, ok := records[id]
+	return record, ok, nil
+}
+
+// WebhookEvents implements WebhookEventStore.
+func (s *FileWebhookEventStore) WebhookEvents(status WebhookEventStatus) ([]WebhookEventRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return filterWebhookEvents(records, status), nil
+}
+
+// load returns the store's records, reading the file on first use.
+func (s *FileWebhookEventStore) load() (map[string]WebhookEventRecord, error) {
+	if s.Path == "" {
+		return nil, NewValidationError("path", "path cannot be empty")
+	}
+	if s.records != nil {
+		return s.records, nil
+	}
+
+	records := make(map[string]WebhookEventRecord)
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.records = records
+		return records, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook events: %w", err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook events: %w", err)
+	}
+	s.records = records
+	return records, nil
+}
+
+// pruneWebhookEvents deletes processed records last updated more than
+// retention (default: DefaultWebhookEventRetention) before now, the update
+// time of the record being saved.
+func pruneWebhookEvents(records map[string]WebhookEventRecord, retention time.Duration, now time.Time) {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if retention <= 0 {
+		retention = DefaultWebhookEventRetention
+	}
+	cutoff := now.Add(-retention)
+	for id, record := range records {
+		if record.Status == WebhookEventProcessed && record.UpdatedAt.Before(cutoff) {
+			delete(records, id)
+		}
+	}
+}
+
+func filterWebhookEvents(records map[string]WebhookEventRecord, status WebhookEventStatus) []WebhookEventRecord {
+	var filtered []WebhookEventRecord
+	for _, record := range records {
+		if record.Status == status {
+			filtered = append(filtered, record)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		if !filtered[i].ReceivedAt.Equal(filtered[j].ReceivedAt) {
+			return filtered[i].ReceivedAt.Before(filtered[j].ReceivedAt)
+		}
+		return filtered[i].ID < filtered[j].ID
+	})
+	return filtered
+}
+
+func webhookEventID(topic string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return topic + ":" + hex.EncodeToString(sum[:16])
+}
+
+// RetryPending re-runs the handlers of stored events whose retry time has
+// passed and returns the number that succeeded. Events being handled at the
+// same time, for example by a redelivery, are skipped. It requires Store to
+// be set.
+func (h *WebhookHandler) RetryPending(ctx context.Context) (int, error) {
+	if h.Store == nil {
+		return 0, NewValidationError("store", "webhook handler has no event store")
+	}
+
+	pending, err := h.Store.WebhookEvents(WebhookEventPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending webhook events: %w", err)
+	}
+
+	now := h.now()
+	succeeded := 0
+	for _, record := range pending {
+		if err := ctx.Err(); err != nil {
+			return succeeded, err
+		}
+		if record.NextAttempt.After(now) {
+			continue
+		}
+		ok, err := h.retry(ctx, record.ID, now)
+		if err != nil {
+			return succeeded, err
+		}
+		if ok {
+			succeeded++
+		}
+	}
+	return succeeded, nil
+}
+
+// retry runs the handler for the pending event id if it is due and not
+// being handled already, reporting whether it succeeded.
+func (h *WebhookHandler) retry(ctx context.Context, id string, now time.Time) (bool, error) {
+	if !h.claim(id) {
+		return false, nil
+	}
+	defer h.unclaim(id)
+
+	// Reload the event: it may have been handled since it was listed.
+	record, found, err := h.Store.LoadWebhookEvent(id)
+	if err != nil || !found || record.Status != WebhookEventPending || record.NextAttempt.After(now) {
+		return false, err
+	}
+	record, err = h.process(ctx, record)
+	return err == nil && record.Status == WebhookEventProcessed, err
+}
+
+// RunRetries calls RetryPending every interval until ctx is cancelled,
+// passing each error to onError if it is not nil.
+func (h *WebhookHandler) RunRetries(ctx context.Context, interval time.Duration, onError func(error)) error {
+	return runEvery(ctx, interval, func(ctx context.Context) error {
+		_, err := h.RetryPending(ctx)
+		return err
+	}, onError)
+}
+
+// DeadLetters returns the stored events whose handlers failed MaxAttempts times.
+func (h *WebhookHandler) DeadLetters() ([]WebhookEventRecord, error) {
+	if h.Store == nil {
+		return nil, NewValidationError("store", "webhook handler has no event store")
+	}
+	return h.Store.WebhookEvents(WebhookEventDead)
+}
+
+// Replay runs the handler for a stored event again, typically a dead letter
+// after the consumer bug has been fixed. The attempt count is reset first.
+// It fails if the event is being handled at the same time.
+func (h *WebhookHandler) Replay(ctx context.Context, id string) (WebhookEventRecord, error) {
+	if h.Store == nil {
+		return WebhookEventRecord{}, NewValidationError("store", "webhook handler has no event store")
+	}
+	if !h.claim(id) {
+		return WebhookEventRecord{}, NewValidationError("id", fmt.Sprintf("webhook event %q is being handled", id))
+	}
+	defer h.unclaim(id)
+
+	record, ok, err := h.Store.LoadWebhookEvent(id)
+	if err != nil {
+		return WebhookEventRecord{}, err
+	}
+	if !ok {
+		return WebhookEventRecord{}, NewValidationError("id", fmt.Sprintf("no webhook event %q", id))
+	}
+
+	record.Attempts = 0
+	return h.process(ctx, record)
+}
+
+// process decodes a stored event, runs its handler and saves the outcome.
+// Handler failures are recorded on the returned record rather than returned;
+// only store errors are returned.
+func (h *WebhookHandler) process(ctx context.Context, record WebhookEventRecord) (WebhookEventRecord, error) {
+	var event WebhookEvent
+	var err error
+	if record.Topic != "" {
+		event, err = DecodeWebhookEventForTopic(record.Topic, record.Payload)
+	} else {
+		event, err = DecodeWebhookEvent(record.Payload)
+	}
+	if err == nil {
+		err = h.dispatch(ctx, event)
+	}
+
+	now := h.now()
+	record.Attempts++
+	record.UpdatedAt = now
+	record.NextAttempt = time.Time{}
+	switch {
+	case err == nil:
+		record.Status = WebhookEventProcessed
+		record.LastError = ""
+	case record.Attempts >= h.maxAttempts():
+		record.Status = WebhookEventDead
+		record.LastError = err.Error()
+	default:
+		record.Status = WebhookEventPending
+		record.LastError = err.Error()
+		record.NextAttempt = now.Add(h.retryBackoff(record.Attempts))
+	}
+	if err != nil && h.OnError != nil {
+		h.OnError(fmt.Errorf("webhook: event %s attempt %d failed: %w", record.ID, record.Attempts, err))
+	}
+
+	if err := h.Store.SaveWebhookEvent(record); err != nil {
+		return record, fmt.Errorf("failed to save webhook event %s: %w", record.ID, err)
+	}
+	return record, nil
+}
+
+func (h *WebhookHandler) maxAttempts() int {
+	if h.MaxAttempts > 0 {
+		return h.MaxAttempts
+	}
+	return DefaultWebhookMaxAttempts
+}
+
+// retryBackoff returns the delay before the retry following attempt, doubling
+// from RetryBackoff up to an hour.
+func (h *WebhookHandler) retryBackoff(attempt int) time.Duration {
+	backoff := h.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultWebhookRetryBackoff
+	}
+	for i := 1; i < attempt && backoff < maxWebhookRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxWebhookRetryBackoff {
+		backoff = maxWebhookRetryBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,215 @@
+package manapool
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookHandler_Store(t *testing.T) {
+	stores := map[string]func(t *testing.T) WebhookEventStore{
+		"memory": func(t *testing.T) WebhookEventStore { return NewMemoryWebhookEventStore() },
+		"file": func(t *testing.T) WebhookEventStore {
+			return NewFileWebhookEventStore(filepath.Join(t.TempDir(), "events.json"))
+		},
+	}
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			now := time.Unix(1709680834, 0)
+			failures := 0
+			calls := 0
+			handler := NewWebhookHandler("secret")
+			handler.Store = newStore(t)
+			handler.MaxAttempts = 3
+			handler.RetryBackoff = time.Minute
+			handler.Now = func() time.Time { return now }
+			handler.Handle(WebhookTopicOrderCreated, func(ctx context.Context, event WebhookEvent) error {
+				calls++
+				if failures > 0 {
+					failures--
+					return errors.New("consumer bug")
+				}
+				return nil
+			})
+
+			send := func() int {
+				req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(orderCreatedBody))
+				req.Header.Set(WebhookSignatureHeader, WebhookSignatureHeaderValue("secret", now.Unix(), []byte(orderCreatedBody)))
+				req.Header.Set(WebhookEventHeader, WebhookTopicOrderCreated)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				return rec.Code
+			}
+			ctx := context.Background()
+
+			// A failing handler is acknowledged and stored for retry.
+			failures = 10
+			if code := send(); code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", code)
+			}
+			pending, err := handler.Store.WebhookEvents(WebhookEventPending)
+			if err != nil || len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError == "" {
+				t.Fatalf("pending = %+v, %v", pending, err)
+			}
+			if !pending[0].NextAttempt.Equal(now.Add(time.Minute)) {
+				t.Errorf("NextAttempt = %v, want %v", pending[0].NextAttempt, now.Add(time.Minute))
+			}
+
+			// A redelivery before the retry time is left to RetryPending.
+			if code := send(); code != http.StatusOK || calls != 1 {
+				t.Fatalf("early redelivery status = %d with %d calls, want 200 and 1", code, calls)
+			}
+
+			// Retries wait for the backoff, which doubles each attempt.
+			if n, err := handler.RetryPending(ctx); err != nil || n != 0 || calls != 1 {
+				t.Fatalf("early RetryPending() = %d, %v with %d calls", n, err, calls)
+			}
+			now = now.Add(time.Minute)
+			if _, err := handler.RetryPending(ctx); err != nil {
+				t.Fatalf("RetryPending() error = %v", err)
+			}
+			record, _, _ := handler.Store.LoadWebhookEvent(pending[0].ID)
+			if record.Attempts != 2 || !record.NextAttempt.Equal(now.Add(2*time.Minute)) {
+				t.Fatalf("unexpected record after retry: %+v", record)
+			}
+
+			// The third failure makes the event a dead letter.
+			now = now.Add(2 * time.Minute)
+			if _, err := handler.RetryPending(ctx); err != nil {
+				t.Fatalf("RetryPending() error = %v", err)
+			}
+			dead, err := handler.DeadLetters()
+			if err != nil || len(dead) != 1 || dead[0].Attempts != 3 {
+				t.Fatalf("DeadLetters() = %+v, %v", dead, err)
+			}
+
+			// Dead letters are only run again by Replay.
+			before := calls
+			if code := send(); code != http.StatusOK || calls != before {
+				t.Fatalf("dead letter redelivery status = %d, calls = %d; want 200 without a call", code, calls-before)
+			}
+
+			// Replaying after the fix processes the event, and redeliveries
+			// are acknowledged without running the handler again.
+			failures = 0
+			record, err = handler.Replay(ctx, dead[0].ID)
+			if err != nil || record.Status != WebhookEventProcessed || record.LastError != "" {
+				t.Fatalf("Replay() = %+v, %v", record, err)
+			}
+			before = calls
+			if code := send(); code != http.StatusOK || calls != before {
+				t.Errorf("redelivery status = %d, calls = %d; want 200 without a call", code, calls-before)
+			}
+
+			var valErr *ValidationError
+			if _, err := handler.Replay(ctx, "missing"); !errors.As(err, &valErr) {
+				t.Errorf("expected ValidationError for unknown event, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_StoreSerializesEvents(t *testing.T) {
+	now := time.Unix(1709680834, 0)
+	calls := 0
+	handler := NewWebhookHandler("secret")
+	handler.Store = NewMemoryWebhookEventStore()
+	handler.Now = func() time.Time { return now }
+	handler.Handle(WebhookTopicOrderCreated, func(ctx context.Context, event WebhookEvent) error {
+		calls++
+		return errors.New("consumer bug")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(orderCreatedBody))
+	req.Header.Set(WebhookSignatureHeader, WebhookSignatureHeaderValue("secret", now.Unix(), []byte(orderCreatedBody)))
+	req.Header.Set(WebhookEventHeader, WebhookTopicOrderCreated)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	pending, _ := handler.Store.WebhookEvents(WebhookEventPending)
+	if len(pending) != 1 || calls != 1 {
+		t.Fatalf("pending = %+v after %d calls", pending, calls)
+	}
+
+	// While the event is being handled elsewhere it is not run again.
+	id := pending[0].ID
+	now = now.Add(time.Hour)
+	handler.claim(id)
+	if n, err := handler.RetryPending(context.Background()); err != nil || n != 0 || calls != 1 {
+		t.Errorf("RetryPending() = %d, %v with %d calls; want the claimed event skipped", n, err, calls)
+	}
+	var valErr *ValidationError
+	if _, err := handler.Replay(context.Background(), id); !errors.As(err, &valErr) {
+		t.Errorf("Replay() error = %v, want ValidationError", err)
+	}
+	handler.unclaim(id)
+
+	if _, err := handler.RetryPending(context.Background()); err != nil || calls != 2 {
+		t.Errorf("RetryPending() after release = %v with %d calls, want 2", err, calls)
+	}
+}
+
+func TestFileWebhookEventStore_Retention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	store := NewFileWebhookEventStore(path)
+	store.Retention = time.Hour
+	now := time.Unix(1709680834, 0)
+
+	old := now.Add(-2 * time.Hour)
+	for _, record := range []WebhookEventRecord{
+		{ID: "processed", Status: WebhookEventProcessed, UpdatedAt: old},
+		{ID: "dead", Status: WebhookEventDead, UpdatedAt: old},
+		{ID: "recent", Status: WebhookEventProcessed, UpdatedAt: now.Add(-time.Minute)},
+		{ID: "new", Status: WebhookEventPending, UpdatedAt: now},
+	} {
+		if err := store.SaveWebhookEvent(record); err != nil {
+			t.Fatalf("SaveWebhookEvent() error = %v", err)
+		}
+	}
+
+	reopened := NewFileWebhookEventStore(path)
+	for id, want := range map[string]bool{"processed": false, "dead": true, "recent": true, "new": true} {
+		if _, ok, err := reopened.LoadWebhookEvent(id); err != nil || ok != want {
+			t.Errorf("LoadWebhookEvent(%q) = %v, %v; want %v", id, ok, err, want)
+		}
+	}
+}
+
+func TestWebhookHandler_StoreNotConfigured(t *testing.T) {
+	handler := NewWebhookHandler("secret")
+	var valErr *ValidationError
+	if _, err := handler.RetryPending(context.Background()); !errors.As(err, &valErr) {
+		t.Errorf("RetryPending() expected ValidationError, got %v", err)
+	}
+	if _, err := handler.DeadLetters(); !errors.As(err, &valErr) {
+		t.Errorf("DeadLetters() expected ValidationError, got %v", err)
+	}
+	if err := handler.RunRetries(context.Background(), 0, nil); !errors.As(err, &valErr) {
+		t.Errorf("RunRetries() expected ValidationError, got %v", err)
+	}
+}
+
+func TestFileWebhookEventStore_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	record := WebhookEventRecord{ID: "order_created:abc", Topic: WebhookTopicOrderCreated, Payload: []byte(`{"order":{"id":"o1"}}`), Status: WebhookEventDead}
+	if err := NewFileWebhookEventStore(path).SaveWebhookEvent(record); err != nil {
+		t.Fatalf("SaveWebhookEvent() error = %v", err)
+	}
+
+	loaded, ok, err := NewFileWebhookEventStore(path).LoadWebhookEvent(record.ID)
+	if err != nil || !ok || loaded.Status != WebhookEventDead {
+		t.Fatalf("LoadWebhookEvent() = %+v, %v, %v", loaded, ok, err)
+	}
+	event, err := DecodeWebhookEventForTopic(loaded.Topic, loaded.Payload)
+	if err != nil || event.(*OrderCreatedEvent).Order.ID != "o1" {
+		t.Errorf("stored payload decoded to %+v, %v", event, err)
+	}
+
+	var valErr *ValidationError
+	if _, err := (&FileWebhookEventStore{}).WebhookEvents(WebhookEventPending); !errors.As(err, &valErr) {
+		t.Errorf("expected ValidationError for empty path, got %v", err)
+	}
+}
@@ -32,7 +32,7 @@ func (c *Client) GetWebhook(ctx context.Context, id string) (*Webhook, error) {
 		return nil, NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/webhooks/%s", id)
+	endpoint := endpointPath("/webhooks/%s", id)
 	resp, err := c.doRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhook: %w", err)
@@ -67,7 +67,7 @@ func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
 		return NewValidationError("id", "id cannot be empty")
 	}
 
-	endpoint := fmt.Sprintf("/webhooks/%s", id)
+	endpoint := endpointPath("/webhooks/%s", id)
 	resp, err := c.doRequest(ctx, "DELETE", endpoint, nil)
 	if err != nil {
 		return fmt.Errorf("failed to delete webhook: %w", err)
@@ -75,3 +75,38 @@ func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
 
 	return c.decodeResponse(resp, nil)
 }
+
+// EnsureWebhook makes sure the webhook for topic points at callbackURL. It
+// returns the existing webhook if it already matches, and otherwise registers
+// callbackURL, which replaces any webhook registered for the topic. Secret is
+// only set on the returned webhook when a registration was made.
+//
+// Example:
+//
+//	webhook, err := client.EnsureWebhook(ctx, manapool.WebhookTopicOrderCreated, "https://example.com/hooks/manapool")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if webhook.Secret != "" {
+//	    saveSecret(webhook.Secret) // the secret is only returned once
+//	}
+func (c *Client) EnsureWebhook(ctx context.Context, topic, callbackURL string) (*Webhook, error) {
+	if topic == "" {
+		return nil, NewValidationError("topic", "topic cannot be empty")
+	}
+	if callbackURL == "" {
+		return nil, NewValidationError("callback_url", "callback URL cannot be empty")
+	}
+
+	existing, err := c.GetWebhooks(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	for i, webhook := range existing.Webhooks {
+		if webhook.Topic == topic && webhook.CallbackURL == callbackURL {
+			return &existing.Webhooks[i], nil
+		}
+	}
+
+	return c.RegisterWebhook(ctx, WebhookRegisterRequest{Topic: topic, CallbackURL: callbackURL})
+}
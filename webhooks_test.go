@@ -91,3 +91,75 @@ func TestClient_WebhooksEndpoints(t *testing.T) {
 		}
 	})
 }
+
+func TestClient_EnsureWebhook(t *testing.T) {
+	current := "https://example.com/old"
+	registrations := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/webhooks":
+			if r.URL.Query().Get("topic") != WebhookTopicOrderCreated {
+				t.Errorf("topic = %q, want order_created", r.URL.Query().Get("topic"))
+			}
+			if current == "" {
+				_, _ = w.Write([]byte(`{"webhooks":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"webhooks":[{"id":"wh","topic":"order_created","callback_url":"` + current + `"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/webhooks/register":
+			var payload WebhookRegisterRequest
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			registrations++
+			current = payload.CallbackURL
+			_, _ = w.Write([]byte(`{"id":"wh2","topic":"order_created","callback_url":"` + current + `","secret":"s3cret"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "test@example.com", WithBaseURL(server.URL+"/"))
+	ctx := context.Background()
+
+	t.Run("updates differing URL", func(t *testing.T) {
+		webhook, err := client.EnsureWebhook(ctx, WebhookTopicOrderCreated, "https://example.com/new")
+		if err != nil {
+			t.Fatalf("EnsureWebhook error: %v", err)
+		}
+		if registrations != 1 || webhook.ID != "wh2" || webhook.Secret != "s3cret" {
+			t.Fatalf("unexpected webhook %+v after %d registrations", webhook, registrations)
+		}
+	})
+
+	t.Run("keeps matching webhook", func(t *testing.T) {
+		webhook, err := client.EnsureWebhook(ctx, WebhookTopicOrderCreated, "https://example.com/new")
+		if err != nil {
+			t.Fatalf("EnsureWebhook error: %v", err)
+		}
+		if registrations != 1 || webhook.ID != "wh" || webhook.Secret != "" {
+			t.Fatalf("unexpected webhook %+v after %d registrations", webhook, registrations)
+		}
+	})
+
+	t.Run("registers missing webhook", func(t *testing.T) {
+		current = ""
+		if _, err := client.EnsureWebhook(ctx, WebhookTopicOrderCreated, "https://example.com/new"); err != nil {
+			t.Fatalf("EnsureWebhook error: %v", err)
+		}
+		if registrations != 2 {
+			t.Fatalf("registrations = %d, want 2", registrations)
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		var valErr *ValidationError
+		if _, err := client.EnsureWebhook(ctx, "", "https://example.com"); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty topic, got %v", err)
+		}
+		if _, err := client.EnsureWebhook(ctx, WebhookTopicOrderCreated, ""); !errors.As(err, &valErr) {
+			t.Errorf("expected ValidationError for empty URL, got %v", err)
+		}
+	})
+}